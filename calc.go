@@ -14,6 +14,7 @@ package excelize
 import (
 	"bytes"
 	"container/list"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"math"
@@ -116,6 +117,12 @@ var (
 		">=": 2,
 		"&":  1,
 	}
+	// lazyFormulaFuncs defined the formula functions which arguments will be
+	// evaluated on demand
+	lazyFormulaFuncs = map[string]func(args []func() formulaArg) formulaArg{
+		"IFS":    calcIFS,
+		"SWITCH": calcSWITCH,
+	}
 	month2num = map[string]int{
 		"january":   1,
 		"february":  2,
@@ -220,6 +227,8 @@ type calcContext struct {
 	maxCalcIterations uint
 	iterations        map[string]uint
 	iterationsCache   map[string]formulaArg
+	definedNames      map[string]struct{}
+	results           map[string]formulaArg
 }
 
 // cellRef defines the structure of a cell reference.
@@ -681,6 +690,7 @@ type formulaFuncs struct {
 //	RAND
 //	RANDBETWEEN
 //	RANK
+//	RANK.AVG
 //	RANK.EQ
 //	RATE
 //	RECEIVED
@@ -711,6 +721,8 @@ type formulaFuncs struct {
 //	SLN
 //	SLOPE
 //	SMALL
+//	SORT
+//	SORTBY
 //	SQRT
 //	SQRTPI
 //	STANDARDIZE
@@ -746,7 +758,10 @@ type formulaFuncs struct {
 //	TBILLPRICE
 //	TBILLYIELD
 //	TDIST
+//	TEXTAFTER
+//	TEXTBEFORE
 //	TEXTJOIN
+//	TEXTSPLIT
 //	TIME
 //	TIMEVALUE
 //	TINV
@@ -761,6 +776,7 @@ type formulaFuncs struct {
 //	TYPE
 //	UNICHAR
 //	UNICODE
+//	UNIQUE
 //	UPPER
 //	VALUE
 //	VAR
@@ -789,23 +805,36 @@ type formulaFuncs struct {
 //	Z.TEST
 //	ZTEST
 func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string, err error) {
+	_, result, err = f.calcCellResult(sheet, cell, opts...)
+	return
+}
+
+// calcCellResult provides a function to calculate the value of the formula
+// cell by given worksheet name, cell reference and options, and returns the
+// formula argument of the calculated result with the formatted result.
+func (f *File) calcCellResult(sheet, cell string, opts ...Options) (token formulaArg, result string, err error) {
 	var (
 		rawCellValue = getOptions(opts...).RawCellValue
 		styleIdx     int
-		token        formulaArg
 	)
 	if token, err = f.calcCellValue(&calcContext{
 		entry:             fmt.Sprintf("%s!%s", sheet, cell),
 		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
 		iterations:        make(map[string]uint),
 		iterationsCache:   make(map[string]formulaArg),
+		definedNames:      make(map[string]struct{}),
 	}, sheet, cell); err != nil {
-		result = token.String
+		if result = token.String; token.Type != ArgError {
+			token = calcErrorFormulaArg(err)
+		}
 		return
 	}
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
+	if token.Type == ArgMatrix && len(token.Matrix) > 0 && len(token.Matrix[0]) > 0 {
+		token = token.Matrix[0][0]
+	}
 	result = token.Value()
 	if isNum, precision, decimal := isNumeric(result); isNum {
 		if precision > 15 {
@@ -819,6 +848,20 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 	return
 }
 
+// calcErrorFormulaArg returns the formula error argument for the error of the
+// formula evaluation, or an empty argument when the error is not a formula
+// error value, such as the invalid formula.
+func calcErrorFormulaArg(err error) formulaArg {
+	if inStrSlice([]string{
+		formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+		formulaErrorVALUE, formulaErrorREF, formulaErrorNULL, formulaErrorSPILL,
+		formulaErrorCALC, formulaErrorGETTINGDATA,
+	}, err.Error(), true) == -1 {
+		return newEmptyFormulaArg()
+	}
+	return newErrorFormulaArg(err.Error(), err.Error())
+}
+
 // calcCellValue calculate cell value by given context, worksheet name and cell
 // reference.
 func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formulaArg, err error) {
@@ -835,6 +878,715 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	return
 }
 
+// formulaCellRef defines the structure of a formula cell reference in the
+// dependency graph of formulas.
+type formulaCellRef struct {
+	sheet, cell  string
+	col, row     int
+	toCol, toRow int
+}
+
+// String returns the reference of the formula cell with the worksheet name.
+func (ref formulaCellRef) String() string {
+	return fmt.Sprintf("%s!%s", ref.sheet, ref.cell)
+}
+
+// formulaGraph defines the structure of the dependency graph of formulas. The
+// references store the cell ranges which referenced by each formula cell, the
+// precedents store the indexes of the formula cells which referenced by each
+// formula cell, and the dependents store the indexes of the formula cells
+// which reference each formula cell. The key is the digest of the formulas
+// and defined names in the workbook which the graph built from, and the
+// results store the calculated results of the formula cells, both of them
+// will be reused until the formulas or defined names have been changed. The
+// results are guarded by the mutex, each calculation works on its own copy of
+// the results and merges them back after the calculation.
+type formulaGraph struct {
+	mu         sync.Mutex
+	key        [sha256.Size]byte
+	results    map[string]formulaArg
+	cells      []formulaCellRef
+	index      map[string]int
+	columns    map[string]map[int][]int
+	references [][]cellRange
+	precedents [][]int
+	dependents [][]int
+}
+
+// CalcAll provides a function to calculate all formula cells in the workbook
+// in dependency order, and stores the calculated results as the cached
+// values of the formula cells, so that the applications which don't
+// recalculate formulas can display the correct values. Each formula cell
+// will be calculated once, and the result will be reused by the formula cells
+// which depend on it. The formula cells in the circular references will be
+// set as the #REF! error, and the formula cells which failed to calculate
+// will be set as the #VALUE! error. The calculation doesn't stop at these
+// cells, and a CalcErrors error which lists these cells will be returned after
+// all formula cells have been calculated. The formulas which return an array
+// with multiple values, such as UNIQUE and SORT, will spill the values into
+// the adjacent cells, and the #SPILL! error will be stored without changing
+// the adjacent cells if the spill range isn't empty. Note that the
+// references by the dynamic reference functions, such as INDIRECT and OFFSET,
+// can't be traced in the dependency graph. For example:
+//
+//	if err := f.CalcAll(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) CalcAll(opts ...Options) error {
+	graph, err := f.getFormulaGraph()
+	if err != nil {
+		return err
+	}
+	all := make([]int, len(graph.cells))
+	for i := range all {
+		all[i] = i
+	}
+	return f.calcFormulaCells(graph, all, opts...)
+}
+
+// RecalcFrom provides a function to recalculate the formula cells which
+// directly or indirectly depend on the given cell in dependency order, and
+// stores the calculated results as the cached values of these formula cells.
+// If the given cell is a formula cell, it will be recalculated too. This
+// function avoids recalculating the whole workbook by CalcAll after changing
+// a few input cells. The dependency graph and the calculated results of the
+// formula cells will be reused by the subsequent calls until the formulas or
+// defined names have been changed, so the formula cells which don't depend on
+// the given cell will not be recalculated. For example, recalculate the formula cells which depend
+// on cell A1 on Sheet1 after changing its value:
+//
+//	if err := f.SetCellValue("Sheet1", "A1", 100); err != nil {
+//	    fmt.Println(err)
+//	}
+//	if err := f.RecalcFrom("Sheet1", "A1"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RecalcFrom(sheet, cell string, opts ...Options) error {
+	if err := checkSheetName(sheet); err != nil {
+		return err
+	}
+	if _, ok := f.getSheetXMLPath(sheet); !ok {
+		return ErrSheetNotExist{sheet}
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	graph, err := f.getFormulaGraph()
+	if err != nil {
+		return err
+	}
+	var queue []int
+	affected := make(map[int]struct{})
+	for idx, refs := range graph.references {
+		for _, cr := range refs {
+			if strings.EqualFold(cr.From.Sheet, sheet) && col >= cr.From.Col && col <= cr.To.Col &&
+				row >= cr.From.Row && row <= cr.To.Row {
+				queue = append(queue, idx)
+				break
+			}
+		}
+	}
+	if idx, ok := graph.index[strings.ToUpper(formulaCellRef{sheet: sheet, cell: cell}.String())]; ok {
+		queue = append(queue, idx)
+	}
+	for len(queue) > 0 {
+		idx := queue[0]
+		queue = queue[1:]
+		if _, ok := affected[idx]; ok {
+			continue
+		}
+		affected[idx] = struct{}{}
+		queue = append(queue, graph.dependents[idx]...)
+	}
+	return f.calcFormulaCells(graph, sortedKeys(affected), opts...)
+}
+
+// CalcError directly maps the error of a formula cell which calculated by the
+// CalcSheet, CalcAll or RecalcFrom function. The Value is the formula error
+// value of the cell, such as #DIV/0! or #N/A, and it will be empty if the
+// formula failed to parse or calculate. The Err is the error returned on
+// calculating the formula.
+type CalcError struct {
+	Sheet   string
+	Cell    string
+	Formula string
+	Value   string
+	Err     error
+}
+
+// Error returns the error message of the formula cell.
+func (e CalcError) Error() string {
+	cell := e.Cell
+	if e.Sheet != "" {
+		cell = e.Sheet + "!" + e.Cell
+	}
+	return fmt.Sprintf("formula cell %s: %s", cell, e.Err.Error())
+}
+
+// CalcErrors directly maps the errors of the formula cells which calculated
+// by the CalcAll or RecalcFrom function.
+type CalcErrors []CalcError
+
+// Error returns the error messages of the formula cells.
+func (e CalcErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, calcErr := range e {
+		messages[i] = calcErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// CalcSheet provides a function to calculate all formula cells in the given
+// worksheet without stopping at the first error. It returns the calculated
+// values of the formula cells keyed by cell reference, and the list of formula
+// cells which evaluated to formula error values or failed to parse in row
+// order. The cells which failed to calculate will not be included in the
+// values. The error will be returned only when the worksheet can't be read.
+// For example, audit the formulas in the worksheet named Sheet1:
+//
+//	values, calcErrs, err := f.CalcSheet("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, calcErr := range calcErrs {
+//	    fmt.Println(calcErr.Cell, calcErr.Value, calcErr.Err)
+//	}
+//	fmt.Println(values)
+func (f *File) CalcSheet(sheet string, opts ...Options) (map[string]string, []CalcError, error) {
+	values, calcErrs := make(map[string]string), []CalcError{}
+	if err := checkSheetName(sheet); err != nil {
+		return values, calcErrs, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return values, calcErrs, err
+	}
+	f.mu.Unlock()
+	var cells []string
+	ws.mu.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F != nil {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	ws.mu.Unlock()
+	for _, cell := range cells {
+		token, result, err := f.calcCellResult(sheet, cell, opts...)
+		if err == nil && token.Type != ArgError {
+			values[cell] = result
+			continue
+		}
+		formula, _ := f.GetCellFormula(sheet, cell)
+		calcErr := CalcError{Cell: cell, Formula: formula, Err: err}
+		if token.Type == ArgError {
+			calcErr.Value, values[cell] = token.String, token.String
+			if err == nil {
+				calcErr.Err = errors.New(token.String)
+			}
+		}
+		calcErrs = append(calcErrs, calcErr)
+	}
+	return values, calcErrs, nil
+}
+
+// calcFormulaCells provides a function to calculate the given formula cells
+// in dependency order, and stores the calculated results as the cached values
+// of the formula cells. The result of each formula cell will be calculated
+// once and reused by the formula cells which depend on it. The formula cells
+// in the circular references will be set as the #REF! error, and the errors
+// of all formula cells will be returned after all given cells have been
+// calculated.
+func (f *File) calcFormulaCells(graph *formulaGraph, cells []int, opts ...Options) error {
+	calc := make(map[int]struct{}, len(cells))
+	for _, idx := range cells {
+		calc[idx] = struct{}{}
+	}
+	var calcErrs CalcErrors
+	results := graph.loadResults()
+	defer graph.storeResults(results)
+	setCachedValue := func(idx int, token formulaArg, calcErr error) error {
+		ref := graph.cells[idx]
+		if calcErr != nil {
+			formula, _ := f.GetCellFormula(ref.sheet, ref.cell)
+			calcErrs = append(calcErrs, CalcError{
+				Sheet: ref.sheet, Cell: ref.cell, Formula: formula, Value: token.String, Err: calcErr,
+			})
+		}
+		if _, ok := calc[idx]; !ok {
+			return nil
+		}
+		return f.setFormulaCachedValue(ref, token)
+	}
+	order, circular := graph.sort(cells)
+	for _, idx := range circular {
+		results[strings.ToUpper(graph.cells[idx].String())] = newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	}
+	for _, idx := range circular {
+		if _, ok := calc[idx]; !ok {
+			continue
+		}
+		if err := setCachedValue(idx, results[strings.ToUpper(graph.cells[idx].String())], ErrCircularReference); err != nil {
+			return err
+		}
+	}
+	for _, idx := range order {
+		if _, ok := calc[idx]; !ok {
+			if _, ok = results[strings.ToUpper(graph.cells[idx].String())]; ok {
+				continue
+			}
+		}
+		token, err := f.calcFormulaCell(graph.cells[idx], results, opts...)
+		if err != nil {
+			token = newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+		}
+		result := token
+		if result.Type == ArgMatrix && len(result.Matrix) > 0 && len(result.Matrix[0]) > 0 {
+			result = result.Matrix[0][0]
+		}
+		results[strings.ToUpper(graph.cells[idx].String())] = result
+		if err = setCachedValue(idx, token, err); err != nil {
+			return err
+		}
+	}
+	if len(calcErrs) > 0 {
+		return calcErrs
+	}
+	return nil
+}
+
+// loadResults provides a function to get a copy of the calculated results of
+// the formula cells in the dependency graph.
+func (graph *formulaGraph) loadResults() map[string]formulaArg {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	results := make(map[string]formulaArg, len(graph.results))
+	for ref, result := range graph.results {
+		results[ref] = result
+	}
+	return results
+}
+
+// storeResults provides a function to merge the calculated results of the
+// formula cells into the dependency graph.
+func (graph *formulaGraph) storeResults(results map[string]formulaArg) {
+	graph.mu.Lock()
+	defer graph.mu.Unlock()
+	for ref, result := range results {
+		graph.results[ref] = result
+	}
+}
+
+// calcFormulaCell provides a function to calculate the formula cell by given
+// calculated results of the formula cells which it depends on.
+func (f *File) calcFormulaCell(ref formulaCellRef, results map[string]formulaArg, opts ...Options) (formulaArg, error) {
+	token, err := f.calcCellValue(&calcContext{
+		entry:             ref.String(),
+		maxCalcIterations: getOptions(opts...).MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+		definedNames:      make(map[string]struct{}),
+		results:           results,
+	}, ref.sheet, ref.cell)
+	if err != nil && strings.HasPrefix(err.Error(), "#") {
+		return newErrorFormulaArg(err.Error(), err.Error()), nil
+	}
+	return token, err
+}
+
+// setFormulaCachedValue provides a function to store the calculated result as
+// the cached value of the formula cell. If the result is an array with more
+// than one value, the values will spill into the adjacent cells, and the
+// formula will be stored as an array formula which references the spill
+// range.
+func (f *File) setFormulaCachedValue(ref formulaCellRef, token formulaArg) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(ref.sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	c, _, _, err := ws.prepareCell(ref.cell)
+	if err != nil {
+		return err
+	}
+	var spill [][]formulaArg
+	if token.Type == ArgMatrix && len(token.Matrix) > 0 && len(token.Matrix[0]) > 0 {
+		if spill, token = token.Matrix, token.Matrix[0][0]; len(spill) == 1 && len(spill[0]) == 1 {
+			spill = nil
+		}
+	}
+	if c.F != nil && (c.F.T == "" || c.F.T == STCellFormulaTypeArray) {
+		if !ws.setFormulaSpillValues(ref, spill) {
+			token = newErrorFormulaArg(formulaErrorSPILL, formulaErrorSPILL)
+		}
+		c = &ws.SheetData.Row[ref.row-1].C[ref.col-1]
+	}
+	c.T, c.V = getFormulaCachedValue(token)
+	c.IS = nil
+	return err
+}
+
+// getFormulaCachedValue returns the cell type and cached value of the formula
+// cell by given calculated result.
+func getFormulaCachedValue(token formulaArg) (t, v string) {
+	switch token.Type {
+	case ArgError:
+		if t, v = "e", token.String; !strings.HasPrefix(v, "#") {
+			v = formulaErrorVALUE
+		}
+	case ArgNumber:
+		if token.Boolean {
+			return setCellBool(token.Number == 1)
+		}
+		v = strings.ToUpper(strconv.FormatFloat(token.Number, 'G', 15, 64))
+		if isNum, precision, decimal := isNumeric(v); isNum && precision <= 15 {
+			v = strconv.FormatFloat(decimal, 'f', -1, 64)
+		}
+	case ArgString:
+		t, v = "str", token.String
+	}
+	return
+}
+
+// setFormulaSpillValues provides a function to store the values of the array
+// which calculated by the formula cell into the spill range which starts from
+// the formula cell, and clear the values in the previous spill range of the
+// formula cell. It returns false if the spill range is out of the worksheet or
+// contains non-empty cells, and the formula cell will not spill in this case,
+// the cells in the blocked spill range will be kept unchanged.
+func (ws *xlsxWorksheet) setFormulaSpillValues(ref formulaCellRef, spill [][]formulaArg) bool {
+	c := &ws.SheetData.Row[ref.row-1].C[ref.col-1]
+	inRange := func(coordinates []int, col, row int) bool {
+		return coordinates != nil && col >= coordinates[0] && col <= coordinates[2] &&
+			row >= coordinates[1] && row <= coordinates[3]
+	}
+	var prev, next []int
+	if c.F != nil && c.F.T == STCellFormulaTypeArray && c.F.Ref != "" {
+		if coordinates, err := rangeRefToCoordinates(c.F.Ref); err == nil && sortCoordinates(coordinates) == nil &&
+			coordinates[0] == ref.col && coordinates[1] == ref.row {
+			prev = coordinates
+		}
+	}
+	if spill != nil {
+		next = []int{ref.col, ref.row, ref.col + len(spill[0]) - 1, ref.row + len(spill) - 1}
+		if next[2] > MaxColumns || next[3] > TotalRows {
+			next = nil
+		}
+		for row := ref.row; next != nil && row <= next[3]; row++ {
+			for col := ref.col; col <= next[2]; col++ {
+				if (col == ref.col && row == ref.row) || inRange(prev, col, row) {
+					continue
+				}
+				if !ws.isBlankCell(col, row) {
+					next = nil
+					break
+				}
+			}
+		}
+	}
+	for row := ref.row; prev != nil && row <= prev[3]; row++ {
+		for col := ref.col; col <= prev[2]; col++ {
+			if (col == ref.col && row == ref.row) || inRange(next, col, row) {
+				continue
+			}
+			ws.prepareSheetXML(col, row)
+			cell := &ws.SheetData.Row[row-1].C[col-1]
+			cell.T, cell.V, cell.IS = "", "", nil
+		}
+	}
+	if c = &ws.SheetData.Row[ref.row-1].C[ref.col-1]; next == nil {
+		if c.F.T == STCellFormulaTypeArray {
+			c.F.Ref = ref.cell
+		}
+		return spill == nil
+	}
+	for r, values := range spill {
+		for col, value := range values {
+			if r == 0 && col == 0 {
+				continue
+			}
+			ws.prepareSheetXML(ref.col+col, ref.row+r)
+			cell := &ws.SheetData.Row[ref.row+r-1].C[ref.col+col-1]
+			cell.T, cell.V = getFormulaCachedValue(value)
+			cell.IS = nil
+		}
+	}
+	lastCell, _ := CoordinatesToCellName(next[2], next[3])
+	c.F.T, c.F.Ref = STCellFormulaTypeArray, ref.cell+":"+lastCell
+	return true
+}
+
+// isBlankCell provides a function to check if the cell by given coordinates
+// doesn't contain any formula or value, without creating the cell in the
+// worksheet.
+func (ws *xlsxWorksheet) isBlankCell(col, row int) bool {
+	if row > len(ws.SheetData.Row) || col > len(ws.SheetData.Row[row-1].C) {
+		return true
+	}
+	cell := ws.SheetData.Row[row-1].C[col-1]
+	return cell.F == nil && cell.V == "" && cell.IS == nil
+}
+
+// getFormulaGraph provides a function to get the dependency graph of all
+// formula cells in the workbook. The graph which built previously will be
+// reused if the formulas and defined names in the workbook haven't been
+// changed.
+func (f *File) getFormulaGraph() (*formulaGraph, error) {
+	graph, err := f.newFormulaGraph()
+	if err != nil {
+		return graph, err
+	}
+	f.mu.Lock()
+	if cached := f.formulaGraph; cached != nil && cached.key == graph.key {
+		f.mu.Unlock()
+		return cached, err
+	}
+	f.mu.Unlock()
+	if err = graph.build(f); err != nil {
+		return graph, err
+	}
+	f.mu.Lock()
+	f.formulaGraph = graph
+	f.mu.Unlock()
+	return graph, err
+}
+
+// newFormulaGraph provides a function to collect all formula cells in the
+// workbook, and calculate the digest of the formulas and defined names for
+// building the dependency graph.
+func (f *File) newFormulaGraph() (*formulaGraph, error) {
+	graph := &formulaGraph{
+		results: make(map[string]formulaArg),
+		index:   make(map[string]int),
+		columns: make(map[string]map[int][]int),
+	}
+	h := sha256.New()
+	f.mu.Lock()
+	wb, err := f.workbookReader()
+	f.mu.Unlock()
+	if err != nil {
+		return graph, err
+	}
+	if wb.DefinedNames != nil {
+		for _, dn := range wb.DefinedNames.DefinedName {
+			var scope string
+			if dn.LocalSheetID != nil {
+				scope = strconv.Itoa(*dn.LocalSheetID)
+			}
+			_, _ = fmt.Fprintf(h, "%s\x00%s\x00%s\n", dn.Name, scope, dn.Data)
+		}
+	}
+	for _, sheet := range f.GetSheetList() {
+		f.mu.Lock()
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			f.mu.Unlock()
+			return graph, err
+		}
+		f.mu.Unlock()
+		ws.mu.Lock()
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F == nil {
+					continue
+				}
+				col, r, err := CellNameToCoordinates(c.R)
+				if err != nil {
+					continue
+				}
+				var si string
+				if c.F.Si != nil {
+					si = strconv.Itoa(*c.F.Si)
+				}
+				_, _ = fmt.Fprintf(h, "%s!%s\x00%s\x00%s\x00%s\x00%s\n", sheet, c.R, c.F.T, c.F.Ref, si, c.F.Content)
+				ref := formulaCellRef{sheet: sheet, cell: c.R, col: col, row: r, toCol: col, toRow: r}
+				if c.F.T == STCellFormulaTypeArray && c.F.Ref != "" {
+					if coordinates, err := rangeRefToCoordinates(c.F.Ref); err == nil && sortCoordinates(coordinates) == nil &&
+						coordinates[0] == col && coordinates[1] == r {
+						ref.toCol, ref.toRow = coordinates[2], coordinates[3]
+					}
+				}
+				graph.index[strings.ToUpper(ref.String())] = len(graph.cells)
+				if graph.columns[strings.ToUpper(sheet)] == nil {
+					graph.columns[strings.ToUpper(sheet)] = make(map[int][]int)
+				}
+				for col := ref.col; col <= ref.toCol; col++ {
+					graph.columns[strings.ToUpper(sheet)][col] = append(graph.columns[strings.ToUpper(sheet)][col], len(graph.cells))
+				}
+				graph.cells = append(graph.cells, ref)
+			}
+		}
+		ws.mu.Unlock()
+	}
+	copy(graph.key[:], h.Sum(nil))
+	return graph, nil
+}
+
+// build provides a function to build the dependency graph by the references
+// of the collected formula cells.
+func (graph *formulaGraph) build(f *File) error {
+	graph.references = make([][]cellRange, len(graph.cells))
+	graph.precedents = make([][]int, len(graph.cells))
+	graph.dependents = make([][]int, len(graph.cells))
+	for idx, ref := range graph.cells {
+		formula, err := f.GetCellFormula(ref.sheet, ref.cell)
+		if err != nil {
+			return err
+		}
+		precedents := map[int]struct{}{}
+		graph.references[idx] = f.getFormulaReferences(ref.sheet, formula, map[string]struct{}{})
+		for _, cr := range graph.references[idx] {
+			for _, p := range graph.find(cr) {
+				precedents[p] = struct{}{}
+			}
+		}
+		for _, p := range sortedKeys(precedents) {
+			graph.precedents[idx] = append(graph.precedents[idx], p)
+			graph.dependents[p] = append(graph.dependents[p], idx)
+		}
+	}
+	return nil
+}
+
+// find provides a function to get the indexes of the formula cells in the
+// given cell range, including the formula cells which spill into the range.
+func (graph *formulaGraph) find(cr cellRange) []int {
+	cells := map[int]struct{}{}
+	for col, indexes := range graph.columns[strings.ToUpper(cr.From.Sheet)] {
+		if col < cr.From.Col || col > cr.To.Col {
+			continue
+		}
+		for _, idx := range indexes {
+			if ref := graph.cells[idx]; ref.toRow >= cr.From.Row && ref.row <= cr.To.Row {
+				cells[idx] = struct{}{}
+			}
+		}
+	}
+	return sortedKeys(cells)
+}
+
+// sort provides a function to sort the given formula cells and the formula
+// cells which they depend on in topological order, the precedents will be
+// placed before the dependents. The formula cells in the circular references
+// will be returned separately in ascending index order.
+func (graph *formulaGraph) sort(cells []int) (order, circular []int) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(graph.cells))
+	inCycle := make(map[int]struct{})
+	var visit func(idx int, path []int)
+	visit = func(idx int, path []int) {
+		state[idx] = visiting
+		path = append(path, idx)
+		for _, p := range graph.precedents[idx] {
+			switch state[p] {
+			case unvisited:
+				visit(p, path)
+			case visiting:
+				for i := len(path) - 1; i >= 0; i-- {
+					inCycle[path[i]] = struct{}{}
+					if path[i] == p {
+						break
+					}
+				}
+			}
+		}
+		state[idx] = visited
+		order = append(order, idx)
+	}
+	for _, idx := range cells {
+		if state[idx] == unvisited {
+			visit(idx, nil)
+		}
+	}
+	var sorted []int
+	for _, idx := range order {
+		if _, ok := inCycle[idx]; !ok {
+			sorted = append(sorted, idx)
+		}
+	}
+	for idx := range inCycle {
+		circular = append(circular, idx)
+	}
+	sort.Ints(circular)
+	return sorted, circular
+}
+
+// getFormulaReferences provides a function to get the cell ranges which
+// referenced by the given formula, the defined names in the formula will be
+// resolved to the cell ranges which they refer to.
+func (f *File) getFormulaReferences(sheet, formula string, names map[string]struct{}) []cellRange {
+	var cellRanges []cellRange
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(strings.TrimPrefix(formula, "=")) {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		if cr, ok := f.parseFormulaReference(sheet, token.TValue); ok {
+			cellRanges = append(cellRanges, cr)
+			continue
+		}
+		name := strings.ToUpper(sheet + "!" + token.TValue)
+		if _, ok := names[name]; ok {
+			continue
+		}
+		if refTo := f.getDefinedNameRefTo(token.TValue, sheet); refTo != "" {
+			names[name] = struct{}{}
+			cellRanges = append(cellRanges, f.getFormulaReferences(sheet, refTo, names)...)
+		}
+	}
+	return cellRanges
+}
+
+// parseFormulaReference provides a function to parse the cell reference or
+// range reference in the formula to the cell range.
+func (f *File) parseFormulaReference(sheet, reference string) (cellRange, bool) {
+	var cr cellRange
+	refs := strings.Split(strings.ReplaceAll(reference, "$", ""), ":")
+	if len(refs) > 2 {
+		return cr, false
+	}
+	for i, ref := range refs {
+		refSheet := sheet
+		if idx := strings.LastIndex(ref, "!"); idx != -1 {
+			refSheet = strings.ReplaceAll(strings.Trim(ref[:idx], "'"), "''", "'")
+			ref = ref[idx+1:]
+		}
+		cellRef, col, row, err := f.parseRef(ref)
+		if err != nil || (len(refs) == 1 && (col || row)) {
+			return cr, false
+		}
+		cellRef.Sheet = refSheet
+		if i == 0 {
+			cr.From, cr.To = cellRef, cellRef
+			if col {
+				cr.From.Row, cr.To.Row = 1, TotalRows
+			}
+			if row {
+				cr.From.Col, cr.To.Col = 1, MaxColumns
+			}
+			sheet = refSheet
+			continue
+		}
+		if err = cr.prepareCellRange(col, row, cellRef); err != nil {
+			return cr, false
+		}
+	}
+	return cr, true
+}
+
 // getPriority calculate arithmetic operator priority.
 func getPriority(token efp.Token) (pri int) {
 	pri = tokenPriority[token.TValue]
@@ -911,7 +1663,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 
 		// out of function stack
 		if opfStack.Len() == 0 {
-			if err = f.parseToken(ctx, sheet, token, opdStack, optStack); err != nil {
+			if err = f.parseToken(ctx, sheet, cell, token, opdStack, optStack); err != nil {
 				return newEmptyFormulaArg(), err
 			}
 		}
@@ -926,6 +1678,21 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				inArrayRow = true
 				continue
 			}
+			if fn, ok := lazyFormulaFuncs[strings.TrimPrefix(token.TValue, "_xlfn.")]; ok {
+				var (
+					args      []func() formulaArg
+					nextToken efp.Token
+				)
+				if args, i = f.parseLazyFuncArgs(ctx, sheet, cell, tokens, i); i+1 < len(tokens) {
+					nextToken = tokens[i+1]
+				}
+				arg := fn(args)
+				if arg.Type == ArgError && opfStack.Len() == 0 {
+					return arg, errors.New(arg.Error)
+				}
+				pushFormulaFuncResult(arg, nextToken, opfStack, opdStack, opftStack, opfdStack, argsStack)
+				continue
+			}
 			opfStack.Push(token)
 			argsStack.Push(list.New().Init())
 			opftStack.Push(token) // to know which operators belong to a function use the function as a separator
@@ -942,12 +1709,8 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 			// current token is args or range, skip next token, order required: parse reference first
 			if token.TSubType == efp.TokenSubTypeRange {
 				if opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
 					// parse reference: must reference at here
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseNameOrReference(ctx, sheet, cell, token.TValue)
 					if err != nil {
 						return result, err
 					}
@@ -956,11 +1719,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				}
 				if nextToken.TType == efp.TokenTypeArgument || nextToken.TType == efp.TokenTypeFunction {
 					// parse reference: reference or range at here
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseNameOrReference(ctx, sheet, cell, token.TValue)
 					if err != nil {
 						return result, err
 					}
@@ -983,7 +1742,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 			}
 
 			// check current token is opft
-			if err = f.parseToken(ctx, sheet, token, opfdStack, opftStack); err != nil {
+			if err = f.parseToken(ctx, sheet, cell, token, opfdStack, opftStack); err != nil {
 				return newEmptyFormulaArg(), err
 			}
 
@@ -1041,7 +1800,7 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
 	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}, strings.NewReplacer(
-		"_xlfn.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
+		"_xlfn.", "", "_xlws.", "", ".", "dot").Replace(opfStack.Peek().(efp.Token).TValue),
 		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
@@ -1049,6 +1808,13 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	argsStack.Pop()
 	opftStack.Pop() // remove current function separator
 	opfStack.Pop()
+	pushFormulaFuncResult(arg, nextToken, opfStack, opdStack, opftStack, opfdStack, argsStack)
+	return newEmptyFormulaArg()
+}
+
+// pushFormulaFuncResult push the result of the formula function into the
+// operand stack, or the arguments list of the outer formula function.
+func pushFormulaFuncResult(arg formulaArg, nextToken efp.Token, opfStack, opdStack, opftStack, opfdStack, argsStack *Stack) {
 	if opfStack.Len() > 0 { // still in function stack
 		if nextToken.TType == efp.TokenTypeOperatorInfix || (opftStack.Len() > 1 && opfdStack.Len() > 0) {
 			// mathematics calculate in formula function
@@ -1057,13 +1823,72 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 			argsStack.Peek().(*list.List).PushBack(arg)
 		}
 	} else {
-		val := arg.Value()
-		if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
-			val = arg.Matrix[0][0].Value()
+		opdStack.Push(arg)
+	}
+}
+
+// parseLazyFuncArgs split the tokens of the formula function which starts at
+// the given index into arguments, and returns the functions to evaluate each
+// argument on demand, and the index of the function stop token.
+func (f *File) parseLazyFuncArgs(ctx *calcContext, sheet, cell string, tokens []efp.Token, start int) ([]func() formulaArg, int) {
+	var (
+		args  []func() formulaArg
+		depth int
+		from  = start + 1
+		end   = len(tokens)
+	)
+	lazyArg := func(argTokens []efp.Token) func() formulaArg {
+		return func() formulaArg {
+			if len(argTokens) == 0 {
+				return newEmptyFormulaArg()
+			}
+			if len(argTokens) == 1 && argTokens[0].TSubType == efp.TokenSubTypeRange {
+				result, err := f.parseNameOrReference(ctx, sheet, cell, argTokens[0].TValue)
+				if err != nil && result.Type != ArgError {
+					return newCalcErrorFormulaArg(err)
+				}
+				return result
+			}
+			result, err := f.evalInfixExp(ctx, sheet, cell, argTokens)
+			if err != nil && result.Type != ArgError {
+				return newCalcErrorFormulaArg(err)
+			}
+			return result
 		}
-		opdStack.Push(newStringFormulaArg(val))
 	}
-	return newEmptyFormulaArg()
+	for i := from; i < len(tokens); i++ {
+		token := tokens[i]
+		if isFunctionStartToken(token) || isBeginParenthesesToken(token) {
+			depth++
+			continue
+		}
+		if isFunctionStopToken(token) || isEndParenthesesToken(token) {
+			if depth == 0 {
+				end = i
+				break
+			}
+			depth--
+			continue
+		}
+		if depth == 0 && token.TType == efp.TokenTypeArgument {
+			args = append(args, lazyArg(tokens[from:i]))
+			from = i + 1
+		}
+	}
+	if end > start+1 {
+		args = append(args, lazyArg(tokens[from:end]))
+	}
+	return args, end
+}
+
+// newCalcErrorFormulaArg create a formula error argument by given error of
+// evaluating the argument of the formula function on demand, the operand
+// conversion errors will be mapped to the #VALUE! formula error.
+func newCalcErrorFormulaArg(err error) formulaArg {
+	if err == ErrInvalidFormula || strings.HasPrefix(err.Error(), "#") {
+		return newErrorFormulaArg(err.Error(), err.Error())
+	}
+	return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 }
 
 // prepareEvalInfixExp check the token and stack state for formula function
@@ -1363,7 +2188,7 @@ func isOperatorPrefixToken(token efp.Token) bool {
 
 // isOperand determine if the token is parse operand.
 func isOperand(token efp.Token) bool {
-	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical)
+	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical || token.TSubType == efp.TokenSubTypeError)
 }
 
 // tokenToFormulaArg create a formula argument by given token.
@@ -1374,6 +2199,8 @@ func tokenToFormulaArg(token efp.Token) formulaArg {
 	case efp.TokenSubTypeNumber:
 		num, _ := strconv.ParseFloat(token.TValue, 64)
 		return newNumberFormulaArg(num)
+	case efp.TokenSubTypeError:
+		return newErrorFormulaArg(token.TValue, token.TValue)
 	default:
 		return newStringFormulaArg(token.TValue)
 	}
@@ -1387,6 +2214,8 @@ func formulaArgToToken(arg formulaArg) efp.Token {
 			return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeLogical}
 		}
 		return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber}
+	case ArgError:
+		return efp.Token{TValue: arg.String, TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeError}
 	default:
 		return efp.Token{TValue: arg.Value(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeText}
 	}
@@ -1394,14 +2223,10 @@ func formulaArgToToken(arg formulaArg) efp.Token {
 
 // parseToken parse basic arithmetic operator priority and evaluate based on
 // operators and operands.
-func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdStack, optStack *Stack) error {
+func (f *File) parseToken(ctx *calcContext, sheet, cell string, token efp.Token, opdStack, optStack *Stack) error {
 	// parse reference: must reference at here
 	if token.TSubType == efp.TokenSubTypeRange {
-		refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-		if refTo != "" {
-			token.TValue = refTo
-		}
-		result, err := f.parseReference(ctx, sheet, token.TValue)
+		result, err := f.parseNameOrReference(ctx, sheet, cell, token.TValue)
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
@@ -1488,6 +2313,37 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 	return nil
 }
 
+// parseNameOrReference parse the defined name or reference and extract values
+// by given reference characters and default sheet name. If the defined name
+// refers to a constant or formula instead of a reference, it will be
+// evaluated as a formula. The defined name which refers to itself directly or
+// indirectly will be treated as a circular reference.
+func (f *File) parseNameOrReference(ctx *calcContext, sheet, cell, reference string) (formulaArg, error) {
+	refTo := f.getDefinedNameRefTo(reference, sheet)
+	if refTo == "" {
+		return f.parseReference(ctx, sheet, reference)
+	}
+	name := strings.ToUpper(fmt.Sprintf("%s!%s", sheet, reference))
+	ctx.mu.Lock()
+	if _, ok := ctx.definedNames[name]; ok {
+		ctx.mu.Unlock()
+		return newErrorFormulaArg(formulaErrorNAME, "circular reference in defined name"), errors.New(formulaErrorNAME)
+	}
+	ctx.definedNames[name] = struct{}{}
+	ctx.mu.Unlock()
+	defer func() {
+		ctx.mu.Lock()
+		delete(ctx.definedNames, name)
+		ctx.mu.Unlock()
+	}()
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(strings.TrimPrefix(refTo, "="))
+	if len(tokens) == 1 && tokens[0].TSubType == efp.TokenSubTypeRange {
+		return f.parseNameOrReference(ctx, sheet, cell, tokens[0].TValue)
+	}
+	return f.evalInfixExp(ctx, sheet, cell, tokens)
+}
+
 // parseReference parse reference and extract values by given reference
 // characters and default sheet name.
 func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
@@ -1570,14 +2426,23 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		value string
 		err   error
 	)
+	if f.isMergedCellNonAnchor(sheet, cell) {
+		return newEmptyFormulaArg(), err
+	}
 	ref := fmt.Sprintf("%s!%s", sheet, cell)
 	if formula, _ := f.GetCellFormula(sheet, cell); len(formula) != 0 {
 		ctx.mu.Lock()
+		if result, ok := ctx.results[strings.ToUpper(ref)]; ok {
+			ctx.mu.Unlock()
+			return result, nil
+		}
 		if ctx.entry != ref {
 			if ctx.iterations[ref] <= f.options.MaxCalcIterations {
 				ctx.iterations[ref]++
 				ctx.mu.Unlock()
-				arg, _ = f.calcCellValue(ctx, sheet, cell)
+				if arg, _ = f.calcCellValue(ctx, sheet, cell); arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
+					arg = arg.Matrix[0][0]
+				}
 				ctx.iterationsCache[ref] = arg
 				return arg, nil
 			}
@@ -1606,6 +2471,29 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 	}
 }
 
+// isMergedCellNonAnchor provides a function to check if the given cell is in a
+// merged cell range but not the top-left cell of the range. Only the top-left
+// cell of a merged cell range holds the value, and the other cells in the
+// range are blank in the formula calculation.
+func (f *File) isMergedCellNonAnchor(sheet, cell string) bool {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return false
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	anchor, err := ws.mergeCellsParser(cell)
+	if err != nil {
+		return false
+	}
+	col, row, _ := CellNameToCoordinates(cell)
+	anchorCol, anchorRow, err := CellNameToCoordinates(anchor)
+	return err == nil && (col != anchorCol || row != anchorRow)
+}
+
 // rangeResolver extract value as string from given reference and range list.
 // This function will not ignore the empty cell. For example, A1:A2:A2:B3 will
 // be reference A1:B3.
@@ -4651,6 +5539,12 @@ func minor(sqMtx [][]float64, idx int) [][]float64 {
 
 // det determinant of the 2x2 matrix.
 func det(sqMtx [][]float64) float64 {
+	switch len(sqMtx) {
+	case 0:
+		return 1
+	case 1:
+		return sqMtx[0][0]
+	}
 	if len(sqMtx) == 2 {
 		m00 := sqMtx[0][0]
 		m01 := sqMtx[0][1]
@@ -10102,7 +10996,13 @@ func (fn *formulaFuncs) PERCENTILEdotEXC(argsList *list.List) formulaArg {
 	cnt := len(numbers)
 	sort.Float64s(numbers)
 	idx := k.Number * (float64(cnt) + 1)
+	if idx < 1 || idx > float64(cnt) {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	base := math.Floor(idx)
+	if idx == base {
+		return newNumberFormulaArg(numbers[int(base)-1])
+	}
 	next := base - 1
 	proportion := math.Nextafter(idx, idx) - base
 	return newNumberFormulaArg(numbers[int(next)] + ((numbers[int(base)] - numbers[int(next)]) * proportion))
@@ -10135,7 +11035,7 @@ func (fn *formulaFuncs) PERCENTILE(argsList *list.List) formulaArg {
 		return k
 	}
 	if k.Number < 0 || k.Number > 1 {
-		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
 	}
 	var numbers []float64
 	for _, arg := range array {
@@ -10147,6 +11047,9 @@ func (fn *formulaFuncs) PERCENTILE(argsList *list.List) formulaArg {
 		}
 	}
 	cnt := len(numbers)
+	if cnt == 0 {
+		return newErrorFormulaArg(formulaErrorNUM, formulaErrorNUM)
+	}
 	sort.Float64s(numbers)
 	idx := k.Number * (float64(cnt) - 1)
 	base := math.Floor(idx)
@@ -10351,7 +11254,8 @@ func (fn *formulaFuncs) QUARTILEdotINC(argsList *list.List) formulaArg {
 	return fn.QUARTILE(argsList)
 }
 
-// rank is an implementation of the formula functions RANK and RANK.EQ.
+// rank is an implementation of the formula functions RANK, RANK.AVG and
+// RANK.EQ.
 func (fn *formulaFuncs) rank(name string, argsList *list.List) formulaArg {
 	if argsList.Len() < 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 2 arguments", name))
@@ -10379,10 +11283,27 @@ func (fn *formulaFuncs) rank(name string, argsList *list.List) formulaArg {
 	if order.Number == 0 {
 		sort.Sort(sort.Reverse(sort.Float64Slice(arr)))
 	}
-	if idx := inFloat64Slice(arr, num.Number); idx != -1 {
-		return newNumberFormulaArg(float64(idx + 1))
+	idx := inFloat64Slice(arr, num.Number)
+	if idx == -1 {
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 	}
-	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	if name == "RANK.AVG" {
+		last := idx
+		for last+1 < len(arr) && arr[last+1] == num.Number {
+			last++
+		}
+		return newNumberFormulaArg(float64(idx+last)/2 + 1)
+	}
+	return newNumberFormulaArg(float64(idx + 1))
+}
+
+// RANKdotAVG function returns the statistical rank of a given value, within a
+// supplied array of values. If there are duplicate values in the list, the
+// average rank is returned. The syntax of the function is:
+//
+//	RANK.AVG(number,ref,[order])
+func (fn *formulaFuncs) RANKdotAVG(argsList *list.List) formulaArg {
+	return fn.rank("RANK.AVG", argsList)
 }
 
 // RANKdotEQ function returns the statistical rank of a given value, within a
@@ -11634,18 +12555,42 @@ func (fn *formulaFuncs) IFNA(argsList *list.List) formulaArg {
 // IFS function tests a number of supplied conditions and returns the result
 // corresponding to the first condition that evaluates to TRUE. If none of
 // the supplied conditions evaluate to TRUE, the function returns the #N/A
-// error.
+// error. The conditions after the first TRUE condition and the values of the
+// other conditions will not affect the result, even if they are errors. The
+// syntax of the function is:
 //
 //	IFS(logical_test1,value_if_true1,[logical_test2,value_if_true2],...)
 func (fn *formulaFuncs) IFS(argsList *list.List) formulaArg {
-	if argsList.Len() < 2 {
+	return calcIFS(formulaArgFuncs(argsList))
+}
+
+// calcIFS evaluates the IFS function by given functions to evaluate each
+// argument, only the conditions before the first TRUE condition and the
+// corresponding value will be evaluated.
+func calcIFS(args []func() formulaArg) formulaArg {
+	if len(args) < 2 {
 		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires at least 2 arguments")
 	}
-	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
-		if arg.Value.(formulaArg).ToBool().Number == 1 {
-			return arg.Next().Value.(formulaArg)
+	if len(args)%2 != 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "IFS requires an even number of arguments")
+	}
+	for i := 0; i < len(args); i += 2 {
+		cond := args[i]()
+		switch cond.Type {
+		case ArgError:
+			return cond
+		case ArgNumber:
+			if cond.Number != 0 {
+				return args[i+1]()
+			}
+		case ArgString:
+			if cond = cond.ToBool(); cond.Type == ArgError {
+				return cond
+			}
+			if cond.Number == 1 {
+				return args[i+1]()
+			}
 		}
-		arg = arg.Next()
 	}
 	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
 }
@@ -11716,34 +12661,48 @@ func (fn *formulaFuncs) OR(argsList *list.List) formulaArg {
 // SWITCH function compares a number of supplied values to a supplied test
 // expression and returns a result corresponding to the first value that
 // matches the test expression. A default value can be supplied, to be
-// returned if none of the supplied values match the test expression. The
-// syntax of the function is:
+// returned if none of the supplied values match the test expression. The text
+// values are compared case-insensitively, and the results which don't
+// correspond to the matched value will not affect the result, even if they
+// are errors. The syntax of the function is:
 //
 //	SWITCH(expression,value1,result1,[value2,result2],[value3,result3],...,[default])
 func (fn *formulaFuncs) SWITCH(argsList *list.List) formulaArg {
-	if argsList.Len() < 3 {
+	return calcSWITCH(formulaArgFuncs(argsList))
+}
+
+// calcSWITCH evaluates the SWITCH function by given functions to evaluate
+// each argument, only the values before the matched value and the
+// corresponding result will be evaluated.
+func calcSWITCH(args []func() formulaArg) formulaArg {
+	if len(args) < 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "SWITCH requires at least 3 arguments")
 	}
-	target := argsList.Front().Value.(formulaArg)
-	argCount := argsList.Len() - 1
-	switchCount := int(math.Floor(float64(argCount) / 2))
-	hasDefaultClause := argCount%2 != 0
-	result := newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
-	if hasDefaultClause {
-		result = argsList.Back().Value.(formulaArg)
-	}
-	if switchCount > 0 {
-		arg := argsList.Front()
-		for i := 0; i < switchCount; i++ {
-			arg = arg.Next()
-			if target.Value() == arg.Value.(formulaArg).Value() {
-				result = arg.Next().Value.(formulaArg)
-				break
-			}
-			arg = arg.Next()
+	target := args[0]()
+	if target.Type == ArgError {
+		return target
+	}
+	switchCount := (len(args) - 1) / 2
+	for i := 0; i < switchCount; i++ {
+		if strings.EqualFold(target.Value(), args[2*i+1]().Value()) {
+			return args[2*i+2]()
 		}
 	}
-	return result
+	if (len(args)-1)%2 != 0 {
+		return args[len(args)-1]()
+	}
+	return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+}
+
+// formulaArgFuncs converts the list of evaluated formula arguments to the
+// functions which return each argument.
+func formulaArgFuncs(argsList *list.List) []func() formulaArg {
+	args := make([]func() formulaArg, 0, argsList.Len())
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		value := arg.Value.(formulaArg)
+		args = append(args, func() formulaArg { return value })
+	}
+	return args
 }
 
 // TRUE function returns the logical value TRUE. The syntax of the function
@@ -11855,17 +12814,23 @@ func calcDateDif(unit string, diff float64, seq []int, startArg, endArg formulaA
 }
 
 // DATEDIF function calculates the number of days, months, or years between
-// two dates. The syntax of the function is:
+// two dates. The start_date and end_date can be serial numbers or date text,
+// and the unit is one of "Y", "M", "D", "MD", "YM" and "YD". The syntax of
+// the function is:
 //
 //	DATEDIF(start_date,end_date,unit)
 func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 	if argsList.Len() != 3 {
 		return newErrorFormulaArg(formulaErrorVALUE, "DATEDIF requires 3 number arguments")
 	}
-	startArg, endArg := argsList.Front().Value.(formulaArg).ToNumber(), argsList.Front().Next().Value.(formulaArg).ToNumber()
-	if startArg.Type != ArgNumber || endArg.Type != ArgNumber {
+	startArg := toExcelDateArg(argsList.Front().Value.(formulaArg))
+	if startArg.Type != ArgNumber {
 		return startArg
 	}
+	endArg := toExcelDateArg(argsList.Front().Next().Value.(formulaArg))
+	if endArg.Type != ArgNumber {
+		return endArg
+	}
 	if startArg.Number > endArg.Number {
 		return newErrorFormulaArg(formulaErrorNUM, "start_date > end_date")
 	}
@@ -11897,7 +12862,7 @@ func (fn *formulaFuncs) DATEDIF(argsList *list.List) formulaArg {
 	case "d", "md", "ym", "yd":
 		diff = calcDateDif(unit, diff, []int{ey, sy, em, sm, ed, sd}, startArg, endArg)
 	default:
-		return newErrorFormulaArg(formulaErrorVALUE, "DATEDIF has invalid unit")
+		return newErrorFormulaArg(formulaErrorNUM, "DATEDIF has invalid unit")
 	}
 	return newNumberFormulaArg(diff)
 }
@@ -13743,6 +14708,165 @@ func (fn *formulaFuncs) SUBSTITUTE(argsList *list.List) formulaArg {
 	return newStringFormulaArg(pre + targetText.Value() + post)
 }
 
+// textDelimiterMatch defines the position of a matched delimiter in the text
+// for the formula functions TEXTAFTER, TEXTBEFORE and TEXTSPLIT.
+type textDelimiterMatch struct {
+	start, end int
+}
+
+// getTextDelimiters returns the delimiters by given formula argument, the
+// delimiters can be a single text or an array of texts.
+func getTextDelimiters(arg formulaArg) []string {
+	var delimiters []string
+	for _, delimiter := range arg.ToList() {
+		delimiters = append(delimiters, delimiter.Value())
+	}
+	if len(delimiters) == 0 {
+		delimiters = append(delimiters, arg.Value())
+	}
+	return delimiters
+}
+
+// findTextDelimiters returns the positions of the non-overlapping delimiters
+// in the text from left to right. The earliest delimiter will be matched if
+// multiple delimiters are found, and the longest one will be matched if they
+// start at the same position. The empty delimiters will be ignored.
+func findTextDelimiters(text string, delimiters []string, ignoreCase bool) []textDelimiterMatch {
+	var matches []textDelimiterMatch
+	for pos := 0; pos < len(text); {
+		match := textDelimiterMatch{start: -1}
+		for _, delimiter := range delimiters {
+			if delimiter == "" {
+				continue
+			}
+			start, end := indexTextDelimiter(text[pos:], delimiter, ignoreCase)
+			if start == -1 {
+				continue
+			}
+			if start, end = start+pos, end+pos; match.start == -1 || start < match.start || (start == match.start && end > match.end) {
+				match = textDelimiterMatch{start: start, end: end}
+			}
+		}
+		if match.start == -1 {
+			break
+		}
+		matches, pos = append(matches, match), match.end
+	}
+	return matches
+}
+
+// indexTextDelimiter returns the start and end byte offsets of the first
+// delimiter in the text, or -1 if the delimiter isn't found. The
+// case-insensitive matching compares the delimiter with each window of the
+// same number of runes in the original text, so that the offsets are always
+// valid for the original text even if the case conversion changes the length
+// of the characters.
+func indexTextDelimiter(text, delimiter string, ignoreCase bool) (int, int) {
+	if !ignoreCase {
+		if idx := strings.Index(text, delimiter); idx != -1 {
+			return idx, idx + len(delimiter)
+		}
+		return -1, -1
+	}
+	count := utf8.RuneCountInString(delimiter)
+	for start := 0; start < len(text); {
+		end := start
+		for n := 0; n < count && end < len(text); n++ {
+			_, size := utf8.DecodeRuneInString(text[end:])
+			end += size
+		}
+		if strings.EqualFold(text[start:end], delimiter) {
+			return start, end
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		start += size
+	}
+	return -1, -1
+}
+
+// textAfterBefore is an implementation of the formula functions TEXTAFTER and
+// TEXTBEFORE.
+func (fn *formulaFuncs) textAfterBefore(name string, argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least 2 arguments", name))
+	}
+	if argsList.Len() > 6 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s accepts at most 6 arguments", name))
+	}
+	var args []formulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		if arg.Value.(formulaArg).Type == ArgError {
+			return arg.Value.(formulaArg)
+		}
+		args = append(args, arg.Value.(formulaArg))
+	}
+	text, delimiters := args[0].Value(), getTextDelimiters(args[1])
+	opts := []formulaArg{newNumberFormulaArg(1), newNumberFormulaArg(0), newNumberFormulaArg(0)}
+	for i := 2; i < len(args) && i < 5; i++ {
+		if args[i].Type == ArgEmpty {
+			continue
+		}
+		if opts[i-2] = args[i].ToNumber(); opts[i-2].Type != ArgNumber {
+			return opts[i-2]
+		}
+	}
+	instanceNum, matchMode, matchEnd := int(opts[0].Number), opts[1].Number, opts[2].Number
+	if instanceNum == 0 || math.Abs(float64(instanceNum)) > float64(len(text)) && len(text) > 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires instance_num not be 0 or greater than the length of text", name))
+	}
+	if matchMode != 0 && matchMode != 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires match_mode to be 0 or 1", name))
+	}
+	matches := findTextDelimiters(text, delimiters, matchMode == 1)
+	if inStrSlice(delimiters, "", true) != -1 {
+		matches = []textDelimiterMatch{{}}
+		if instanceNum < 0 {
+			matches[0] = textDelimiterMatch{start: len(text), end: len(text)}
+		}
+	}
+	if matchEnd == 1 {
+		if instanceNum > 0 {
+			matches = append(matches, textDelimiterMatch{start: len(text), end: len(text)})
+		} else {
+			matches = append([]textDelimiterMatch{{}}, matches...)
+		}
+	}
+	idx := instanceNum - 1
+	if instanceNum < 0 {
+		idx = len(matches) + instanceNum
+	}
+	if idx < 0 || idx >= len(matches) {
+		if len(args) == 6 {
+			return args[5]
+		}
+		return newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	}
+	if name == "TEXTBEFORE" {
+		return newStringFormulaArg(text[:matches[idx].start])
+	}
+	return newStringFormulaArg(text[matches[idx].end:])
+}
+
+// TEXTAFTER function returns the text that occurs after the given delimiter.
+// The delimiter can be a text or an array of texts, and the negative
+// instance_num searches the delimiter from the end of the text. The syntax of
+// the function is:
+//
+//	TEXTAFTER(text,delimiter,[instance_num],[match_mode],[match_end],[if_not_found])
+func (fn *formulaFuncs) TEXTAFTER(argsList *list.List) formulaArg {
+	return fn.textAfterBefore("TEXTAFTER", argsList)
+}
+
+// TEXTBEFORE function returns the text that occurs before the given
+// delimiter. The delimiter can be a text or an array of texts, and the
+// negative instance_num searches the delimiter from the end of the text. The
+// syntax of the function is:
+//
+//	TEXTBEFORE(text,delimiter,[instance_num],[match_mode],[match_end],[if_not_found])
+func (fn *formulaFuncs) TEXTBEFORE(argsList *list.List) formulaArg {
+	return fn.textAfterBefore("TEXTBEFORE", argsList)
+}
+
 // TEXTJOIN function joins together a series of supplied text strings into one
 // combined text string. The user can specify a delimiter to add between the
 // individual text items, if required. The syntax of the function is:
@@ -13800,6 +14924,98 @@ func textJoin(arg *list.Element, arr []string, ignoreEmpty bool) ([]string, form
 	return arr, newBoolFormulaArg(true)
 }
 
+// splitText splits the text by given delimiters, and the empty texts between
+// the adjacent delimiters will be removed if ignoreEmpty is true.
+func splitText(text string, delimiters []string, ignoreCase, ignoreEmpty bool) []string {
+	var (
+		texts []string
+		pos   int
+	)
+	for _, match := range findTextDelimiters(text, delimiters, ignoreCase) {
+		texts, pos = append(texts, text[pos:match.start]), match.end
+	}
+	texts = append(texts, text[pos:])
+	if !ignoreEmpty {
+		return texts
+	}
+	var result []string
+	for _, t := range texts {
+		if t != "" {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// TEXTSPLIT function splits the text into columns and rows by given column
+// and row delimiters, the delimiters can be a text or an array of texts. The
+// rows which have fewer columns will be padded with the pad_with value, or
+// the #N/A error if it is omitted. The syntax of the function is:
+//
+//	TEXTSPLIT(text,col_delimiter,[row_delimiter],[ignore_empty],[match_mode],[pad_with])
+func (fn *formulaFuncs) TEXTSPLIT(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT requires at least 2 arguments")
+	}
+	if argsList.Len() > 6 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT accepts at most 6 arguments")
+	}
+	var args []formulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	if args[0].Type == ArgError {
+		return args[0]
+	}
+	colDelimiters, rowDelimiters := getTextDelimiters(args[1]), []string{}
+	if len(args) > 2 {
+		rowDelimiters = getTextDelimiters(args[2])
+	}
+	if strings.Join(colDelimiters, "") == "" && strings.Join(rowDelimiters, "") == "" {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT requires col_delimiter or row_delimiter")
+	}
+	opts := []formulaArg{newBoolFormulaArg(false), newNumberFormulaArg(0)}
+	for i := 3; i < len(args) && i < 5; i++ {
+		if args[i].Type == ArgEmpty {
+			continue
+		}
+		if opts[i-3] = args[i].ToNumber(); opts[i-3].Type != ArgNumber {
+			return opts[i-3]
+		}
+	}
+	ignoreEmpty, matchMode := opts[0].Number != 0, opts[1].Number
+	if matchMode != 0 && matchMode != 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "TEXTSPLIT requires match_mode to be 0 or 1")
+	}
+	padWith := newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+	if len(args) == 6 {
+		padWith = args[5]
+	}
+	var (
+		mtx  [][]formulaArg
+		cols int
+	)
+	for _, row := range splitText(args[0].Value(), rowDelimiters, matchMode == 1, ignoreEmpty) {
+		var cells []formulaArg
+		for _, cell := range splitText(row, colDelimiters, matchMode == 1, ignoreEmpty) {
+			cells = append(cells, newStringFormulaArg(cell))
+		}
+		if len(cells) > cols {
+			cols = len(cells)
+		}
+		mtx = append(mtx, cells)
+	}
+	if cols == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, "TEXTSPLIT returns an empty array")
+	}
+	for r := range mtx {
+		for len(mtx[r]) < cols {
+			mtx[r] = append(mtx[r], padWith)
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
 // TRIM removes extra spaces (i.e. all spaces except for single spaces between
 // words or characters) from a supplied text string. The syntax of the
 // function is:
@@ -13923,6 +15139,8 @@ func (fn *formulaFuncs) IF(argsList *list.List) formulaArg {
 		switch value.Type {
 		case ArgNumber:
 			result = value.ToNumber()
+		case ArgError:
+			result = value
 		default:
 			result = newStringFormulaArg(value.String)
 		}
@@ -13933,6 +15151,8 @@ func (fn *formulaFuncs) IF(argsList *list.List) formulaArg {
 		switch value.Type {
 		case ArgNumber:
 			result = value.ToNumber()
+		case ArgError:
+			result = value
 		default:
 			result = newStringFormulaArg(value.String)
 		}
@@ -14438,6 +15658,293 @@ func (fn *formulaFuncs) TRANSPOSE(argsList *list.List) formulaArg {
 	return newMatrixFormulaArg(mtx)
 }
 
+// getFormulaArgMatrix returns the values of the formula argument as a matrix,
+// and the empty cells in the matrix will be treated as number zero.
+func getFormulaArgMatrix(arg formulaArg) [][]formulaArg {
+	var src [][]formulaArg
+	switch arg.Type {
+	case ArgMatrix:
+		src = arg.Matrix
+	case ArgList:
+		src = [][]formulaArg{arg.List}
+	default:
+		src = [][]formulaArg{{arg}}
+	}
+	mtx := make([][]formulaArg, 0, len(src))
+	for _, row := range src {
+		values := make([]formulaArg, len(row))
+		for i, cell := range row {
+			if values[i] = cell; cell.Type == ArgEmpty {
+				values[i] = newNumberFormulaArg(0)
+			}
+		}
+		if len(values) > 0 {
+			mtx = append(mtx, values)
+		}
+	}
+	return mtx
+}
+
+// transposeFormulaArgMatrix returns the transposed matrix of the given matrix.
+func transposeFormulaArgMatrix(mtx [][]formulaArg) [][]formulaArg {
+	if len(mtx) == 0 {
+		return mtx
+	}
+	trans := make([][]formulaArg, len(mtx[0]))
+	for c := range trans {
+		trans[c] = make([]formulaArg, len(mtx))
+		for r := range mtx {
+			if c < len(mtx[r]) {
+				trans[c][r] = mtx[r][c]
+			}
+		}
+	}
+	return trans
+}
+
+// getArrayFlagArg returns the boolean value of the optional flag argument in
+// the array functions, the omitted argument will be treated as FALSE.
+func getArrayFlagArg(arg formulaArg) formulaArg {
+	if arg.Type == ArgEmpty {
+		return newBoolFormulaArg(false)
+	}
+	if num := arg.ToNumber(); num.Type == ArgNumber {
+		return newBoolFormulaArg(num.Number != 0)
+	}
+	return arg.ToBool()
+}
+
+// UNIQUE function returns a list of unique values in a list or range. The
+// syntax of the function is:
+//
+//	UNIQUE(array,[by_col],[exactly_once])
+func (fn *formulaFuncs) UNIQUE(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "UNIQUE requires at least 1 argument")
+	}
+	if argsList.Len() > 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "UNIQUE requires at most 3 arguments")
+	}
+	mtx := getFormulaArgMatrix(argsList.Front().Value.(formulaArg))
+	byCol, exactlyOnce := newBoolFormulaArg(false), newBoolFormulaArg(false)
+	if argsList.Len() > 1 {
+		if byCol = getArrayFlagArg(argsList.Front().Next().Value.(formulaArg)); byCol.Type != ArgNumber {
+			return byCol
+		}
+	}
+	if argsList.Len() > 2 {
+		if exactlyOnce = getArrayFlagArg(argsList.Back().Value.(formulaArg)); exactlyOnce.Type != ArgNumber {
+			return exactlyOnce
+		}
+	}
+	if byCol.Number == 1 {
+		mtx = transposeFormulaArgMatrix(mtx)
+	}
+	keys, counts := make([]string, len(mtx)), map[string]int{}
+	for i, row := range mtx {
+		values := make([]string, len(row))
+		for j, cell := range row {
+			values[j] = fmt.Sprintf("%d:%t:%s", cell.Type, cell.Boolean, strings.ToLower(cell.Value()))
+		}
+		keys[i] = strings.Join(values, "\x00")
+		counts[keys[i]]++
+	}
+	var result [][]formulaArg
+	for i, row := range mtx {
+		if count := counts[keys[i]]; count == 0 || (exactlyOnce.Number == 1 && count > 1) {
+			continue
+		}
+		counts[keys[i]] = 0
+		result = append(result, row)
+	}
+	if len(result) == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, "UNIQUE returns an empty array")
+	}
+	if byCol.Number == 1 {
+		result = transposeFormulaArgMatrix(result)
+	}
+	return newMatrixFormulaArg(result)
+}
+
+// compareSortFormulaArg compares the formula arguments in the sort order of
+// the array functions, the numbers are sorted before the text, the logical
+// values and the error values.
+func compareSortFormulaArg(lhs, rhs formulaArg) int {
+	rank := func(arg formulaArg) int {
+		switch arg.Type {
+		case ArgNumber:
+			if arg.Boolean {
+				return 2
+			}
+			return 0
+		case ArgString:
+			return 1
+		case ArgError:
+			return 3
+		}
+		return 4
+	}
+	lr, rr := rank(lhs), rank(rhs)
+	if lr != rr {
+		return lr - rr
+	}
+	switch lr {
+	case 0, 2:
+		if lhs.Number < rhs.Number {
+			return -1
+		}
+		if lhs.Number > rhs.Number {
+			return 1
+		}
+	case 1:
+		return strings.Compare(strings.ToLower(lhs.String), strings.ToLower(rhs.String))
+	}
+	return 0
+}
+
+// sortFormulaArgMatrix sorts the rows of the matrix by the given sort keys and
+// sort orders, the sort is stable. Each sort key contains the values for each
+// row of the matrix, and the sort order 1 for ascending and -1 for descending.
+func sortFormulaArgMatrix(mtx [][]formulaArg, keys [][]formulaArg, orders []float64) [][]formulaArg {
+	indexes := make([]int, len(mtx))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		for k, key := range keys {
+			if cmp := compareSortFormulaArg(key[indexes[i]], key[indexes[j]]); cmp != 0 {
+				return float64(cmp)*orders[k] < 0
+			}
+		}
+		return false
+	})
+	sorted := make([][]formulaArg, len(mtx))
+	for i, idx := range indexes {
+		sorted[i] = mtx[idx]
+	}
+	return sorted
+}
+
+// getSortOrderArg returns the sort order argument of the array functions, the
+// sort order must be 1 for ascending or -1 for descending.
+func getSortOrderArg(name string, arg formulaArg) formulaArg {
+	if arg.Type == ArgEmpty {
+		return newNumberFormulaArg(1)
+	}
+	order := arg.ToNumber()
+	if order.Type != ArgNumber {
+		return order
+	}
+	if order.Number != 1 && order.Number != -1 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires sort_order to be 1 or -1", name))
+	}
+	return order
+}
+
+// SORT function sorts the contents of a range or array. The syntax of the
+// function is:
+//
+//	SORT(array,[sort_index],[sort_order],[by_col])
+func (fn *formulaFuncs) SORT(argsList *list.List) formulaArg {
+	if argsList.Len() < 1 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORT requires at least 1 argument")
+	}
+	if argsList.Len() > 4 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORT requires at most 4 arguments")
+	}
+	var args []formulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	mtx := getFormulaArgMatrix(args[0])
+	sortIndex, sortOrder, byCol := newNumberFormulaArg(1), newNumberFormulaArg(1), newBoolFormulaArg(false)
+	if len(args) > 1 && args[1].Type != ArgEmpty {
+		if sortIndex = args[1].ToNumber(); sortIndex.Type != ArgNumber {
+			return sortIndex
+		}
+	}
+	if len(args) > 2 {
+		if sortOrder = getSortOrderArg("SORT", args[2]); sortOrder.Type != ArgNumber {
+			return sortOrder
+		}
+	}
+	if len(args) > 3 {
+		if byCol = getArrayFlagArg(args[3]); byCol.Type != ArgNumber {
+			return byCol
+		}
+	}
+	if byCol.Number == 1 {
+		mtx = transposeFormulaArgMatrix(mtx)
+	}
+	if len(mtx) == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, "SORT returns an empty array")
+	}
+	idx := int(sortIndex.Number)
+	if idx < 1 || idx > len(mtx[0]) {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORT requires sort_index in the array")
+	}
+	key := make([]formulaArg, len(mtx))
+	for i, row := range mtx {
+		key[i] = row[idx-1]
+	}
+	sorted := sortFormulaArgMatrix(mtx, [][]formulaArg{key}, []float64{sortOrder.Number})
+	if byCol.Number == 1 {
+		sorted = transposeFormulaArgMatrix(sorted)
+	}
+	return newMatrixFormulaArg(sorted)
+}
+
+// SORTBY function sorts the contents of a range or array based on the values
+// in a corresponding range or array. The syntax of the function is:
+//
+//	SORTBY(array,by_array1,[sort_order1],[by_array2,sort_order2],...)
+func (fn *formulaFuncs) SORTBY(argsList *list.List) formulaArg {
+	if argsList.Len() < 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "SORTBY requires at least 2 arguments")
+	}
+	var args []formulaArg
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	mtx := getFormulaArgMatrix(args[0])
+	if len(mtx) == 0 {
+		return newErrorFormulaArg(formulaErrorCALC, "SORTBY returns an empty array")
+	}
+	var (
+		keys   [][]formulaArg
+		orders []float64
+		byCol  bool
+	)
+	for i := 1; i < len(args); i += 2 {
+		by, col := getFormulaArgMatrix(args[i]), false
+		var key []formulaArg
+		switch {
+		case len(by) == len(mtx) && len(by[0]) == 1:
+			for _, row := range by {
+				key = append(key, row[0])
+			}
+		case len(by) == 1 && len(by[0]) == len(mtx[0]):
+			key, col = by[0], true
+		default:
+			return newErrorFormulaArg(formulaErrorVALUE, "SORTBY requires by_array to be the same size as array")
+		}
+		if i > 1 && col != byCol {
+			return newErrorFormulaArg(formulaErrorVALUE, "SORTBY requires by_array to be the same direction")
+		}
+		sortOrder := newNumberFormulaArg(1)
+		if i+1 < len(args) {
+			if sortOrder = getSortOrderArg("SORTBY", args[i+1]); sortOrder.Type != ArgNumber {
+				return sortOrder
+			}
+		}
+		keys, orders, byCol = append(keys, key), append(orders, sortOrder.Number), col
+	}
+	if byCol {
+		return newMatrixFormulaArg(transposeFormulaArgMatrix(sortFormulaArgMatrix(transposeFormulaArgMatrix(mtx), keys, orders)))
+	}
+	return newMatrixFormulaArg(sortFormulaArgMatrix(mtx, keys, orders))
+}
+
 // lookupLinearSearch sequentially checks each look value of the lookup array until
 // a match is found or the whole list has been searched.
 func lookupLinearSearch(vertical bool, lookupValue, lookupArray, matchMode, searchMode formulaArg) (int, bool) {
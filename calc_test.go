@@ -2,9 +2,11 @@ package excelize
 
 import (
 	"container/list"
+	"errors"
 	"math"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -666,7 +668,8 @@ func TestCalcCellValue(t *testing.T) {
 		"=IMPRODUCT(COMPLEX(5,2),COMPLEX(0,1))": "-2+5i",
 		"=IMPRODUCT(A1:C1)":                     "4",
 		// MINVERSE
-		"=MINVERSE(A1:B2)": "-0",
+		"=MINVERSE(A1:B2)": "-1.66666666666667",
+		"=MINVERSE(A1:A1)": "1",
 		// MMULT
 		"=MMULT(A4:A4,A4:A4)": "0",
 		// MOD
@@ -1232,8 +1235,11 @@ func TestCalcCellValue(t *testing.T) {
 		// PERCENTILE.EXC
 		"=PERCENTILE.EXC(A1:A4,0.2)": "0",
 		"=PERCENTILE.EXC(A1:A4,0.6)": "2",
+		"=PERCENTILE.EXC(A1:A4,0.3)": "0.5",
+		"=PERCENTILE.EXC(A1:A4,0.8)": "3",
 		// PERCENTILE.INC
-		"=PERCENTILE.INC(A1:A4,0.2)": "0.6",
+		"=PERCENTILE.INC(A1:A4,0.2)":  "0.6",
+		"=PERCENTILE.INC(A1:A4,0.35)": "1.05",
 		// PERCENTILE
 		"=PERCENTILE(A1:A4,0.2)": "0.6",
 		"=PERCENTILE(0,0)":       "0",
@@ -1275,6 +1281,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=QUARTILE.EXC(A1:A4,3)": "2.75",
 		// QUARTILE.INC
 		"=QUARTILE.INC(A1:A4,0)": "0",
+		"=QUARTILE.INC(A1:A4,3)": "2.25",
 		// RANK
 		"=RANK(1,A1:B5)":   "5",
 		"=RANK(1,A1:B5,0)": "5",
@@ -1283,6 +1290,9 @@ func TestCalcCellValue(t *testing.T) {
 		"=RANK.EQ(1,A1:B5)":   "5",
 		"=RANK.EQ(1,A1:B5,0)": "5",
 		"=RANK.EQ(1,A1:B5,1)": "2",
+		// RANK.AVG
+		"=RANK.AVG(1,A1:B5)":   "5",
+		"=RANK.AVG(1,A1:B5,1)": "2",
 		// RSQ
 		"=RSQ(A1:A4,B1:B4)": "1",
 		// SKEW
@@ -1464,6 +1474,12 @@ func TestCalcCellValue(t *testing.T) {
 		"=IFS(4>1,5/4,4<-1,-5/4,TRUE,0)":     "1.25",
 		"=IFS(-2>1,5/-2,-2<-1,-5/-2,TRUE,0)": "2.5",
 		"=IFS(0>1,5/0,0<-1,-5/0,TRUE,0)":     "0",
+		"=IFS(1,\"A\",TRUE,\"B\")":           "A",
+		"=IFS(0,\"A\",\"TRUE\",\"B\")":       "B",
+		"=IFS(TRUE,1,1/0,2)":                 "1",
+		"=IFS(TRUE,1,FALSE,Missing!A1)":      "1",
+		"=IFS(FALSE,1,TRUE,2)*3":             "6",
+		"=SUM(IFS(FALSE,1,TRUE,2),3)":        "5",
 		// NOT
 		"=NOT(FALSE())":     "TRUE",
 		"=NOT(\"false\")":   "TRUE",
@@ -1480,6 +1496,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=SWITCH(1,1,\"A\",2,\"B\",3,\"C\",\"N\")": "A",
 		"=SWITCH(3,1,\"A\",2,\"B\",3,\"C\",\"N\")": "C",
 		"=SWITCH(4,1,\"A\",2,\"B\",3,\"C\",\"N\")": "N",
+		"=SWITCH(\"b\",\"A\",1,\"B\",2)":           "2",
+		"=SWITCH(1,1,\"A\",2,1/0)":                 "A",
+		"=SWITCH(1,1,\"A\",Missing!A1)":            "A",
+		"=1+SWITCH(2,1,\"A\",2,5)":                 "6",
 		// TRUE
 		"=TRUE()": "TRUE",
 		// XOR
@@ -1491,19 +1511,25 @@ func TestCalcCellValue(t *testing.T) {
 		"=DATE(2020,10,21)": "2020-10-21 00:00:00 +0000 UTC",
 		"=DATE(1900,1,1)":   "1899-12-31 00:00:00 +0000 UTC",
 		// DATEDIF
-		"=DATEDIF(43101,43101,\"D\")":  "0",
-		"=DATEDIF(43101,43891,\"d\")":  "790",
-		"=DATEDIF(43101,43891,\"Y\")":  "2",
-		"=DATEDIF(42156,44242,\"y\")":  "5",
-		"=DATEDIF(43101,43891,\"M\")":  "26",
-		"=DATEDIF(42171,44242,\"m\")":  "67",
-		"=DATEDIF(42156,44454,\"MD\")": "14",
-		"=DATEDIF(42171,44242,\"md\")": "30",
-		"=DATEDIF(43101,43891,\"YM\")": "2",
-		"=DATEDIF(42171,44242,\"ym\")": "7",
-		"=DATEDIF(43101,43891,\"YD\")": "59",
-		"=DATEDIF(36526,73110,\"YD\")": "60",
-		"=DATEDIF(42171,44242,\"yd\")": "244",
+		"=DATEDIF(43101,43101,\"D\")":                    "0",
+		"=DATEDIF(43101,43891,\"d\")":                    "790",
+		"=DATEDIF(43101,43891,\"Y\")":                    "2",
+		"=DATEDIF(42156,44242,\"y\")":                    "5",
+		"=DATEDIF(43101,43891,\"M\")":                    "26",
+		"=DATEDIF(42171,44242,\"m\")":                    "67",
+		"=DATEDIF(42156,44454,\"MD\")":                   "14",
+		"=DATEDIF(42171,44242,\"md\")":                   "30",
+		"=DATEDIF(43101,43891,\"YM\")":                   "2",
+		"=DATEDIF(42171,44242,\"ym\")":                   "7",
+		"=DATEDIF(43101,43891,\"YD\")":                   "59",
+		"=DATEDIF(36526,73110,\"YD\")":                   "60",
+		"=DATEDIF(42171,44242,\"yd\")":                   "244",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"Y\")":  "4",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"M\")":  "49",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"D\")":  "1516",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"MD\")": "24",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"YM\")": "1",
+		"=DATEDIF(\"2020-01-15\",\"2024-03-10\",\"YD\")": "55",
 		// DATEVALUE
 		"=DATEVALUE(\"01/01/16\")":   "42370",
 		"=DATEVALUE(\"01/01/2016\")": "42370",
@@ -2715,6 +2741,7 @@ func TestCalcCellValue(t *testing.T) {
 		"=SUM(1/)":           {ErrInvalidFormula.Error(), ErrInvalidFormula.Error()},
 		"=SUM(1*SUM(1/0))":   {"#DIV/0!", "#DIV/0!"},
 		"=SUM(1*SUM(1/0)*1)": {"", "#DIV/0!"},
+		"=SUM(1-\"a\",2)":    {"#VALUE!", "strconv.ParseFloat: parsing \"a\": invalid syntax"},
 		// SUMIF
 		"=SUMIF()": {"#VALUE!", "SUMIF requires at least 2 arguments"},
 		// SUMSQ
@@ -3237,13 +3264,17 @@ func TestCalcCellValue(t *testing.T) {
 		"=PERCENTILE.EXC(A1:A4,0)":    {"#NUM!", "#NUM!"},
 		"=PERCENTILE.EXC(A1:A4,1)":    {"#NUM!", "#NUM!"},
 		"=PERCENTILE.EXC(NA(),0.5)":   {"#NUM!", "#NUM!"},
+		"=PERCENTILE.EXC(A1:A4,0.1)":  {"#NUM!", "#NUM!"},
+		"=PERCENTILE.EXC(A1:A4,0.9)":  {"#NUM!", "#NUM!"},
 		// PERCENTILE.INC
 		"=PERCENTILE.INC()": {"#VALUE!", "PERCENTILE.INC requires 2 arguments"},
 		// PERCENTILE
-		"=PERCENTILE()":       {"#VALUE!", "PERCENTILE requires 2 arguments"},
-		"=PERCENTILE(0,\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
-		"=PERCENTILE(0,-1)":   {"#N/A", "#N/A"},
-		"=PERCENTILE(NA(),1)": {"#N/A", "#N/A"},
+		"=PERCENTILE()":          {"#VALUE!", "PERCENTILE requires 2 arguments"},
+		"=PERCENTILE(0,\"\")":    {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=PERCENTILE(0,-1)":      {"#NUM!", "#NUM!"},
+		"=PERCENTILE(0,1.1)":     {"#NUM!", "#NUM!"},
+		"=PERCENTILE(D1:D2,0.5)": {"#NUM!", "#NUM!"},
+		"=PERCENTILE(NA(),1)":    {"#N/A", "#N/A"},
 		// PERCENTRANK.EXC
 		"=PERCENTRANK.EXC()":             {"#VALUE!", "PERCENTRANK.EXC requires 2 or 3 arguments"},
 		"=PERCENTRANK.EXC(A1:B4,\"\")":   {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
@@ -3303,6 +3334,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=RANK.EQ(-1,A1:B5)":     {"#N/A", "#N/A"},
 		"=RANK.EQ(\"\",A1:B5)":   {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
 		"=RANK.EQ(1,A1:B5,\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		// RANK.AVG
+		"=RANK.AVG()":            {"#VALUE!", "RANK.AVG requires at least 2 arguments"},
+		"=RANK.AVG(1,A1:B5,0,0)": {"#VALUE!", "RANK.AVG requires at most 3 arguments"},
+		"=RANK.AVG(-1,A1:B5)":    {"#N/A", "#N/A"},
 		// RSQ
 		"=RSQ()":            {"#VALUE!", "RSQ requires 2 arguments"},
 		"=RSQ(A1:A2,B1:B1)": {"#N/A", "#N/A"},
@@ -3490,8 +3525,12 @@ func TestCalcCellValue(t *testing.T) {
 		// IFNA
 		"=IFNA()": {"#VALUE!", "IFNA requires 2 arguments"},
 		// IFS
-		"=IFS()":            {"#VALUE!", "IFS requires at least 2 arguments"},
-		"=IFS(FALSE,FALSE)": {"#N/A", "#N/A"},
+		"=IFS()":             {"#VALUE!", "IFS requires at least 2 arguments"},
+		"=IFS(FALSE,FALSE)":  {"#N/A", "#N/A"},
+		"=IFS(FALSE,1,TRUE)": {"#VALUE!", "IFS requires an even number of arguments"},
+		"=IFS(1/0,1,TRUE,2)": {"#DIV/0!", "#DIV/0!"},
+		"=IFS(\"X\",1)":      {"#VALUE!", "strconv.ParseBool: parsing \"X\": invalid syntax"},
+		"=IFS(0,1,FALSE,2)":  {"#N/A", "#N/A"},
 		// NOT
 		"=NOT()":      {"#VALUE!", "NOT requires 1 argument"},
 		"=NOT(NOT())": {"#VALUE!", "NOT requires 1 argument"},
@@ -3503,8 +3542,10 @@ func TestCalcCellValue(t *testing.T) {
 		"=OR()":                                  {"#VALUE!", "OR requires at least 1 argument"},
 		"=OR(1" + strings.Repeat(",1", 30) + ")": {"#VALUE!", "OR accepts at most 30 arguments"},
 		// SWITCH
-		"=SWITCH()":      {"#VALUE!", "SWITCH requires at least 3 arguments"},
-		"=SWITCH(0,1,2)": {"#N/A", "#N/A"},
+		"=SWITCH()":                      {"#VALUE!", "SWITCH requires at least 3 arguments"},
+		"=SWITCH(0,1,2)":                 {"#N/A", "#N/A"},
+		"=SWITCH(\"C\",\"A\",1,\"B\",2)": {"#N/A", "#N/A"},
+		"=SWITCH(1/0,1,2,3)":             {"#DIV/0!", "#DIV/0!"},
 		// TRUE
 		"=TRUE(A1)": {"#VALUE!", "TRUE takes no arguments"},
 		// XOR
@@ -3520,9 +3561,11 @@ func TestCalcCellValue(t *testing.T) {
 		"=DATE(2020,10,\"text\")": {"#VALUE!", "DATE requires 3 number arguments"},
 		// DATEDIF
 		"=DATEDIF()":                  {"#VALUE!", "DATEDIF requires 3 number arguments"},
-		"=DATEDIF(\"\",\"\",\"\")":    {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=DATEDIF(\"\",\"\",\"\")":    {"#VALUE!", "#VALUE!"},
 		"=DATEDIF(43891,43101,\"Y\")": {"#NUM!", "start_date > end_date"},
-		"=DATEDIF(43101,43891,\"x\")": {"#VALUE!", "DATEDIF has invalid unit"},
+		"=DATEDIF(43101,43891,\"x\")": {"#NUM!", "DATEDIF has invalid unit"},
+		"=DATEDIF(43101,\"\",\"Y\")":  {"#VALUE!", "#VALUE!"},
+		"=DATEDIF(-1,43891,\"Y\")":    {"#NUM!", "#NUM!"},
 		// DATEVALUE
 		"=DATEVALUE()":             {"#VALUE!", "DATEVALUE requires 1 argument"},
 		"=DATEVALUE(\"01/01\")":    {"#VALUE!", "#VALUE!"}, // valid in Excel, which uses years by the system date
@@ -4481,6 +4524,64 @@ func TestCalcWithDefinedName(t *testing.T) {
 	result, err = f.CalcCellValue("Sheet1", "D1")
 	assert.NoError(t, err)
 	assert.Equal(t, "YES", result, `=IF("B1_as_string"=defined_name1,"YES","NO")`)
+
+	// Test calculate with defined name refers to a constant or formula
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "rate", RefersTo: "=0.5"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "label", RefersTo: `"Total"`}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "total", RefersTo: "Sheet1!$C$1*2+1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "invalid", RefersTo: "1+UNSUPPORT(1)"}))
+	for formula, expected := range map[string]string{
+		"=rate*4":               "2",
+		"=SUM(rate,1)":          "1.5",
+		"=CONCATENATE(label,1)": "Total1",
+		"=total":                "247",
+		"=MAX(total,C1)":        "247",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err = f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=invalid"))
+	result, err = f.CalcCellValue("Sheet1", "D1")
+	assert.EqualError(t, err, formulaErrorNAME)
+	assert.Empty(t, result)
+	definedNames := f.GetDefinedName()
+	assert.Equal(t, "0.5", definedNames[len(definedNames)-4].RefersTo)
+	assert.Equal(t, "Sheet1!$C$1*2+1", definedNames[len(definedNames)-2].RefersTo)
+}
+
+func TestCalcWithNestedDefinedName(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{1, 2, 3}, {4, 5, 6}})
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$A$1:$C$2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Values", RefersTo: "amount"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Values", RefersTo: "Sheet1!$A$1:$C$1", Scope: "Sheet2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Doubled", RefersTo: "SUM(Values)*2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Loop1", RefersTo: "Loop2+1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Loop2", RefersTo: "Loop1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Self", RefersTo: "SUM(Self)"}))
+	for _, tc := range []struct{ sheet, formula, expected string }{
+		{"Sheet1", "=SUM(Amount)", "21"},
+		{"Sheet1", "=SUM(VALUES)", "21"},
+		{"Sheet1", "=Doubled", "42"},
+		{"Sheet1", "=Doubled+SUM(Amount)", "63"},
+		{"Sheet2", "=SUM(Values)", "6"},
+		{"Sheet2", "=Doubled", "12"},
+	} {
+		assert.NoError(t, f.SetCellFormula(tc.sheet, "E1", tc.formula))
+		result, err := f.CalcCellValue(tc.sheet, "E1")
+		assert.NoError(t, err, tc.formula)
+		assert.Equal(t, tc.expected, result, tc.formula)
+	}
+	// Test calculate with circular reference defined names
+	for _, formula := range []string{"=Loop1", "=SUM(Loop2)", "=Self"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, formulaErrorNAME, formula)
+		assert.Empty(t, result, formula)
+	}
 }
 
 func TestCalcISBLANK(t *testing.T) {
@@ -5919,7 +6020,511 @@ func TestEvalInfixExp(t *testing.T) {
 
 func TestParseToken(t *testing.T) {
 	f := NewFile()
-	assert.Equal(t, formulaErrorNAME, f.parseToken(nil, "Sheet1",
+	assert.Equal(t, formulaErrorNAME, f.parseToken(nil, "Sheet1", "A1",
 		efp.Token{TSubType: efp.TokenSubTypeRange, TValue: "1A"}, nil, nil,
 	).Error())
 }
+
+func TestCalcAll(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$B$1:$B$2"}))
+	for cell, value := range map[string]interface{}{"A1": 1, "A2": 2} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for _, item := range []struct{ sheet, cell, formula string }{
+		{"Sheet1", "B1", "=A1*10"},
+		{"Sheet1", "B2", "=B1+A2"},
+		{"Sheet1", "C1", "=SUM(Total)"},
+		{"Sheet 2", "A1", "='Sheet 2'!B1&\"!\""},
+		{"Sheet 2", "B1", "=Sheet1!C1/4"},
+		{"Sheet 2", "C1", "=SUM(Sheet1!B:B)>20"},
+		{"Sheet 2", "D1", "=1/0"},
+		{"Sheet 2", "E1", "=\"\""},
+	} {
+		assert.NoError(t, f.SetCellFormula(item.sheet, item.cell, item.formula))
+	}
+	assert.NoError(t, f.CalcAll())
+	ws, err := f.workSheetReader("Sheet 2")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"str", "", "b", "e", "str"}, []string{
+		ws.SheetData.Row[0].C[0].T, ws.SheetData.Row[0].C[1].T, ws.SheetData.Row[0].C[2].T,
+		ws.SheetData.Row[0].C[3].T, ws.SheetData.Row[0].C[4].T,
+	})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcAll.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestCalcAll.xlsx"))
+	assert.NoError(t, err)
+	for _, item := range []struct{ sheet, cell, expected string }{
+		{"Sheet1", "B1", "10"},
+		{"Sheet1", "B2", "12"},
+		{"Sheet1", "C1", "22"},
+		{"Sheet 2", "A1", "5.5!"},
+		{"Sheet 2", "B1", "5.5"},
+		{"Sheet 2", "C1", "TRUE"},
+		{"Sheet 2", "D1", "#DIV/0!"},
+		{"Sheet 2", "E1", ""},
+	} {
+		value, err := f.GetCellValue(item.sheet, item.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, item.expected, value, item.cell)
+		formula, err := f.GetCellFormula(item.sheet, item.cell)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, formula, item.cell)
+	}
+	// Test calculate all formulas with circular references
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=D2+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D2", "=D1+A1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "=D3"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D4", "=A1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D5", "=D1*2"))
+	err = f.CalcAll()
+	assert.EqualError(t, err, "formula cell Sheet1!D1: circular reference; formula cell Sheet1!D2: circular reference; formula cell Sheet1!D3: circular reference")
+	calcErrs, ok := err.(CalcErrors)
+	assert.True(t, ok)
+	assert.Equal(t, CalcError{Sheet: "Sheet1", Cell: "D1", Formula: "=D2+1", Value: "#REF!", Err: ErrCircularReference}, calcErrs[0])
+	for cell, expected := range map[string]string{"D1": "#REF!", "D2": "#REF!", "D3": "#REF!", "D4": "2", "D5": "#REF!"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test calculate all formulas with invalid formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D3", "=SUM("))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D6", "=D3&\"!\""))
+	assert.EqualError(t, f.CalcAll(), "formula cell Sheet1!D3: "+ErrInvalidFormula.Error())
+	for cell, expected := range map[string]string{"D1": "1", "D2": "2", "D3": "#VALUE!", "D5": "2", "D6": "#VALUE!"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test calculate all formulas concurrently
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.EqualError(t, f.CalcAll(), "formula cell Sheet1!D3: "+ErrInvalidFormula.Error())
+		}()
+	}
+	wg.Wait()
+	// Test calculate all formulas with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CalcAll(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestParseFormulaReference(t *testing.T) {
+	f := NewFile()
+	for reference, expected := range map[string]cellRange{
+		"A1":                  {From: cellRef{1, 1, "Sheet1"}, To: cellRef{1, 1, "Sheet1"}},
+		"$B$3:A1":             {From: cellRef{1, 1, "Sheet1"}, To: cellRef{2, 3, "Sheet1"}},
+		"'Sheet''s 2'!C:C":    {From: cellRef{3, 1, "Sheet's 2"}, To: cellRef{3, TotalRows, "Sheet's 2"}},
+		"Sheet2!$2:3":         {From: cellRef{1, 2, "Sheet2"}, To: cellRef{MaxColumns, 3, "Sheet2"}},
+		"Sheet2!A1:Sheet2!B2": {From: cellRef{1, 1, "Sheet2"}, To: cellRef{2, 2, "Sheet2"}},
+	} {
+		cr, ok := f.parseFormulaReference("Sheet1", reference)
+		assert.True(t, ok, reference)
+		assert.Equal(t, expected, cr, reference)
+	}
+	for _, reference := range []string{"Name", "A", "1", "A1:B2:C3", "A1:Sheet2!B2"} {
+		_, ok := f.parseFormulaReference("Sheet1", reference)
+		assert.False(t, ok, reference)
+	}
+}
+
+func TestRecalcFrom(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{"A1": 1, "A2": 2, "E1": 5} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, formula := range map[string]string{
+		"B1": "=A1*10",
+		"B2": "=B1+A2",
+		"C1": "=SUM(B:B)",
+		"D1": "=E1*2",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.CalcAll())
+	// Test recalculate dependents of the changed cell only
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 10))
+	assert.NoError(t, f.RecalcFrom("Sheet1", "A1"))
+	for cell, expected := range map[string]string{"B1": "20", "B2": "22", "C1": "42", "D1": "10"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test recalculate from a formula cell
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "=A1*100"))
+	assert.NoError(t, f.RecalcFrom("Sheet1", "B1"))
+	value, err := f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "402", value)
+	// Test recalculate with circular references
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=F2+A2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F2", "=F1"))
+	assert.NoError(t, f.RecalcFrom("Sheet1", "A1"))
+	assert.EqualError(t, f.RecalcFrom("Sheet1", "A2"), "formula cell Sheet1!F1: circular reference; formula cell Sheet1!F2: circular reference")
+	// Test recalculate with the cached dependency graph and results
+	f = NewFile()
+	for cell, value := range map[string]interface{}{"A1": 1, "E1": 5} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, formula := range map[string]string{"B1": "=A1*10", "D1": "=E1*2", "G1": "=B1+D1"} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.CalcAll())
+	graph := f.formulaGraph
+	assert.NotNil(t, graph)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E1", 10))
+	assert.NoError(t, f.RecalcFrom("Sheet1", "A1"))
+	assert.Same(t, graph, f.formulaGraph)
+	value, err = f.GetCellValue("Sheet1", "G1")
+	assert.NoError(t, err)
+	assert.Equal(t, "30", value)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=E1*3"))
+	assert.NoError(t, f.RecalcFrom("Sheet1", "D1"))
+	assert.NotSame(t, graph, f.formulaGraph)
+	value, err = f.GetCellValue("Sheet1", "G1")
+	assert.NoError(t, err)
+	assert.Equal(t, "50", value)
+	// Test recalculate with invalid sheet name or cell reference
+	assert.EqualError(t, f.RecalcFrom("SheetN", "A1"), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RecalcFrom("Sheet:1", "A1"), ErrSheetNameInvalid.Error())
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.RecalcFrom("Sheet1", "A"))
+	// Test recalculate with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RecalcFrom("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 2))
+	for cell, formula := range map[string]string{
+		"B1": "=A1*10",
+		"B2": "=1/0",
+		"B3": "=NA()",
+		"B4": "=SUM(",
+		"B5": "=UNKNOWN(1)",
+		"B6": "=IFERROR(1/0,A1)",
+		"B7": "=IF(TRUE,NA())",
+		"B8": "=IF(A1=2,NA(),1)",
+		"B9": "=\"#N/A\"",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	values, calcErrs, err := f.CalcSheet("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"B1": "20", "B2": "#DIV/0!", "B3": "#N/A", "B5": "#VALUE!", "B6": "2",
+		"B7": "#N/A", "B8": "#N/A", "B9": "#N/A",
+	}, values)
+	assert.Equal(t, []CalcError{
+		{Cell: "B2", Formula: "=1/0", Value: "#DIV/0!", Err: errors.New("#DIV/0!")},
+		{Cell: "B3", Formula: "=NA()", Value: "#N/A", Err: errors.New("#N/A")},
+		{Cell: "B4", Formula: "=SUM(", Err: ErrInvalidFormula},
+		{Cell: "B5", Formula: "=UNKNOWN(1)", Value: "#VALUE!", Err: errors.New("not support UNKNOWN function")},
+		{Cell: "B7", Formula: "=IF(TRUE,NA())", Value: "#N/A", Err: errors.New("#N/A")},
+		{Cell: "B8", Formula: "=IF(A1=2,NA(),1)", Value: "#N/A", Err: errors.New("#N/A")},
+	}, calcErrs)
+	assert.Equal(t, "formula cell B4: formula not valid", calcErrs[2].Error())
+	// Test calculate worksheet with invalid sheet name
+	_, _, err = f.CalcSheet("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test calculate not exists worksheet
+	_, _, err = f.CalcSheet("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test calculate worksheet with unsupported charset
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	_, _, err = f.CalcSheet("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCalcUNIQUEandSORT(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Name", "Score", "Group"},
+		{"b", 3, "X"},
+		{"a", 1, "Y"},
+		{"B", 3, "X"},
+		{"c", 2, "Y"},
+		{"a", 1, "X"},
+		{"a", 1, "X"},
+	}
+	f := prepareCalcData(cellData)
+	for formula, expected := range map[string][][]string{
+		"=UNIQUE(A2:A6)":                  {{"b"}, {"a"}, {"c"}},
+		"=UNIQUE(A2:A6,FALSE,TRUE)":       {{"c"}},
+		"=UNIQUE(A2:B6)":                  {{"b", "3"}, {"a", "1"}, {"c", "2"}},
+		"=UNIQUE(B2:C2,TRUE)":             {{"3", "X"}},
+		"=UNIQUE(B3:C3,1)":                {{"1", "Y"}},
+		"=SORT(B2:B6)":                    {{"1"}, {"1"}, {"2"}, {"3"}, {"3"}},
+		"=SORT(A2:B6,2,-1)":               {{"b", "3"}, {"B", "3"}, {"c", "2"}, {"a", "1"}, {"a", "1"}},
+		"=_xlfn._xlws.SORT(A2:A4)":        {{"a"}, {"b"}, {"B"}},
+		"=SORT(A2:C2,1,1,TRUE)":           {{"3", "b", "X"}},
+		"=SORTBY(A2:A6,C2:C6,1,B2:B6,-1)": {{"b"}, {"B"}, {"a"}, {"c"}, {"a"}},
+		"=SORTBY(A2:C2,B4:D4)":            {{"X", "b", "3"}},
+		"=SORT(A1:C1,1,1,TRUE)":           {{"Group", "Name", "Score"}},
+	} {
+		f := prepareCalcData(cellData)
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		assert.NoError(t, f.CalcAll(), formula)
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		for r, row := range expected {
+			for c, value := range row {
+				assert.Equal(t, value, rows[r][c+4], formula)
+			}
+		}
+		for r := len(expected); r < len(rows); r++ {
+			assert.Len(t, rows[r], 3, formula)
+		}
+	}
+	for formula, expected := range map[string][]string{
+		"=UNIQUE()":                         {"#VALUE!", "UNIQUE requires at least 1 argument"},
+		"=UNIQUE(A2:A6,1,1,1)":              {"#VALUE!", "UNIQUE requires at most 3 arguments"},
+		"=UNIQUE(A2:A6,\"\")":               {"#VALUE!", "strconv.ParseBool: parsing \"\": invalid syntax"},
+		"=UNIQUE(A2:A6,FALSE,\"\")":         {"#VALUE!", "strconv.ParseBool: parsing \"\": invalid syntax"},
+		"=UNIQUE(A6:A7,FALSE,TRUE)":         {"#CALC!", "UNIQUE returns an empty array"},
+		"=SORT()":                           {"#VALUE!", "SORT requires at least 1 argument"},
+		"=SORT(A2:A6,1,1,FALSE,1)":          {"#VALUE!", "SORT requires at most 4 arguments"},
+		"=SORT(A2:A6,\"\")":                 {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=SORT(A2:A6,1,\"\")":               {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=SORT(A2:A6,1,0)":                  {"#VALUE!", "SORT requires sort_order to be 1 or -1"},
+		"=SORT(A2:A6,1,1,\"\")":             {"#VALUE!", "strconv.ParseBool: parsing \"\": invalid syntax"},
+		"=SORT(A2:A6,2)":                    {"#VALUE!", "SORT requires sort_index in the array"},
+		"=SORTBY(A2:A6)":                    {"#VALUE!", "SORTBY requires at least 2 arguments"},
+		"=SORTBY(A2:A6,B2:B5)":              {"#VALUE!", "SORTBY requires by_array to be the same size as array"},
+		"=SORTBY(A2:B3,B2:B3,1,A2:B2)":      {"#VALUE!", "SORTBY requires by_array to be the same direction"},
+		"=SORTBY(A2:A6,B2:B6,2)":            {"#VALUE!", "SORTBY requires sort_order to be 1 or -1"},
+		"=SORTBY(A2:A6,B2:B6,1,C2:C6,\"\")": {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	assert.Equal(t, [][]formulaArg{}, getFormulaArgMatrix(newMatrixFormulaArg([][]formulaArg{{}})))
+	assert.Equal(t, [][]formulaArg{}, transposeFormulaArgMatrix([][]formulaArg{}))
+	args := list.New()
+	args.PushBack(newMatrixFormulaArg([][]formulaArg{}))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorCALC, "SORT returns an empty array"), (&formulaFuncs{f: f}).SORT(args))
+	args.PushBack(newMatrixFormulaArg([][]formulaArg{}))
+	assert.Equal(t, newErrorFormulaArg(formulaErrorCALC, "SORTBY returns an empty array"), (&formulaFuncs{f: f}).SORTBY(args))
+	// Test spill the array into the blocked range and shrink the spill range
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=UNIQUE(A2:A6)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "E3", "blocked"))
+	assert.NoError(t, f.CalcAll())
+	value, err := f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorSPILL, value)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E3", nil))
+	assert.NoError(t, f.CalcAll())
+	formula, err := f.GetCellFormula("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=UNIQUE(A2:A6)", formula)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxF{Content: "=UNIQUE(A2:A6)", T: STCellFormulaTypeArray, Ref: "E1:E3"}, ws.SheetData.Row[0].C[4].F)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "=UNIQUE(A2:A6,FALSE,TRUE)", FormulaOpts{Type: &ws.SheetData.Row[0].C[4].F.T, Ref: &ws.SheetData.Row[0].C[4].F.Ref}))
+	assert.NoError(t, f.CalcAll())
+	for cell, expected := range map[string]string{"E1": "c", "E2": "", "E3": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.Equal(t, "E1", ws.SheetData.Row[0].C[4].F.Ref)
+	// Test spill the array into the blocked range without changing the cells
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "=UNIQUE(A2:A6)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "H3", "blocked"))
+	assert.NoError(t, f.CalcAll())
+	value, err = f.GetCellValue("Sheet1", "H1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorSPILL, value)
+	assert.Less(t, len(ws.SheetData.Row[1].C), 8)
+	// Test spill the array out of the worksheet
+	assert.NoError(t, f.SetCellFormula("Sheet1", "XFD1", "=UNIQUE(B2:C2,TRUE)"))
+	assert.NoError(t, f.CalcAll())
+	value, err = f.GetCellValue("Sheet1", "XFD1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorSPILL, value)
+}
+
+func TestCalcRANK(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{1}, {2}, {2}, {3}, {2}})
+	for formula, expected := range map[string]string{
+		"=RANK(2,A1:A5)":       "2",
+		"=RANK.EQ(2,A1:A5,1)":  "2",
+		"=RANK.AVG(2,A1:A5)":   "3",
+		"=RANK.AVG(2,A1:A4)":   "2.5",
+		"=RANK.AVG(2,A1:A4,1)": "2.5",
+		"=RANK.AVG(3,A1:A5)":   "1",
+		"=RANK.AVG(1,A1:A5)":   "5",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B1", formula))
+		result, err := f.CalcCellValue("Sheet1", "B1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+}
+
+func TestCalcMatrixFunctions(t *testing.T) {
+	cellData := [][]interface{}{
+		{1, 2, 3, 5, 6, 0, 1, 2},
+		{3, 4, 1, 7, 8, 0, 2, 4},
+		{2, 0, 1},
+	}
+	for formula, expected := range map[string][][]string{
+		"=MMULT(A1:B2,D1:E2)": {{"19", "22"}, {"43", "50"}},
+		"=MMULT(A1:C3,A1:C3)": {{"13", "10", "8"}, {"17", "22", "14"}, {"4", "4", "7"}},
+		"=MMULT(A1:B2,A1:C3)": {{"#VALUE!"}},
+		"=MINVERSE(A1:B2)":    {{"-2", "1"}, {"1.5", "-0.5"}},
+		"=MINVERSE(A1:C3)": {
+			{"-0.181818181818182", "0.0909090909090909", "0.454545454545455"},
+			{"0.0454545454545455", "0.227272727272727", "-0.363636363636364"},
+			{"0.363636363636364", "-0.181818181818182", "0.0909090909090909"},
+		},
+		"=MINVERSE(G1:H2)":  {{"#NUM!"}},
+		"=TRANSPOSE(A1:C2)": {{"1", "3"}, {"2", "4"}, {"3", "1"}},
+		"=TRANSPOSE(A1:A3)": {{"1", "3", "2"}},
+	} {
+		f := prepareCalcData(cellData)
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		assert.NoError(t, f.CalcAll(), formula)
+		for r, row := range expected {
+			for c, value := range row {
+				cell, err := CoordinatesToCellName(c+10, r+1)
+				assert.NoError(t, err)
+				result, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, value, result, formula)
+			}
+		}
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestCalcTEXTAFTERandTEXTBEFOREandTEXTSPLIT(t *testing.T) {
+	cellData := [][]interface{}{
+		{"Red-Blue_Green-X", "-", "_"},
+		{"a,b;c,,d;e"},
+		{"1x2X3"},
+		{"İstanbul-İzmir"},
+	}
+	f := prepareCalcData(cellData)
+	formulaList := map[string]string{
+		"=TEXTBEFORE(A1,\"-\")":                    "Red",
+		"=TEXTBEFORE(A1,\"-\",2)":                  "Red-Blue_Green",
+		"=TEXTBEFORE(A1,\"-\",-1)":                 "Red-Blue_Green",
+		"=TEXTBEFORE(A1,\"-\",-2)":                 "Red",
+		"=TEXTBEFORE(A1,B1:C1,2)":                  "Red-Blue",
+		"=TEXTBEFORE(A1,\"blue\",1,1)":             "Red-",
+		"=TEXTBEFORE(A1,\"-\",3,0,1)":              "Red-Blue_Green-X",
+		"=TEXTBEFORE(A1,\"\")":                     "",
+		"=TEXTBEFORE(A1,\"\",-1)":                  "Red-Blue_Green-X",
+		"=TEXTBEFORE(A1,\"z\",1,0,0,\"none\")":     "none",
+		"=TEXTAFTER(A1,\"-\")":                     "Blue_Green-X",
+		"=TEXTAFTER(A1,\"-\",-1)":                  "X",
+		"=TEXTAFTER(A1,B1:C1,2)":                   "Green-X",
+		"=TEXTAFTER(A1,B1:C1,-2)":                  "Green-X",
+		"=TEXTAFTER(A1,\"BLUE\",1,1)":              "_Green-X",
+		"=TEXTAFTER(A1,\"-\",-3,0,1)":              "Red-Blue_Green-X",
+		"=TEXTAFTER(A1,\"\")":                      "Red-Blue_Green-X",
+		"=TEXTAFTER(A1,\"\",-1)":                   "",
+		"=TEXTAFTER(A3,\"x\",1,1)":                 "2X3",
+		"=TEXTAFTER(A3,\"x\",-1,1)":                "3",
+		"=TEXTAFTER(A1,\"z\",1,0,0,\"not found\")": "not found",
+		"=TEXTAFTER(A4,\"-\",1,1)":                 "İzmir",
+		"=TEXTAFTER(A4,\"STANBUL\",1,1)":           "-İzmir",
+		"=TEXTBEFORE(A4,\"-\",-1,1)":               "İstanbul",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"=TEXTBEFORE()":                      {"#VALUE!", "TEXTBEFORE requires at least 2 arguments"},
+		"=TEXTBEFORE(A1,\"-\",1,0,0,1,1)":    {"#VALUE!", "TEXTBEFORE accepts at most 6 arguments"},
+		"=TEXTBEFORE(NA(),\"-\")":            {"#N/A", "#N/A"},
+		"=TEXTBEFORE(A1,\"-\",\"\")":         {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=TEXTBEFORE(A1,\"-\",0)":            {"#VALUE!", "TEXTBEFORE requires instance_num not be 0 or greater than the length of text"},
+		"=TEXTBEFORE(A1,\"-\",100)":          {"#VALUE!", "TEXTBEFORE requires instance_num not be 0 or greater than the length of text"},
+		"=TEXTBEFORE(A1,\"-\",1,2)":          {"#VALUE!", "TEXTBEFORE requires match_mode to be 0 or 1"},
+		"=TEXTBEFORE(A1,\"z\")":              {"#N/A", "#N/A"},
+		"=TEXTBEFORE(A1,\"-\",3)":            {"#N/A", "#N/A"},
+		"=TEXTAFTER()":                       {"#VALUE!", "TEXTAFTER requires at least 2 arguments"},
+		"=TEXTAFTER(A1,\"blue\")":            {"#N/A", "#N/A"},
+		"=TEXTAFTER(A1,\"-\",-3)":            {"#N/A", "#N/A"},
+		"=TEXTSPLIT()":                       {"#VALUE!", "TEXTSPLIT requires at least 2 arguments"},
+		"=TEXTSPLIT(A2,\",\",\";\",0,0,1,1)": {"#VALUE!", "TEXTSPLIT accepts at most 6 arguments"},
+		"=TEXTSPLIT(NA(),\",\")":             {"#N/A", "#N/A"},
+		"=TEXTSPLIT(A2,\"\")":                {"#VALUE!", "TEXTSPLIT requires col_delimiter or row_delimiter"},
+		"=TEXTSPLIT(A2,\",\",\";\",\"\")":    {"#VALUE!", "strconv.ParseFloat: parsing \"\": invalid syntax"},
+		"=TEXTSPLIT(A2,\",\",\";\",0,2)":     {"#VALUE!", "TEXTSPLIT requires match_mode to be 0 or 1"},
+		"=TEXTSPLIT(B1,\"-\",\";\",TRUE)":    {"#CALC!", "TEXTSPLIT returns an empty array"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		result, err := f.CalcCellValue("Sheet1", "J1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	for formula, expected := range map[string][][]string{
+		"=TEXTSPLIT(A2,\",\",\";\")":              {{"a", "b", "#N/A"}, {"c", "", "d"}, {"e", "#N/A", "#N/A"}},
+		"=TEXTSPLIT(A2,\",\",\";\",TRUE)":         {{"a", "b"}, {"c", "d"}, {"e", "#N/A"}},
+		"=TEXTSPLIT(A2,\",\",\";\",TRUE,0,\"-\")": {{"a", "b"}, {"c", "d"}, {"e", "-"}},
+		"=TEXTSPLIT(A1,B1:C1)":                    {{"Red", "Blue", "Green", "X"}},
+		"=TEXTSPLIT(A3,\"x\",\"\",FALSE,1)":       {{"1", "2", "3"}},
+		"=TEXTSPLIT(A3,\"\",\"x\")":               {{"1"}, {"2X3"}},
+	} {
+		f := prepareCalcData(cellData)
+		assert.NoError(t, f.SetCellFormula("Sheet1", "J1", formula))
+		assert.NoError(t, f.CalcAll(), formula)
+		for r, row := range expected {
+			for c, value := range row {
+				cell, err := CoordinatesToCellName(c+10, r+1)
+				assert.NoError(t, err)
+				result, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, value, result, formula)
+			}
+		}
+		assert.NoError(t, f.Close())
+	}
+}
+
+func TestCalcMergedCellReference(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{5, nil, 1}, {nil, nil, 2}})
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "B2"))
+	for formula, expected := range map[string]string{
+		"=A1":           "5",
+		"=B1":           "",
+		"=SUM(B2,1)":    "1",
+		"=SUM(A1:C2)":   "8",
+		"=SUM(B1:C2)":   "3",
+		"=COUNT(A1:B2)": "1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	// Test reference to the merged cell with formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=C1+C2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=SUM(A1:B2)*10+SUM(B1)"))
+	result, err := f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "30", result)
+	assert.False(t, f.isMergedCellNonAnchor("SheetN", "A1"))
+	assert.False(t, f.isMergedCellNonAnchor("Sheet1", "A"))
+}
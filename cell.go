@@ -17,10 +17,14 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"unicode/utf8"
+
+	"github.com/xuri/efp"
 )
 
 // CellType is the type of cell value type.
@@ -60,6 +64,30 @@ var cellTypes = map[string]CellType{
 	"inlineStr": CellTypeInlineString,
 }
 
+// CellInfo directly maps the information of a cell, which includes the raw
+// value, the formatted value, the data type, the formula, the style index and
+// the comment of the cell. The Comment will be nil if the cell doesn't have a
+// comment.
+type CellInfo struct {
+	RawValue       string
+	FormattedValue string
+	Type           CellType
+	Formula        string
+	StyleID        int
+	Comment        *Comment
+}
+
+// CellInput directly maps the settings of a cell to be set by the SetCells,
+// which includes the value, the style index, the formula and the comment of
+// the cell. The style of the cell will not be changed if the StyleID is 0,
+// and the formula and comment will not be set if they are empty.
+type CellInput struct {
+	Value   interface{}
+	StyleID int
+	Formula string
+	Comment *Comment
+}
+
 // GetCellValue provides a function to get formatted value from cell by given
 // worksheet name and cell reference in spreadsheet. The return value is
 // converted to the 'string' data type. This function is concurrency safe. If
@@ -77,6 +105,50 @@ func (f *File) GetCellValue(sheet, cell string, opts ...Options) (string, error)
 	})
 }
 
+// GetCellValueWithColor provides a function to get formatted value and the RGB
+// color of the number format section which applies to the cell value by given
+// worksheet name and cell reference in spreadsheet. The color will be empty
+// if the applied section of the cell number format doesn't contain color
+// directive, or get the raw cell value. This function is concurrency safe.
+// For example, set a cell number format with red color for negative values,
+// and get the formatted value and color of the cell:
+//
+//	numFmt := "#,##0.00;[Red]-#,##0.00"
+//	style, err := f.NewStyle(&excelize.Style{CustomNumFmt: &numFmt})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = f.SetCellValue("Sheet1", "A1", -1234.5); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err = f.SetCellStyle("Sheet1", "A1", "A1", style); err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	value, color, err := f.GetCellValueWithColor("Sheet1", "A1")
+//
+// The value will be "-1,234.50" and the color will be "FF0000".
+func (f *File) GetCellValueWithColor(sheet, cell string, opts ...Options) (string, string, error) {
+	var color string
+	value, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		sst, err := f.sharedStringsReader()
+		if err != nil {
+			return "", true, err
+		}
+		raw := getOptions(opts...).RawCellValue
+		v := c.V
+		val, err := c.getValueFrom(f, sst, raw)
+		if err != nil || raw {
+			return val, true, err
+		}
+		color, err = f.formattedColor(&xlsxC{S: c.S, T: c.T, V: v})
+		return val, true, err
+	})
+	return value, color, err
+}
+
 // GetCellType provides a function to get the cell's data type by given
 // worksheet name and cell reference in spreadsheet file.
 func (f *File) GetCellType(sheet, cell string) (CellType, error) {
@@ -94,6 +166,156 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 	return cellType, err
 }
 
+// GetCellFloat provides a function to get the numeric value of a cell by
+// given worksheet name and cell reference. The value of the date cell stored
+// in the ISO 8601 format will be returned as the Excel serial date-time
+// number, and 0 will be returned for the empty cell. An error will be
+// returned if the cell contains text, boolean or error value.
+func (f *File) GetCellFloat(sheet, cell string) (float64, error) {
+	var val float64
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		switch c.T {
+		case "", "n":
+			if c.V == "" {
+				return "", true, nil
+			}
+			if num, err := strconv.ParseFloat(c.V, 64); err == nil {
+				val = num
+				return "", true, nil
+			}
+		case "d":
+			if timestamp, err := c.parseCellDate(); err == nil {
+				val, err = timeToExcelTime(timestamp, false)
+				return "", true, err
+			}
+		}
+		return "", true, f.cellValueConvertError(c, "float")
+	})
+	return val, err
+}
+
+// GetCellBool provides a function to get the boolean value of a cell by
+// given worksheet name and cell reference. The false will be returned for
+// the empty cell, and an error will be returned if the cell doesn't contain
+// a boolean value.
+func (f *File) GetCellBool(sheet, cell string) (bool, error) {
+	var val bool
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		if c.T == "" && c.V == "" {
+			return "", true, nil
+		}
+		if c.T == "b" {
+			if val = c.V == "1" || strings.EqualFold(c.V, "true"); val || c.V == "0" || strings.EqualFold(c.V, "false") {
+				return "", true, nil
+			}
+		}
+		return "", true, f.cellValueConvertError(c, "bool")
+	})
+	return val, err
+}
+
+// GetCellTime provides a function to get the date and time value of a cell
+// by given worksheet name and cell reference. The cell should be a date cell
+// stored in the ISO 8601 format, or a numeric cell with a date or time number
+// format, and the 1904 date system of the workbook will be honored. The zero
+// time will be returned for the empty cell, and an error will be returned if
+// the cell value isn't a date or time. For example, get the date of the cell
+// Sheet1!A1:
+//
+//	t, err := f.GetCellTime("Sheet1", "A1")
+func (f *File) GetCellTime(sheet, cell string) (time.Time, error) {
+	var val time.Time
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		switch c.T {
+		case "", "n":
+			if c.V == "" {
+				return "", true, nil
+			}
+			numFmt, date1904, err := f.getCellNumFmt(c)
+			if err != nil {
+				return "", true, err
+			}
+			if num, err := strconv.ParseFloat(c.V, 64); err == nil && num >= 0 && numFmt != nil && numFmt.isDateTime() {
+				val = timeFromExcelTime(num, date1904)
+				return "", true, nil
+			}
+		case "d":
+			timestamp, err := c.parseCellDate()
+			if err == nil {
+				val = timestamp
+				return "", true, nil
+			}
+		}
+		return "", true, f.cellValueConvertError(c, "time")
+	})
+	return val, err
+}
+
+// cellValueConvertError provides a function to get the error on the value of
+// the cell could not be converted to the given type.
+func (f *File) cellValueConvertError(c *xlsxC, typ string) error {
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return err
+	}
+	val, err := c.getValueFrom(f, sst, true)
+	if err != nil {
+		return err
+	}
+	return newCellValueConvertError(c.R, val, typ)
+}
+
+// GetCell provides a function to get the raw value, formatted value, data
+// type, formula, style index and comment of a cell by given worksheet name and
+// cell reference in one read. This function is concurrency safe. A zero
+// CellInfo will be returned for the empty cell. For example, get the
+// information of the cell A1 in Sheet1:
+//
+//	info, err := f.GetCell("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(info.RawValue, info.FormattedValue, info.Formula)
+func (f *File) GetCell(sheet, cell string) (CellInfo, error) {
+	var info CellInfo
+	if _, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		sst, err := f.sharedStringsReader()
+		if err != nil {
+			return "", true, err
+		}
+		if info.RawValue, err = c.getValueFrom(f, sst, true); err != nil {
+			return "", true, err
+		}
+		if info.FormattedValue, err = c.getValueFrom(f, sst, false); err != nil {
+			return "", true, err
+		}
+		info.Type, info.StyleID = cellTypes[c.T], c.S
+		if c.F != nil {
+			info.Formula = c.F.Content
+			if c.F.T == STCellFormulaTypeShared && c.F.Si != nil {
+				info.Formula = getSharedFormula(x, *c.F.Si, c.R)
+			}
+		}
+		return "", true, nil
+	}); err != nil {
+		return CellInfo{}, err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return CellInfo{}, err
+	}
+	cell, _ = CoordinatesToCellName(col, row)
+	comments, err := f.getComments(sheet, cell)
+	if err != nil {
+		return CellInfo{}, err
+	}
+	if len(comments) > 0 {
+		info.Comment = &comments[0]
+	}
+	return info, err
+}
+
 // SetCellValue provides a function to set the value of a cell. This function
 // is concurrency safe. The specified coordinates should not be in the first
 // row of the table, a complex number can be set with string text. The
@@ -125,37 +347,240 @@ func (f *File) GetCellType(sheet, cell string) (CellType, error) {
 // the cell value as number 0 or 60, then create and bind the date-time number
 // format style for the cell.
 func (f *File) SetCellValue(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
-		err = f.setCellIntFunc(sheet, cell, v)
-	case float32:
-		err = f.SetCellFloat(sheet, cell, float64(v), -1, 32)
-	case float64:
-		err = f.SetCellFloat(sheet, cell, v, -1, 64)
-	case string:
-		err = f.SetCellStr(sheet, cell, v)
-	case []byte:
-		err = f.SetCellStr(sheet, cell, string(v))
-	case time.Duration:
-		_, d := setCellDuration(v)
-		err = f.SetCellDefault(sheet, cell, d)
-		if err != nil {
-			return err
+	ws, date1904, err := f.prepareSetCells(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	c, col, row, err := ws.prepareCell(cell)
+	if err != nil {
+		ws.mu.Unlock()
+		return err
+	}
+	c.S = ws.prepareCellStyle(col, row, c.S)
+	numFmt, err := f.setCellValue(ws, sheet, c, value, date1904)
+	ws.mu.Unlock()
+	if err != nil || numFmt == 0 {
+		return err
+	}
+	return f.setDefaultTimeStyle(sheet, cell, numFmt)
+}
+
+// EmptyCellOpts can be passed to SetCellValueIfEmpty and SetRangeDefaults to
+// specify which cells are treated as empty.
+type EmptyCellOpts struct {
+	TrimSpace bool // Treat the whitespace-only string value as empty
+}
+
+// SetCellValueIfEmpty provides a function to set the value of a cell only if
+// the cell is empty by given worksheet name, cell reference and value, and
+// returns whether the value was set. The cell with a formula is not empty,
+// and the cell with a whitespace-only string value could be treated as empty
+// by the optional settings. The supported data types of the value are the
+// same as the SetCellValue function. For example, set the default value of
+// the cell Sheet1!A1 when it's empty or only contains whitespace:
+//
+//	ok, err := f.SetCellValueIfEmpty("Sheet1", "A1", "N/A", excelize.EmptyCellOpts{TrimSpace: true})
+func (f *File) SetCellValueIfEmpty(sheet, cell string, value interface{}, opts ...EmptyCellOpts) (bool, error) {
+	empty, err := f.isCellEmpty(sheet, cell, opts...)
+	if err != nil || !empty {
+		return false, err
+	}
+	return true, f.SetCellValue(sheet, cell, value)
+}
+
+// SetRangeDefaults provides a function to set the value of the empty cells in
+// a range by given worksheet name, range reference and value, and returns the
+// count of the cells which the value was set. The empty cells are determined
+// in the same way as the SetCellValueIfEmpty function. For example, set the
+// default value of the empty cells in the range Sheet1!B2:D10 to 0:
+//
+//	count, err := f.SetRangeDefaults("Sheet1", "B2:D10", 0)
+func (f *File) SetRangeDefaults(sheet, rangeRef string, value interface{}, opts ...EmptyCellOpts) (int, error) {
+	var count int
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return count, err
+	}
+	_ = sortCoordinates(coordinates)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			ok, err := f.SetCellValueIfEmpty(sheet, cell, value, opts...)
+			if err != nil {
+				return count, err
+			}
+			if ok {
+				count++
+			}
+		}
+	}
+	return count, err
+}
+
+// isCellEmpty provides a function to determine if the cell has no value and
+// formula by given worksheet name, cell reference and optional settings.
+func (f *File) isCellEmpty(sheet, cell string, opts ...EmptyCellOpts) (bool, error) {
+	formula, err := f.GetCellFormula(sheet, cell)
+	if err != nil || formula != "" {
+		return false, err
+	}
+	val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+	if err != nil {
+		return false, err
+	}
+	for _, opt := range opts {
+		if opt.TrimSpace {
+			val = strings.TrimSpace(val)
+		}
+	}
+	return val == "", err
+}
+
+// ClearCell provides a function to clear the value and formula of a cell by
+// given worksheet name and cell reference, the style of the cell will be
+// kept, just like pressing the Delete key in Excel. The merged cells and data
+// validations of the worksheet will not be changed. For example, clear the
+// contents of the cell Sheet1!A1:
+//
+//	err := f.ClearCell("Sheet1", "A1")
+func (f *File) ClearCell(sheet, cell string) error {
+	return f.clearCells(sheet, cell, false)
+}
+
+// ClearRange provides a function to clear the values and formulas of the
+// cells in a range by given worksheet name and range reference, the range
+// reference could be a single cell reference, and the styles of the cells
+// will be kept. For example, clear the contents of the cells in the range
+// Sheet1!A1:D10:
+//
+//	err := f.ClearRange("Sheet1", "A1:D10")
+func (f *File) ClearRange(sheet, rangeRef string) error {
+	return f.clearCells(sheet, rangeRef, false)
+}
+
+// ClearAll provides a function to clear the values, formulas and styles of
+// the cells in a range by given worksheet name and range reference, the
+// range reference could be a single cell reference. For example, clear the
+// contents and formatting of the cells in the range Sheet1!A1:D10:
+//
+//	err := f.ClearAll("Sheet1", "A1:D10")
+func (f *File) ClearAll(sheet, rangeRef string) error {
+	return f.clearCells(sheet, rangeRef, true)
+}
+
+// clearCells provides a function to clear the values and formulas of the
+// existing cells in a range by given worksheet name, range reference and if
+// clear the styles of the cells. The cleared formula cells will be removed
+// from the calculation chain, and the shared formula of the cells outside the
+// range will be kept.
+func (f *File) clearCells(sheet, rangeRef string, clearStyle bool) error {
+	if !strings.Contains(rangeRef, ":") {
+		rangeRef += ":" + rangeRef
+	}
+	coordinates, err := rangeRefToCoordinates(rangeRef)
+	if err != nil {
+		return err
+	}
+	_ = sortCoordinates(coordinates)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[r]
+		if row.R < coordinates[1] || row.R > coordinates[3] {
+			continue
+		}
+		for idx := range row.C {
+			c := &row.C[idx]
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			if col < coordinates[0] || col > coordinates[2] {
+				continue
+			}
+			if c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Ref != "" {
+				if err = f.promoteSharedFormula(ws, c, coordinates); err != nil {
+					return err
+				}
+			}
+			if err = f.removeFormula(c, ws, sheet); err != nil {
+				return err
+			}
+			if c.F != nil {
+				if err = f.deleteCalcChain(f.getSheetID(sheet), c.R); err != nil {
+					return err
+				}
+			}
+			c.T, c.Cm, c.Vm, c.F, c.V, c.IS = "", nil, nil, nil, "", nil
+			if clearStyle {
+				c.S = 0
+			}
 		}
-		err = f.setDefaultTimeStyle(sheet, cell, 21)
-	case time.Time:
-		err = f.setCellTimeFunc(sheet, cell, v)
-	case bool:
-		err = f.SetCellBool(sheet, cell, v)
-	case nil:
-		err = f.SetCellDefault(sheet, cell, "")
-	default:
-		err = f.SetCellStr(sheet, cell, fmt.Sprint(value))
 	}
 	return err
 }
 
+// promoteSharedFormula provides a function to promote the first cell outside
+// the given range which shares the formula with the given master cell of the
+// shared formula to be the new master cell, and the reference range of the
+// new master cell covers all the cells sharing the formula outside the range.
+// The given master cell will be turned into the normal cell of the shared
+// formula, so that only itself will be removed from the shared formula.
+func (f *File) promoteSharedFormula(ws *xlsxWorksheet, c *xlsxC, coordinates []int) error {
+	var (
+		master *xlsxC
+		ref    []int
+	)
+	for r := range ws.SheetData.Row {
+		for idx := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[idx]
+			if cell == c || cell.F == nil || cell.F.Si == nil || *cell.F.Si != *c.F.Si {
+				continue
+			}
+			col, row, err := CellNameToCoordinates(cell.R)
+			if err != nil {
+				return err
+			}
+			if col >= coordinates[0] && col <= coordinates[2] && row >= coordinates[1] && row <= coordinates[3] {
+				continue
+			}
+			if master == nil {
+				master, ref = cell, []int{col, row, col, row}
+				continue
+			}
+			if col < ref[0] {
+				ref[0] = col
+			}
+			if col > ref[2] {
+				ref[2] = col
+			}
+			ref[3] = row
+		}
+	}
+	if master == nil {
+		return nil
+	}
+	sqref, err := f.coordinatesToRangeRef(ref)
+	if err != nil {
+		return err
+	}
+	master.F = &xlsxF{
+		Content: getSharedFormula(ws, *c.F.Si, master.R),
+		T:       STCellFormulaTypeShared,
+		Ref:     sqref,
+		Si:      c.F.Si,
+	}
+	c.F.Ref = ""
+	return err
+}
+
 // String extracts characters from a string item.
 func (x xlsxSI) String() string {
 	var value strings.Builder
@@ -201,65 +626,6 @@ func (f *File) removeFormula(c *xlsxC, ws *xlsxWorksheet, sheet string) error {
 	return nil
 }
 
-// setCellIntFunc is a wrapper of SetCellInt.
-func (f *File) setCellIntFunc(sheet, cell string, value interface{}) error {
-	var err error
-	switch v := value.(type) {
-	case int:
-		err = f.SetCellInt(sheet, cell, v)
-	case int8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case int64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint8:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint16:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint32:
-		err = f.SetCellInt(sheet, cell, int(v))
-	case uint64:
-		err = f.SetCellInt(sheet, cell, int(v))
-	}
-	return err
-}
-
-// setCellTimeFunc provides a method to process time type of value for
-// SetCellValue.
-func (f *File) setCellTimeFunc(sheet, cell string, value time.Time) error {
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
-		return err
-	}
-	c, col, row, err := ws.prepareCell(cell)
-	if err != nil {
-		return err
-	}
-	ws.mu.Lock()
-	c.S = ws.prepareCellStyle(col, row, c.S)
-	ws.mu.Unlock()
-	var date1904, isNum bool
-	wb, err := f.workbookReader()
-	if err != nil {
-		return err
-	}
-	if wb != nil && wb.WorkbookPr != nil {
-		date1904 = wb.WorkbookPr.Date1904
-	}
-	if isNum, err = c.setCellTime(value, date1904); err != nil {
-		return err
-	}
-	if isNum {
-		_ = f.setDefaultTimeStyle(sheet, cell, 22)
-	}
-	return err
-}
-
 // setCellTime prepares cell type and Excel time by given Go time.Time type
 // timestamp.
 func (c *xlsxC) setCellTime(value time.Time, date1904 bool) (isNum bool, err error) {
@@ -427,6 +793,11 @@ func (f *File) setCellString(value string) (t, v string, err error) {
 func (f *File) sharedStringsLoader() (err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	if f.sharedStringSource != nil {
+		_ = f.sharedStringSource.Close()
+		f.sharedStringSource = nil
+	}
+	f.sharedStringDecoder = nil
 	if path, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
 		f.Pkg.Store(defaultXMLPathSharedStrings, f.readBytes(defaultXMLPathSharedStrings))
 		f.tempFiles.Delete(defaultXMLPathSharedStrings)
@@ -551,16 +922,7 @@ func (c *xlsxC) setCellDefault(value string) {
 // getCellDate parse cell value which contains a date in the ISO 8601 format.
 func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 	if !raw {
-		layout := "20060102T150405.999"
-		if strings.HasSuffix(c.V, "Z") {
-			layout = "20060102T150405Z"
-			if strings.Contains(c.V, "-") {
-				layout = "2006-01-02T15:04:05Z"
-			}
-		} else if strings.Contains(c.V, "-") {
-			layout = "2006-01-02 15:04:05Z"
-		}
-		if timestamp, err := time.Parse(layout, strings.ReplaceAll(c.V, ",", ".")); err == nil {
+		if timestamp, err := c.parseCellDate(); err == nil {
 			excelTime, _ := timeToExcelTime(timestamp, false)
 			c.V = strconv.FormatFloat(excelTime, 'G', 15, 64)
 		}
@@ -568,6 +930,21 @@ func (c *xlsxC) getCellDate(f *File, raw bool) (string, error) {
 	return f.formattedValue(c, raw, CellTypeBool)
 }
 
+// parseCellDate parse the value of the cell which contains a date in the ISO
+// 8601 format.
+func (c *xlsxC) parseCellDate() (time.Time, error) {
+	layout := "20060102T150405.999"
+	if strings.HasSuffix(c.V, "Z") {
+		layout = "20060102T150405Z"
+		if strings.Contains(c.V, "-") {
+			layout = "2006-01-02T15:04:05Z"
+		}
+	} else if strings.Contains(c.V, "-") {
+		layout = "2006-01-02 15:04:05Z"
+	}
+	return time.Parse(layout, strings.ReplaceAll(c.V, ",", "."))
+}
+
 // getValueFrom return a value from a column/row cell, this function is
 // intended to be used with for range on rows an argument with the spreadsheet
 // opened file.
@@ -581,7 +958,10 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 		if c.V != "" {
 			xlsxSI, _ := strconv.Atoi(strings.TrimSpace(c.V))
 			if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
-				return f.formattedValue(&xlsxC{S: c.S, V: f.getFromStringItem(xlsxSI)}, raw, CellTypeSharedString)
+				f.mu.Lock()
+				v := f.getFromStringItem(xlsxSI)
+				f.mu.Unlock()
+				return f.formattedValue(&xlsxC{S: c.S, V: v}, raw, CellTypeSharedString)
 			}
 			d.mu.Lock()
 			defer d.mu.Unlock()
@@ -644,8 +1024,9 @@ func (f *File) GetCellFormula(sheet, cell string) (string, error) {
 
 // FormulaOpts can be passed to SetCellFormula to use other formula types.
 type FormulaOpts struct {
-	Type *string // Formula type
-	Ref  *string // Shared formula ref
+	Type    *string // Formula type
+	Ref     *string // Shared or array formula ref
+	Dynamic bool    // Dynamic array formula
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -683,14 +1064,23 @@ type FormulaOpts struct {
 //	err := f.SetCellFormula("Sheet1", "A3", "=A1:A2",
 //	       excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 6, set shared formula "=A1+B1" for the cell "C1:C5"
+// Example 6, set multi-cell array formula "=A1:A3*B1:B3" for the cells
+// "C1:C3" on "Sheet1", the reference of the array formula should be a range
+// starts with the formula cell, and set the Dynamic option to mark the formula
+// as a dynamic array formula, which will be spilled into the range:
+//
+//	formulaType, ref := excelize.STCellFormulaTypeArray, "C1:C3"
+//	err := f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3",
+//	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true})
+//
+// Example 7, set shared formula "=A1+B1" for the cell "C1:C5"
 // on "Sheet1", "C1" is the master cell:
 //
 //	formulaType, ref := excelize.STCellFormulaTypeShared, "C1:C5"
 //	err := f.SetCellFormula("Sheet1", "C1", "=A1+B1",
 //	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 8, set table formula "=SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //	package main
@@ -735,7 +1125,7 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	if err != nil {
 		return err
 	}
-	c, _, _, err := ws.prepareCell(cell)
+	c, col, row, err := ws.prepareCell(cell)
 	if err != nil {
 		return err
 	}
@@ -750,6 +1140,7 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		c.F = &xlsxF{Content: formula}
 	}
 
+	var dynamic bool
 	for _, opt := range opts {
 		if opt.Type != nil {
 			if *opt.Type == STCellFormulaTypeDataTable {
@@ -760,16 +1151,127 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 				if err = ws.setSharedFormula(*opt.Ref); err != nil {
 					return err
 				}
+				c = &ws.SheetData.Row[row-1].C[col-1]
 			}
 		}
 		if opt.Ref != nil {
 			c.F.Ref = *opt.Ref
 		}
+		dynamic = dynamic || opt.Dynamic
+	}
+	if c.F.T == STCellFormulaTypeArray {
+		ref, err := ws.setArrayFormula(col, row, c.F.Ref)
+		if err != nil {
+			return err
+		}
+		c = &ws.SheetData.Row[row-1].C[col-1]
+		c.F.Ref = ref
+		if dynamic {
+			cm, err := f.getDynamicArrayCellMetadata()
+			if err != nil {
+				return err
+			}
+			c.Cm = &cm
+		}
 	}
 	c.T, c.IS = "str", nil
 	return err
 }
 
+// setArrayFormula provides a function to prepare the cells in the range of
+// the array formula by given column and row number of the formula cell and
+// the reference of the array formula. The reference should be a range which
+// starts with the formula cell, and it will be the formula cell itself if
+// it's empty. The formulas in other cells of the range will be removed, and
+// the normalized reference will be returned.
+func (ws *xlsxWorksheet) setArrayFormula(col, row int, ref string) (string, error) {
+	if ref == "" {
+		cell, err := CoordinatesToCellName(col, row)
+		return cell + ":" + cell, err
+	}
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return ref, ErrArrayFormulaRef
+	}
+	_ = sortCoordinates(coordinates)
+	if coordinates[0] != col || coordinates[1] != row {
+		return ref, ErrArrayFormulaRef
+	}
+	if (coordinates[2]-coordinates[0]+1)*(coordinates[3]-coordinates[1]+1) > MaxExpandRangeCells {
+		return ref, ErrRangeTooLarge
+	}
+	for r := coordinates[1]; r <= coordinates[3]; r++ {
+		for c := coordinates[0]; c <= coordinates[2]; c++ {
+			ws.prepareSheetXML(c, r)
+			if c != col || r != row {
+				ws.SheetData.Row[r-1].C[c-1].F = nil
+			}
+		}
+	}
+	firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	lastCell, err := CoordinatesToCellName(coordinates[2], coordinates[3])
+	return firstCell + ":" + lastCell, err
+}
+
+// getDynamicArrayCellMetadata provides a function to get the 1-based index of
+// the cell metadata for the dynamic array formula, the workbook metadata part
+// will be created if it doesn't exist, and the metadata of the dynamic array
+// formula will be appended if the workbook metadata part doesn't contain it.
+func (f *File) getDynamicArrayCellMetadata() (uint, error) {
+	if _, ok := f.Pkg.Load(defaultXMLPathMetadata); !ok {
+		f.saveFileList(defaultXMLPathMetadata, []byte(templateMetadataDynamicArray))
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "metadata.xml", "")
+		return 1, f.addContentTypePart(0, "sheetMetadata")
+	}
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return 0, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	if metadata.CellMetadata == nil {
+		metadata.CellMetadata = &xlsxMetadataBlocks{}
+	}
+	var typeID int
+	for t, metadataType := range metadata.MetadataTypes.MetadataType {
+		if metadataType.Name == "XLDAPR" {
+			typeID = t + 1
+			break
+		}
+	}
+	for idx, bk := range metadata.CellMetadata.Bk {
+		for _, rc := range bk.Rc {
+			if typeID != 0 && rc.T == typeID {
+				return uint(idx + 1), err
+			}
+		}
+	}
+	if typeID == 0 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLDAPR", MinSupportedVersion: 120000, Copy: true, PasteAll: true, PasteValues: true,
+			Merge: true, SplitFirst: true, RowColShift: true, ClearFormats: true, ClearComments: true,
+			Assign: true, Coerce: true, CellMeta: true,
+		})
+		typeID = len(metadata.MetadataTypes.MetadataType)
+	}
+	var dynamic bool
+	for _, futureMetadata := range metadata.FutureMetadata {
+		dynamic = dynamic || futureMetadata.Name == "XLDAPR"
+	}
+	if !dynamic {
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{
+			Name: "XLDAPR",
+			Bk: []xlsxFutureMetadataBlock{{ExtLst: &xlsxInnerXML{
+				Content: `<ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray" fDynamic="1" fCollapsed="0"/></ext>`,
+			}}},
+		})
+	}
+	metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{Rc: []xlsxMetadataRecord{{T: typeID}}})
+	f.metadataWriter(metadata)
+	return uint(len(metadata.CellMetadata.Bk)), err
+}
+
 // setSharedFormula set shared formula for the cells.
 func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	coordinates, err := rangeRefToCoordinates(ref)
@@ -792,6 +1294,171 @@ func (ws *xlsxWorksheet) setSharedFormula(ref string) error {
 	return err
 }
 
+// RewriteFormulaRefs provides a function to rewrite the worksheet names in the
+// references of the cell formulas on the worksheet by given worksheet name and
+// the mapping of the original worksheet names and the new worksheet names.
+// The original worksheet names are case-insensitive, the new worksheet names
+// will be quoted if it contains spaces or special characters, and the
+// references to external workbooks will be kept. The formulas that reference
+// the mapped worksheets will be normalized, such as the whitespace between
+// tokens will be removed. For example, rewrite the references to the
+// worksheet Sheet2 as the worksheet 'Summary 2019' in the formulas on Sheet1:
+//
+//	err := f.RewriteFormulaRefs("Sheet1", map[string]string{"Sheet2": "Summary 2019"})
+//
+// The formula SUM(Sheet2!A1:A10) will be rewritten as
+// SUM('Summary 2019'!A1:A10).
+func (f *File) RewriteFormulaRefs(sheet string, mapping map[string]string) error {
+	sheetNames := make(map[string]string, len(mapping))
+	for source, target := range mapping {
+		if err := checkSheetName(target); err != nil {
+			return err
+		}
+		sheetNames[strings.ToLower(source)] = target
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for rowIdx := range ws.SheetData.Row {
+		for colIdx := range ws.SheetData.Row[rowIdx].C {
+			c := &ws.SheetData.Row[rowIdx].C[colIdx]
+			if c.F == nil || c.F.Content == "" {
+				continue
+			}
+			if formula, ok := rewriteFormulaSheetRefs(c.F.Content, sheetNames); ok {
+				c.F.Content = formula
+			}
+		}
+	}
+	return err
+}
+
+// rewriteFormulaSheetRefs provides a function to rewrite the worksheet names
+// in the references of the formula by given mapping of the lowercase original
+// worksheet names and the new worksheet names, and returns if the formula
+// references any mapped worksheet.
+func rewriteFormulaSheetRefs(formula string, sheetNames map[string]string) (string, bool) {
+	var changed bool
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(formula)
+	for i, token := range tokens {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			var ok bool
+			if tokens[i].TValue, ok = rewriteSheetRef(token.TValue, sheetNames); ok {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return formula, changed
+	}
+	var (
+		output    strings.Builder
+		funcStack []string
+	)
+	for _, token := range tokens {
+		switch {
+		case token.TSubType == efp.TokenSubTypeStart:
+			funcStack = append(funcStack, token.TValue)
+			if token.TType == efp.TokenTypeSubexpression {
+				output.WriteString(efp.ParenOpen)
+				break
+			}
+			switch token.TValue {
+			case "ARRAY":
+				output.WriteString(efp.BraceOpen)
+			case "ARRAYROW":
+			default:
+				output.WriteString(token.TValue + efp.ParenOpen)
+			}
+		case token.TSubType == efp.TokenSubTypeStop:
+			var name string
+			if len(funcStack) > 0 {
+				name, funcStack = funcStack[len(funcStack)-1], funcStack[:len(funcStack)-1]
+			}
+			switch {
+			case token.TType == efp.TokenTypeSubexpression:
+				output.WriteString(efp.ParenClose)
+			case name == "ARRAY":
+				output.WriteString(efp.BraceClose)
+			case name != "ARRAYROW":
+				output.WriteString(efp.ParenClose)
+			}
+		case token.TType == efp.TokenTypeArgument:
+			if len(funcStack) > 0 && funcStack[len(funcStack)-1] == "ARRAY" {
+				output.WriteString(efp.Semicolon)
+				break
+			}
+			output.WriteString(efp.Comma)
+		case token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeText:
+			output.WriteString(efp.QuoteDouble + strings.ReplaceAll(token.TValue, efp.QuoteDouble, efp.QuoteDouble+efp.QuoteDouble) + efp.QuoteDouble)
+		case token.TType == efp.TokenTypeOperatorInfix && token.TSubType == efp.TokenSubTypeIntersection,
+			token.TType == efp.TokenTypeWhitespace:
+			output.WriteString(efp.Whitespace)
+		default:
+			output.WriteString(token.TValue)
+		}
+	}
+	return output.String(), changed
+}
+
+// rewriteSheetRef provides a function to rewrite the worksheet names in the
+// reference by given mapping of the lowercase original worksheet names and
+// the new worksheet names, and quote the worksheet names if necessary. This
+// function returns if the reference contains any mapped worksheet.
+func rewriteSheetRef(ref string, sheetNames map[string]string) (string, bool) {
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return ref, false
+	}
+	var (
+		changed bool
+		quote   bool
+		book    string
+		names   = strings.Split(ref[:idx], ":")
+	)
+	if strings.HasPrefix(names[0], "[") {
+		if bookIdx := strings.Index(names[0], "]"); bookIdx != -1 {
+			book, names[0] = names[0][:bookIdx+1], names[0][bookIdx+1:]
+		}
+	}
+	for i, name := range names {
+		if target, ok := sheetNames[strings.ToLower(name)]; ok && book == "" {
+			names[i], changed = target, true
+		}
+		quote = quote || sheetNameNeedQuote(names[i])
+	}
+	sheets := book + strings.Join(names, ":")
+	if quote {
+		sheets = "'" + strings.ReplaceAll(sheets, "'", "''") + "'"
+	}
+	return sheets + ref[idx:], changed
+}
+
+var (
+	sheetNameExp   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	r1c1CellRefExp = regexp.MustCompile(`^(?i)(R\d*C?\d*|C\d*)$`)
+)
+
+// sheetNameNeedQuote returns if the worksheet name should be quoted in the
+// formula references, such as the name contains spaces or special characters,
+// starts with a digit, or looks like a cell reference.
+func sheetNameNeedQuote(name string) bool {
+	if !sheetNameExp.MatchString(name) {
+		return true
+	}
+	if _, _, err := CellNameToCoordinates(name); err == nil {
+		return true
+	}
+	return r1c1CellRefExp.MatchString(name)
+}
+
 // countSharedFormula count shared formula in the given worksheet.
 func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 	for _, row := range ws.SheetData.Row {
@@ -807,35 +1474,152 @@ func (ws *xlsxWorksheet) countSharedFormula() (count int) {
 // GetCellHyperLink gets a cell hyperlink based on the given worksheet name and
 // cell reference. If the cell has a hyperlink, it will return 'true' and
 // the link address, otherwise it will return 'false' and an empty link
-// address.
+// address. The link created by the HYPERLINK formula function in the cell
+// will also be returned if the cell doesn't have a hyperlink, the link
+// location argument of the function can be a string or a cell reference, the
+// cell reference will be resolved to the cell value, and it will return
+// 'false' and an empty link address if the reference couldn't be resolved,
+// such as referring to a worksheet which doesn't exist.
 //
 // For example, get a hyperlink to a 'H6' cell on a worksheet named 'Sheet1':
 //
 //	link, target, err := f.GetCellHyperLink("Sheet1", "H6")
 func (f *File) GetCellHyperLink(sheet, cell string) (bool, string, error) {
+	link, target, _, err := f.getCellHyperLink(sheet, cell)
+	return link, target, err
+}
+
+// GetCellHyperLinkDisplay provides a function to get the display text of the
+// cell hyperlink by given worksheet name and cell reference. For the link
+// created by the HYPERLINK formula function, the friendly name argument of
+// the function will be returned, it can be a string or a cell reference, and
+// the cached value of the formula cell will be returned if the friendly name
+// is an expression. The link location will be returned if the friendly name
+// is omitted. For example, get the display text of the hyperlink in the cell
+// 'H6' on a worksheet named 'Sheet1':
+//
+//	display, err := f.GetCellHyperLinkDisplay("Sheet1", "H6")
+func (f *File) GetCellHyperLinkDisplay(sheet, cell string) (string, error) {
+	_, _, display, err := f.getCellHyperLink(sheet, cell)
+	return display, err
+}
+
+// getCellHyperLink provides a function to get the cell hyperlink, link
+// address and display text by given worksheet name and cell reference.
+func (f *File) getCellHyperLink(sheet, cell string) (bool, string, string, error) {
 	// Check for correct cell name
 	if _, _, err := SplitCellName(cell); err != nil {
-		return false, "", err
+		return false, "", "", err
 	}
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
-		return false, "", err
+		return false, "", "", err
 	}
 	if ws.Hyperlinks != nil {
 		for _, link := range ws.Hyperlinks.Hyperlink {
 			ok, err := f.checkCellInRangeRef(cell, link.Ref)
 			if err != nil {
-				return false, "", err
+				return false, "", "", err
 			}
 			if link.Ref == cell || ok {
 				if link.RID != "" {
-					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), err
+					return true, f.getSheetRelationshipsTargetByID(sheet, link.RID), link.Display, err
 				}
-				return true, link.Location, err
+				return true, link.Location, link.Display, err
 			}
 		}
 	}
-	return false, "", err
+	formula, err := f.GetCellFormula(sheet, cell)
+	if err != nil || formula == "" {
+		return false, "", "", err
+	}
+	args := getHyperlinkFormulaArgs(formula)
+	if len(args) == 0 {
+		return false, "", "", err
+	}
+	target, ok, err := f.getHyperlinkFormulaArgValue(sheet, args[0])
+	if err != nil || !ok {
+		return ok, target, "", err
+	}
+	if len(args) < 2 {
+		return ok, target, target, err
+	}
+	display, resolved, err := f.getHyperlinkFormulaArgValue(sheet, args[1])
+	if err == nil && !resolved {
+		display, err = f.GetCellValue(sheet, cell)
+	}
+	return ok, target, display, err
+}
+
+// getHyperlinkFormulaArgs provides a function to get the tokens of each
+// argument of the HYPERLINK formula function by given formula. It returns nil
+// if the formula isn't a HYPERLINK function call.
+func getHyperlinkFormulaArgs(formula string) [][]efp.Token {
+	var tokens []efp.Token
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(strings.TrimPrefix(formula, "=")) {
+		if token.TType != efp.TokenTypeWhitespace {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) < 3 || tokens[0].TType != efp.TokenTypeFunction || tokens[0].TSubType != efp.TokenSubTypeStart ||
+		!strings.EqualFold(tokens[0].TValue, "HYPERLINK") {
+		return nil
+	}
+	last := tokens[len(tokens)-1]
+	if last.TType != efp.TokenTypeFunction || last.TSubType != efp.TokenSubTypeStop {
+		return nil
+	}
+	var (
+		depth int
+		args  = [][]efp.Token{{}}
+	)
+	for _, token := range tokens[1 : len(tokens)-1] {
+		if token.TType == efp.TokenTypeFunction || token.TType == efp.TokenTypeSubexpression {
+			if token.TSubType == efp.TokenSubTypeStart {
+				depth++
+			} else if depth--; depth < 0 {
+				return nil
+			}
+		}
+		if token.TType == efp.TokenTypeArgument && depth == 0 {
+			args = append(args, []efp.Token{})
+			continue
+		}
+		args[len(args)-1] = append(args[len(args)-1], token)
+	}
+	return args
+}
+
+// getHyperlinkFormulaArgValue provides a function to get the value of the
+// argument of the HYPERLINK formula function by given worksheet name and the
+// tokens of the argument. The argument should be a string, number or single
+// cell reference, the cell reference will be resolved to the cell value. It
+// returns false if the argument couldn't be resolved, including the reference
+// to a worksheet which doesn't exist.
+func (f *File) getHyperlinkFormulaArgValue(sheet string, tokens []efp.Token) (string, bool, error) {
+	if len(tokens) != 1 || tokens[0].TType != efp.TokenTypeOperand {
+		return "", false, nil
+	}
+	switch tokens[0].TSubType {
+	case efp.TokenSubTypeText, efp.TokenSubTypeNumber:
+		return tokens[0].TValue, tokens[0].TValue != "", nil
+	case efp.TokenSubTypeRange:
+		cell := strings.ReplaceAll(tokens[0].TValue, "$", "")
+		if idx := strings.LastIndex(cell, "!"); idx != -1 {
+			sheet = strings.ReplaceAll(strings.Trim(cell[:idx], "'"), "''", "'")
+			cell = cell[idx+1:]
+		}
+		if _, _, err := CellNameToCoordinates(cell); err != nil {
+			return "", false, nil
+		}
+		if _, ok := f.getSheetXMLPath(sheet); !ok {
+			return "", false, nil
+		}
+		val, err := f.GetCellValue(sheet, cell)
+		return val, val != "", err
+	}
+	return "", false, nil
 }
 
 // HyperlinkOpts can be passed to SetCellHyperlink to set optional hyperlink
@@ -955,7 +1739,12 @@ func getCellRichText(si *xlsxSI) (runs []RichTextRun) {
 }
 
 // GetCellRichText provides a function to get rich text of cell by given
-// worksheet.
+// worksheet. If the cell is inside a merged range, the rich text of the
+// top-left cell of the merged range will be returned for any cell in the
+// range, the same as the GetCellValue function does. For example, get the
+// rich text of the cell B1 in the merged range A1:C1 on Sheet1:
+//
+//	runs, err := f.GetCellRichText("Sheet1", "B1")
 func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err error) {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -965,8 +1754,11 @@ func (f *File) GetCellRichText(sheet, cell string) (runs []RichTextRun, err erro
 	if err != nil {
 		return
 	}
+	if c.T != "s" {
+		return
+	}
 	siIdx, err := strconv.Atoi(c.V)
-	if err != nil || c.T != "s" {
+	if err != nil {
 		return
 	}
 	sst, err := f.sharedStringsReader()
@@ -1236,6 +2028,26 @@ func (f *File) SetSheetCol(sheet, cell string, slice interface{}) error {
 	return f.setSheetCells(sheet, cell, slice, columns)
 }
 
+// SetCellValues provides a function to write a horizontal run of cells by
+// given worksheet name, top-left cell reference and values. Each value will be
+// set as the SetCellValue does, the nil value will leave a blank cell, and the
+// same string will be stored in the shared strings table only once. For
+// example, write a row of values start with the cell A2 on Sheet1:
+//
+//	err := f.SetCellValues("Sheet1", "A2", []interface{}{
+//	    "Excelize", 1, 2.5, true, nil, time.Now(),
+//	})
+func (f *File) SetCellValues(sheet, topLeftCell string, values []interface{}) error {
+	col, _, err := CellNameToCoordinates(topLeftCell)
+	if err != nil {
+		return err
+	}
+	if col+len(values)-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	return f.setSheetCells(sheet, topLeftCell, &values, rows)
+}
+
 // setSheetCells provides a function to set worksheet cells value.
 func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDirection) error {
 	col, row, err := CellNameToCoordinates(cell)
@@ -1268,6 +2080,183 @@ func (f *File) setSheetCells(sheet, cell string, slice interface{}, dir adjustDi
 	return err
 }
 
+// SetCells provides a function to set the value, style, formula and comment
+// of multiple cells by given worksheet name and a map of cell reference and
+// the cell settings. All cell references and styles will be validated before
+// setting any cell, and the cells will be set in row-major order in one pass
+// of the worksheet. For example, set
+// the value and style of cell A1, and the formula of cell B1 on Sheet1:
+//
+//	err := f.SetCells("Sheet1", map[string]excelize.CellInput{
+//	    "A1": {Value: 100, StyleID: style},
+//	    "B1": {Formula: "A1*2", Comment: &excelize.Comment{Text: "Double"}},
+//	})
+func (f *File) SetCells(sheet string, cells map[string]CellInput) error {
+	type cellRef struct {
+		cell     string
+		col, row int
+	}
+	refs := make([]cellRef, 0, len(cells))
+	for cell := range cells {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, cellRef{cell: cell, col: col, row: row})
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].row != refs[j].row {
+			return refs[i].row < refs[j].row
+		}
+		return refs[i].col < refs[j].col
+	})
+	ws, date1904, err := f.prepareSetCells(sheet)
+	if err != nil {
+		return err
+	}
+	styleIDs := make([]int, 0, len(refs))
+	for _, ref := range refs {
+		if cells[ref.cell].StyleID != 0 {
+			styleIDs = append(styleIDs, cells[ref.cell].StyleID)
+		}
+	}
+	if err = f.checkStyleIDs(styleIDs...); err != nil {
+		return err
+	}
+	timeCells := map[string]int{}
+	ws.mu.Lock()
+	for _, ref := range refs {
+		input := cells[ref.cell]
+		c, col, row, err := ws.prepareCell(ref.cell)
+		if err != nil {
+			ws.mu.Unlock()
+			return err
+		}
+		c.S = ws.prepareCellStyle(col, row, c.S)
+		numFmt, err := f.setCellValue(ws, sheet, c, input.Value, date1904)
+		if err != nil {
+			ws.mu.Unlock()
+			return err
+		}
+		if input.Formula != "" {
+			if c.F != nil {
+				c.F.Content = input.Formula
+			} else {
+				c.F = &xlsxF{Content: input.Formula}
+			}
+			c.T, c.IS = "str", nil
+		}
+		if input.StyleID != 0 {
+			c.S = input.StyleID
+		}
+		if numFmt != 0 && c.S == 0 {
+			timeCells[c.R] = numFmt
+		}
+	}
+	ws.mu.Unlock()
+	for _, ref := range refs {
+		if numFmt, ok := timeCells[ref.cell]; ok {
+			if err = f.setDefaultTimeStyle(sheet, ref.cell, numFmt); err != nil {
+				return err
+			}
+		}
+		if input := cells[ref.cell]; input.Comment != nil {
+			comment := *input.Comment
+			comment.Cell = ref.cell
+			if err = f.AddComment(sheet, comment); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// prepareSetCells provides a function to get the worksheet and the date
+// system of the workbook for setting multiple cells by given worksheet name.
+func (f *File) prepareSetCells(sheet string) (*xlsxWorksheet, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return ws, false, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return ws, false, err
+	}
+	return ws, wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904, err
+}
+
+// checkStyleIDs provides a function to check if all given style indexes
+// exist in the cell formats of the workbook.
+func (f *File) checkStyleIDs(styleIDs ...int) error {
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, styleID := range styleIDs {
+		if styleID < 0 || s.CellXfs == nil || len(s.CellXfs.Xf) <= styleID {
+			return newInvalidStyleID(styleID)
+		}
+	}
+	return err
+}
+
+// setCellValue provides a function to set the value of the prepared cell by
+// given worksheet, worksheet name, cell, value and the date system of the
+// workbook. The supported data types of the value are the same as the
+// SetCellValue function, and the caller should hold the lock of the
+// worksheet. It returns the built-in number format ID for the date and
+// duration values, which should be applied to the cell without style.
+func (f *File) setCellValue(ws *xlsxWorksheet, sheet string, c *xlsxC, value interface{}, date1904 bool) (int, error) {
+	var (
+		numFmt int
+		err    error
+	)
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		c.T, c.V = setCellInt(int(reflect.ValueOf(v).Convert(reflect.TypeOf(0)).Int()))
+		c.IS = nil
+	case float32:
+		c.T, c.V = setCellFloat(float64(v), -1, 32)
+		c.IS = nil
+	case float64:
+		c.T, c.V = setCellFloat(v, -1, 64)
+		c.IS = nil
+	case string:
+		c.T, c.V, err = f.setCellString(v)
+		c.IS = nil
+	case []byte:
+		c.T, c.V, err = f.setCellString(string(v))
+		c.IS = nil
+	case time.Duration:
+		_, d := setCellDuration(v)
+		c.setCellDefault(d)
+		numFmt = 21
+	case time.Time:
+		var isNum bool
+		if isNum, err = c.setCellTime(v, date1904); isNum {
+			numFmt = 22
+		}
+	case bool:
+		c.T, c.V = setCellBool(v)
+		c.IS = nil
+	case nil:
+		c.setCellDefault("")
+	default:
+		c.T, c.V, err = f.setCellString(fmt.Sprint(value))
+		c.IS = nil
+	}
+	if err != nil {
+		return numFmt, err
+	}
+	return numFmt, f.removeFormula(c, ws, sheet)
+}
+
 // getCellInfo does common preparation for all set cell value functions.
 func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 	var err error
@@ -1288,10 +2277,13 @@ func (ws *xlsxWorksheet) prepareCell(cell string) (*xlsxC, int, int, error) {
 // value function. Passed function implements specific part of required
 // logic.
 func (f *File) getCellStringFunc(sheet, cell string, fn func(x *xlsxWorksheet, c *xlsxC) (string, bool, error)) (string, error) {
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
+		f.mu.Unlock()
 		return "", err
 	}
+	f.mu.Unlock()
 	cell, err = ws.mergeCellsParser(cell)
 	if err != nil {
 		return "", err
@@ -1343,15 +2335,50 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	if raw || c.S == 0 {
 		return c.V, nil
 	}
+	numFmt, date1904, err := f.getCellNumFmt(c)
+	if numFmt != nil {
+		return numFmt.format(c.V, date1904, cellType, f.options), err
+	}
+	return c.V, err
+}
+
+// formattedColor provides a function to returns the RGB color of the number
+// format section which applies to the cell value. The color will be empty if
+// the cell hasn't number format, or the applied section doesn't contain color.
+func (f *File) formattedColor(c *xlsxC) (string, error) {
+	if c.S == 0 {
+		return "", nil
+	}
+	cellType := CellTypeNumber
+	switch c.T {
+	case "b", "d":
+		cellType = CellTypeBool
+	case "s":
+		cellType = CellTypeSharedString
+	case "inlineStr":
+		cellType = CellTypeInlineString
+	}
+	numFmt, _, err := f.getCellNumFmt(c)
+	if numFmt != nil {
+		return numFmt.color(c.V, cellType), err
+	}
+	return "", err
+}
+
+// getCellNumFmt provides a function to get the number format applier of the
+// cell style, and if the workbook uses the 1904 date system. This function
+// returns nil number format applier if the cell style or number format can
+// not be found.
+func (f *File) getCellNumFmt(c *xlsxC) (*styleNumFmt, bool, error) {
 	styleSheet, err := f.stylesReader()
 	if err != nil {
-		return c.V, err
+		return nil, false, err
 	}
 	if styleSheet.CellXfs == nil {
-		return c.V, err
+		return nil, false, err
 	}
 	if c.S >= len(styleSheet.CellXfs.Xf) || c.S < 0 {
-		return c.V, err
+		return nil, false, err
 	}
 	var numFmtID int
 	if styleSheet.CellXfs.Xf[c.S].NumFmtID != nil {
@@ -1360,23 +2387,12 @@ func (f *File) formattedValue(c *xlsxC, raw bool, cellType CellType) (string, er
 	date1904 := false
 	wb, err := f.workbookReader()
 	if err != nil {
-		return c.V, err
+		return nil, false, err
 	}
 	if wb != nil && wb.WorkbookPr != nil {
 		date1904 = wb.WorkbookPr.Date1904
 	}
-	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
-		return f.applyBuiltInNumFmt(c, fmtCode, numFmtID, date1904, cellType), err
-	}
-	if styleSheet.NumFmts == nil {
-		return c.V, err
-	}
-	for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
-		if xlsxFmt.NumFmtID == numFmtID {
-			return format(c.V, xlsxFmt.FormatCode, date1904, cellType, f.options), err
-		}
-	}
-	return c.V, err
+	return f.getStyleNumFmt(styleSheet, c.S, numFmtID), date1904, err
 }
 
 // prepareCellStyle provides a function to prepare style index of cell in
@@ -1426,13 +2442,13 @@ func (ws *xlsxWorksheet) mergeCellsParser(cell string) (string, error) {
 				_ = sortCoordinates(rect)
 				ws.MergeCells.Cells[i].rect = rect
 			}
-			if cellInRange([]int{col, row}, ws.MergeCells.Cells[i].rect) {
-				cell = strings.Split(ws.MergeCells.Cells[i].Ref, ":")[0]
+			if rect := ws.MergeCells.Cells[i].rect; cellInRange([]int{col, row}, rect) {
+				cell, err = CoordinatesToCellName(rect[0], rect[1])
 				break
 			}
 		}
 	}
-	return cell, nil
+	return cell, err
 }
 
 // checkCellInRangeRef provides a function to determine if a given cell reference
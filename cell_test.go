@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"encoding/xml"
 	"fmt"
 	_ "image/jpeg"
 	"os"
@@ -100,6 +101,54 @@ func TestConcurrency(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestConcurrentReadSheets(t *testing.T) {
+	f := NewFile()
+	sheets := []string{"Sheet1", "Sheet2", "Sheet3", "Sheet4"}
+	for i, sheet := range sheets {
+		if i > 0 {
+			_, err := f.NewSheet(sheet)
+			assert.NoError(t, err)
+		}
+		for row := 1; row <= 20; row++ {
+			assert.NoError(t, f.SetSheetRow(sheet, fmt.Sprintf("A%d", row), &[]interface{}{sheet, row, float64(row) / 2}))
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	// Reopen the workbook for lazy parsing worksheets in goroutines
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 4; i++ {
+		for _, sheet := range sheets {
+			wg.Add(1)
+			go func(sheet string) {
+				defer wg.Done()
+				val, err := f.GetCellValue(sheet, "A1")
+				assert.NoError(t, err)
+				assert.Equal(t, sheet, val)
+				val, err = f.GetCellValue(sheet, "B20")
+				assert.NoError(t, err)
+				assert.Equal(t, "20", val)
+				_, err = f.GetCellStyle(sheet, "C3")
+				assert.NoError(t, err)
+				rows, err := f.GetRows(sheet)
+				assert.NoError(t, err)
+				assert.Len(t, rows, 20)
+				cols, err := f.GetCols(sheet)
+				assert.NoError(t, err)
+				assert.Len(t, cols, 3)
+				result, err := f.SearchSheet(sheet, "11")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"B11"}, result)
+			}(sheet)
+		}
+	}
+	wg.Wait()
+	assert.NoError(t, f.Close())
+}
+
 func TestCheckCellInRangeRef(t *testing.T) {
 	f := NewFile()
 	expectedTrueCellInRangeRefList := [][2]string{
@@ -441,6 +490,48 @@ func TestGetCellValue(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellValueWithColor(t *testing.T) {
+	f := NewFile()
+	numFmt := "#,##0.00;[Red]-#,##0.00;[Blue]0;[Green]@"
+	style, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	for cell, value := range map[string]interface{}{"A1": 1234.5, "A2": -1234.5, "A3": 0, "A4": "text", "A5": true} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A5", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", -1234.5))
+	for _, expected := range [][]string{
+		{"A1", "1,234.50", ""},
+		{"A2", "-1,234.50", "FF0000"},
+		{"A3", "0", "0000FF"},
+		{"A4", "text", "00FF00"},
+		{"A5", "TRUE", "00FF00"},
+		{"B1", "-1234.5", ""},
+		{"C1", "", ""},
+	} {
+		value, color, err := f.GetCellValueWithColor("Sheet1", expected[0])
+		assert.NoError(t, err, expected[0])
+		assert.Equal(t, expected[1], value, expected[0])
+		assert.Equal(t, expected[2], color, expected[0])
+	}
+	// Test get cell value with color with raw cell value
+	value, color, err := f.GetCellValueWithColor("Sheet1", "A2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "-1234.5", value)
+	assert.Empty(t, color)
+	// Test get cell value with color with invalid sheet name
+	_, _, err = f.GetCellValueWithColor("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get cell value with color with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, _, err = f.GetCellValueWithColor("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.Styles = nil
+	_, err = f.formattedColor(&xlsxC{S: 1})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetCellType(t *testing.T) {
 	f := NewFile()
 	cellType, err := f.GetCellType("Sheet1", "A1")
@@ -457,6 +548,166 @@ func TestGetCellType(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellTypedValues(t *testing.T) {
+	f := NewFile()
+	// Test get typed values of the empty cell
+	num, err := f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, num)
+	b, err := f.GetCellBool("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, b)
+	tm, err := f.GetCellTime("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, tm.IsZero())
+
+	style, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1.5))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", true))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", false))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", 45000))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A4", "A4", style))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "text"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)))
+
+	num, err = f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, num)
+	num, err = f.GetCellFloat("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, 45000.0, num)
+	b, err = f.GetCellBool("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, b)
+	b, err = f.GetCellBool("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.False(t, b)
+	tm, err = f.GetCellTime("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), tm)
+	tm, err = f.GetCellTime("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC), tm)
+
+	// Test get typed values of the date cell stored in the ISO 8601 format
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0] = xlsxC{R: "A1", T: "d", V: "2023-03-15T12:00:00Z"}
+	num, err = f.GetCellFloat("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, 45000.5, num)
+	tm, err = f.GetCellTime("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 3, 15, 12, 0, 0, 0, time.UTC), tm)
+
+	// Test get time value with the 1904 date system
+	assert.NoError(t, f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: boolPtr(true)}))
+	tm, err = f.GetCellTime("Sheet1", "A4")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2027, 3, 16, 0, 0, 0, 0, time.UTC), tm)
+
+	// Test get typed values of the cell which couldn't be converted
+	_, err = f.GetCellFloat("Sheet1", "A2")
+	assert.EqualError(t, err, newCellValueConvertError("A2", "1", "float").Error())
+	_, err = f.GetCellFloat("Sheet1", "A5")
+	assert.EqualError(t, err, newCellValueConvertError("A5", "text", "float").Error())
+	_, err = f.GetCellBool("Sheet1", "A4")
+	assert.EqualError(t, err, newCellValueConvertError("A4", "45000", "bool").Error())
+	_, err = f.GetCellTime("Sheet1", "A2")
+	assert.EqualError(t, err, newCellValueConvertError("A2", "1", "time").Error())
+	_, err = f.GetCellTime("Sheet1", "A5")
+	assert.EqualError(t, err, newCellValueConvertError("A5", "text", "time").Error())
+	// Test get time value of the numeric cell without date number format
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", 1.5))
+	_, err = f.GetCellTime("Sheet1", "B1")
+	assert.EqualError(t, err, newCellValueConvertError("B1", "1.5", "time").Error())
+
+	// Test get typed values with not exist worksheet
+	_, err = f.GetCellFloat("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellBool("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellTime("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+
+	// Test get typed values with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetCellFloat("Sheet1", "A5")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get time value with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetCellTime("Sheet1", "A4")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCell(t *testing.T) {
+	f := NewFile()
+	info, err := f.GetCell("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellInfo{}, info)
+	style, err := f.NewStyle(&Style{NumFmt: 10})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 0.5))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	info, err = f.GetCell("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.5", info.RawValue)
+	assert.Equal(t, "50.00%", info.FormattedValue)
+	assert.Equal(t, CellTypeUnset, info.Type)
+	assert.Equal(t, style, info.StyleID)
+	assert.Empty(t, info.Formula)
+	if assert.NotNil(t, info.Comment) {
+		assert.Equal(t, "Comment", info.Comment.Text)
+		assert.Equal(t, "Excelize", info.Comment.Author)
+	}
+	// Test get cell comment with lower case cell reference
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "E1", Text: "Note"}))
+	info, err = f.GetCell("Sheet1", "e1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, info.Comment) {
+		assert.Equal(t, "E1", info.Comment.Cell)
+		assert.Equal(t, "Note", info.Comment.Text)
+	}
+	// Test get cell with formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "A1*2"))
+	info, err = f.GetCell("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1*2", info.Formula)
+	assert.Nil(t, info.Comment)
+	// Test get cell with shared formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1", FormulaOpts{Type: stringPtr(STCellFormulaTypeShared), Ref: stringPtr("C1:C2")}))
+	info, err = f.GetCell("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "A2", info.Formula)
+	// Test get cell with shared string
+	assert.NoError(t, f.SetCellValue("Sheet1", "D1", "text"))
+	info, err = f.GetCell("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellInfo{RawValue: "text", FormattedValue: "text", Type: CellTypeSharedString}, info)
+	// Test get cell with invalid cell reference
+	_, err = f.GetCell("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get cell with invalid sheet name
+	_, err = f.GetCell("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get cell with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.GetCell("Sheet1", "D1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get cell with unsupported charset comments part
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment"}))
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCell("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetValueFrom(t *testing.T) {
 	f := NewFile()
 	c := xlsxC{T: "s"}
@@ -615,6 +866,112 @@ func TestSetCellFormula(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellFormula6.xlsx")))
 }
 
+func TestSetCellArrayFormula(t *testing.T) {
+	f := NewFile()
+	for r := 1; r <= 3; r++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", r), &[]interface{}{r, r + 3}))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "=A2"))
+	// Test set multi-cell array formula
+	formulaType, ref := STCellFormulaTypeArray, "c3:c1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	sheetData := ws.(*xlsxWorksheet).SheetData
+	assert.Equal(t, &xlsxF{Content: "=A1:A3*B1:B3", T: STCellFormulaTypeArray, Ref: "C1:C3"}, sheetData.Row[0].C[2].F)
+	assert.Nil(t, sheetData.Row[0].C[2].Cm)
+	assert.Nil(t, sheetData.Row[1].C[2].F)
+	assert.Nil(t, sheetData.Row[2].C[2].F)
+	// Test set array formula of the SUM function
+	ref = "D1:D1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "=SUM(A1:A3*B1:B3)", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	formula, err := f.GetCellFormula("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=SUM(A1:A3*B1:B3)", formula)
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxF{Content: "=SUM(A1:A3*B1:B3)", T: STCellFormulaTypeArray, Ref: "D1:D1"}, ws.(*xlsxWorksheet).SheetData.Row[0].C[3].F)
+	// Test set array formula without reference
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E5", "=SUM(A1:A3*B1:B3)", FormulaOpts{Type: &formulaType}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "E5:E5", ws.(*xlsxWorksheet).SheetData.Row[4].C[4].F.Ref)
+	// Test set array formula with invalid reference
+	for _, ref := range []string{"C1", "B1:C3", "C1:D", "C1:XFE1"} {
+		ref := ref
+		assert.EqualError(t, f.SetCellFormula("Sheet1", "C1", "=A1:A3*B1:B3", FormulaOpts{Ref: &ref, Type: &formulaType}), ErrArrayFormulaRef.Error())
+	}
+	// Test set dynamic array formula
+	ref = "F1:F3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=A1:A3*2", FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true}))
+	ref = "G1:G3"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "=B1:B3*2", FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	cm := uint(1)
+	assert.Equal(t, &cm, ws.(*xlsxWorksheet).SheetData.Row[0].C[5].Cm)
+	assert.Equal(t, &cm, ws.(*xlsxWorksheet).SheetData.Row[0].C[6].Cm)
+	metadata, err := f.metadataReader()
+	assert.NoError(t, err)
+	assert.Equal(t, "XLDAPR", metadata.MetadataTypes.MetadataType[0].Name)
+	rels, err := f.relsReader(defaultXMLPathWorkbookRels)
+	assert.NoError(t, err)
+	var relCount int
+	for _, rel := range rels.Relationships {
+		if rel.Type == SourceRelationshipSheetMetadata {
+			relCount++
+			assert.Equal(t, "metadata.xml", rel.Target)
+		}
+	}
+	assert.Equal(t, 1, relCount)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Overrides, xlsxOverride{PartName: "/xl/metadata.xml", ContentType: ContentTypeSpreadSheetMLSheetMetadata})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetCellArrayFormula.xlsx")))
+
+	// Test set dynamic array formula with existing workbook metadata
+	ref = "F1:F3"
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="2"><metadataType name="XLRICHVALUE"/><metadataType name="XLDAPR"/></metadataTypes><cellMetadata count="2"><bk><rc t="1" v="0"/></bk><bk><rc t="2" v="0"/></bk></cellMetadata></metadata>`))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=A1:A3*2", FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	cm = 2
+	assert.Equal(t, &cm, ws.(*xlsxWorksheet).SheetData.Row[0].C[5].Cm)
+	// Test set dynamic array formula with workbook metadata without dynamic array
+	daprType := `<metadataType name="XLDAPR" minSupportedVersion="120000" copy="true" pasteAll="true" pasteValues="true" merge="true" splitFirst="true" rowColShift="true" clearFormats="true" clearComments="true" assign="true" coerce="true" cellMeta="true"></metadataType>`
+	daprFutureMetadata := `<futureMetadata name="XLDAPR" count="1"><bk><extLst><ext uri="{bdbb8cdc-fa1e-496e-a857-3c3f30c029c3}"><xda:dynamicArrayProperties xmlns:xda="http://schemas.microsoft.com/office/spreadsheetml/2017/dynamicarray" fDynamic="1" fCollapsed="0"/></ext></extLst></bk></futureMetadata>`
+	for metadata, expected := range map[string]string{
+		`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk/></futureMetadata><cellMetadata count="1"><bk><rc t="1" v="0"/></bk></cellMetadata><valueMetadata count="1"><bk><rc t="1" v="0"/></bk></valueMetadata></metadata>`: `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="2"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"></metadataType>` + daprType + `</metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk></bk></futureMetadata>` + daprFutureMetadata + `<cellMetadata count="2"><bk><rc t="1" v="0"></rc></bk><bk><rc t="2" v="0"></rc></bk></cellMetadata><valueMetadata count="1"><bk><rc t="1" v="0"></rc></bk></valueMetadata></metadata>`,
+		`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"></metadata>`: `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="1">` + daprType + `</metadataTypes>` + daprFutureMetadata + `<cellMetadata count="1"><bk><rc t="1" v="0"></rc></bk></cellMetadata></metadata>`,
+		`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"/>`:           `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><metadataTypes count="1">` + daprType + `</metadataTypes>` + daprFutureMetadata + `<cellMetadata count="1"><bk><rc t="1" v="0"></rc></bk></cellMetadata></metadata>`,
+		`<x:metadata xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><x:metadataTypes count="1"><x:metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="1"/></x:metadataTypes><x:futureMetadata name="XLRICHVALUE" count="1"><x:bk><x:extLst><x:ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></x:ext></x:extLst></x:bk></x:futureMetadata><x:valueMetadata count="1"><x:bk><x:rc t="1" v="0"/></x:bk></x:valueMetadata></x:metadata>`: `<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="2"><metadataType name="XLRICHVALUE" minSupportedVersion="120000" copy="true"></metadataType>` + daprType + `</metadataTypes><futureMetadata name="XLRICHVALUE" count="1"><bk><extLst><x:ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></x:ext></extLst></bk></futureMetadata>` + daprFutureMetadata + `<cellMetadata count="1"><bk><rc t="2" v="0"></rc></bk></cellMetadata><valueMetadata count="1"><bk><rc t="1" v="0"></rc></bk></valueMetadata></metadata>`,
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+		f.Pkg.Store(defaultXMLPathMetadata, []byte(metadata))
+		assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "=A1:A3*2", FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true}))
+		assert.Equal(t, xml.Header+expected, string(f.readXML(defaultXMLPathMetadata)))
+		cm, err := f.getDynamicArrayCellMetadata()
+		assert.NoError(t, err)
+		ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+		assert.True(t, ok)
+		assert.Equal(t, ws.(*xlsxWorksheet).SheetData.Row[0].C[5].Cm, &cm)
+		assert.NoError(t, f.Close())
+	}
+	// Test set array formula with too many cells in the reference
+	ref = "F1:XFD1048576"
+	assert.Equal(t, ErrRangeTooLarge, f.SetCellFormula("Sheet1", "F1", "=A1:A3*2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	ref = "F1:F3"
+	// Test set dynamic array formula with unsupported charset workbook metadata
+	f.Pkg.Store(defaultXMLPathMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "F1", "=A1:A3*2", FormulaOpts{Ref: &ref, Type: &formulaType, Dynamic: true}), "XML syntax error on line 1: invalid UTF-8")
+	// Test set dynamic array formula with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetCellFormula("Sheet1", "A1", "=B1:B3", FormulaOpts{Type: &formulaType, Dynamic: true}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetCellRichText(t *testing.T) {
 	f, theme := NewFile(), 1
 
@@ -699,6 +1056,40 @@ func TestGetCellRichText(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellRichTextMergedCells(t *testing.T) {
+	f := NewFile()
+	runsSource := []RichTextRun{{Text: "Merged "}, {Text: "rich text", Font: &Font{Bold: true}}}
+	assert.NoError(t, f.SetCellRichText("Sheet1", "A1", runsSource))
+	assert.NoError(t, f.MergeCell("Sheet1", "A1", "C1"))
+	expected, err := f.GetCellRichText("Sheet1", "A1")
+	assert.NoError(t, err)
+	if assert.Len(t, expected, 2) {
+		assert.Equal(t, "rich text", expected[1].Text)
+		assert.True(t, expected[1].Font.Bold)
+	}
+	// Test get the rich text of the top-left cell for all cells in the merged range
+	for _, cell := range []string{"A1", "B1", "C1"} {
+		runs, err := f.GetCellRichText("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, runs, cell)
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "Merged rich text", val, cell)
+	}
+	runs, err := f.GetCellRichText("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+	// Test get the rich text in the merged range with reversed reference
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "C1:A1"}}}
+	for _, cell := range []string{"A1", "B1", "C1"} {
+		runs, err := f.GetCellRichText("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, runs, cell)
+	}
+}
+
 func TestSetCellRichText(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.SetRowHeight("Sheet1", 1, 35))
@@ -998,3 +1389,315 @@ func TestSharedStringsError(t *testing.T) {
 func TestSIString(t *testing.T) {
 	assert.Empty(t, xlsxSI{}.String())
 }
+
+func TestRewriteFormulaRefs(t *testing.T) {
+	f := NewFile()
+	for cell, formula := range map[string]string{
+		"A1": "SUM(Sheet2!A1:A10)",
+		"A2": "SUM('Sheet2'!A1, sheet2!$B$2)+Sheet3!A1",
+		"A3": "'Old Data'!A1&\"Sheet2!A1\"&\"a\"\"b\"",
+		"A4": "SUM(Sheet2:Sheet3!A1)",
+		"A5": "[1]Sheet2!A1+Sheet2!A1",
+		"A6": "SUM({1,2;3,4}*Sheet2!A1:B2)",
+		"A7": "Sheet3!A1",
+		"A8": "SUM( Sheet3!A1 , Sheet3!A2 )",
+		"A9": "IF(Sheet2!A1>0,-(Sheet2!A1 Sheet2!A1:B2),'It''s'!A1%)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.RewriteFormulaRefs("Sheet1", map[string]string{
+		"Sheet2":   "Summary 2019",
+		"Old Data": "New_Data",
+		"It's":     "A1",
+	}))
+	for cell, expected := range map[string]string{
+		"A1": "SUM('Summary 2019'!A1:A10)",
+		"A2": "SUM('Summary 2019'!A1,'Summary 2019'!$B$2)+Sheet3!A1",
+		"A3": "New_Data!A1&\"Sheet2!A1\"&\"a\"\"b\"",
+		"A4": "SUM('Summary 2019:Sheet3'!A1)",
+		"A5": "[1]Sheet2!A1+'Summary 2019'!A1",
+		"A6": "SUM({1,2;3,4}*'Summary 2019'!A1:B2)",
+		"A7": "Sheet3!A1",
+		"A8": "SUM( Sheet3!A1 , Sheet3!A2 )",
+		"A9": "IF('Summary 2019'!A1>0,-('Summary 2019'!A1 'Summary 2019'!A1:B2),'A1'!A1%)",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	// Test rewrite formula references with the names should be quoted
+	for name, expected := range map[string]bool{
+		"Sheet1": false, "_data.1": false, "Sheet 1": true, "1Sheet": true, "XFD1": true,
+		"R1C1": true, "r2": true, "C": true, "Réponse": true, "It's": true,
+	} {
+		assert.Equal(t, expected, sheetNameNeedQuote(name), name)
+	}
+	// Test rewrite formula references with invalid worksheet name
+	assert.EqualError(t, f.RewriteFormulaRefs("Sheet:1", nil), ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.RewriteFormulaRefs("SheetN", nil), "sheet SheetN does not exist")
+	assert.EqualError(t, f.RewriteFormulaRefs("Sheet1", map[string]string{"Sheet2": ""}), ErrSheetNameBlank.Error())
+	// Test rewrite formula references with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	assert.EqualError(t, f.RewriteFormulaRefs("Sheet1", nil), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetCellValueIfEmpty(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "value"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "  "))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A4", "=\"\""))
+	for _, c := range []struct {
+		cell     string
+		opts     []EmptyCellOpts
+		expected bool
+		value    string
+	}{
+		{cell: "A1", expected: true, value: "default"},
+		{cell: "A2", value: "value"},
+		{cell: "A3", value: "  "},
+		{cell: "A3", opts: []EmptyCellOpts{{TrimSpace: true}}, expected: true, value: "default"},
+		{cell: "A4"},
+	} {
+		ok, err := f.SetCellValueIfEmpty("Sheet1", c.cell, "default", c.opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, ok, c.cell)
+		val, err := f.GetCellValue("Sheet1", c.cell)
+		assert.NoError(t, err)
+		assert.Equal(t, c.value, val, c.cell)
+	}
+	// Test set cell value if empty with invalid cell reference
+	_, err := f.SetCellValueIfEmpty("Sheet1", "A", "default")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell value if empty on not exists worksheet
+	_, err = f.SetCellValueIfEmpty("SheetN", "A1", "default")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test set cell value if empty with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.SetCellValueIfEmpty("Sheet1", "A2", "default")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetRangeDefaults(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "B2", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", " "))
+	count, err := f.SetRangeDefaults("Sheet1", "C3:B2", 0, EmptyCellOpts{TrimSpace: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"", "1", "0"}, {"", "0", "0"}}, rows)
+	count, err = f.SetRangeDefaults("Sheet1", "B2:C3", 0)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	// Test set range defaults with invalid range reference
+	_, err = f.SetRangeDefaults("Sheet1", "B2", 0)
+	assert.Equal(t, ErrParameterInvalid, err)
+	// Test set range defaults on not exists worksheet
+	_, err = f.SetRangeDefaults("SheetN", "B2:C3", 0)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestClearCell(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{1, "text", true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", style))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", "merged"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "A1:D1"
+	assert.NoError(t, dv.SetRange(10, 20, DataValidationTypeWhole, DataValidationOperatorBetween))
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+
+	assert.NoError(t, f.ClearCell("Sheet1", "A1"))
+	assert.NoError(t, f.ClearRange("Sheet1", "D1:B1"))
+	for _, cell := range []string{"A1", "B1", "C1", "D1"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, val, cell)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, style, styleID, cell)
+	}
+	// Test clear the cell which isn't the top-left cell of the merged cell
+	assert.NoError(t, f.ClearCell("Sheet1", "B3"))
+	val, err := f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "merged", val)
+	// Test clear all with the styles of the cells
+	assert.NoError(t, f.ClearAll("Sheet1", "A1:B1"))
+	assert.NoError(t, f.ClearAll("Sheet1", "A3"))
+	for cell, expected := range map[string]int{"A1": 0, "B1": 0, "C1": style, "D1": style} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	val, err = f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	// Test clear cell with invalid cell reference
+	assert.EqualError(t, f.ClearCell("Sheet1", "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test clear range with single cell reference
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "text"))
+	assert.NoError(t, f.ClearRange("Sheet1", "A1"))
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test clear range removes the dynamic array formula cells from the calculation chain
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "SUM(A1:A2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "SUM(A1:A2)"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[4].Vm = uintPtr(1)
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "E1", I: 1}, {R: "F1", I: 1}}}
+	assert.NoError(t, f.ClearRange("Sheet1", "E1"))
+	assert.Equal(t, []xlsxCalcChainC{{R: "F1", I: 1}}, f.CalcChain.C)
+	// Test clear range with the master cell of the shared formula
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	formulaType, ref := STCellFormulaTypeShared, "A1:B5"
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "=C1*2", FormulaOpts{Ref: &ref, Type: &formulaType}))
+	assert.NoError(t, f.ClearRange("Sheet2", "A1:A2"))
+	for cell, expected := range map[string]string{"A1": "", "A2": "", "B1": "=D1*2", "A3": "=C3*2", "B5": "=D5*2"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	ws2, ok := f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxF{Content: "=D1*2", T: STCellFormulaTypeShared, Ref: "A1:B5", Si: intPtr(0)}, ws2.(*xlsxWorksheet).SheetData.Row[0].C[1].F)
+	// Test clear range with all the cells of the shared formula
+	assert.NoError(t, f.ClearRange("Sheet2", "A1:B5"))
+	for _, cell := range []string{"A3", "B1", "B5"} {
+		formula, err := f.GetCellFormula("Sheet2", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+	}
+	// Test clear cell on not exists worksheet
+	assert.EqualError(t, f.ClearRange("SheetN", "A1:B2"), "sheet SheetN does not exist")
+	// Test clear cell with invalid cell reference in the worksheet
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A"
+	assert.EqualError(t, f.ClearCell("Sheet1", "A1"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test clear cell with unsupported charset calculation chain
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].R = "A1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "SUM(A1:A2)"))
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ClearCell("Sheet1", "D1"), "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSetCellValuesRow(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 3, 15, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", "overwrite"))
+	assert.NoError(t, f.SetCellValues("Sheet1", "B2", []interface{}{
+		1, 2.5, "Excelize", nil, true, date, "Excelize",
+	}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"", "1", "2.5", "Excelize", "", "TRUE", "3/15/23 00:00", "Excelize"}}, rows)
+	cellType, err := f.GetCellType("Sheet1", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeBool, cellType)
+	// Test the same string stored in the shared strings table only once
+	sst, err := f.sharedStringsReader()
+	assert.NoError(t, err)
+	assert.Len(t, sst.SI, 2)
+	// Test set cell values with empty values
+	assert.NoError(t, f.SetCellValues("Sheet1", "A1", nil))
+	// Test set cell values with invalid cell reference
+	assert.EqualError(t, f.SetCellValues("Sheet1", "A", []interface{}{1}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test set cell values exceeds the maximum column
+	assert.EqualError(t, f.SetCellValues("Sheet1", "XFD1", []interface{}{1, 2}), ErrColumnNumber.Error())
+	// Test set cell values with not exist worksheet
+	assert.EqualError(t, f.SetCellValues("SheetN", "A1", []interface{}{1}), "sheet SheetN does not exist")
+}
+
+func TestSetCells(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCells("Sheet1", map[string]CellInput{
+		"B2": {Value: 1.5, StyleID: style},
+		"A2": {Value: "Excelize"},
+		"A1": {Value: true, Comment: &Comment{Cell: "Z1", Author: "Excelize", Text: "Comment"}},
+		"C2": {Formula: "B2*2"},
+	}))
+	val, err := f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.50", val)
+	val, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Excelize", val)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", val)
+	formula, err := f.GetCellFormula("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2*2", formula)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	if assert.Len(t, comments, 1) {
+		assert.Equal(t, "A1", comments[0].Cell)
+		assert.Equal(t, "Comment", comments[0].Text)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Len(t, ws.(*xlsxWorksheet).SheetData.Row, 2)
+	// Test set cells with invalid cell reference, no cells should be changed
+	assert.EqualError(t, f.SetCells("Sheet1", map[string]CellInput{
+		"D1": {Value: 1}, "D": {Value: 2},
+	}), newCellNameToCoordinatesError("D", newInvalidCellNameError("D")).Error())
+	val, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test set cells with invalid style ID, no cells should be changed
+	assert.EqualError(t, f.SetCells("Sheet1", map[string]CellInput{
+		"D1": {Value: 1}, "D2": {StyleID: -1},
+	}), newInvalidStyleID(-1).Error())
+	val, err = f.GetCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Empty(t, val)
+	// Test set cells with date and time values, and override the formula cell
+	assert.NoError(t, f.SetCells("Sheet1", map[string]CellInput{
+		"E1": {Value: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"E2": {Value: time.Hour * 36},
+		"E3": {Value: uint8(8)},
+		"E4": {Value: []byte("Bytes")},
+		"E5": {Value: float32(0.5)},
+		"E6": {Value: nil},
+		"E7": {Value: struct{}{}},
+		"C2": {Value: 3},
+	}))
+	for cell, expected := range map[string]string{
+		"E1": "1/2/23 00:00", "E2": "12:00:00", "E3": "8", "E4": "Bytes", "E5": "0.5", "E6": "", "E7": "{}", "C2": "3",
+	} {
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	formula, err = f.GetCellFormula("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Empty(t, formula)
+	// Test set cells with not exist worksheet
+	assert.EqualError(t, f.SetCells("SheetN", map[string]CellInput{"A1": {Value: 1}}), "sheet SheetN does not exist")
+	// Test set cells with unsupported charset comments
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	f.Comments["xl/comments1.xml"] = nil
+	assert.EqualError(t, f.SetCells("Sheet1", map[string]CellInput{"B1": {Comment: &Comment{Text: "Comment"}}}), "XML syntax error on line 1: invalid UTF-8")
+}
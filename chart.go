@@ -12,8 +12,12 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
+	"reflect"
 	"strconv"
 	"strings"
 )
@@ -78,6 +82,9 @@ const (
 	WireframeContour
 	Bubble
 	Bubble3D
+	Sunburst
+	Treemap
+	Waterfall
 )
 
 // This section defines the default value of chart properties.
@@ -259,6 +266,42 @@ var (
 		Bubble:                      0,
 		Bubble3D:                    0,
 	}
+	// chartTrendlineUnsupportedTypes defined the chart types which series
+	// doesn't support the trendline, includes pie, doughnut, radar, surface,
+	// stacked and percent stacked area, bar and column charts, and 3-D area,
+	// bar, column and line charts.
+	chartTrendlineUnsupportedTypes = map[ChartType]bool{
+		AreaStacked: true, AreaPercentStacked: true, BarStacked: true, BarPercentStacked: true,
+		ColStacked: true, ColPercentStacked: true,
+		Area3D: true, Area3DStacked: true, Area3DPercentStacked: true,
+		Bar3DClustered: true, Bar3DStacked: true, Bar3DPercentStacked: true,
+		Bar3DConeClustered: true, Bar3DConeStacked: true, Bar3DConePercentStacked: true,
+		Bar3DPyramidClustered: true, Bar3DPyramidStacked: true, Bar3DPyramidPercentStacked: true,
+		Bar3DCylinderClustered: true, Bar3DCylinderStacked: true, Bar3DCylinderPercentStacked: true,
+		Col3D: true, Col3DClustered: true, Col3DStacked: true, Col3DPercentStacked: true,
+		Col3DCone: true, Col3DConeClustered: true, Col3DConeStacked: true, Col3DConePercentStacked: true,
+		Col3DPyramid: true, Col3DPyramidClustered: true, Col3DPyramidStacked: true, Col3DPyramidPercentStacked: true,
+		Col3DCylinder: true, Col3DCylinderClustered: true, Col3DCylinderStacked: true, Col3DCylinderPercentStacked: true,
+		Line3D: true, Doughnut: true, Pie: true, Pie3D: true, PieOfPie: true, BarOfPie: true, Radar: true,
+		Surface3D: true, WireframeSurface3D: true, Contour: true, WireframeContour: true,
+	}
+	// chartErrorBarsUnsupportedTypes defined the chart types which series
+	// doesn't support the error bars, includes pie, doughnut, radar, surface
+	// and 3-D area, bar, column and line charts.
+	chartErrorBarsUnsupportedTypes = map[ChartType]bool{
+		Area3D: true, Area3DStacked: true, Area3DPercentStacked: true,
+		Bar3DClustered: true, Bar3DStacked: true, Bar3DPercentStacked: true,
+		Bar3DConeClustered: true, Bar3DConeStacked: true, Bar3DConePercentStacked: true,
+		Bar3DPyramidClustered: true, Bar3DPyramidStacked: true, Bar3DPyramidPercentStacked: true,
+		Bar3DCylinderClustered: true, Bar3DCylinderStacked: true, Bar3DCylinderPercentStacked: true,
+		Col3D: true, Col3DClustered: true, Col3DStacked: true, Col3DPercentStacked: true,
+		Col3DCone: true, Col3DConeClustered: true, Col3DConeStacked: true, Col3DConePercentStacked: true,
+		Col3DPyramid: true, Col3DPyramidClustered: true, Col3DPyramidStacked: true, Col3DPyramidPercentStacked: true,
+		Col3DCylinder: true, Col3DCylinderClustered: true, Col3DCylinderStacked: true, Col3DCylinderPercentStacked: true,
+		Line3D:   true,
+		Doughnut: true, Pie: true, Pie3D: true, PieOfPie: true, BarOfPie: true, Radar: true,
+		Surface3D: true, WireframeSurface3D: true, Contour: true, WireframeContour: true,
+	}
 	chartLegendPosition = map[string]string{
 		"bottom":    "b",
 		"left":      "l",
@@ -474,6 +517,30 @@ var (
 		true:  "r",
 		false: "l",
 	}
+	chartExLayoutIDs = map[ChartType]string{
+		Sunburst:  "sunburst",
+		Treemap:   "treemap",
+		Waterfall: "waterfall",
+	}
+	chartPieTypes = map[ChartType]bool{
+		Doughnut: true,
+		Pie:      true,
+		Pie3D:    true,
+		PieOfPie: true,
+		BarOfPie: true,
+	}
+	chartSecondaryAxisTypes = map[ChartType]bool{
+		Area:               true,
+		AreaStacked:        true,
+		AreaPercentStacked: true,
+		Bar:                true,
+		BarStacked:         true,
+		BarPercentStacked:  true,
+		Col:                true,
+		ColStacked:         true,
+		ColPercentStacked:  true,
+		Line:               true,
+	}
 	valTickLblPos = map[ChartType]string{
 		Contour:          "none",
 		WireframeContour: "none",
@@ -507,6 +574,14 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.Legend.Position == "" {
 		opts.Legend.Position = defaultChartLegendPosition
 	}
+	if _, ok := chartLegendPosition[opts.Legend.Position]; !ok && opts.Legend.Position != "none" {
+		return opts, ErrChartLegendPosition
+	}
+	for _, idx := range opts.Legend.HiddenEntries {
+		if idx < 0 {
+			return opts, ErrChartLegendEntry
+		}
+	}
 	if opts.Title.Name == "" {
 		opts.Title.Name = " "
 	}
@@ -516,9 +591,81 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 	if opts.ShowBlanksAs == "" {
 		opts.ShowBlanksAs = defaultChartShowBlanksAs
 	}
+	if opts.GapWidth != nil && (*opts.GapWidth < 0 || *opts.GapWidth > 500) {
+		return opts, ErrChartGapWidth
+	}
+	if opts.Overlap != nil && (*opts.Overlap < -100 || *opts.Overlap > 100) {
+		return opts, ErrChartOverlap
+	}
+	for _, axis := range []ChartAxis{opts.XAxis, opts.YAxis} {
+		if axis.LogBase != 0 && axis.LogBase <= 1 {
+			return opts, ErrChartAxisLogBase
+		}
+	}
+	for _, series := range opts.Series {
+		if series.Trendline.Type != "" && chartTrendlineUnsupportedTypes[opts.Type] {
+			return opts, newUnsupportedChartTrendlineError(opts.Type)
+		}
+		if err := parseChartTrendlineOptions(series.Trendline); err != nil {
+			return opts, err
+		}
+		if series.ErrorBars.Type != "" && chartErrorBarsUnsupportedTypes[opts.Type] {
+			return opts, newUnsupportedChartErrorBarsError(opts.Type)
+		}
+		if err := parseChartErrorBarsOptions(series.ErrorBars); err != nil {
+			return opts, err
+		}
+		if opts.Type == Waterfall {
+			length := getChartSeriesValuesCount(series)
+			for _, idx := range series.SubTotals {
+				if idx < 0 || (length != -1 && idx >= length) {
+					return opts, ErrChartWaterfallSubTotal
+				}
+			}
+		}
+	}
 	return opts, nil
 }
 
+// parseChartErrorBarsOptions provides a function to validate the format
+// settings of the chart series error bars.
+func parseChartErrorBarsOptions(opts ChartErrorBars) error {
+	if opts.Type == "" {
+		return nil
+	}
+	if _, ok := map[string]bool{"cust": true, "fixedVal": true, "percentage": true, "stdDev": true, "stdErr": true}[opts.Type]; !ok {
+		return ErrChartErrorBarsType
+	}
+	if _, ok := map[string]bool{"": true, "both": true, "minus": true, "plus": true}[opts.Direction]; !ok {
+		return ErrChartErrorBarsDirection
+	}
+	if custom := opts.Plus != "" || opts.Minus != ""; custom != (opts.Type == "cust") {
+		return ErrChartErrorBarsCustom
+	}
+	return nil
+}
+
+// parseChartTrendlineOptions provides a function to validate the format
+// settings of the chart series trendline.
+func parseChartTrendlineOptions(opts ChartTrendline) error {
+	switch opts.Type {
+	case "":
+		return nil
+	case "movingAvg":
+		if opts.Period < 2 || opts.Period > 255 {
+			return ErrChartTrendlinePeriod
+		}
+	case "poly":
+		if opts.Order < 2 || opts.Order > 6 {
+			return ErrChartTrendlineOrder
+		}
+	case "exp", "linear", "log", "power":
+	default:
+		return ErrChartTrendlineType
+	}
+	return nil
+}
+
 // AddChart provides the method to add chart in a sheet by given chart format
 // set (such as offset, scale, aspect ratio setting and print settings) and
 // properties set. For example, create 3D clustered column chart with data
@@ -652,6 +799,29 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	 52 | WireframeContour            | wireframe contour chart
 //	 53 | Bubble                      | bubble chart
 //	 54 | Bubble3D                    | 3D bubble chart
+//	 55 | Sunburst                    | sunburst chart
+//	 56 | Treemap                     | treemap chart
+//	 57 | Waterfall                   | waterfall chart
+//
+// The sunburst, treemap and waterfall chart are saved in the extended chart
+// part, only the first series will be used and the chart can't be combined
+// with other charts or added as a chartsheet. The 'Categories' of
+// the series could be a range with multiple columns, each column specifies a
+// level of the hierarchy from the root level on the left to the leaf level on
+// the right. For example, add a treemap chart with the regions in the column
+// A, the countries in the column B and the sales in the column C:
+//
+//	err := f.AddChart("Sheet1", "E1", &excelize.Chart{
+//	    Type: excelize.Treemap,
+//	    Series: []excelize.ChartSeries{
+//	        {
+//	            Name:       "Sheet1!$C$1",
+//	            Categories: "Sheet1!$A$2:$B$7",
+//	            Values:     "Sheet1!$C$2:$C$7",
+//	        },
+//	    },
+//	    Title: excelize.ChartTitle{Name: "Sales"},
+//	})
 //
 // In Excel a chart series is a collection of information that defines which
 // data is plotted such as values, axis labels and formatting.
@@ -665,6 +835,10 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Fill
 //	Line
 //	Marker
+//	Trendline
+//	ErrorBars
+//	DataPoints
+//	SubTotals
 //
 // Name: Set the name for the series. The name is displayed in the chart legend
 // and in the formula bar. The 'Name' property is optional and if it isn't
@@ -705,10 +879,92 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	x
 //	auto
 //
+// Trendline: This sets the trendline of the data series, the trendline will be
+// omitted if the 'Type' field is empty. The options that can be set are:
+//
+//	Type
+//	Order
+//	Period
+//	Forward
+//	Backward
+//	DisplayEquation
+//	DisplayRSquared
+//
+// The enumeration value of the 'Type' field are:
+//
+//	exp       | Exponential trendline
+//	linear    | Linear trendline
+//	log       | Logarithmic trendline
+//	movingAvg | Moving average trendline
+//	poly      | Polynomial trendline
+//	power     | Power trendline
+//
+// Order specifies the order of the polynomial trendline, the range is 2-6.
+// Period specifies the period of the moving average trendline, the range is
+// 2-255. Forward and Backward specifies the number of periods that the
+// trendline extends forward and backward. DisplayEquation and DisplayRSquared
+// specifies whether to display the trendline equation and the R-squared value
+// on the chart. The Forward, Backward, DisplayEquation and DisplayRSquared
+// options are not available for the moving average trendline. The trendline
+// is not supported for the pie, doughnut, radar, surface, 3-D bar and 3-D
+// column charts.
+//
+// ErrorBars: This sets the error bars of the data series, the error bars will
+// be omitted if the 'Type' field is empty. The options that can be set are:
+//
+//	Type
+//	Direction
+//	Value
+//	NoEndCap
+//	Plus
+//	Minus
+//
+// The enumeration value of the 'Type' field are:
+//
+//	cust       | Custom error amounts from the cell ranges
+//	fixedVal   | Fixed value error amount
+//	percentage | Percentage error amount
+//	stdDev     | Standard deviation error amount
+//	stdErr     | Standard error amount
+//
+// Direction specifies the direction of the error bars, the enumeration value
+// are 'both', 'minus' and 'plus', and the default value is 'both'. Value
+// specifies the error amount for the fixed value, percentage and standard
+// deviation error bars. NoEndCap specifies the error bars without the end cap.
+// Plus and Minus specifies the cell ranges of the positive and negative error
+// amounts, such as Sheet1!$C$2:$C$4, which only works with the 'cust' type,
+// and at least one of them is required for the 'cust' type. The error bars
+// are not supported for the pie, doughnut, radar and surface charts.
+//
+// DataPoints: This sets the format of the individual data points in the data
+// series by the zero-based 'Index' field, the data points with the index
+// beyond the length of the series values will be ignored. The options that
+// can be set are:
+//
+//	Index
+//	Fill
+//	BorderColor
+//	BorderWidth
+//	ShowLabel
+//
+// The 'Fill' field specifies the solid fill color of the data point, the
+// 'BorderColor' and 'BorderWidth' fields specifies the border color and width
+// in points of the data point, and the 'ShowLabel' field specifies if showing
+// or hiding the data label of the data point.
+//
+// SubTotals: This sets the zero-based indices of the data points which are
+// the subtotal or total columns of the waterfall chart series, the index
+// should be within the length of the series values. The increase, decrease
+// and total columns of the waterfall chart will be filled with the accent 1,
+// 2 and 3 color of the theme by default, and the color of each column could be
+// overridden by the 'DataPoints' field.
+//
 // Set properties of the chart legend. The options that can be set are:
 //
 //	Position
+//	Overlay
 //	ShowLegendKey
+//	HiddenEntries
 //
 // Position: Set the position of the chart legend. The default legend position
 // is bottom. The available positions are:
@@ -720,17 +976,39 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	right
 //	top_right
 //
+// Overlay: Set the legend overlaps the plot area of the chart. The default
+// value is false.
+//
 // ShowLegendKey: Set the legend keys shall be shown in data labels. The default
 // value is false.
 //
+// HiddenEntries: Set the zero-based indices of the legend entries to be
+// hidden from the legend, the legend entries are the series of the chart in
+// most chart types, or the data points for the chart with varied colors such
+// as the pie chart. For example, hide the helper series from the legend.
+//
 // Set properties of the chart title. The properties that can be set are:
 //
-//	Title
+//	Name
+//	Auto
+//	Hidden
 //
 // Name: Set the name (title) for the chart. The name is displayed above the
 // chart. The name can also be a formula such as Sheet1!$A$1 or a list with a
-// sheet name. The name property is optional. The default is to have no chart
-// title.
+// sheet name. The name property is optional. The default is to have an empty
+// chart title.
+//
+// Auto: Set the chart to use the title generated by the application instead
+// of the name, such as the name of the series for the chart with a single
+// series. The 'Auto' property is optional. The default value is false.
+//
+// Hidden: Set the chart without any title, this also hides the title
+// generated by the application. The 'Hidden' property takes precedence over
+// the 'Auto' property, and it is optional. The default value is false.
+//
+// The 'GetCharts' function reports the manual title by the 'Name' property,
+// and reports the title generated by the application or no title by the
+// 'Auto' or 'Hidden' property.
 //
 // Specifies how blank cells are plotted on the chart by 'ShowBlanksAs'. The
 // default value is gap. The options that can be set are:
@@ -748,6 +1026,18 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // Specifies that each data marker in the series has a different color by
 // 'VaryColors'. The default value is true.
 //
+// Specifies the space between the bar or column clusters as a percentage of
+// the bar or column width by 'GapWidth', the value should be between 0 and
+// 500. The default value is 150.
+//
+// Specifies how much the bars or columns in the same cluster overlap by
+// 'Overlap', the value should be between -100 and 100, and the negative value
+// specifies the gap between the bars or columns. The 'Overlap' property only
+// works with the 2-D bar and column charts, the default value is 100 for the
+// stacked charts and 0 for the clustered charts. In combo charts, the
+// 'GapWidth' and 'Overlap' properties apply to each bar or column chart
+// group individually.
+//
 // Set chart offset, scale, aspect ratio setting and print settings by format,
 // same as function 'AddPicture'.
 //
@@ -761,6 +1051,8 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	ShowPercent
 //	ShowSerName
 //	ShowVal
+//	ShowDataTable
+//	DataTable
 //
 // SecondPlotValues: Specifies the values in second plot for the 'pieOfPie' and
 // 'barOfPie' chart.
@@ -783,6 +1075,30 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // ShowVal: Specifies that the value shall be shown in a data label.
 // The 'ShowVal' property is optional. The default value is false.
 //
+// ShowDataTable: Specifies that the data table shall be shown beneath the plot
+// area of the chart, this only works with the charts which have a category
+// axis. The 'ShowDataTable' property is optional. The default value is false.
+//
+// DataTable: Specifies the format of the data table. The properties that can be
+// set are:
+//
+//	ShowLegendKeys
+//	HideHorzBorder
+//	HideVertBorder
+//	HideOutline
+//
+// ShowLegendKeys: Specifies that the legend keys shall be shown in the data
+// table. The default value is false.
+//
+// HideHorzBorder: Specifies that the horizontal borders of the data table
+// shall be hidden. The default value is false.
+//
+// HideVertBorder: Specifies that the vertical borders of the data table shall
+// be hidden. The default value is false.
+//
+// HideOutline: Specifies that the outline of the data table shall be hidden.
+// The default value is false.
+//
 // Set the primary horizontal and vertical axis options by 'XAxis' and 'YAxis'.
 // The properties of 'XAxis' that can be set are:
 //
@@ -794,6 +1110,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Maximum
 //	Minimum
 //	Font
+//	NumFmt
 //
 // The properties of 'YAxis' that can be set are:
 //
@@ -805,6 +1122,9 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Maximum
 //	Minimum
 //	Font
+//	LogBase
+//	NumFmt
+//	Secondary
 //
 // None: Disable axes.
 //
@@ -823,11 +1143,11 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 // (orientation of the chart). The ReverseOrder property is optional. The
 // default value is false.
 //
-// Maximum: Specifies that the fixed maximum, 0 is auto. The 'Maximum' property
-// is optional. The default value is auto.
+// Maximum: Specifies that the fixed maximum, nil is auto. The 'Maximum'
+// property is optional. The default value is auto.
 //
-// Minimum: Specifies that the fixed minimum, 0 is auto. The 'Minimum' property
-// is optional. The default value is auto.
+// Minimum: Specifies that the fixed minimum, nil is auto. The 'Minimum'
+// property is optional. The default value is auto.
 //
 // Font: Specifies that the font of the horizontal and vertical axis. The
 // properties of font that can be set are:
@@ -841,12 +1161,32 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	Color
 //	VertAlign
 //
+// LogBase: Specifies the base of the logarithmic scale of the vertical axis,
+// 0 is linear scale. The base less than or equal to 1 will return an error,
+// and the base out of the range 2-1000 will be ignored. The 'LogBase' property
+// is optional. The default value is linear scale.
+//
+// NumFmt: Specifies the number format of the axis tick labels by the
+// 'CustomNumFmt' field, such as "$#,##0.00" for currency, and the
+// 'SourceLinked' field specifies if the number format is linked to the source
+// data. The 'NumFmt' property is optional. The default value is general.
+//
+// Secondary: Specifies that the series of the combo chart will be plotted on
+// the secondary vertical axis at the right side of the plot area. The
+// 'Secondary' property only works with the 'YAxis' of the combo charts, and
+// the default value is false. Only the 2-D area, bar, column and line charts
+// could be plotted on the secondary axis, and an error will be returned for
+// the other chart types, such as 3-D, surface, radar, bubble and scatter
+// charts.
+//
 // Set chart size by 'Dimension' property. The 'Dimension' property is optional.
 // The default width is 480, and height is 290.
 //
 // combo: Specifies the create a chart that combines two or more chart types in
-// a single chart. For example, create a clustered column - line chart with
-// data Sheet1!$E$1:$L$15:
+// a single chart. The pie, 3-D pie, doughnut, pie of pie and bar of pie charts
+// could not be combined with other charts. For example, create a clustered
+// column - line chart with the line on the secondary axis with data
+// Sheet1!$E$1:$L$15:
 //
 //	package main
 //
@@ -876,7 +1216,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	    }
 //	    enable, disable := true, false
 //	    if err := f.AddChart("Sheet1", "E1", &excelize.Chart{
-//	        Type: "col",
+//	        Type: excelize.Col,
 //	        Series: []excelize.ChartSeries{
 //	            {
 //	                Name:       "Sheet1!$A$2",
@@ -908,7 +1248,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	            ShowVal:         true,
 //	        },
 //	    }, &excelize.Chart{
-//	        Type: "line",
+//	        Type: excelize.Line,
 //	        Series: []excelize.ChartSeries{
 //	            {
 //	                Name:       "Sheet1!$A$4",
@@ -932,6 +1272,7 @@ func parseChartOptions(opts *Chart) (*Chart, error) {
 //	            Position:      "right",
 //	            ShowLegendKey: false,
 //	        },
+//	        YAxis: excelize.ChartAxis{Secondary: true},
 //	        PlotArea: excelize.ChartPlotArea{
 //	            ShowCatName:     false,
 //	            ShowLeaderLines: false,
@@ -964,6 +1305,20 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
 	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		chartID = f.countChartExs() + 1
+		drawingRID := f.addRels(drawingRels, SourceRelationshipChartEx, "../charts/chartEx"+strconv.Itoa(chartID)+".xml", "")
+		if err = f.addDrawingChartEx(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format); err != nil {
+			return err
+		}
+		f.addChartEx(chartID, opts)
+		if err = f.addContentTypePart(chartID, "chartEx"); err != nil {
+			return err
+		}
+		_ = f.addContentTypePart(drawingID, "drawings")
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		return err
+	}
 	drawingRID := f.addRels(drawingRels, SourceRelationshipChart, "../charts/chart"+strconv.Itoa(chartID)+".xml", "")
 	err = f.addDrawingChart(sheet, drawingXML, cell, int(opts.Dimension.Width), int(opts.Dimension.Height), drawingRID, &opts.Format)
 	if err != nil {
@@ -978,6 +1333,50 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	return err
 }
 
+// AddPivotChart provides the method to add a pivot chart in a worksheet by
+// given pivot chart options. The PivotTableRange is the range reference of
+// an existing pivot table, which was used as the PivotTableRange of the
+// AddPivotTable function. The chart type and series are the same as the
+// AddChart function, the series should reference the cells of the pivot
+// table, and the chart will be updated with the pivot table after refreshing.
+// The pivot source of the chart references the pivot table by the worksheet
+// name and pivot table name, without the workbook file name. Note that the
+// pivot chart doesn't support the chart types of the chartEx
+// part, such as sunburst, treemap and waterfall. For example, create a
+// clustered column pivot chart for the pivot table on Sheet1!$G$2:$M$34:
+//
+//	err := f.AddPivotChart("Sheet1", excelize.PivotChartOptions{
+//	    Cell:            "O2",
+//	    PivotTableRange: "Sheet1!$G$2:$M$34",
+//	    Chart: excelize.Chart{
+//	        Type: excelize.Col,
+//	        Series: []excelize.ChartSeries{
+//	            {
+//	                Name:       "Sheet1!$H$4",
+//	                Categories: "Sheet1!$G$5:$G$16",
+//	                Values:     "Sheet1!$H$5:$H$16",
+//	            },
+//	        },
+//	        Title: excelize.ChartTitle{Name: "Sales by Month"},
+//	    },
+//	})
+func (f *File) AddPivotChart(sheet string, opts PivotChartOptions) error {
+	if _, ok := chartExLayoutIDs[opts.Chart.Type]; ok {
+		return newUnsupportedChartType(opts.Chart.Type)
+	}
+	pivotTableSheet, pivotTableXML, pt, err := f.getPivotTableByRange(opts.PivotTableRange)
+	if err != nil {
+		return err
+	}
+	chart := opts.Chart
+	chart.pivotSource = &cPivotSource{Name: pivotTableSheet + "!" + pt.Name, FmtID: &attrValInt{Val: intPtr(pt.ChartFormat)}}
+	if err = f.AddChart(sheet, opts.Cell, &chart); err != nil {
+		return err
+	}
+	f.setPivotTableChartFormat(pivotTableXML, pt.ChartFormat+1)
+	return err
+}
+
 // AddChartSheet provides the method to create a chartsheet by given chart
 // format set (such as offset, scale, aspect ratio setting and print settings)
 // and properties set. In Excel a chartsheet is a worksheet that only contains
@@ -995,6 +1394,9 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
+	if _, ok := chartExLayoutIDs[opts.Type]; ok {
+		return newUnsupportedChartType(opts.Type)
+	}
 	cs := xlsxChartsheet{
 		SheetViews: &xlsxChartsheetViews{
 			SheetView: []*xlsxChartsheetView{{ZoomScaleAttr: 100, ZoomToFitAttr: true}},
@@ -1051,18 +1453,33 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 			return options, comboCharts, err
 		}
 		if _, ok := chartValAxNumFmtFormatCode[comboChart.Type]; !ok {
-			return options, comboCharts, newUnsupportedChartType(comboChart.Type)
+			if _, ok = chartExLayoutIDs[comboChart.Type]; !ok {
+				return options, comboCharts, newUnsupportedChartType(comboChart.Type)
+			}
+		}
+		_, chartEx := chartExLayoutIDs[options.Type]
+		if _, ok := chartExLayoutIDs[comboChart.Type]; ok || chartEx ||
+			chartPieTypes[options.Type] || chartPieTypes[comboChart.Type] {
+			return options, comboCharts, newIncompatibleComboChartError(options.Type, comboChart.Type)
+		}
+		if comboChart.YAxis.Secondary && !chartSecondaryAxisTypes[comboChart.Type] {
+			return options, comboCharts, newUnsupportedChartSecondaryAxisError(comboChart.Type)
 		}
 		comboCharts = append(comboCharts, comboChart)
 	}
 	if _, ok := chartValAxNumFmtFormatCode[options.Type]; !ok {
-		return options, comboCharts, newUnsupportedChartType(options.Type)
+		if _, ok = chartExLayoutIDs[options.Type]; !ok {
+			return options, comboCharts, newUnsupportedChartType(options.Type)
+		}
 	}
 	return options, comboCharts, err
 }
 
 // DeleteChart provides a function to delete chart in spreadsheet by given
-// worksheet name and cell reference.
+// worksheet name and cell reference. The chart part, the relationship and
+// content type of the chart will be removed, and the shapes and pictures
+// anchored at the same cell will be kept. The drawing part of the worksheet
+// will be removed when the last drawing element was deleted.
 func (f *File) DeleteChart(sheet, cell string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
@@ -1070,15 +1487,645 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	}
 	col--
 	row--
-	ws, err := f.workSheetReader(sheet)
-	if err != nil {
+	wsDr, drawingXML, err := f.sheetDrawingParser(sheet)
+	if err != nil || wsDr == nil {
+		return err
+	}
+	var rIDs []string
+	for _, anchor := range wsDr.TwoCellAnchor {
+		output, _ := xml.Marshal(anchor)
+		deAnchor := new(decodeTwoCellAnchor)
+		if err = f.xmlNewDecoder(bytes.NewReader(output)).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return err
+		}
+		if deAnchor.From == nil || deAnchor.From.Col != col || deAnchor.From.Row != row ||
+			deAnchor.Pic != nil || deAnchor.Sp != nil || deAnchor.CxnSp != nil {
+			continue
+		}
+		graphicFrame := deAnchor.GraphicFrame
+		if deAnchor.AlternateContent != nil {
+			graphicFrame = deAnchor.AlternateContent.Choice.GraphicFrame
+		}
+		if graphicFrame != nil && graphicFrame.Graphic.GraphicData.Chart != nil {
+			rIDs = append(rIDs, graphicFrame.Graphic.GraphicData.Chart.RID)
+		}
+	}
+	if err = f.deleteDrawing(col, row, drawingXML, "Chart"); err != nil {
+		return err
+	}
+	drawingRels := strings.ReplaceAll(strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	for _, rID := range rIDs {
+		if err = f.deleteChartPart(drawingRels, rID); err != nil {
+			return err
+		}
+	}
+	return f.deleteEmptyDrawing(sheet, drawingXML, wsDr)
+}
+
+// deleteChartPart provides a function to delete the chart part, the chart
+// part relationships and content type by given drawing relationships path and
+// the relationship ID of the chart.
+func (f *File) deleteChartPart(drawingRels, rID string) error {
+	rels, err := f.relsReader(drawingRels)
+	if err != nil || rels == nil {
 		return err
 	}
-	if ws.Drawing == nil {
+	var target string
+	rels.mu.Lock()
+	for idx, rel := range rels.Relationships {
+		if rel.ID == rID {
+			target = rel.Target
+			rels.Relationships = append(rels.Relationships[:idx], rels.Relationships[idx+1:]...)
+			break
+		}
+	}
+	rels.mu.Unlock()
+	if target == "" {
 		return err
 	}
-	drawingXML := strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl")
-	return f.deleteDrawing(col, row, drawingXML, "Chart")
+	chartXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	chartRels := strings.ReplaceAll(strings.ReplaceAll(chartXML, "xl/charts", "xl/charts/_rels"), ".xml", ".xml.rels")
+	f.Pkg.Delete(chartXML)
+	f.Pkg.Delete(chartRels)
+	f.Relationships.Delete(chartRels)
+	return f.deleteSheetFromContentTypes("/" + chartXML)
+}
+
+// GetCharts provides a function to get the format settings of the charts in
+// a worksheet by given worksheet name. The charts will be read from the chart
+// parts referenced by the drawing of the worksheet, and the pictures and
+// shapes in the drawing will be skipped. Only the chart type, series, title,
+// legend and plot settings could be read, and only the primary chart of the
+// combo chart will be returned. For example, get the series references of
+// the charts in the worksheet named Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    for _, series := range chart.Series {
+//	        fmt.Println(series.Name, series.Categories, series.Values)
+//	    }
+//	}
+func (f *File) GetCharts(sheet string) ([]*Chart, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil || ws.Drawing == nil {
+		return nil, err
+	}
+	return f.getDrawingCharts(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID))
+}
+
+// GetChartSheets provides a function to get the format settings of the charts
+// in the chartsheets of the workbook, and returns the charts keyed by the
+// name of the chartsheet. For example:
+//
+//	charts, err := f.GetChartSheets()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for name, chart := range charts {
+//	    fmt.Println(name, chart.Type, chart.Title.Name)
+//	}
+func (f *File) GetChartSheets() (map[string]*Chart, error) {
+	charts := make(map[string]*Chart)
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		if !strings.HasPrefix(sheetXMLPath, "xl/chartsheets/") {
+			continue
+		}
+		cs := new(xlsxChartsheet)
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(sheetXMLPath)))).
+			Decode(cs); err != nil && err != io.EOF {
+			return charts, err
+		}
+		if cs.Drawing == nil {
+			continue
+		}
+		rels := "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+		rel := f.getDrawingRelationships(rels, cs.Drawing.RID)
+		if rel == nil {
+			continue
+		}
+		sheetCharts, err := f.getDrawingCharts(rel.Target)
+		if err != nil {
+			return charts, err
+		}
+		if len(sheetCharts) > 0 {
+			charts[sheet] = sheetCharts[0]
+		}
+	}
+	return charts, nil
+}
+
+// GetChartTemplate provides a function to get the format settings of the
+// chart anchored at the given cell in a worksheet as a reusable chart template
+// by given worksheet name and cell reference. The template is read as the
+// same as the GetCharts function with the size of the chart, but the data
+// references of the series will be removed, the series name, categories,
+// values, sizes and custom error bars ranges will be empty. For example, get
+// the chart template from the chart anchored at Sheet1!$E$1:
+//
+//	template, err := f.GetChartTemplate("Sheet1", "E1")
+func (f *File) GetChartTemplate(sheet, cell string) (*Chart, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	wsDr, drawingXML, err := f.sheetDrawingParser(sheet)
+	if err != nil {
+		return nil, err
+	}
+	if wsDr == nil {
+		return nil, newNoExistChartError(sheet, cell)
+	}
+	wsDr.mu.Lock()
+	anchors := make([]*xdrCellAnchor, 0, len(wsDr.OneCellAnchor)+len(wsDr.TwoCellAnchor))
+	anchors = append(append(anchors, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for _, anchor := range anchors {
+		output, _ := xml.Marshal(anchor)
+		deAnchor := new(decodeTwoCellAnchor)
+		if err = f.xmlNewDecoder(bytes.NewReader(output)).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return nil, err
+		}
+		if deAnchor.From == nil || deAnchor.From.Col != col-1 || deAnchor.From.Row != row-1 ||
+			deAnchor.GraphicFrame == nil || deAnchor.GraphicFrame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		drawingRels := strings.ReplaceAll(strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+		rel := f.getDrawingRelationships(drawingRels, deAnchor.GraphicFrame.Graphic.GraphicData.Chart.RID)
+		if rel == nil {
+			continue
+		}
+		chart, err := f.getChart(strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/"))
+		if err != nil || chart == nil {
+			return nil, err
+		}
+		if deAnchor.To != nil {
+			width, height := (deAnchor.To.ColOff-deAnchor.From.ColOff)/EMU, (deAnchor.To.RowOff-deAnchor.From.RowOff)/EMU
+			for col := deAnchor.From.Col; col < deAnchor.To.Col; col++ {
+				width += f.getColWidth(sheet, col+1)
+			}
+			for row := deAnchor.From.Row; row < deAnchor.To.Row; row++ {
+				height += f.getRowHeight(sheet, row+1)
+			}
+			chart.Dimension = ChartDimension{Width: uint(math.Max(float64(width), 0)), Height: uint(math.Max(float64(height), 0))}
+		}
+		for idx := range chart.Series {
+			series := &chart.Series[idx]
+			series.Name, series.Categories, series.Values, series.Sizes = "", "", "", ""
+			series.ErrorBars.Plus, series.ErrorBars.Minus = "", ""
+		}
+		return chart, nil
+	}
+	return nil, newNoExistChartError(sheet, cell)
+}
+
+// ApplyChartTemplate provides a function to create a chart in a worksheet
+// from the chart template by given worksheet name, cell reference, chart
+// template and data series. The format settings of each data series will be
+// inherited from the series of the template at the same index, and the data
+// references will be taken from the given series. The template will not be
+// changed. For example, create a chart with data Sheet2!$A$2:$B$4 by the
+// template of the chart anchored at Sheet1!$E$1:
+//
+//	template, err := f.GetChartTemplate("Sheet1", "E1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.ApplyChartTemplate("Sheet2", "E1", template, []excelize.ChartSeries{
+//	    {
+//	        Name:       "Sheet2!$B$1",
+//	        Categories: "Sheet2!$A$2:$A$4",
+//	        Values:     "Sheet2!$B$2:$B$4",
+//	    },
+//	})
+func (f *File) ApplyChartTemplate(sheet, cell string, template *Chart, series []ChartSeries) error {
+	if template == nil {
+		return ErrParameterInvalid
+	}
+	chart := *template
+	chart.Series = make([]ChartSeries, len(series))
+	for idx, ser := range series {
+		if idx < len(template.Series) {
+			chart.Series[idx] = template.Series[idx]
+			chart.Series[idx].Name, chart.Series[idx].Categories = ser.Name, ser.Categories
+			chart.Series[idx].Values, chart.Series[idx].Sizes = ser.Values, ser.Sizes
+			chart.Series[idx].ErrorBars.Plus, chart.Series[idx].ErrorBars.Minus = ser.ErrorBars.Plus, ser.ErrorBars.Minus
+			continue
+		}
+		chart.Series[idx] = ser
+	}
+	return f.AddChart(sheet, cell, &chart)
+}
+
+// getDrawingCharts provides a function to get the format settings of the
+// charts by given relationship target of the drawing part.
+func (f *File) getDrawingCharts(target string) ([]*Chart, error) {
+	drawingRels, err := f.relsReader(strings.ReplaceAll(
+		strings.ReplaceAll(target, "../drawings", "xl/drawings/_rels"), ".xml", ".xml.rels"))
+	if err != nil || drawingRels == nil {
+		return nil, err
+	}
+	var targets []string
+	drawingRels.mu.Lock()
+	for _, rel := range drawingRels.Relationships {
+		if rel.Type == SourceRelationshipChart {
+			targets = append(targets, strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/"))
+		}
+	}
+	drawingRels.mu.Unlock()
+	var charts []*Chart
+	for _, chartXML := range targets {
+		chart, err := f.getChart(chartXML)
+		if err != nil {
+			return charts, err
+		}
+		if chart != nil {
+			charts = append(charts, chart)
+		}
+	}
+	return charts, err
+}
+
+// getChart provides a function to get the format settings of the chart by
+// given chart part path. The nil will be returned if the chart type is not
+// supported.
+func (f *File) getChart(chartXML string) (*Chart, error) {
+	content := namespaceStrictToTransitional(f.readXML(chartXML))
+	cs, format := new(decodeChartSpace), new(decodeChartSpaceFormat)
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(cs); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if err := f.xmlNewDecoder(bytes.NewReader(content)).Decode(format); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if cs.Chart.PlotArea == nil {
+		return nil, nil
+	}
+	field, group := getChartGroup(cs.Chart.PlotArea)
+	chartType, ok := f.getChartType(field, group)
+	if !ok {
+		return nil, nil
+	}
+	groupField, _ := reflect.TypeOf(cPlotArea{}).FieldByName(field)
+	groupFormat := format.getPlotAreaElement(groupField.Tag.Get("xml"))
+	chart := &Chart{Type: chartType, Legend: ChartLegend{Position: "none"}, VaryColors: boolPtr(false)}
+	if title := cs.Chart.Title; title != nil && title.Tx.Rich != nil {
+		for _, p := range title.Tx.Rich.P {
+			for _, r := range p.R {
+				chart.Title.Name += r.T
+			}
+		}
+	}
+	if cs.Chart.Title == nil {
+		chart.Title.Hidden = cs.Chart.AutoTitleDeleted != nil && cs.Chart.AutoTitleDeleted.Val
+		chart.Title.Auto = !chart.Title.Hidden
+	}
+	if legend := cs.Chart.Legend; legend != nil {
+		chart.Legend.Position = "right"
+		if legend.LegendPos != nil && legend.LegendPos.Val != nil {
+			chart.Legend.Position = getMapKeyByValue(chartLegendPosition, *legend.LegendPos.Val)
+		}
+		if legend.Overlay != nil && legend.Overlay.Val != nil {
+			chart.Legend.Overlay = *legend.Overlay.Val
+		}
+		for _, entry := range legend.LegendEntry {
+			if entry.Idx != nil && entry.Idx.Val != nil && entry.Delete != nil && entry.Delete.Val != nil && *entry.Delete.Val {
+				chart.Legend.HiddenEntries = append(chart.Legend.HiddenEntries, *entry.Idx.Val)
+			}
+		}
+	}
+	if cs.Chart.DispBlanksAs != nil && cs.Chart.DispBlanksAs.Val != nil {
+		chart.ShowBlanksAs = *cs.Chart.DispBlanksAs.Val
+	}
+	if group.VaryColors != nil && group.VaryColors.Val != nil {
+		chart.VaryColors = boolPtr(*group.VaryColors.Val)
+	}
+	if group.HoleSize != nil && group.HoleSize.Val != nil {
+		chart.HoleSize = *group.HoleSize.Val
+	}
+	if group.GapWidth != nil && group.GapWidth.Val != nil {
+		chart.GapWidth = intPtr(*group.GapWidth.Val)
+	}
+	if group.Overlap != nil && group.Overlap.Val != nil {
+		chart.Overlap = intPtr(*group.Overlap.Val)
+	}
+	if group.SplitPos != nil && group.SplitPos.Val != nil {
+		chart.PlotArea.SecondPlotValues = *group.SplitPos.Val
+	}
+	if dTable := cs.Chart.PlotArea.DTable; dTable != nil {
+		chart.PlotArea.ShowDataTable = true
+		chart.PlotArea.DataTable = ChartDataTable{
+			ShowLegendKeys: dTable.ShowKeys != nil && dTable.ShowKeys.Val != nil && *dTable.ShowKeys.Val,
+			HideHorzBorder: dTable.ShowHorzBorder != nil && dTable.ShowHorzBorder.Val != nil && !*dTable.ShowHorzBorder.Val,
+			HideVertBorder: dTable.ShowVertBorder != nil && dTable.ShowVertBorder.Val != nil && !*dTable.ShowVertBorder.Val,
+			HideOutline:    dTable.ShowOutline != nil && dTable.ShowOutline.Val != nil && !*dTable.ShowOutline.Val,
+		}
+	}
+	dLbls := group.DLbls
+	if group.Ser != nil {
+		for idx, ser := range *group.Ser {
+			var spPr *decodeSpPr
+			if groupFormat != nil && idx < len(groupFormat.Ser) {
+				spPr = groupFormat.Ser[idx].SpPr
+			}
+			chart.Series = append(chart.Series, newChartSeries(ser, spPr))
+			if dLbls == nil {
+				dLbls = ser.DLbls
+			}
+		}
+	}
+	if dLbls != nil {
+		chart.Legend.ShowLegendKey = dLbls.ShowLegendKey != nil && dLbls.ShowLegendKey.Val != nil && *dLbls.ShowLegendKey.Val
+		chart.PlotArea.ShowVal = dLbls.ShowVal != nil && dLbls.ShowVal.Val != nil && *dLbls.ShowVal.Val
+		chart.PlotArea.ShowCatName = dLbls.ShowCatName != nil && dLbls.ShowCatName.Val != nil && *dLbls.ShowCatName.Val
+		chart.PlotArea.ShowSerName = dLbls.ShowSerName != nil && dLbls.ShowSerName.Val != nil && *dLbls.ShowSerName.Val
+		chart.PlotArea.ShowPercent = dLbls.ShowPercent != nil && dLbls.ShowPercent.Val != nil && *dLbls.ShowPercent.Val
+		chart.PlotArea.ShowBubbleSize = dLbls.ShowBubbleSize != nil && dLbls.ShowBubbleSize.Val != nil && *dLbls.ShowBubbleSize.Val
+		chart.PlotArea.ShowLeaderLines = dLbls.ShowLeaderLines != nil && dLbls.ShowLeaderLines.Val != nil && *dLbls.ShowLeaderLines.Val
+		if dLbls.NumFmt != nil {
+			chart.PlotArea.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+		}
+	}
+	if len(cs.Chart.PlotArea.CatAx) > 0 {
+		chart.XAxis = newChartAxis(cs.Chart.PlotArea.CatAx[0], "General")
+		chart.YAxis.Font = newChartAxisFont(format.getPlotAreaElement("catAx"))
+	}
+	if len(cs.Chart.PlotArea.ValAx) > 0 {
+		font := chart.YAxis.Font
+		chart.YAxis = newChartAxis(cs.Chart.PlotArea.ValAx[0], chartValAxNumFmtFormatCode[chartType])
+		chart.YAxis.Font = font
+		chart.XAxis.Font = newChartAxisFont(format.getPlotAreaElement("valAx"))
+	}
+	return chart, nil
+}
+
+// getPlotAreaElement provides a function to get the first chart group or axis
+// element in the plot area by given element name.
+func (format *decodeChartSpaceFormat) getPlotAreaElement(name string) *decodeChartPlotAreaElement {
+	for idx := range format.PlotArea.Elements {
+		if format.PlotArea.Elements[idx].XMLName.Local == name {
+			return &format.PlotArea.Elements[idx]
+		}
+	}
+	return nil
+}
+
+// newChartAxis provides a function to create the format settings of the chart
+// axis by given axis element and the default number format code of the axis.
+// The font settings of the axis should be created by the newChartAxisFont
+// function.
+func newChartAxis(ax *cAxs, numFmtCode string) ChartAxis {
+	var opts ChartAxis
+	opts.None = ax.Delete != nil && ax.Delete.Val != nil && *ax.Delete.Val
+	opts.MajorGridLines, opts.MinorGridLines = ax.MajorGridlines != nil, ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		opts.MajorUnit = *ax.MajorUnit.Val
+	}
+	if ax.TickLblSkip != nil && ax.TickLblSkip.Val != nil {
+		opts.TickLabelSkip = *ax.TickLblSkip.Val
+	}
+	if scaling := ax.Scaling; scaling != nil {
+		opts.ReverseOrder = scaling.Orientation != nil && scaling.Orientation.Val != nil && *scaling.Orientation.Val == orientation[true]
+		if scaling.Max != nil {
+			opts.Maximum = scaling.Max.Val
+		}
+		if scaling.Min != nil {
+			opts.Minimum = scaling.Min.Val
+		}
+		if scaling.LogBase != nil && scaling.LogBase.Val != nil {
+			opts.LogBase = *scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil && (ax.NumFmt.FormatCode != numFmtCode || ax.NumFmt.SourceLinked) {
+		opts.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	return opts
+}
+
+// newChartAxisFont provides a function to create the font settings of the
+// chart axis by given axis element.
+func newChartAxisFont(ax *decodeChartPlotAreaElement) Font {
+	var font Font
+	if ax == nil || ax.TxPr == nil || len(ax.TxPr.P) == 0 || ax.TxPr.P[0].PPr == nil || ax.TxPr.P[0].PPr.DefRPr == nil {
+		return font
+	}
+	rPr := ax.TxPr.P[0].PPr.DefRPr
+	font.Bold, font.Italic = rPr.B, rPr.I
+	if rPr.U != "none" {
+		font.Underline = rPr.U
+	}
+	if rPr.SolidFill != nil && rPr.SolidFill.SrgbClr != nil && rPr.SolidFill.SrgbClr.Val != nil {
+		font.Color = *rPr.SolidFill.SrgbClr.Val
+	}
+	return font
+}
+
+// newChartSeries provides a function to create the format settings of the
+// chart series by given series element and the shape properties of the
+// series.
+func newChartSeries(ser cSer, spPr *decodeSpPr) ChartSeries {
+	var series ChartSeries
+	if spPr != nil {
+		if spPr.SolidFill != nil && spPr.SolidFill.SrgbClr != nil && spPr.SolidFill.SrgbClr.Val != nil {
+			series.Fill.Color = []string{*spPr.SolidFill.SrgbClr.Val}
+		}
+		if ln := spPr.Ln; ln != nil && ln.SolidFill != nil {
+			if ln.SolidFill.SrgbClr != nil && ln.SolidFill.SrgbClr.Val != nil {
+				series.Fill.Color = []string{*ln.SolidFill.SrgbClr.Val}
+			}
+			series.Line.Width = float64(ln.W) / 12700
+		}
+	}
+	if ser.Tx != nil && ser.Tx.StrRef != nil {
+		series.Name = ser.Tx.StrRef.F
+	}
+	for _, cat := range []*cCat{ser.Cat, ser.XVal} {
+		if cat != nil && cat.StrRef != nil {
+			series.Categories = cat.StrRef.F
+		}
+	}
+	for _, val := range []*cVal{ser.Val, ser.YVal} {
+		if val != nil && val.NumRef != nil {
+			series.Values = val.NumRef.F
+		}
+	}
+	if ser.BubbleSize != nil && ser.BubbleSize.NumRef != nil {
+		series.Sizes = ser.BubbleSize.NumRef.F
+	}
+	if ser.Smooth != nil && ser.Smooth.Val != nil {
+		series.Line.Smooth = *ser.Smooth.Val
+	}
+	if ser.Marker != nil {
+		if ser.Marker.Symbol != nil && ser.Marker.Symbol.Val != nil {
+			series.Marker.Symbol = *ser.Marker.Symbol.Val
+		}
+		if ser.Marker.Size != nil && ser.Marker.Size.Val != nil {
+			series.Marker.Size = *ser.Marker.Size.Val
+		}
+	}
+	if ser.Trendline != nil {
+		series.Trendline = newChartTrendline(ser.Trendline)
+	}
+	if ser.ErrBars != nil {
+		series.ErrorBars = newChartErrorBars(ser.ErrBars)
+	}
+	return series
+}
+
+// newChartErrorBars provides a function to create the format settings of the
+// chart series error bars by given c:errBars element.
+func newChartErrorBars(errBars *cErrBars) ChartErrorBars {
+	var opts ChartErrorBars
+	if errBars.ErrValType != nil && errBars.ErrValType.Val != nil {
+		opts.Type = *errBars.ErrValType.Val
+	}
+	if errBars.ErrBarType != nil && errBars.ErrBarType.Val != nil {
+		opts.Direction = *errBars.ErrBarType.Val
+	}
+	if errBars.Val != nil && errBars.Val.Val != nil {
+		opts.Value = *errBars.Val.Val
+	}
+	if errBars.NoEndCap != nil && errBars.NoEndCap.Val != nil {
+		opts.NoEndCap = *errBars.NoEndCap.Val
+	}
+	if errBars.Plus != nil && errBars.Plus.NumRef != nil {
+		opts.Plus = errBars.Plus.NumRef.F
+	}
+	if errBars.Minus != nil && errBars.Minus.NumRef != nil {
+		opts.Minus = errBars.Minus.NumRef.F
+	}
+	return opts
+}
+
+// newChartTrendline provides a function to create the format settings of the
+// chart series trendline by given c:trendline element.
+func newChartTrendline(trendline *cTrendline) ChartTrendline {
+	var opts ChartTrendline
+	if trendline.TrendlineType != nil && trendline.TrendlineType.Val != nil {
+		opts.Type = *trendline.TrendlineType.Val
+	}
+	if trendline.Order != nil && trendline.Order.Val != nil {
+		opts.Order = *trendline.Order.Val
+	}
+	if trendline.Period != nil && trendline.Period.Val != nil {
+		opts.Period = *trendline.Period.Val
+	}
+	if trendline.Forward != nil && trendline.Forward.Val != nil {
+		opts.Forward = *trendline.Forward.Val
+	}
+	if trendline.Backward != nil && trendline.Backward.Val != nil {
+		opts.Backward = *trendline.Backward.Val
+	}
+	if trendline.DispEq != nil && trendline.DispEq.Val != nil {
+		opts.DisplayEquation = *trendline.DispEq.Val
+	}
+	if trendline.DispRSqr != nil && trendline.DispRSqr.Val != nil {
+		opts.DisplayRSquared = *trendline.DispRSqr.Val
+	}
+	return opts
+}
+
+// getChartGroup provides a function to get the field name and settings of
+// the chart group in the plot area which contains the series with the
+// minimum order, that is the primary chart of the combo chart.
+func getChartGroup(plotArea *cPlotArea) (string, *cCharts) {
+	var (
+		field string
+		group *cCharts
+		order int
+	)
+	v := reflect.ValueOf(plotArea).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		c, ok := v.Field(i).Interface().(*cCharts)
+		if !ok || c == nil {
+			continue
+		}
+		idx := math.MaxInt32
+		if c.Ser != nil && len(*c.Ser) > 0 && (*c.Ser)[0].Order != nil && (*c.Ser)[0].Order.Val != nil {
+			idx = *(*c.Ser)[0].Order.Val
+		}
+		if group == nil || idx < order {
+			field, group, order = v.Type().Field(i).Name, c, idx
+		}
+	}
+	return field, group
+}
+
+// getChartType provides a function to get the chart type by given field name
+// and settings of the chart group in the plot area. The chart type will be
+// matched with the chart group drawn by each supported chart types, and the
+// first chart type of the same chart group and bar direction will be used if
+// no chart type matched exactly.
+func (f *File) getChartType(field string, group *cCharts) (ChartType, bool) {
+	if group == nil {
+		return 0, false
+	}
+	var (
+		chartType ChartType
+		ok        bool
+	)
+	signature, plotAreaFunc := getChartGroupSignature(group), f.getPlotAreaFuncs()
+	for typ := Area; typ <= Bubble3D; typ++ {
+		opts, _ := parseChartOptions(&Chart{Type: typ, Series: []ChartSeries{{}}})
+		name, c := getChartGroup(plotAreaFunc[typ](opts))
+		if name != field {
+			continue
+		}
+		if getChartGroupSignature(c) == signature {
+			return typ, true
+		}
+		if !ok && reflect.DeepEqual(c.BarDir, group.BarDir) {
+			chartType, ok = typ, true
+		}
+	}
+	return chartType, ok
+}
+
+// getChartGroupSignature provides a function to get the signature of the
+// chart group by the settings which distinguishes the chart types.
+func getChartGroupSignature(c *cCharts) string {
+	var signature []string
+	for _, attr := range []*attrValString{c.BarDir, c.Grouping, c.Shape, c.OfPieType} {
+		var val string
+		if attr != nil && attr.Val != nil {
+			val = *attr.Val
+		}
+		signature = append(signature, val)
+	}
+	var bubble3D bool
+	if c.Ser != nil {
+		for _, ser := range *c.Ser {
+			bubble3D = bubble3D || (ser.Bubble3D != nil && ser.Bubble3D.Val != nil && *ser.Bubble3D.Val)
+		}
+	}
+	return strings.Join(append(signature,
+		strconv.FormatBool(c.Wireframe != nil && c.Wireframe.Val != nil && *c.Wireframe.Val),
+		strconv.FormatBool(bubble3D)), ",")
+}
+
+// countChartExs provides a function to get extended chart files count storage
+// in the folder xl/charts.
+func (f *File) countChartExs() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if name := k.(string); strings.HasPrefix(name, "xl/charts/chartEx") {
+			if idx, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/charts/chartEx"), ".xml")); idx > count {
+				count = idx
+			}
+		}
+		return true
+	})
+	return count
 }
 
 // countCharts provides a function to get chart files count storage in the
@@ -1086,8 +2133,10 @@ func (f *File) DeleteChart(sheet, cell string) error {
 func (f *File) countCharts() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/charts/chart") {
-			count++
+		if name := k.(string); strings.HasPrefix(name, "xl/charts/chart") {
+			if idx, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/charts/chart"), ".xml")); idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
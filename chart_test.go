@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -277,14 +278,14 @@ func TestAddChart(t *testing.T) {
 	assert.NoError(t, err)
 	clusteredColumnCombo := [][]interface{}{
 		{"A1", Line, "Clustered Column - Line Chart"},
-		{"I1", Doughnut, "Clustered Column - Doughnut Chart"},
+		{"I1", Area, "Clustered Column - Area Chart"},
 	}
 	for _, props := range clusteredColumnCombo {
-		assert.NoError(t, f.AddChart("Combo Charts", props[0].(string), &Chart{Type: Col, Series: series[:4], Format: format, Legend: legend, Title: ChartTitle{Name: props[2].(string)}, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}, &Chart{Type: props[1].(ChartType), Series: series[4:], Format: format, Legend: legend, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}))
+		assert.NoError(t, f.AddChart("Combo Charts", props[0].(string), &Chart{Type: Col, Series: series[:4], Format: format, Legend: legend, Title: ChartTitle{Name: props[2].(string)}, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}, &Chart{Type: props[1].(ChartType), Series: series[4:], Format: format, Legend: legend, YAxis: ChartAxis{Secondary: true}, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}))
 	}
 	stackedAreaCombo := map[string][]interface{}{
 		"A16": {Line, "Stacked Area - Line Chart"},
-		"I16": {Doughnut, "Stacked Area - Doughnut Chart"},
+		"I16": {Col, "Stacked Area - Clustered Column Chart"},
 	}
 	for axis, props := range stackedAreaCombo {
 		assert.NoError(t, f.AddChart("Combo Charts", axis, &Chart{Type: AreaStacked, Series: series[:4], Format: format, Legend: legend, Title: ChartTitle{Name: props[1].(string)}, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}, &Chart{Type: props[0].(ChartType), Series: series[4:], Format: format, Legend: legend, PlotArea: ChartPlotArea{ShowBubbleSize: true, ShowCatName: false, ShowLeaderLines: false, ShowPercent: true, ShowSerName: true, ShowVal: true}}))
@@ -295,11 +296,14 @@ func TestAddChart(t *testing.T) {
 	// Test with illegal cell reference
 	assert.EqualError(t, f.AddChart("Sheet2", "A", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "2D Column Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x37, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "Bubble 3D Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: 0x3A, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "Bubble 3D Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}), newUnsupportedChartType(0x3A).Error())
 	// Test add combo chart with invalid format set
 	assert.EqualError(t, f.AddChart("Sheet2", "BD32", &Chart{Type: Col, Series: series, Format: format, Legend: legend, Title: ChartTitle{Name: "2D Column Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero"}, nil), ErrParameterInvalid.Error())
 	// Test add combo chart with unsupported chart type
-	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x37, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChart("Sheet2", "BD64", &Chart{Type: BarOfPie, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}, &Chart{Type: 0x3A, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$A$30:$D$37", Values: "Sheet1!$B$30:$B$37"}}, Format: format, Legend: legend, Title: ChartTitle{Name: "Bar of Pie Chart"}, PlotArea: plotArea, ShowBlanksAs: "zero", XAxis: ChartAxis{MajorGridLines: true}, YAxis: ChartAxis{MajorGridLines: true}}), newUnsupportedChartType(0x3A).Error())
+	// Test add combo chart with incompatible chart types
+	assert.EqualError(t, f.AddChart("Combo Charts", "Q1", &Chart{Type: Col, Series: series[:4]}, &Chart{Type: Doughnut, Series: series[4:]}), newIncompatibleComboChartError(Col, Doughnut).Error())
+	assert.EqualError(t, f.AddChart("Combo Charts", "Q1", &Chart{Type: Pie, Series: series[:4]}, &Chart{Type: Line, Series: series[4:]}), newIncompatibleComboChartError(Pie, Line).Error())
 	assert.NoError(t, f.Close())
 
 	// Test add chart with unsupported charset content types.
@@ -308,6 +312,297 @@ func TestAddChart(t *testing.T) {
 	assert.EqualError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}, Title: ChartTitle{Name: "2D Column Chart"}}), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestAddChartComboSecondaryAxis(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"", "Sales", "Rate"}, {"A", 10, 0.1}, {"B", 20, 0.3}, {"C", 30, 0.2}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"}},
+		YAxis:  ChartAxis{Secondary: true},
+	}))
+	chartSpace := xlsxChartSpace{}
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	plotArea := chartSpace.Chart.PlotArea
+	assert.NotNil(t, plotArea.BarChart)
+	assert.NotNil(t, plotArea.LineChart)
+	assert.Equal(t, 754001152, *plotArea.BarChart.AxID[0].Val)
+	assert.Equal(t, 753999904, *plotArea.BarChart.AxID[1].Val)
+	assert.Equal(t, 754001154, *plotArea.LineChart.AxID[0].Val)
+	assert.Equal(t, 753999906, *plotArea.LineChart.AxID[1].Val)
+	assert.Len(t, plotArea.CatAx, 2)
+	assert.Len(t, plotArea.ValAx, 2)
+	assert.True(t, *plotArea.CatAx[1].Delete.Val)
+	assert.Equal(t, "r", *plotArea.ValAx[1].AxPos.Val)
+	assert.Equal(t, "max", *plotArea.ValAx[1].Crosses.Val)
+	// Test add combo chart with secondary axis for unsupported chart types
+	for _, chartType := range []ChartType{Col3D, Line3D, Surface3D, Radar, Bubble, Scatter} {
+		assert.EqualError(t, f.AddChart("Sheet1", "E20", &Chart{
+			Type:   Col,
+			Series: []ChartSeries{{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"}},
+		}, &Chart{
+			Type:   chartType,
+			Series: []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"}},
+			YAxis:  ChartAxis{Secondary: true},
+		}), newUnsupportedChartSecondaryAxisError(chartType).Error())
+	}
+	// Test get the primary chart of the combo chart with secondary axis
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Col, charts[0].Type)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartComboSecondaryAxis.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartTrendline(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"", "Sales"}, {"A", 10}, {"B", 20}, {"C", 30}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	series := []ChartSeries{{
+		Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4",
+		Trendline: ChartTrendline{Type: "linear", Forward: 1, DisplayEquation: true},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Line, Series: series}))
+	chartSpace := xlsxChartSpace{}
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<trendline><trendlineType val=\"linear\"></trendlineType><forward val=\"1\"></forward><dispRSqr val=\"0\"></dispRSqr><dispEq val=\"1\"></dispEq></trendline>")
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	trendline := (*chartSpace.Chart.PlotArea.LineChart.Ser)[0].Trendline
+	assert.Equal(t, "linear", *trendline.TrendlineType.Val)
+	assert.True(t, *trendline.DispEq.Val)
+	assert.Nil(t, trendline.Period)
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, series[0].Trendline, charts[0].Series[0].Trendline)
+	// Test add chart series without trendline
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$4"}}}))
+	chart, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(chart.([]byte)), "<trendline>")
+	// Test add moving average and polynomial trendline
+	assert.NoError(t, f.AddChart("Sheet1", "E31", &Chart{Type: Col, Series: []ChartSeries{
+		{Values: "Sheet1!$B$2:$B$4", Trendline: ChartTrendline{Type: "movingAvg", Period: 2}},
+		{Values: "Sheet1!$B$2:$B$4", Trendline: ChartTrendline{Type: "poly", Order: 3}},
+	}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTrendline.xlsx")))
+	// Test add chart series trendline with invalid options
+	for _, c := range []struct {
+		trendline ChartTrendline
+		err       error
+	}{
+		{ChartTrendline{Type: "unknown"}, ErrChartTrendlineType},
+		{ChartTrendline{Type: "movingAvg"}, ErrChartTrendlinePeriod},
+		{ChartTrendline{Type: "movingAvg", Period: -1}, ErrChartTrendlinePeriod},
+		{ChartTrendline{Type: "poly", Order: 7}, ErrChartTrendlineOrder},
+	} {
+		assert.Equal(t, c.err, f.AddChart("Sheet1", "E46", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$4", Trendline: c.trendline}}}))
+	}
+	// Test add trendline for the chart types which doesn't support it
+	for _, chartType := range []ChartType{Pie, Doughnut, Radar, Surface3D, Col3DClustered, AreaStacked, BarPercentStacked, ColStacked, Area3D, Line3D} {
+		assert.EqualError(t, f.AddChart("Sheet1", "E46", &Chart{
+			Type: chartType, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$4", Trendline: ChartTrendline{Type: "linear"}}},
+		}), newUnsupportedChartTrendlineError(chartType).Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartErrorBars(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"", "Mean", "Plus", "Minus"}, {"A", 10, 1, 2}, {"B", 20, 2, 1}, {"C", 30, 3, 3}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4", ErrorBars: ChartErrorBars{Type: "stdErr", Direction: "plus"}},
+		{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4", ErrorBars: ChartErrorBars{Type: "cust", NoEndCap: true, Plus: "Sheet1!$C$2:$C$4", Minus: "Sheet1!$D$2:$D$4"}},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{Type: Col, Series: series}))
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<errBars><errBarType val=\"plus\"></errBarType><errValType val=\"stdErr\"></errValType><noEndCap val=\"0\"></noEndCap></errBars>")
+	assert.Contains(t, string(chart.([]byte)), "<errBars><errBarType val=\"both\"></errBarType><errValType val=\"cust\"></errValType><noEndCap val=\"1\"></noEndCap><plus><numRef><f>Sheet1!$C$2:$C$4</f></numRef></plus><minus><numRef><f>Sheet1!$D$2:$D$4</f></numRef></minus></errBars>")
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, series[0].ErrorBars, charts[0].Series[0].ErrorBars)
+	assert.Equal(t, ChartErrorBars{Type: "cust", Direction: "both", NoEndCap: true, Plus: "Sheet1!$C$2:$C$4", Minus: "Sheet1!$D$2:$D$4"}, charts[0].Series[1].ErrorBars)
+	// Test add error bars with the fixed value on the scatter chart
+	assert.NoError(t, f.AddChart("Sheet1", "F16", &Chart{Type: Scatter, Series: []ChartSeries{
+		{Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4", ErrorBars: ChartErrorBars{Type: "fixedVal", Value: 5}},
+	}}))
+	chart, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(chart.([]byte)), "<errBars><errDir val=\"y\"></errDir><errBarType val=\"both\"></errBarType><errValType val=\"fixedVal\"></errValType><noEndCap val=\"0\"></noEndCap><val val=\"5\"></val></errBars>")
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartErrorBars.xlsx")))
+	// Test add chart series error bars with invalid options
+	for _, c := range []struct {
+		errorBars ChartErrorBars
+		err       error
+	}{
+		{ChartErrorBars{Type: "unknown"}, ErrChartErrorBarsType},
+		{ChartErrorBars{Type: "stdErr", Direction: "unknown"}, ErrChartErrorBarsDirection},
+		{ChartErrorBars{Type: "percentage", Value: 5, Plus: "Sheet1!$C$2:$C$4"}, ErrChartErrorBarsCustom},
+		{ChartErrorBars{Type: "cust"}, ErrChartErrorBarsCustom},
+	} {
+		assert.Equal(t, c.err, f.AddChart("Sheet1", "F31", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$4", ErrorBars: c.errorBars}}}))
+	}
+	// Test add error bars for the chart types which doesn't support it
+	for _, chartType := range []ChartType{Pie, Doughnut, Radar, Contour, Area3D, Bar3DClustered, Col3DCylinder, Line3D} {
+		assert.EqualError(t, f.AddChart("Sheet1", "F31", &Chart{
+			Type: chartType, Series: []ChartSeries{{Values: "Sheet1!$B$2:$B$4", ErrorBars: ChartErrorBars{Type: "stdErr"}}},
+		}), newUnsupportedChartErrorBarsError(chartType).Error())
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDataPoints(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"", "Sales"}, {"A", 10}, {"B", 20}, {"C", 30}, {"D", 40}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{{
+			Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5",
+			DataPoints: []ChartDataPoint{
+				{Index: 2, Fill: Fill{Color: []string{"#FF0000"}}, BorderColor: "000000", BorderWidth: 1.5, ShowLabel: boolPtr(false)},
+				{Index: 3, ShowLabel: boolPtr(true)},
+				{Index: 4, Fill: Fill{Color: []string{"00FF00"}}, ShowLabel: boolPtr(false)},
+				{Index: -1, Fill: Fill{Color: []string{"00FF00"}}},
+			},
+		}},
+		PlotArea: ChartPlotArea{ShowVal: true},
+	}))
+	chartSpace := xlsxChartSpace{}
+	chart, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	ser := (*chartSpace.Chart.PlotArea.BarChart.Ser)[0]
+	// Test only the third bar was colored and the points beyond the series were ignored
+	assert.Len(t, ser.DPt, 1)
+	assert.Equal(t, 2, *ser.DPt[0].IDx.Val)
+	assert.Contains(t, string(chart.([]byte)), "<dPt><idx val=\"2\"></idx><bubble3D val=\"0\"></bubble3D><spPr><a:solidFill><a:srgbClr val=\"FF0000\"></a:srgbClr></a:solidFill><a:ln w=\"19050\"><a:solidFill><a:srgbClr val=\"000000\"></a:srgbClr></a:solidFill></a:ln></spPr></dPt>")
+	// Test hide the label of the third bar and keep others default
+	assert.Len(t, ser.DLbls.DLbl, 2)
+	assert.Equal(t, 2, *ser.DLbls.DLbl[0].IDx.Val)
+	assert.True(t, *ser.DLbls.DLbl[0].Delete.Val)
+	assert.Nil(t, ser.DLbls.DLbl[0].ShowVal)
+	assert.Equal(t, 3, *ser.DLbls.DLbl[1].IDx.Val)
+	assert.Nil(t, ser.DLbls.DLbl[1].Delete)
+	assert.True(t, *ser.DLbls.DLbl[1].ShowVal.Val)
+	assert.True(t, *ser.DLbls.ShowVal.Val)
+	// Test add data points on the pie chart
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{
+		Type: Pie,
+		Series: []ChartSeries{{
+			Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5",
+			DataPoints: []ChartDataPoint{{Index: 0, Fill: Fill{Color: []string{"FF0000"}}}, {Index: 1, BorderWidth: 2}},
+		}},
+	}))
+	chartSpace = xlsxChartSpace{}
+	chart, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+	assert.Len(t, (*chartSpace.Chart.PlotArea.PieChart.Ser)[0].DPt, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDataPoints.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestChartTemplate(t *testing.T) {
+	f := NewFile()
+	for row, values := range [][]interface{}{{"", "Sales", "Cost"}, {"A", 10, 5}, {"B", 20, 8}, {"C", 30, 12}} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+1), &values))
+	}
+	assert.NoError(t, f.AddPicture("Sheet1", "E1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4", Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, Line: ChartLine{Smooth: true, Width: 1.5}, Marker: ChartMarker{Symbol: "diamond", Size: 8}},
+			{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4", Line: ChartLine{Width: 2}, Trendline: ChartTrendline{Type: "linear"}},
+		},
+		Dimension: ChartDimension{Width: 640, Height: 320},
+		Title:     ChartTitle{Name: "Sales Report"},
+		Legend:    ChartLegend{Position: "top"},
+		XAxis:     ChartAxis{MajorGridLines: true, Font: Font{Bold: true, Color: "000000"}},
+		YAxis:     ChartAxis{MajorGridLines: true, MinorGridLines: true, ReverseOrder: true, Maximum: float64Ptr(40), Minimum: float64Ptr(0), MajorUnit: 10, NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}, Font: Font{Italic: true, Underline: "sng"}},
+		PlotArea:  ChartPlotArea{ShowVal: true, ShowCatName: true, NumFmt: ChartNumFmt{CustomNumFmt: "0%", SourceLinked: true}},
+	}))
+	template, err := f.GetChartTemplate("Sheet1", "E1")
+	assert.NoError(t, err)
+	expected := &Chart{
+		Type: Line,
+		Series: []ChartSeries{
+			{Fill: Fill{Color: []string{"FF0000"}}, Line: ChartLine{Smooth: true, Width: 1.5}, Marker: ChartMarker{Symbol: "diamond", Size: 8}},
+			{Line: ChartLine{Width: 2}, Marker: ChartMarker{Size: 5}, Trendline: ChartTrendline{Type: "linear"}},
+		},
+		Dimension:    ChartDimension{Width: 640, Height: 320},
+		Title:        ChartTitle{Name: "Sales Report"},
+		Legend:       ChartLegend{Position: "top"},
+		VaryColors:   boolPtr(false),
+		XAxis:        ChartAxis{MajorGridLines: true, Font: Font{Bold: true, Color: "000000"}},
+		YAxis:        ChartAxis{MajorGridLines: true, MinorGridLines: true, ReverseOrder: true, Maximum: float64Ptr(40), Minimum: float64Ptr(0), MajorUnit: 10, NumFmt: ChartNumFmt{CustomNumFmt: "0.00"}, Font: Font{Italic: true, Underline: "sng"}},
+		PlotArea:     ChartPlotArea{ShowVal: true, ShowCatName: true, NumFmt: ChartNumFmt{CustomNumFmt: "0%", SourceLinked: true}},
+		ShowBlanksAs: "gap",
+	}
+	assert.Equal(t, expected, template)
+	// Test apply the chart template with new data references
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.ApplyChartTemplate("Sheet2", "A1", template, []ChartSeries{
+		{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"},
+		{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+		{Name: "Sheet1!$B$1", Values: "Sheet1!$B$2:$B$4"},
+	}))
+	charts, err := f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, "Sales Report", charts[0].Title.Name)
+	assert.Len(t, charts[0].Series, 3)
+	assert.Equal(t, "Sheet1!$C$2:$C$4", charts[0].Series[0].Values)
+	assert.Equal(t, ChartMarker{Symbol: "diamond", Size: 8}, charts[0].Series[0].Marker)
+	assert.True(t, charts[0].Series[0].Line.Smooth)
+	assert.Equal(t, "linear", charts[0].Series[1].Trendline.Type)
+	assert.Equal(t, "Sheet1!$B$2:$B$4", charts[0].Series[2].Values)
+	// Test the template will not be changed
+	assert.Len(t, template.Series, 2)
+	assert.Empty(t, template.Series[0].Values)
+	// Test the chart created from the template has the same format settings
+	assert.NoError(t, f.ApplyChartTemplate("Sheet2", "K1", template, []ChartSeries{
+		{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$C$2:$C$4"},
+		{Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$4", Values: "Sheet1!$B$2:$B$4"},
+	}))
+	applied, err := f.GetChartTemplate("Sheet2", "K1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, applied)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestChartTemplate.xlsx")))
+	// Test get chart template without chart anchored at the cell
+	_, err = f.GetChartTemplate("Sheet1", "A1")
+	assert.EqualError(t, err, newNoExistChartError("Sheet1", "A1").Error())
+	_, err = f.GetChartTemplate("Sheet3", "A1")
+	assert.EqualError(t, err, newNoExistSheetError("Sheet3").Error())
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	_, err = f.GetChartTemplate("Sheet3", "A1")
+	assert.EqualError(t, err, newNoExistChartError("Sheet3", "A1").Error())
+	// Test get chart template with invalid cell reference
+	_, err = f.GetChartTemplate("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test apply chart template with invalid template
+	assert.Equal(t, ErrParameterInvalid, f.ApplyChartTemplate("Sheet2", "A1", nil, nil))
+	// Test get chart template with unsupported charset chart
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetChartTemplate("Sheet1", "E1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestAddChartSheet(t *testing.T) {
 	categories := map[string]string{"A2": "Small", "A3": "Normal", "A4": "Large", "B1": "Apple", "C1": "Orange", "D1": "Pear"}
 	values := map[string]int{"B2": 2, "C2": 3, "D2": 3, "B3": 5, "C3": 2, "D3": 4, "B4": 6, "C4": 7, "D4": 8}
@@ -342,7 +637,7 @@ func TestAddChartSheet(t *testing.T) {
 	// Test add chartsheet with invalid sheet name
 	assert.EqualError(t, f.AddChartSheet("Sheet:1", nil, &Chart{Type: Col3DClustered, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), ErrSheetNameInvalid.Error())
 	// Test with unsupported chart type
-	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x37, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), newUnsupportedChartType(0x37).Error())
+	assert.EqualError(t, f.AddChartSheet("Chart2", &Chart{Type: 0x3A, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}), newUnsupportedChartType(0x3A).Error())
 
 	assert.NoError(t, f.UpdateLinkedValue())
 
@@ -398,6 +693,50 @@ func TestDeleteChart(t *testing.T) {
 	// Test delete chart on no chart worksheet
 	assert.NoError(t, NewFile().DeleteChart("Sheet1", "A1"))
 	assert.NoError(t, f.Close())
+
+	// Test delete chart removes the chart part, relationship and content type
+	f = NewFile()
+	series = []ChartSeries{{Name: "Sheet1!$A$1", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}}
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "J1", &Chart{Type: Line, Series: series}))
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect", Paragraph: []RichTextRun{{Text: "Rectangle"}}}))
+	assert.NoError(t, f.DeleteChart("Sheet1", "A1"))
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, Line, charts[0].Type)
+	_, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/xl/charts/chart1.xml", override.PartName)
+	}
+	drawingRels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range drawingRels.Relationships {
+		assert.NotEqual(t, "../charts/chart1.xml", rel.Target)
+	}
+	objects, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+	// Test add chart after delete chart without part name collision
+	assert.NoError(t, f.AddChart("Sheet1", "A10", &Chart{Type: Bar, Series: series}))
+	_, ok = f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	// Test delete all drawing objects removes the drawing part
+	assert.NoError(t, f.DeleteChart("Sheet1", "J1"))
+	assert.NoError(t, f.DeleteChart("Sheet1", "A10"))
+	assert.NoError(t, f.DeleteShape("Sheet1", "A1"))
+	_, ok = f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteChart2.xlsx")))
+	assert.NoError(t, f.Close())
 }
 
 func TestChartWithLogarithmicBase(t *testing.T) {
@@ -501,3 +840,554 @@ func TestChartWithLogarithmicBase(t *testing.T) {
 		}
 	}
 }
+
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+	}
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddShape("Sheet1", "A5", &Shape{Type: "rect", Paragraph: []RichTextRun{{Text: "Rectangle"}}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Bar, Series: series, Title: ChartTitle{Name: "Fruit Bar Chart"}, Legend: ChartLegend{Position: "top"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "E20", &Chart{Type: Col, Series: series[:1]}, &Chart{Type: Line, Series: series[1:]}))
+	// Test get charts and skip the pictures and shapes
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, Bar, charts[0].Type)
+	assert.Equal(t, "Fruit Bar Chart", charts[0].Title.Name)
+	assert.Equal(t, "top", charts[0].Legend.Position)
+	assert.Equal(t, series, charts[0].Series)
+	// Test get the primary chart of the combo chart
+	assert.Equal(t, Col, charts[1].Type)
+	assert.Equal(t, series[:1], charts[1].Series)
+	// Test get the type of the charts
+	for typ := Area; typ <= Bubble3D; typ++ {
+		sheet := fmt.Sprintf("Chart%d", typ)
+		_, err = f.NewSheet(sheet)
+		assert.NoError(t, err)
+		assert.NoError(t, f.AddChart(sheet, "A1", &Chart{Type: typ, Series: series, Legend: ChartLegend{Position: "none"}}))
+		charts, err = f.GetCharts(sheet)
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, typ, charts[0].Type)
+		assert.Equal(t, "none", charts[0].Legend.Position)
+		assert.Equal(t, series[1].Values, charts[0].Series[1].Values)
+	}
+	// Test get charts on the worksheet without drawing
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	// Test get charts on not exists worksheet
+	_, err = f.GetCharts("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get charts in the chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart", &Chart{Type: Col3DClustered, Series: series, Title: ChartTitle{Name: "Fruit 3D Clustered Column Chart"}}))
+	_, err = f.GetCharts("Chart")
+	assert.EqualError(t, err, "sheet Chart is not a worksheet")
+	chartSheets, err := f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Len(t, chartSheets, 1)
+	assert.Equal(t, Col3DClustered, chartSheets["Chart"].Type)
+	assert.Equal(t, "Fruit 3D Clustered Column Chart", chartSheets["Chart"].Title.Name)
+	assert.Equal(t, series, chartSheets["Chart"].Series)
+	// Test get charts after save and reopen the workbook
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetCharts.xlsx"))
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, series, charts[0].Series)
+	chartSheets, err = f.GetChartSheets()
+	assert.NoError(t, err)
+	assert.Equal(t, Col3DClustered, chartSheets["Chart"].Type)
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get chartsheets with unsupported charset chartsheet
+	sheetXMLPath, ok := f.getSheetXMLPath("Chart")
+	assert.True(t, ok)
+	f.Pkg.Store(sheetXMLPath, MacintoshCyrillicCharset)
+	_, err = f.GetChartSheets()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartEx(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Region", "Country", "Sales"},
+		{"Asia", "China", 30}, {nil, "Japan", 20},
+		{"Europe", "France", 15}, {nil, "Germany", 25},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{Name: "Sheet1!$C$1", Categories: "Sheet1!$A$2:$B$5", Values: "Sheet1!$C$2:$C$5"}}
+	assert.NoError(t, f.AddChart("Sheet1", "E1", &Chart{Type: Col, Series: series}))
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Treemap, Series: series, Title: ChartTitle{Name: "Sales"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "M16", &Chart{Type: Sunburst, Series: series, Legend: ChartLegend{Position: "none"}}))
+	// Test the hierarchical categories mapped to the levels of the chart data
+	chartSpace := new(xlsxChartExSpace)
+	content, ok := f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	data := chartSpace.ChartData.Data[0]
+	assert.Equal(t, "Sheet1!$A$2:$B$5", data.StrDim.F)
+	assert.Equal(t, []cxLvl{
+		{PtCount: 4, Pt: []cxPt{{Idx: 0, V: "China"}, {Idx: 1, V: "Japan"}, {Idx: 2, V: "France"}, {Idx: 3, V: "Germany"}}},
+		{PtCount: 4, Pt: []cxPt{{Idx: 0, V: "Asia"}, {Idx: 2, V: "Europe"}}},
+	}, data.StrDim.Lvl)
+	assert.Equal(t, []cxLvl{
+		{PtCount: 4, FormatCode: "General", Pt: []cxPt{{Idx: 0, V: "30"}, {Idx: 1, V: "20"}, {Idx: 2, V: "15"}, {Idx: 3, V: "25"}}},
+	}, data.NumDim.Lvl)
+	ser := chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0]
+	assert.Equal(t, "treemap", ser.LayoutID)
+	assert.Equal(t, "Sales", ser.Tx.TxData.V)
+	assert.Equal(t, "Sales", chartSpace.Chart.Title.Tx.TxData.V)
+	assert.Equal(t, "b", chartSpace.Chart.Legend.Pos)
+	chartSpace = new(xlsxChartExSpace)
+	content, ok = f.Pkg.Load("xl/charts/chartEx2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	assert.Equal(t, "sunburst", chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0].LayoutID)
+	assert.Nil(t, chartSpace.Chart.Title)
+	assert.Nil(t, chartSpace.Chart.Legend)
+	// Test the content types and relationships of the extended chart
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, contentTypes.Overrides, xlsxOverride{PartName: "/xl/charts/chartEx1.xml", ContentType: ContentTypeDrawingMLChartEx})
+	drawingRels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	assert.Equal(t, SourceRelationshipChartEx, drawingRels.Relationships[1].Type)
+	assert.Equal(t, "../charts/chartEx1.xml", drawingRels.Relationships[1].Target)
+	objects, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "chart", objects[1].Type)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartEx.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestAddChartEx.xlsx"))
+	assert.NoError(t, err)
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "E16", objects[1].Cell)
+	assert.Equal(t, "chart", objects[1].Type)
+	// Test delete extended chart
+	assert.NoError(t, f.DeleteChart("Sheet1", "E16"))
+	_, ok = f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load("xl/charts/chartEx2.xml")
+	assert.True(t, ok)
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	// Test add extended chart after delete extended chart
+	assert.NoError(t, f.AddChart("Sheet1", "E16", &Chart{Type: Treemap, Series: series}))
+	_, ok = f.Pkg.Load("xl/charts/chartEx3.xml")
+	assert.True(t, ok)
+	// Test add extended chart with combo chart
+	assert.EqualError(t, f.AddChart("Sheet1", "E32", &Chart{Type: Treemap, Series: series}, &Chart{Type: Col, Series: series}),
+		newIncompatibleComboChartError(Treemap, Col).Error())
+	assert.EqualError(t, f.AddChart("Sheet1", "E32", &Chart{Type: Col, Series: series}, &Chart{Type: Sunburst, Series: series}),
+		newIncompatibleComboChartError(Col, Sunburst).Error())
+	// Test add extended chart in chartsheet
+	assert.EqualError(t, f.AddChartSheet("Chart1", &Chart{Type: Sunburst, Series: series}), newUnsupportedChartType(Sunburst).Error())
+	// Test add extended chart with invalid and empty data reference
+	assert.NoError(t, f.AddChart("Sheet1", "E48", &Chart{Type: Treemap, Series: []ChartSeries{{Categories: "Sheet1!A", Values: "SheetN!$C$2:$C$5"}}}))
+	assert.NoError(t, f.AddChart("Sheet1", "M48", &Chart{Type: Treemap}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartWaterfall(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{"Item", "Amount"}, {"Revenue", 100}, {"Services", 50}, {"Costs", -30}, {"Net", 120},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{{
+		Name: "Sheet1!$B$1", Categories: "Sheet1!$A$2:$A$5", Values: "Sheet1!$B$2:$B$5", SubTotals: []int{3},
+		DataPoints: []ChartDataPoint{{Index: 1, Fill: Fill{Type: "pattern", Color: []string{"#70AD47"}, Pattern: 1}}},
+	}}
+	assert.NoError(t, f.AddChart("Sheet1", "D1", &Chart{Type: Waterfall, Series: series, Title: ChartTitle{Name: "Net Income"}}))
+	content, ok := f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.True(t, ok)
+	chartSpace := new(xlsxChartExSpace)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	assert.Equal(t, "val", chartSpace.ChartData.Data[0].NumDim.Type)
+	ser := chartSpace.Chart.PlotArea.PlotAreaRegion.Series[0]
+	assert.Equal(t, "waterfall", ser.LayoutID)
+	assert.Equal(t, []attrValInt{{Val: intPtr(3)}}, ser.LayoutPr.Subtotals.Idx)
+	assert.Len(t, ser.DataPt, 4)
+	assert.Len(t, chartSpace.Chart.PlotArea.Axis, 2)
+	assert.Contains(t, string(content.([]byte)), `<layoutPr><subtotals><idx val="3"></idx></subtotals></layoutPr>`)
+	// Test the default colors of the increase, decrease and total columns, and
+	// the color overridden by the data point settings
+	for idx, fill := range []string{
+		`<a:schemeClr val="accent1">`, `<a:srgbClr val="70AD47">`, `<a:schemeClr val="accent2">`, `<a:schemeClr val="accent3">`,
+	} {
+		assert.Contains(t, string(content.([]byte)), fmt.Sprintf(`<dataPt idx="%d"><spPr><a:solidFill>%s`, idx, fill))
+	}
+	// Test add waterfall chart with subtotal index out of range
+	for _, subTotals := range [][]int{{-1}, {4}} {
+		series[0].SubTotals = subTotals
+		assert.Equal(t, ErrChartWaterfallSubTotal, f.AddChart("Sheet1", "D16", &Chart{Type: Waterfall, Series: series}))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartWaterfall.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartAxisScaling(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{
+		Type: Col, Series: series,
+		XAxis: ChartAxis{Minimum: float64Ptr(1), Maximum: float64Ptr(3)},
+		YAxis: ChartAxis{Minimum: float64Ptr(0), Maximum: float64Ptr(500), MajorUnit: 100, NumFmt: ChartNumFmt{CustomNumFmt: "$#,##0.00"}},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Col, Series: series}))
+	chartSpace := new(xlsxChartSpace)
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	valAx, catAx := chartSpace.Chart.PlotArea.ValAx[0], chartSpace.Chart.PlotArea.CatAx[0]
+	assert.Equal(t, 0.0, *valAx.Scaling.Min.Val)
+	assert.Equal(t, 500.0, *valAx.Scaling.Max.Val)
+	assert.Equal(t, 100.0, *valAx.MajorUnit.Val)
+	assert.Equal(t, "$#,##0.00", valAx.NumFmt.FormatCode)
+	assert.Equal(t, 1.0, *catAx.Scaling.Min.Val)
+	assert.Equal(t, 3.0, *catAx.Scaling.Max.Val)
+	// Test the axis scaling will be auto without the minimum and maximum
+	chartSpace = new(xlsxChartSpace)
+	content, ok = f.Pkg.Load("xl/charts/chart2.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	valAx = chartSpace.Chart.PlotArea.ValAx[0]
+	assert.Nil(t, valAx.Scaling.Min)
+	assert.Nil(t, valAx.Scaling.Max)
+	assert.Nil(t, valAx.MajorUnit)
+	assert.Equal(t, "General", valAx.NumFmt.FormatCode)
+	// Test add chart with invalid logarithmic base of the axis
+	for _, logBase := range []float64{-2, 0.5, 1} {
+		assert.Equal(t, ErrChartAxisLogBase, f.AddChart("Sheet1", "P40", &Chart{Type: Col, Series: series, YAxis: ChartAxis{LogBase: logBase}}))
+		assert.Equal(t, ErrChartAxisLogBase, f.AddChart("Sheet1", "P40", &Chart{Type: Col, Series: series}, &Chart{Type: Line, Series: series, XAxis: ChartAxis{LogBase: logBase}}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartLegend(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31"},
+		{Name: "Sheet1!$A$32", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$32:$D$32"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{
+		Type: Col, Series: series,
+		Legend: ChartLegend{Position: "top_right", Overlay: true, HiddenEntries: []int{2, 0, 2}},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Col, Series: series}))
+	chartSpace := new(xlsxChartSpace)
+	content, ok := f.Pkg.Load("xl/charts/chart1.xml")
+	assert.True(t, ok)
+	assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+	legend := chartSpace.Chart.Legend
+	assert.Equal(t, "tr", *legend.LegendPos.Val)
+	assert.True(t, *legend.Overlay.Val)
+	assert.Equal(t, []cLegendEntry{
+		{Idx: &attrValInt{Val: intPtr(0)}, Delete: &attrValBool{Val: boolPtr(true)}},
+		{Idx: &attrValInt{Val: intPtr(2)}, Delete: &attrValBool{Val: boolPtr(true)}},
+	}, legend.LegendEntry)
+	assert.Contains(t, string(content.([]byte)), `<legend><legendPos val="tr"></legendPos><legendEntry><idx val="0"></idx><delete val="1"></delete></legendEntry>`)
+	// Test get the legend settings of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 2)
+	assert.Equal(t, ChartLegend{Position: "top_right", Overlay: true, HiddenEntries: []int{0, 2}}, charts[0].Legend)
+	assert.Equal(t, ChartLegend{Position: "bottom"}, charts[1].Legend)
+	// Test add extended chart with overlay legend
+	assert.NoError(t, f.AddChart("Sheet1", "P40", &Chart{Type: Treemap, Series: series[:1], Legend: ChartLegend{Position: "left", Overlay: true}}))
+	content, ok = f.Pkg.Load("xl/charts/chartEx1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<legend pos="l" align="ctr" overlay="true"></legend>`)
+	// Test add chart with invalid legend options
+	assert.Equal(t, ErrChartLegendPosition, f.AddChart("Sheet1", "P60", &Chart{Type: Col, Series: series, Legend: ChartLegend{Position: "center"}}))
+	assert.Equal(t, ErrChartLegendEntry, f.AddChart("Sheet1", "P60", &Chart{Type: Col, Series: series, Legend: ChartLegend{HiddenEntries: []int{-1}}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartGapWidthOverlap(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series, GapWidth: intPtr(50), Overlap: intPtr(-20)}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Bar3DClustered, Series: series, GapWidth: intPtr(0), Overlap: intPtr(50)}))
+	assert.NoError(t, f.AddChart("Sheet1", "P40", &Chart{Type: Col, Series: series[:1], GapWidth: intPtr(300)},
+		&Chart{Type: Line, Series: series[1:], GapWidth: intPtr(20), Overlap: intPtr(80)}))
+	assert.NoError(t, f.AddChart("Sheet1", "P60", &Chart{Type: Line, Series: series, GapWidth: intPtr(50), Overlap: intPtr(50)}))
+	getChartSpace := func(name string) *xlsxChartSpace {
+		chartSpace := new(xlsxChartSpace)
+		content, ok := f.Pkg.Load(name)
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+		return chartSpace
+	}
+	barChart := getChartSpace("xl/charts/chart1.xml").Chart.PlotArea.BarChart
+	assert.Equal(t, 50, *barChart.GapWidth.Val)
+	assert.Equal(t, -20, *barChart.Overlap.Val)
+	// Test the overlap doesn't work with the 3-D bar chart
+	bar3DChart := getChartSpace("xl/charts/chart2.xml").Chart.PlotArea.Bar3DChart
+	assert.Equal(t, 0, *bar3DChart.GapWidth.Val)
+	assert.Nil(t, bar3DChart.Overlap)
+	// Test apply the gap width and overlap for each group of the combo chart
+	plotArea := getChartSpace("xl/charts/chart3.xml").Chart.PlotArea
+	assert.Equal(t, 300, *plotArea.BarChart.GapWidth.Val)
+	assert.Nil(t, plotArea.BarChart.Overlap)
+	assert.Nil(t, plotArea.LineChart.GapWidth)
+	assert.Nil(t, plotArea.LineChart.Overlap)
+	// Test get the gap width and overlap of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 4)
+	for _, chart := range charts {
+		switch chart.Type {
+		case Col:
+			if chart.Overlap != nil {
+				assert.Equal(t, 50, *chart.GapWidth)
+				assert.Equal(t, -20, *chart.Overlap)
+				continue
+			}
+			assert.Equal(t, 300, *chart.GapWidth)
+		case Bar3DClustered:
+			assert.Equal(t, 0, *chart.GapWidth)
+			assert.Nil(t, chart.Overlap)
+		}
+	}
+	// Test the gap width and overlap don't work with the line chart
+	lineChart := getChartSpace("xl/charts/chart4.xml").Chart.PlotArea.LineChart
+	assert.Nil(t, lineChart.GapWidth)
+	assert.Nil(t, lineChart.Overlap)
+	// Test add chart with invalid gap width and overlap
+	for _, gapWidth := range []int{-1, 501} {
+		assert.Equal(t, ErrChartGapWidth, f.AddChart("Sheet1", "P80", &Chart{Type: Col, Series: series, GapWidth: intPtr(gapWidth)}))
+	}
+	for _, overlap := range []int{-101, 101} {
+		assert.Equal(t, ErrChartOverlap, f.AddChart("Sheet1", "P80", &Chart{Type: Col, Series: series, Overlap: intPtr(overlap)}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartDataTable(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"},
+		{Name: "Sheet1!$A$31", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$31:$D$31"},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series, PlotArea: ChartPlotArea{ShowDataTable: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Line, Series: series, PlotArea: ChartPlotArea{
+		ShowDataTable: true, DataTable: ChartDataTable{ShowLegendKeys: true, HideVertBorder: true, HideOutline: true},
+	}}))
+	assert.NoError(t, f.AddChart("Sheet1", "P40", &Chart{Type: Pie, Series: series[:1], PlotArea: ChartPlotArea{ShowDataTable: true}}))
+	getChartSpace := func(name string) *xlsxChartSpace {
+		chartSpace := new(xlsxChartSpace)
+		content, ok := f.Pkg.Load(name)
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+		return chartSpace
+	}
+	dTable := getChartSpace("xl/charts/chart1.xml").Chart.PlotArea.DTable
+	assert.NotNil(t, dTable)
+	assert.True(t, *dTable.ShowHorzBorder.Val)
+	assert.True(t, *dTable.ShowVertBorder.Val)
+	assert.True(t, *dTable.ShowOutline.Val)
+	assert.False(t, *dTable.ShowKeys.Val)
+	dTable = getChartSpace("xl/charts/chart2.xml").Chart.PlotArea.DTable
+	assert.NotNil(t, dTable)
+	assert.True(t, *dTable.ShowHorzBorder.Val)
+	assert.False(t, *dTable.ShowVertBorder.Val)
+	assert.False(t, *dTable.ShowOutline.Val)
+	assert.True(t, *dTable.ShowKeys.Val)
+	// Test the data table doesn't work with the chart without category axis
+	assert.Nil(t, getChartSpace("xl/charts/chart3.xml").Chart.PlotArea.DTable)
+	// Test get the data table settings of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 3)
+	for _, chart := range charts {
+		switch chart.Type {
+		case Col:
+			assert.True(t, chart.PlotArea.ShowDataTable)
+			assert.Equal(t, ChartDataTable{}, chart.PlotArea.DataTable)
+		case Line:
+			assert.True(t, chart.PlotArea.ShowDataTable)
+			assert.Equal(t, ChartDataTable{ShowLegendKeys: true, HideVertBorder: true, HideOutline: true}, chart.PlotArea.DataTable)
+		case Pie:
+			assert.False(t, chart.PlotArea.ShowDataTable)
+		}
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartDataTable.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddChartTitleVisibility(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{{Name: "Sheet1!$A$30", Categories: "Sheet1!$B$29:$D$29", Values: "Sheet1!$B$30:$D$30"}}
+	assert.NoError(t, f.AddChart("Sheet1", "P1", &Chart{Type: Col, Series: series, Title: ChartTitle{Name: "Sales"}}))
+	assert.NoError(t, f.AddChart("Sheet1", "P20", &Chart{Type: Col, Series: series, Title: ChartTitle{Auto: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "P40", &Chart{Type: Col, Series: series, Title: ChartTitle{Name: "Sales", Hidden: true}}))
+	assert.NoError(t, f.AddChart("Sheet1", "P60", &Chart{Type: Col, Series: series}))
+	getChartSpace := func(name string) *xlsxChartSpace {
+		chartSpace := new(xlsxChartSpace)
+		content, ok := f.Pkg.Load(name)
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(content.([]byte), chartSpace))
+		return chartSpace
+	}
+	chartSpace := getChartSpace("xl/charts/chart1.xml")
+	assert.NotNil(t, chartSpace.Chart.Title)
+	assert.Nil(t, chartSpace.Chart.AutoTitleDeleted)
+	chartSpace = getChartSpace("xl/charts/chart2.xml")
+	assert.Nil(t, chartSpace.Chart.Title)
+	assert.False(t, chartSpace.Chart.AutoTitleDeleted.Val)
+	chartSpace = getChartSpace("xl/charts/chart3.xml")
+	assert.Nil(t, chartSpace.Chart.Title)
+	assert.True(t, chartSpace.Chart.AutoTitleDeleted.Val)
+	content, ok := f.Pkg.Load("xl/charts/chart3.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<autoTitleDeleted val="true"></autoTitleDeleted>`)
+	// Test get the title of the charts
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 4)
+	var titles []ChartTitle
+	for _, chart := range charts {
+		titles = append(titles, chart.Title)
+	}
+	assert.ElementsMatch(t, []ChartTitle{{Name: "Sales"}, {Auto: true}, {Hidden: true}, {Name: " "}}, titles)
+	// Test get the title of the chart with the auto title deleted by numeric value
+	f.Pkg.Store("xl/charts/chart3.xml", bytes.ReplaceAll(content.([]byte), []byte(`<autoTitleDeleted val="true"></autoTitleDeleted>`), []byte(`<autoTitleDeleted val="1"/>`)))
+	chart, err := f.getChart("xl/charts/chart3.xml")
+	assert.NoError(t, err)
+	assert.Equal(t, ChartTitle{Hidden: true}, chart.Title)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddChartTitleVisibility.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPivotChart(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Type", "Sales"}))
+	for row, v := range [][]interface{}{
+		{"Jan", "Meat", 100}, {"Jan", "Dairy", 200}, {"Feb", "Meat", 300}, {"Feb", "Dairy", 400},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row+2), &v))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!$A$1:$C$5",
+		PivotTableRange: "Sheet1!$E$2:$H$8",
+		Rows:            []PivotTableField{{Data: "Month"}},
+		Columns:         []PivotTableField{{Data: "Type"}},
+		Data:            []PivotTableField{{Data: "Sales"}},
+	}))
+	opts := PivotChartOptions{
+		Cell:            "J2",
+		PivotTableRange: "Sheet1!$H$8:$E$2",
+		Chart: Chart{
+			Type:   Col,
+			Series: []ChartSeries{{Name: "Sheet1!$F$3", Categories: "Sheet1!$E$4:$E$5", Values: "Sheet1!$F$4:$F$5"}},
+		},
+	}
+	assert.NoError(t, f.AddPivotChart("Sheet1", opts))
+	opts.Cell = "J20"
+	assert.NoError(t, f.AddPivotChart("Sheet1", opts))
+	for i, fmtID := range []int{0, 1} {
+		chartSpace := xlsxChartSpace{}
+		chart, ok := f.Pkg.Load(fmt.Sprintf("xl/charts/chart%d.xml", i+1))
+		assert.True(t, ok)
+		assert.NoError(t, xml.Unmarshal(chart.([]byte), &chartSpace))
+		assert.Equal(t, "Sheet1!Pivot Table1", chartSpace.PivotSource.Name)
+		assert.Equal(t, fmtID, *chartSpace.PivotSource.FmtID.Val)
+	}
+	pt := xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotTables/pivotTable1.xml"), &pt))
+	assert.Equal(t, 2, pt.ChartFormat)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPivotChart.xlsx")))
+	// Test add pivot chart will keep the elements of the pivot table which are
+	// not supported by the pivot table definition
+	pivotTable := strings.Replace(string(f.readXML("xl/pivotTables/pivotTable1.xml")), "</pivotTableDefinition>", "<unknownElement attr=\"value\"/></pivotTableDefinition>", 1)
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", []byte(pivotTable))
+	opts.Cell = "J38"
+	assert.NoError(t, f.AddPivotChart("Sheet1", opts))
+	chartSpace := xlsxChartSpace{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/charts/chart3.xml"), &chartSpace))
+	assert.Equal(t, "Sheet1!Pivot Table1", chartSpace.PivotSource.Name)
+	assert.Equal(t, 2, *chartSpace.PivotSource.FmtID.Val)
+	pivotTable = string(f.readXML("xl/pivotTables/pivotTable1.xml"))
+	assert.Contains(t, pivotTable, `<unknownElement attr="value"/></pivotTableDefinition>`)
+	assert.Contains(t, pivotTable, ` chartFormat="3"`)
+	assert.Equal(t, 1, strings.Count(pivotTable, "chartFormat="))
+	assert.Equal(t, 1, strings.Count(pivotTable, "<?xml"))
+	// Test set chart format on the pivot table definition with prefixed
+	// element name and the attribute value contains the greater-than sign
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", []byte(xml.Header+`<x:pivotTableDefinition xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main" name="A&gt;B" dataCaption='"Values">' chartFormat="1"><x:location ref="A1:B2"/></x:pivotTableDefinition>`))
+	f.setPivotTableChartFormat("xl/pivotTables/pivotTable2.xml", 5)
+	assert.Equal(t, xml.Header+`<x:pivotTableDefinition chartFormat="5" xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main" name="A&gt;B" dataCaption="&#34;Values&#34;&gt;"><x:location ref="A1:B2"/></x:pivotTableDefinition>`, string(f.readXML("xl/pivotTables/pivotTable2.xml")))
+	pt = xlsxPivotTableDefinition{}
+	assert.NoError(t, xml.Unmarshal(f.readXML("xl/pivotTables/pivotTable2.xml"), &pt))
+	assert.Equal(t, 5, pt.ChartFormat)
+	assert.Equal(t, "A>B", pt.Name)
+	assert.Equal(t, `"Values">`, pt.DataCaption)
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", []byte(`<pivotTableDefinition/>`))
+	f.setPivotTableChartFormat("xl/pivotTables/pivotTable2.xml", 1)
+	assert.Equal(t, `<pivotTableDefinition chartFormat="1"/>`, string(f.readXML("xl/pivotTables/pivotTable2.xml")))
+	// Test set chart format on the pivot table part without definition
+	f.Pkg.Store("xl/pivotTables/pivotTable2.xml", []byte("<pivotCacheDefinition/>"))
+	f.setPivotTableChartFormat("xl/pivotTables/pivotTable2.xml", 1)
+	assert.Equal(t, []byte("<pivotCacheDefinition/>"), f.readXML("xl/pivotTables/pivotTable2.xml"))
+	// Test add pivot chart with unsupported chart type
+	assert.EqualError(t, f.AddPivotChart("Sheet1", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "Sheet1!$E$2:$H$8", Chart: Chart{Type: Waterfall},
+	}), newUnsupportedChartType(Waterfall).Error())
+	// Test add pivot chart with invalid pivot table range
+	assert.EqualError(t, f.AddPivotChart("Sheet1", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "E2:H8", Chart: opts.Chart,
+	}), "parameter 'PivotTableRange' parsing error: parameter is invalid")
+	// Test add pivot chart on not exists pivot table
+	assert.EqualError(t, f.AddPivotChart("Sheet1", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "Sheet1!$A$1:$C$5", Chart: opts.Chart,
+	}), "pivot table on Sheet1!$A$1:$C$5 does not exist")
+	assert.EqualError(t, f.AddPivotChart("Sheet1", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "SheetN!$E$2:$H$8", Chart: opts.Chart,
+	}), "sheet SheetN does not exist")
+	// Test add pivot chart on worksheet without relationships
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.EqualError(t, f.AddPivotChart("Sheet1", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "Sheet2!$E$2:$H$8", Chart: opts.Chart,
+	}), "pivot table on Sheet2!$E$2:$H$8 does not exist")
+	// Test add pivot chart on not exists worksheet
+	assert.EqualError(t, f.AddPivotChart("SheetN", PivotChartOptions{
+		Cell: "J2", PivotTableRange: "Sheet1!$E$2:$H$8", Chart: opts.Chart,
+	}), "sheet SheetN does not exist")
+	// Test add pivot chart with unsupported charset pivot table
+	f.Pkg.Store("xl/pivotTables/pivotTable1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPivotChart("Sheet1", opts), "XML syntax error on line 1: invalid UTF-8")
+	// Test add pivot chart with unsupported charset worksheet relationships
+	f.Relationships.Delete("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPivotChart("Sheet1", opts), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
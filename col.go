@@ -218,9 +218,11 @@ func (f *File) Cols(sheet string) (*Cols, error) {
 	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
 		ws := worksheet.(*xlsxWorksheet)
 		ws.mu.Lock()
-		defer ws.mu.Unlock()
 		output, _ := xml.Marshal(ws)
+		ws.mu.Unlock()
+		f.mu.Lock()
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		f.mu.Unlock()
 	}
 	var colIterator columnXMLIterator
 	colIterator.cols.sheetXML = f.readBytes(name)
@@ -279,6 +281,75 @@ func (f *File) GetColVisible(sheet, col string) (bool, error) {
 	return visible, err
 }
 
+// GetHiddenCols provides a function to get the hidden columns name in
+// ascending order by given worksheet name. This function is concurrency safe.
+// For example, get hidden columns in Sheet1:
+//
+//	cols, err := f.GetHiddenCols("Sheet1")
+func (f *File) GetHiddenCols(sheet string) ([]string, error) {
+	var cols []string
+	reasons, err := f.getHiddenColReasons(sheet)
+	if err != nil {
+		return cols, err
+	}
+	colNums := make(map[int]struct{}, len(reasons))
+	for colNum := range reasons {
+		colNums[colNum] = struct{}{}
+	}
+	for _, colNum := range sortedKeys(colNums) {
+		colName, _ := ColumnNumberToName(colNum)
+		cols = append(cols, colName)
+	}
+	return cols, err
+}
+
+// GetHiddenColReasons provides a function to get the reasons of the hidden
+// columns by given worksheet name, the returned map is keyed by the column
+// name. The hidden column with outline level is considered as hidden by the
+// collapsed outline, otherwise it is hidden manually. This function is
+// concurrency safe.
+func (f *File) GetHiddenColReasons(sheet string) (map[string]HiddenReason, error) {
+	reasons := make(map[string]HiddenReason)
+	colReasons, err := f.getHiddenColReasons(sheet)
+	for colNum, reason := range colReasons {
+		colName, _ := ColumnNumberToName(colNum)
+		reasons[colName] = reason
+	}
+	return reasons, err
+}
+
+// getHiddenColReasons provides a function to get the reasons of the hidden
+// columns by given worksheet name, the returned map is keyed by the column
+// number.
+func (f *File) getHiddenColReasons(sheet string) (map[int]HiddenReason, error) {
+	reasons := make(map[int]HiddenReason)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return reasons, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.Cols == nil {
+		return reasons, err
+	}
+	for _, col := range ws.Cols.Col {
+		for colNum := col.Min; colNum <= col.Max && colNum <= MaxColumns; colNum++ {
+			if !col.Hidden {
+				delete(reasons, colNum)
+				continue
+			}
+			reasons[colNum] = HiddenReasonManual
+			if col.OutlineLevel > 0 {
+				reasons[colNum] = HiddenReasonOutline
+			}
+		}
+	}
+	return reasons, err
+}
+
 // SetColVisible provides a function to set visible columns by given worksheet
 // name, columns range and visibility. This function is concurrency safe.
 //
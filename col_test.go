@@ -247,6 +247,31 @@ func TestColumnVisibility(t *testing.T) {
 	})
 }
 
+func TestGetHiddenCols(t *testing.T) {
+	f := NewFile()
+	cols, err := f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, cols)
+	assert.NoError(t, f.SetColVisible("Sheet1", "B:D", false))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", true))
+	assert.NoError(t, f.SetColVisible("Sheet1", "F", false))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "F", 1))
+	cols, err = f.GetHiddenCols("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "D", "F"}, cols)
+	reasons, err := f.GetHiddenColReasons("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]HiddenReason{"B": HiddenReasonManual, "D": HiddenReasonManual, "F": HiddenReasonOutline}, reasons)
+	// Test get hidden columns on not exists worksheet
+	_, err = f.GetHiddenCols("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetHiddenColReasons("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get hidden columns with invalid sheet name
+	_, err = f.GetHiddenCols("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
 func TestOutlineLevel(t *testing.T) {
 	f := NewFile()
 	level, err := f.GetColOutlineLevel("Sheet1", "D")
@@ -16,13 +16,24 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // GetComments retrieves all comments in a worksheet by given worksheet name.
 func (f *File) GetComments(sheet string) ([]Comment, error) {
+	return f.getComments(sheet, "")
+}
+
+// getComments provides a function to get the comments in a worksheet by given
+// worksheet name, only the comment of the cell will be returned if the given
+// cell reference isn't empty.
+func (f *File) getComments(sheet, cell string) ([]Comment, error) {
 	var comments []Comment
 	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
 	if !ok {
@@ -37,9 +48,18 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 	if err != nil {
 		return comments, err
 	}
+	var shapes map[string]Comment
 	if cmts != nil {
 		for _, cmt := range cmts.CommentList.Comment {
-			comment := Comment{}
+			if cell != "" && cmt.Ref != cell {
+				continue
+			}
+			if shapes == nil {
+				if shapes, err = f.getCommentShapes(sheet); err != nil {
+					return comments, err
+				}
+			}
+			comment := shapes[cmt.Ref]
 			if cmt.AuthorID < len(cmts.Authors.Author) {
 				comment.Author = cmts.Authors.Author[cmt.AuthorID]
 			}
@@ -60,9 +80,145 @@ func (f *File) GetComments(sheet string) ([]Comment, error) {
 			comments = append(comments, comment)
 		}
 	}
+	return comments, f.getThreadedComments(sheetXMLPath, comments)
+}
+
+// getThreadedComments provides a function to fill the resolved state,
+// creation time and replies of the threaded comments into the given comments
+// by given worksheet XML path.
+func (f *File) getThreadedComments(sheetXMLPath string, comments []Comment) error {
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		return nil
+	}
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return err
+	}
+	authors, threads := make(map[string]string), make(map[string]int)
+	for _, person := range persons.Person {
+		authors[person.ID] = person.DisplayName
+	}
+	for _, tc := range tcs.ThreadedComment {
+		if tc.ParentID != "" {
+			if idx, ok := threads[tc.ParentID]; ok {
+				comments[idx].Replies = append(comments[idx].Replies, CommentReply{
+					Author: authors[tc.PersonID], Text: tc.Text, Created: parseThreadedCommentTime(tc.DT),
+				})
+			}
+			continue
+		}
+		for idx := range comments {
+			if comments[idx].Cell != tc.Ref || comments[idx].Threaded {
+				continue
+			}
+			comments[idx].Threaded, comments[idx].Resolved = true, boolPtr(tc.Done)
+			comments[idx].Author, comments[idx].Text, comments[idx].Runs = authors[tc.PersonID], tc.Text, nil
+			comments[idx].Created = parseThreadedCommentTime(tc.DT)
+			threads[tc.ID] = idx
+			break
+		}
+	}
+	return err
+}
+
+// parseThreadedCommentTime provides a function to parse the dT attribute of
+// the threaded comment, such as "2023-01-02T15:04:05.00".
+func parseThreadedCommentTime(dT string) time.Time {
+	t, _ := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(dT, "Z"))
+	return t
+}
+
+// getCommentShapes provides a function to get the anchor position and size of
+// the comment boxes in the VML drawing part by given worksheet name, the
+// returned map is keyed by the cell reference which the comment attached to.
+func (f *File) getCommentShapes(sheet string) (map[string]Comment, error) {
+	comments := make(map[string]Comment)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.LegacyDrawing == nil {
+		return comments, err
+	}
+	drawingVML := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawing.RID)
+	if !strings.HasPrefix(drawingVML, "/") {
+		drawingVML = "xl" + strings.TrimPrefix(drawingVML, "..")
+	}
+	drawingVML = strings.TrimPrefix(drawingVML, "/")
+	var shapes []decodeShape
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		for _, shape := range vml.Shape {
+			shapes = append(shapes, decodeShape{Style: shape.Style, Val: shape.Val})
+		}
+	} else {
+		d, err := f.decodeVMLDrawingReader(drawingVML)
+		if err != nil || d == nil {
+			return comments, err
+		}
+		shapes = d.Shape
+	}
+	for _, shape := range shapes {
+		var val decodeShapeVal
+		if err = f.xmlNewDecoder(strings.NewReader("<shape>" + shape.Val + "</shape>")).
+			Decode(&val); err != nil && err != io.EOF {
+			return comments, err
+		}
+		if val.ClientData == nil || val.ClientData.ObjectType != "Note" {
+			continue
+		}
+		cell, err := CoordinatesToCellName(val.ClientData.Column+1, val.ClientData.Row+1)
+		if err != nil {
+			continue
+		}
+		var comment Comment
+		comment.Width, comment.Height = parseVMLShapeSize(shape.Style)
+		var coordinates []int
+		for _, s := range strings.Split(val.ClientData.Anchor, ",") {
+			if n, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+				coordinates = append(coordinates, n)
+			}
+		}
+		if len(coordinates) == 8 {
+			comment.Anchor = &CommentAnchor{
+				LeftColumn: coordinates[0], LeftOffset: coordinates[1],
+				TopRow: coordinates[2], TopOffset: coordinates[3],
+				RightColumn: coordinates[4], RightOffset: coordinates[5],
+				BottomRow: coordinates[6], BottomOffset: coordinates[7],
+			}
+		}
+		comments[cell] = comment
+	}
 	return comments, nil
 }
 
+// parseVMLShapeSize provides a function to parse the width and height in
+// pixels by given VML shape style, such as "width:108pt;height:59.25pt".
+func parseVMLShapeSize(style string) (width, height uint) {
+	for _, prop := range strings.Split(style, ";") {
+		kv := strings.SplitN(prop, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		val, unit := strings.TrimSpace(kv[1]), 0.75
+		if strings.HasSuffix(val, "px") {
+			val, unit = strings.TrimSuffix(val, "px"), 1
+		}
+		size, err := strconv.ParseFloat(strings.TrimSuffix(val, "pt"), 64)
+		if err != nil || size < 0 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "width":
+			width = uint(math.Round(size / unit))
+		case "height":
+			height = uint(math.Round(size / unit))
+		}
+	}
+	return
+}
+
 // getSheetComments provides the method to get the target comment reference by
 // given worksheet file path.
 func (f *File) getSheetComments(sheetFile string) string {
@@ -79,6 +235,26 @@ func (f *File) getSheetComments(sheetFile string) string {
 	return ""
 }
 
+// getSheetThreadedComments provides a function to get the threaded comments
+// part path by given worksheet file path, returns empty string if the
+// worksheet doesn't have threaded comments.
+func (f *File) getSheetThreadedComments(sheetFile string) string {
+	rels, _ := f.relsReader("xl/worksheets/_rels/" + sheetFile + ".rels")
+	if sheetRels := rels; sheetRels != nil {
+		sheetRels.mu.Lock()
+		defer sheetRels.mu.Unlock()
+		for _, v := range sheetRels.Relationships {
+			if v.Type == SourceRelationshipThreadedComment {
+				if !strings.HasPrefix(v.Target, "/") {
+					return "xl" + strings.TrimPrefix(v.Target, "..")
+				}
+				return strings.TrimPrefix(v.Target, "/")
+			}
+		}
+	}
+	return ""
+}
+
 // AddComment provides the method to add comment in a sheet by given worksheet
 // index, cell and format set (such as author and text). Note that the max
 // author length is 255 and the max text length is 32512. For example, add a
@@ -92,12 +268,56 @@ func (f *File) getSheetComments(sheetFile string) string {
 //	        {Text: "This is a comment."},
 //	    },
 //	})
+//
+// Set the Width and Height fields to specify the size of the comment box in
+// pixels, or set the AutoSize field to estimate the size of the comment box
+// from the text length and font size, the estimated width will be capped at
+// 400 pixels and the text will be wrapped. The explicitly specified width or
+// height takes precedence over the estimated one. For example:
+//
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:     "A12",
+//	    Author:   "Excelize",
+//	    Text:     "This is a comment with long text.",
+//	    AutoSize: true,
+//	})
+//
+// Set the Threaded field to add a threaded comment with replies, the text of
+// the runs will be joined as the plain text of the threaded comment. The
+// creation time will be the current time if the Created field was not
+// specified. For example, add a resolved threaded comment with a reply in
+// Sheet1!$A$1:
+//
+//	resolved := true
+//	err := f.AddComment("Sheet1", excelize.Comment{
+//	    Cell:     "A1",
+//	    Author:   "Excelize",
+//	    Text:     "Please check this value.",
+//	    Threaded: true,
+//	    Resolved: &resolved,
+//	    Replies: []excelize.CommentReply{
+//	        {Author: "Reviewer", Text: "Confirmed."},
+//	    },
+//	})
 func (f *File) AddComment(sheet string, comment Comment) error {
 	// Read sheet data.
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	var (
+		tcs     *xlsxThreadedComments
+		persons *xlsxPersonList
+	)
+	if comment.Threaded {
+		if tcs, persons, err = f.newThreadedComment(sheet, &comment); err != nil {
+			return err
+		}
+	}
+	anchor, style, err := f.getCommentLayout(sheet, &comment)
+	if err != nil {
+		return err
+	}
 	commentID := f.countComments() + 1
 	drawingVML := "xl/drawings/vmlDrawing" + strconv.Itoa(commentID) + ".vml"
 	sheetRelationshipsComments := "../comments" + strconv.Itoa(commentID) + ".xml"
@@ -117,25 +337,16 @@ func (f *File) AddComment(sheet string, comment Comment) error {
 		f.addSheetLegacyDrawing(sheet, rID)
 	}
 	commentsXML := "xl/comments" + strconv.Itoa(commentID) + ".xml"
-	var rows, cols int
-	for _, runs := range comment.Runs {
-		for _, subStr := range strings.Split(runs.Text, "\n") {
-			rows++
-			if chars := len(subStr); chars > cols {
-				cols = chars
-			}
-		}
-	}
-	if len(comment.Runs) == 0 {
-		rows, cols = 1, len(comment.Text)
-	}
-	if err = f.addDrawingVML(commentID, drawingVML, comment.Cell, rows+1, cols); err != nil {
+	if err = f.addDrawingVML(commentID, drawingVML, comment.Cell, anchor, style); err != nil {
 		return err
 	}
 	if err = f.addComment(commentsXML, comment); err != nil {
 		return err
 	}
-	return f.addContentTypePart(commentID, "comments")
+	if err = f.addContentTypePart(commentID, "comments"); err != nil || !comment.Threaded {
+		return err
+	}
+	return f.addThreadedComment(sheet, tcs, persons)
 }
 
 // DeleteComment provides the method to delete comment in a sheet by given
@@ -177,12 +388,391 @@ func (f *File) DeleteComment(sheet, cell string) error {
 		}
 		f.Comments[commentsXML] = cmts
 	}
+	return f.deleteThreadedComment(sheetXMLPath, cell)
+}
+
+// NotesToHyperlinks provides a function to convert the notes which only
+// contain a single URL into the hyperlinks of the cells by given worksheet
+// name, and returns the count of the converted notes. The conversion is
+// conservative: only the notes with a single http, https or ftp URL will be
+// converted, and the leading author name line written by Excel will be
+// ignored. The threaded comments and the notes of the cells which already
+// have a hyperlink will be kept, and the cell values will not be changed. The
+// converted notes will be removed. For example, convert the URL-only notes in
+// Sheet1:
+//
+//	count, err := f.NotesToHyperlinks("Sheet1")
+func (f *File) NotesToHyperlinks(sheet string) (int, error) {
+	var count int
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return count, err
+	}
+	for _, comment := range comments {
+		if comment.Threaded {
+			continue
+		}
+		link, ok := getNoteURL(comment)
+		if !ok {
+			continue
+		}
+		if ok, _, err = f.GetCellHyperLink(sheet, comment.Cell); err != nil || ok {
+			if err != nil {
+				return count, err
+			}
+			continue
+		}
+		if err = f.SetCellHyperLink(sheet, comment.Cell, link, "External"); err != nil {
+			return count, err
+		}
+		if err = f.DeleteComment(sheet, comment.Cell); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, err
+}
+
+// HyperlinksToNotes provides a function to convert the external hyperlinks of
+// the cells into the notes by given worksheet name, and returns the count of
+// the converted hyperlinks. The text of the note will be the URL of the
+// hyperlink. The conversion is conservative: only the external hyperlinks of
+// a single cell with a http, https or ftp URL will be converted. The
+// hyperlinks of the cells which already have a note or threaded comment, the
+// hyperlinks for moving to the location in the workbook and the HYPERLINK
+// formulas will be kept, and the cell values will not be changed. The
+// converted hyperlinks will be removed. For example, convert the hyperlinks
+// in Sheet1:
+//
+//	count, err := f.HyperlinksToNotes("Sheet1")
+func (f *File) HyperlinksToNotes(sheet string) (int, error) {
+	var count int
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return count, err
+	}
+	commented := make(map[string]struct{}, len(comments))
+	for _, comment := range comments {
+		commented[strings.ToUpper(comment.Cell)] = struct{}{}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Hyperlinks == nil {
+		return count, err
+	}
+	var kept []xlsxHyperlink
+	for i, link := range ws.Hyperlinks.Hyperlink {
+		target := f.getSheetRelationshipsTargetByID(sheet, link.RID)
+		if _, ok := commented[strings.ToUpper(link.Ref)]; ok || link.RID == "" ||
+			strings.Contains(link.Ref, ":") || !isNoteURL(target) {
+			kept = append(kept, link)
+			continue
+		}
+		if err = f.AddComment(sheet, Comment{Cell: link.Ref, Text: target}); err != nil {
+			ws.Hyperlinks.Hyperlink = append(kept, ws.Hyperlinks.Hyperlink[i:]...)
+			return count, err
+		}
+		f.deleteSheetRelationships(sheet, link.RID)
+		count++
+	}
+	ws.Hyperlinks.Hyperlink = kept
+	if len(ws.Hyperlinks.Hyperlink) == 0 {
+		ws.Hyperlinks = nil
+	}
+	return count, err
+}
+
+// getNoteURL provides a function to get the URL of the note by given comment,
+// the second return value will be false if the text of the note isn't a
+// single http, https or ftp URL.
+func getNoteURL(comment Comment) (string, bool) {
+	text := comment.Text
+	for _, run := range comment.Runs {
+		text += run.Text
+	}
+	text = strings.TrimSpace(text)
+	if comment.Author != "" && strings.HasPrefix(text, comment.Author+":") {
+		text = strings.TrimSpace(strings.TrimPrefix(text, comment.Author+":"))
+	}
+	return text, isNoteURL(text)
+}
+
+// isNoteURL provides a function to check if the given text is a single http,
+// https or ftp URL which can be converted between the note and hyperlink.
+func isNoteURL(text string) bool {
+	if text == "" || strings.ContainsAny(text, " \t\r\n") {
+		return false
+	}
+	u, err := url.Parse(text)
+	return err == nil && u.Host != "" && inStrSlice([]string{"http", "https", "ftp"}, strings.ToLower(u.Scheme), true) != -1
+}
+
+// newThreadedComment provides a function to create the threaded comment and
+// replies by given worksheet name and comment settings, and replace the author
+// and text of the given comment with the legacy comment content for
+// compatibility. The returned threaded comments and persons will not be saved
+// until calling the addThreadedComment function.
+func (f *File) newThreadedComment(sheet string, comment *Comment) (*xlsxThreadedComments, *xlsxPersonList, error) {
+	if _, _, err := CellNameToCoordinates(comment.Cell); err != nil {
+		return nil, nil, err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	tcs, err := f.threadedCommentsReader(f.getSheetThreadedComments(filepath.Base(sheetXMLPath)))
+	if err != nil {
+		return tcs, nil, err
+	}
+	persons, err := f.personsReader()
+	if err != nil {
+		return tcs, persons, err
+	}
+	if _, err = f.relsReader(f.getWorkbookRelsPath()); err != nil {
+		return tcs, persons, err
+	}
+	text := comment.Text
+	for _, run := range comment.Runs {
+		text += run.Text
+	}
+	ids := make(map[string]bool)
+	for _, tc := range tcs.ThreadedComment {
+		ids[tc.ID] = true
+	}
+	newID := func() string {
+		for n := len(ids) + 1; ; n++ {
+			if id := fmt.Sprintf("{%08X-0000-0000-0000-%012X}", f.getSheetID(sheet), n); !ids[id] {
+				ids[id] = true
+				return id
+			}
+		}
+	}
+	tc := xlsxThreadedComment{
+		Ref:      comment.Cell,
+		DT:       formatThreadedCommentTime(comment.Created),
+		PersonID: persons.getPersonID(comment.Author),
+		ID:       newID(),
+		Text:     text,
+	}
+	if comment.Resolved != nil {
+		tc.Done = *comment.Resolved
+	}
+	legacy := "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    " + text
+	tcs.ThreadedComment = append(tcs.ThreadedComment, tc)
+	for _, reply := range comment.Replies {
+		tcs.ThreadedComment = append(tcs.ThreadedComment, xlsxThreadedComment{
+			Ref:      comment.Cell,
+			DT:       formatThreadedCommentTime(reply.Created),
+			PersonID: persons.getPersonID(reply.Author),
+			ID:       newID(),
+			ParentID: tc.ID,
+			Text:     reply.Text,
+		})
+		legacy += "\nReply:\n    " + reply.Text
+	}
+	comment.Author, comment.Text, comment.Runs = "tc="+tc.ID, legacy, nil
+	return tcs, persons, err
+}
+
+// addThreadedComment provides a function to save the threaded comments which
+// created by the newThreadedComment function into the threaded comments part
+// of the worksheet, and save the persons part of the workbook by given
+// worksheet name. The parts and relationships will be created if not exist.
+func (f *File) addThreadedComment(sheet string, tcs *xlsxThreadedComments, persons *xlsxPersonList) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		threadedCommentID := f.countThreadedComments() + 1
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentID) + ".xml"
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentID)+".xml", "")
+		if err := f.addContentTypePart(threadedCommentID, "threadedComments"); err != nil {
+			return err
+		}
+	}
+	if err := f.addPersons(); err != nil {
+		return err
+	}
+	output, _ := xml.Marshal(tcs)
+	f.saveFileList(threadedCommentsXML, output)
+	output, _ = xml.Marshal(persons)
+	f.saveFileList(defaultXMLPathPersons, output)
+	return nil
+}
+
+// deleteThreadedComment provides a function to delete the threaded comments
+// and replies anchored at the given cell by given worksheet XML path.
+func (f *File) deleteThreadedComment(sheetXMLPath, cell string) error {
+	threadedCommentsXML := f.getSheetThreadedComments(filepath.Base(sheetXMLPath))
+	if threadedCommentsXML == "" {
+		return nil
+	}
+	tcs, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	threads := make(map[string]bool)
+	var threadedComments []xlsxThreadedComment
+	for _, tc := range tcs.ThreadedComment {
+		if (tc.ParentID == "" && tc.Ref == cell) || threads[tc.ParentID] {
+			threads[tc.ID] = true
+			continue
+		}
+		threadedComments = append(threadedComments, tc)
+	}
+	tcs.ThreadedComment = threadedComments
+	output, _ := xml.Marshal(tcs)
+	f.saveFileList(threadedCommentsXML, output)
 	return err
 }
 
+// formatThreadedCommentTime provides a function to format the time as the dT
+// attribute of the threaded comment, the current time will be used if the
+// given time is zero.
+func formatThreadedCommentTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now().UTC()
+	}
+	return t.Format("2006-01-02T15:04:05.00")
+}
+
+// addPersons provides a function to create the relationship and the content
+// type of the persons part of the workbook if not exist.
+func (f *File) addPersons() error {
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.Type == SourceRelationshipPerson {
+				rels.mu.Unlock()
+				return err
+			}
+		}
+		rels.mu.Unlock()
+	}
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, strings.TrimPrefix(defaultXMLPathPersons, "xl/"), "")
+	return f.addContentTypePart(0, "persons")
+}
+
+// getPersonID provides a function to get the ID of the person by given display
+// name, the person will be added if not exist.
+func (persons *xlsxPersonList) getPersonID(name string) string {
+	if name == "" {
+		name = "Author"
+	}
+	for _, person := range persons.Person {
+		if person.DisplayName == name {
+			return person.ID
+		}
+	}
+	person := xlsxPerson{
+		DisplayName: name,
+		ID:          fmt.Sprintf("{00000000-0000-0000-0000-%012X}", len(persons.Person)+1),
+		UserID:      name,
+		ProviderID:  "None",
+	}
+	persons.Person = append(persons.Person, person)
+	return person.ID
+}
+
+// getCommentLayout provides a function to get the anchor position and the
+// style of the comment box by given worksheet name and comment settings.
+func (f *File) getCommentLayout(sheet string, comment *Comment) (string, string, error) {
+	col, row, err := CellNameToCoordinates(comment.Cell)
+	if err != nil {
+		return "", "", err
+	}
+	yAxis, xAxis := col-1, row-1
+	var anchor string
+	width, height := getCommentSize(comment)
+	if width == 0 || height == 0 {
+		var rows, cols int
+		for _, runs := range comment.Runs {
+			for _, subStr := range strings.Split(runs.Text, "\n") {
+				rows++
+				if chars := len(subStr); chars > cols {
+					cols = chars
+				}
+			}
+		}
+		if len(comment.Runs) == 0 {
+			rows, cols = 1, len(comment.Text)
+		}
+		width, height = defaultCommentWidth, defaultCommentHeight
+		anchor = fmt.Sprintf("%d, 23, %d, 0, %d, %d, %d, 5",
+			1+yAxis, 1+xAxis, 3+yAxis+rows, cols+yAxis, 3+xAxis+rows)
+	} else {
+		colStart, rowStart, colEnd, rowEnd, x2, y2 := f.positionObjectPixels(sheet, 1+yAxis, 1+xAxis, 23, 0, width, height)
+		x1 := 23
+		for c := 1 + yAxis; c < colStart; c++ {
+			x1 -= f.getColWidth(sheet, c+1)
+		}
+		anchor = fmt.Sprintf("%d, %d, %d, 0, %d, %d, %d, %d", colStart, x1, rowStart, colEnd, x2, rowEnd, y2)
+	}
+	return anchor, fmt.Sprintf("position:absolute;73.5pt;width:%gpt;height:%gpt;z-index:1;visibility:hidden",
+		float64(width)*0.75, float64(height)*0.75), err
+}
+
+// getCommentSize provides a function to get the size of the comment box in
+// pixels by given comment settings. The size will be estimated by the text
+// length and font size if the AutoSize was set, and returns zero size if
+// neither the size nor the AutoSize was specified.
+func getCommentSize(comment *Comment) (int, int) {
+	width, height := int(comment.Width), int(comment.Height)
+	if !comment.AutoSize {
+		if width == 0 && height == 0 {
+			return 0, 0
+		}
+		if width == 0 {
+			width = defaultCommentWidth
+		}
+		if height == 0 {
+			height = defaultCommentHeight
+		}
+		return width, height
+	}
+	runs := comment.Runs
+	if comment.Text != "" {
+		runs = append([]RichTextRun{{Text: comment.Text}}, runs...)
+	}
+	// Estimate the average character width as 2/3 of the font size and the
+	// line height as 1.6 times of the font size in pixels.
+	const padding = 10
+	fontSize, lines := float64(defaultCommentFontSize), []float64{0}
+	for _, run := range runs {
+		size := float64(defaultCommentFontSize)
+		if run.Font != nil && run.Font.Size > 0 {
+			size = run.Font.Size
+		}
+		fontSize = math.Max(fontSize, size)
+		for i, text := range strings.Split(run.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, 0)
+			}
+			lines[len(lines)-1] += float64(utf8.RuneCountInString(text)) * size * 2 / 3
+		}
+	}
+	if width == 0 {
+		var textWidth float64
+		for _, lineWidth := range lines {
+			textWidth = math.Max(textWidth, lineWidth)
+		}
+		width = int(math.Ceil(math.Min(textWidth, maxCommentAutoSizeWidth-padding))) + padding
+	}
+	if height == 0 {
+		var lineCount float64
+		textWidth := math.Max(float64(width-padding), 1)
+		for _, lineWidth := range lines {
+			lineCount += math.Max(math.Ceil(lineWidth/textWidth), 1)
+		}
+		height = int(math.Ceil(lineCount*fontSize*1.6)) + padding
+	}
+	return width, height
+}
+
 // addDrawingVML provides a function to create comment as
-// xl/drawings/vmlDrawing%d.vml by given commit ID and cell.
-func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount, colCount int) error {
+// xl/drawings/vmlDrawing%d.vml by given commit ID, cell, anchor position and
+// the style of the comment box.
+func (f *File) addDrawingVML(commentID int, drawingVML, cell, anchor, style string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -224,10 +814,13 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		}
 		if d != nil {
 			for _, v := range d.Shape {
+				if v.Style == "" {
+					v.Style = "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden"
+				}
 				s := xlsxShape{
 					ID:          "_x0000_s1025",
 					Type:        "#_x0000_t202",
-					Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+					Style:       v.Style,
 					Fillcolor:   "#FBF6D6",
 					Strokecolor: "#EDEAA1",
 					Val:         v.Val,
@@ -262,19 +855,17 @@ func (f *File) addDrawingVML(commentID int, drawingVML, cell string, lineCount,
 		},
 		ClientData: &xClientData{
 			ObjectType: "Note",
-			Anchor: fmt.Sprintf(
-				"%d, 23, %d, 0, %d, %d, %d, 5",
-				1+yAxis, 1+xAxis, 2+yAxis+lineCount, colCount+yAxis, 2+xAxis+lineCount),
-			AutoFill: "True",
-			Row:      xAxis,
-			Column:   yAxis,
+			Anchor:     anchor,
+			AutoFill:   "True",
+			Row:        xAxis,
+			Column:     yAxis,
 		},
 	}
 	s, _ := xml.Marshal(sp)
 	shape := xlsxShape{
 		ID:          "_x0000_s1025",
 		Type:        "#_x0000_t202",
-		Style:       "position:absolute;73.5pt;width:108pt;height:59.25pt;z-index:1;visibility:hidden",
+		Style:       style,
 		Fillcolor:   "#FBF6D6",
 		Strokecolor: "#EDEAA1",
 		Val:         string(s[13 : len(s)-14]),
@@ -374,6 +965,41 @@ func (f *File) countComments() int {
 	return c1
 }
 
+// countThreadedComments provides a function to get threaded comments files
+// count storage in the folder xl/threadedComments.
+func (f *File) countThreadedComments() int {
+	count := 0
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.Contains(k.(string), "xl/threadedComments/threadedComment") {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(path string) (*xlsxThreadedComments, error) {
+	var tcs xlsxThreadedComments
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+		Decode(&tcs); err != nil && err != io.EOF {
+		return &tcs, err
+	}
+	return &tcs, nil
+}
+
+// personsReader provides a function to get the pointer to the structure after
+// deserialization of xl/persons/person.xml.
+func (f *File) personsReader() (*xlsxPersonList, error) {
+	var persons xlsxPersonList
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathPersons)))).
+		Decode(&persons); err != nil && err != io.EOF {
+		return &persons, err
+	}
+	return &persons, nil
+}
+
 // decodeVMLDrawingReader provides a function to get the pointer to the
 // structure after deserialization of xl/drawings/vmlDrawing%d.xml.
 func (f *File) decodeVMLDrawingReader(path string) (*decodeVmlDrawing, error) {
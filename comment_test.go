@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -79,6 +80,101 @@ func TestAddComment(t *testing.T) {
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
 
+func TestGetCommentsAnchor(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B7", Author: "Excelize", Text: "Comment"}))
+	expected := &CommentAnchor{LeftColumn: 2, LeftOffset: 23, TopRow: 7, RightColumn: 5, RightOffset: 8, BottomRow: 10, BottomOffset: 5}
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, expected, comments[0].Anchor)
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B7", comments[0].Cell)
+	assert.Equal(t, expected, comments[0].Anchor)
+	// Test get comments without the comment shape in the VML drawing part
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", []byte(`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:x="urn:schemas-microsoft-com:office:excel"><v:shape><x:ClientData ObjectType="Drop"><x:Anchor>1, 0, 1, 0, 2, 0, 2, 0</x:Anchor><x:Row>6</x:Row><x:Column>1</x:Column></x:ClientData></v:shape><v:shape><x:ClientData ObjectType="Note"><x:Anchor>1, 0</x:Anchor><x:Row>6</x:Row><x:Column>1</x:Column></x:ClientData></v:shape></xml>`))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Nil(t, comments[0].Anchor)
+	// Test get comments with invalid shape in the VML drawing part
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = &decodeVmlDrawing{Shape: []decodeShape{{Val: "<x:ClientData>"}}}
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <ClientData> closed by </shape>")
+	// Test get comments with unsupported charset VML drawing part
+	f.DecodeVMLDrawing["xl/drawings/vmlDrawing1.vml"] = nil
+	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCommentSize(t *testing.T) {
+	f := NewFile()
+	for _, comment := range []Comment{
+		{Cell: "A1", Text: "Comment"},
+		{Cell: "A3", Text: "Comment", Width: 200},
+		{Cell: "B7", Text: "Comment", Width: 200, Height: 100},
+		{Cell: "A10", Text: "This is a comment.", AutoSize: true},
+		{Cell: "A20", Text: strings.Repeat("a", 100), AutoSize: true},
+		{Cell: "A30", Text: "This is a comment.", Width: 100, AutoSize: true},
+		{Cell: "A40", Runs: []RichTextRun{{Text: "Excelize: ", Font: &Font{Bold: true, Size: 12}}, {Text: "\nThis is a comment."}}, AutoSize: true},
+	} {
+		assert.NoError(t, f.AddComment("Sheet1", comment))
+	}
+	expected := map[string][]uint{
+		"A1": {144, 79}, "A3": {200, 79}, "B7": {200, 100}, "A10": {118, 25},
+		"A20": {400, 39}, "A30": {100, 39}, "A40": {118, 49},
+	}
+	check := func(f *File) {
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, comments, len(expected))
+		for _, comment := range comments {
+			assert.Equal(t, expected[comment.Cell], []uint{comment.Width, comment.Height}, comment.Cell)
+			if comment.Cell == "B7" {
+				assert.Equal(t, &CommentAnchor{LeftColumn: 2, LeftOffset: 23, TopRow: 7, RightColumn: 5, RightOffset: 31, BottomRow: 12, BottomOffset: 10}, comment.Anchor)
+			}
+		}
+	}
+	check(f)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCommentSize.xlsx")))
+	f, err := OpenFile(filepath.Join("test", "TestCommentSize.xlsx"))
+	assert.NoError(t, err)
+	check(f)
+	// Test add comment after reopen keeps the size of the exists comments
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A50", Text: "Comment", Height: 50}))
+	expected["A50"] = []uint{144, 50}
+	check(f)
+	assert.NoError(t, f.Close())
+	// Test add comment with offset greater than the column width
+	f = NewFile()
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "B", 2))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Comment", Width: 100, Height: 40}))
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, comments[0].Anchor.LeftColumn)
+	assert.Equal(t, 3, comments[0].Anchor.LeftOffset)
+	// Test parse VML shape size
+	for style, size := range map[string][]uint{
+		"width:96px;height:48px":   {96, 48},
+		"width:72pt;height:36pt":   {96, 48},
+		"width:auto;height:-1pt;x": {0, 0},
+	} {
+		width, height := parseVMLShapeSize(style)
+		assert.Equal(t, size, []uint{width, height}, style)
+	}
+}
+
 func TestDeleteComment(t *testing.T) {
 	f, err := prepareTestBook1()
 	if !assert.NoError(t, err) {
@@ -122,6 +218,97 @@ func TestDeleteComment(t *testing.T) {
 	assert.EqualError(t, f.DeleteComment("Sheet2", "A41"), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestThreadedComment(t *testing.T) {
+	f := NewFile()
+	created := time.Date(2023, 5, 10, 8, 12, 34, 0, time.UTC)
+	replied := created.Add(time.Hour)
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Please check this value.", Threaded: true, Resolved: boolPtr(true), Created: created,
+		Replies: []CommentReply{{Author: "Reviewer", Text: "Confirmed.", Created: replied}},
+	}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Runs: []RichTextRun{{Text: "Open "}, {Text: "thread"}}, Threaded: true, Created: created}))
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Author: "Excelize", Text: "Legacy note"}))
+	threadedComments, ok := f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(threadedComments.([]byte)), `<threadedComment ref="A1" dT="2023-05-10T08:12:34.00" personId="{00000000-0000-0000-0000-000000000001}" id="{00000001-0000-0000-0000-000000000001}" done="true"><text>Please check this value.</text></threadedComment>`)
+	assert.Contains(t, string(threadedComments.([]byte)), `parentId="{00000001-0000-0000-0000-000000000001}"`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestThreadedComment.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err := OpenFile(filepath.Join("test", "TestThreadedComment.xlsx"))
+	assert.NoError(t, err)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.True(t, comments[0].Threaded)
+	assert.Equal(t, "Excelize", comments[0].Author)
+	assert.Equal(t, "Please check this value.", comments[0].Text)
+	assert.Equal(t, boolPtr(true), comments[0].Resolved)
+	assert.Equal(t, created, comments[0].Created)
+	assert.Equal(t, []CommentReply{{Author: "Reviewer", Text: "Confirmed.", Created: replied}}, comments[0].Replies)
+	assert.Equal(t, "Author", comments[1].Author)
+	assert.Equal(t, "Open thread", comments[1].Text)
+	assert.Nil(t, comments[1].Runs)
+	assert.Equal(t, boolPtr(false), comments[1].Resolved)
+	// Test get resolved state of the legacy note
+	assert.False(t, comments[2].Threaded)
+	assert.Nil(t, comments[2].Resolved)
+	assert.True(t, comments[2].Created.IsZero())
+	// Test add threaded comment to the worksheet with existing threaded comments
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "D4", Author: "Reviewer", Text: "New thread", Threaded: true}))
+	// Test delete threaded comment with replies
+	assert.NoError(t, f.DeleteComment("Sheet1", "A1"))
+	comments, err = f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, "B2", comments[0].Cell)
+	assert.Equal(t, "D4", comments[2].Cell)
+	assert.Equal(t, "Reviewer", comments[2].Author)
+	assert.False(t, comments[2].Created.IsZero())
+	threadedComments, ok = f.Pkg.Load("xl/threadedComments/threadedComment1.xml")
+	assert.True(t, ok)
+	assert.NotContains(t, string(threadedComments.([]byte)), "Confirmed.")
+	// Test add threaded comment with invalid cell reference
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "A", Threaded: true}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get threaded comments with unsupported charset
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.DeleteComment("Sheet1", "B2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "E5", Threaded: true}), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", []byte(xml.Header+`<ThreadedComments xmlns="http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments"/>`))
+	f.Pkg.Store(defaultXMLPathPersons, MacintoshCyrillicCharset)
+	_, err = f.GetComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "E5", Threaded: true}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	// Test add threaded comment with unsupported charset workbook relationships
+	f = NewFile()
+	f.Relationships.Delete(defaultXMLPathWorkbookRels)
+	f.Pkg.Store(defaultXMLPathWorkbookRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Threaded: true}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset content types
+	f = NewFile()
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Threaded: true}), "XML syntax error on line 1: invalid UTF-8")
+	// Test add threaded comment with unsupported charset comments part will
+	// not create the threaded comments and persons parts
+	f = NewFile()
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A1", Text: "Note"}))
+	delete(f.Comments, "xl/comments1.xml")
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddComment("Sheet1", Comment{Cell: "B2", Text: "Thread", Threaded: true}), "XML syntax error on line 1: invalid UTF-8")
+	for _, path := range []string{"xl/threadedComments/threadedComment1.xml", defaultXMLPathPersons} {
+		_, ok := f.Pkg.Load(path)
+		assert.False(t, ok, path)
+	}
+	sheetRels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range sheetRels.Relationships {
+		assert.NotEqual(t, SourceRelationshipThreadedComment, rel.Type)
+	}
+}
+
 func TestDecodeVMLDrawingReader(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/vmlDrawing1.xml"
@@ -144,3 +331,121 @@ func TestCountComments(t *testing.T) {
 	f.Comments["xl/comments1.xml"] = nil
 	assert.Equal(t, f.countComments(), 1)
 }
+
+func TestNotesToHyperlinks(t *testing.T) {
+	f := NewFile()
+	for _, comment := range []Comment{
+		{Cell: "A1", Text: " https://github.com/xuri/excelize "},
+		{Cell: "A2", Author: "Excelize", Runs: []RichTextRun{{Text: "Excelize:", Font: &Font{Bold: true}}, {Text: "\nftp://example.com/file.txt"}}},
+		{Cell: "A3", Text: "See https://github.com/xuri/excelize"},
+		{Cell: "A4", Text: "https://github.com/xuri/excelize"},
+		{Cell: "A5", Text: "javascript:alert(1)"},
+		{Cell: "A6", Text: "https://github.com/xuri/excelize", Threaded: true},
+	} {
+		assert.NoError(t, f.AddComment("Sheet1", comment))
+	}
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A4", "https://example.com", "External"))
+	count, err := f.NotesToHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	for cell, expected := range map[string]string{
+		"A1": "https://github.com/xuri/excelize",
+		"A2": "ftp://example.com/file.txt",
+		"A4": "https://example.com",
+	} {
+		ok, link, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, link)
+	}
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	var cells []string
+	for _, comment := range comments {
+		cells = append(cells, comment.Cell)
+	}
+	assert.Equal(t, []string{"A3", "A4", "A5", "A6"}, cells)
+	// Test convert notes to hyperlinks again without URL-only notes
+	count, err = f.NotesToHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	// Test convert notes to hyperlinks on not exists worksheet
+	_, err = f.NotesToHyperlinks("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test convert notes to hyperlinks with unsupported charset comments
+	f.Comments["xl/comments1.xml"] = nil
+	f.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	_, err = f.NotesToHyperlinks("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestHyperlinksToNotes(t *testing.T) {
+	f := NewFile()
+	for cell, link := range map[string][]string{
+		"A1": {"https://github.com/xuri/excelize", "External"},
+		"A2": {"ftp://example.com/file.txt", "External"},
+		"A3": {"Sheet1!A1", "Location"},
+		"A4": {"mailto:xuri.me@gmail.com", "External"},
+		"A5": {"https://example.com", "External"},
+	} {
+		assert.NoError(t, f.SetCellHyperLink("Sheet1", cell, link[0], link[1]))
+	}
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "A5", Text: "Note"}))
+	count, err := f.HyperlinksToNotes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	for cell, expected := range map[string]string{"A3": "Sheet1!A1", "A4": "mailto:xuri.me@gmail.com", "A5": "https://example.com"} {
+		ok, link, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, expected, link)
+	}
+	for _, cell := range []string{"A1", "A2"} {
+		ok, _, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	}
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	notes := make(map[string]string)
+	for _, comment := range comments {
+		notes[comment.Cell] = comment.Text
+	}
+	assert.Equal(t, map[string]string{
+		"A1": "https://github.com/xuri/excelize", "A2": "ftp://example.com/file.txt", "A5": "Note",
+	}, notes)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotContains(t, []string{"https://github.com/xuri/excelize", "ftp://example.com/file.txt"}, rel.Target)
+	}
+	// Test convert the notes back to the hyperlinks
+	count, err = f.NotesToHyperlinks("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	// Test convert hyperlinks to notes without hyperlinks
+	f = NewFile()
+	count, err = f.HyperlinksToNotes("Sheet1")
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	count, err = f.HyperlinksToNotes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Nil(t, ws.Hyperlinks)
+	// Test convert hyperlinks to notes on not exists worksheet
+	_, err = f.HyperlinksToNotes("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test convert hyperlinks to notes with unsupported charset content types
+	f = NewFile()
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	_, err = f.HyperlinksToNotes("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	ok, _, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -16,6 +16,7 @@ import (
 	"encoding/xml"
 	"io"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -62,13 +63,14 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		Date1904:       &attrValBool{Val: boolPtr(false)},
 		Lang:           &attrValString{Val: stringPtr("en-US")},
 		RoundedCorners: &attrValBool{Val: boolPtr(false)},
+		PivotSource:    opts.pivotSource,
 		Chart: cChart{
 			Title: &cTitle{
 				Tx: cTx{
 					Rich: &cRich{
 						P: aP{
 							PPr: &aPPr{
-								DefRPr: aRPr{
+								DefRPr: &aRPr{
 									Kern:   1200,
 									Strike: "noStrike",
 									U:      "none",
@@ -108,7 +110,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 				TxPr: cTxPr{
 					P: aP{
 						PPr: &aPPr{
-							DefRPr: aRPr{
+							DefRPr: &aRPr{
 								Kern:   1200,
 								U:      "none",
 								Sz:     14000,
@@ -139,8 +141,9 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			},
 			PlotArea: &cPlotArea{},
 			Legend: &cLegend{
-				LegendPos: &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
-				Overlay:   &attrValBool{Val: boolPtr(false)},
+				LegendPos:   &attrValString{Val: stringPtr(chartLegendPosition[opts.Legend.Position])},
+				LegendEntry: getChartLegendEntries(opts.Legend.HiddenEntries),
+				Overlay:     &attrValBool{Val: boolPtr(opts.Legend.Overlay)},
 			},
 
 			PlotVisOnly:      &attrValBool{Val: boolPtr(false)},
@@ -180,7 +183,278 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 			},
 		},
 	}
-	plotAreaFunc := map[ChartType]func(*Chart) *cPlotArea{
+	plotAreaFunc := f.getPlotAreaFuncs()
+	if opts.Legend.Position == "none" {
+		xlsxChartSpace.Chart.Legend = nil
+	}
+	if opts.Title.Auto || opts.Title.Hidden {
+		xlsxChartSpace.Chart.Title = nil
+		xlsxChartSpace.Chart.AutoTitleDeleted = &cAutoTitleDeleted{Val: opts.Title.Hidden}
+	}
+	addChart := func(c, p *cPlotArea) {
+		immutable, mutable := reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem()
+		for i := 0; i < mutable.NumField(); i++ {
+			field := mutable.Field(i)
+			if field.IsNil() {
+				continue
+			}
+			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
+		}
+	}
+	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[opts.Type](opts))
+	order := len(opts.Series)
+	for idx := range comboCharts {
+		comboCharts[idx].order = order
+		plotArea := plotAreaFunc[comboCharts[idx].Type](comboCharts[idx])
+		if comboCharts[idx].YAxis.Secondary {
+			drawPlotAreaSecondaryAxis(xlsxChartSpace.Chart.PlotArea, plotArea)
+		}
+		addChart(xlsxChartSpace.Chart.PlotArea, plotArea)
+		order += len(comboCharts[idx].Series)
+	}
+	if opts.PlotArea.ShowDataTable && len(xlsxChartSpace.Chart.PlotArea.CatAx) > 0 {
+		xlsxChartSpace.Chart.PlotArea.DTable = drawChartDataTable(opts)
+	}
+	chart, _ := xml.Marshal(xlsxChartSpace)
+	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
+	f.saveFileList(media, chart)
+}
+
+// drawChartDataTable provides a function to draw the data table beneath the
+// plot area of the chart by given format sets.
+func drawChartDataTable(opts *Chart) *cDTable {
+	return &cDTable{
+		ShowHorzBorder: &attrValBool{Val: boolPtr(!opts.PlotArea.DataTable.HideHorzBorder)},
+		ShowVertBorder: &attrValBool{Val: boolPtr(!opts.PlotArea.DataTable.HideVertBorder)},
+		ShowOutline:    &attrValBool{Val: boolPtr(!opts.PlotArea.DataTable.HideOutline)},
+		ShowKeys:       &attrValBool{Val: boolPtr(opts.PlotArea.DataTable.ShowLegendKeys)},
+	}
+}
+
+// getChartLegendEntries provides a function to get the legend entries to be
+// deleted from the chart legend by given zero-based indices of the legend
+// entries, the duplicate indices will be ignored.
+func getChartLegendEntries(hiddenEntries []int) []cLegendEntry {
+	var entries []cLegendEntry
+	indices := make(map[int]struct{}, len(hiddenEntries))
+	for _, idx := range hiddenEntries {
+		if _, ok := indices[idx]; ok {
+			continue
+		}
+		indices[idx] = struct{}{}
+		entries = append(entries, cLegendEntry{Idx: &attrValInt{Val: intPtr(idx)}, Delete: &attrValBool{Val: boolPtr(true)}})
+	}
+	sort.Slice(entries, func(i, j int) bool { return *entries[i].Idx.Val < *entries[j].Idx.Val })
+	return entries
+}
+
+// addChartEx provides a function to create the extended chart part as
+// xl/charts/chartEx%d.xml by given chart index and format sets, only the
+// first series will be used.
+func (f *File) addChartEx(chartID int, opts *Chart) {
+	chartSpace := xlsxChartExSpace{
+		XMLNSa: NameSpaceDrawingML.Value,
+		XMLNSr: SourceRelationship.Value,
+	}
+	if name := strings.TrimSpace(opts.Title.Name); name != "" && !opts.Title.Auto && !opts.Title.Hidden {
+		chartSpace.Chart.Title = &cxTitle{Pos: "t", Align: "ctr", Tx: cxTx{TxData: cxTxData{V: name}}}
+	}
+	if pos, ok := map[string]string{
+		"bottom": "b", "left": "l", "right": "r", "top": "t", "top_right": "r",
+	}[opts.Legend.Position]; ok {
+		chartSpace.Chart.Legend = &cxLegend{Pos: pos, Align: "ctr", Overlay: opts.Legend.Overlay}
+	}
+	if len(opts.Series) > 0 {
+		series := opts.Series[0]
+		data := cxData{
+			StrDim: &cxStrDim{Type: "cat", F: series.Categories, Lvl: f.getChartExDimLevels(series.Categories, false)},
+			NumDim: &cxNumDim{Type: "size", F: series.Values, Lvl: f.getChartExDimLevels(series.Values, true)},
+		}
+		ser := cxSeries{
+			LayoutID:   chartExLayoutIDs[opts.Type],
+			UniqueID:   "{00000000-0000-0000-0000-000000000001}",
+			DataLabels: &cxDataLabels{Pos: "ctr", Visibility: &cxVisibility{CategoryName: true}},
+			DataID:     attrValInt{Val: intPtr(0)},
+		}
+		if series.Name != "" {
+			ser.Tx = &cxTx{TxData: cxTxData{F: series.Name}}
+			if lvl := f.getChartExDimLevels(series.Name, false); len(lvl) > 0 && len(lvl[0].Pt) > 0 {
+				ser.Tx.TxData.V = lvl[0].Pt[0].V
+			}
+		}
+		switch opts.Type {
+		case Treemap:
+			ser.DataLabels.Pos = "inEnd"
+			ser.LayoutPr = &cxLayoutPr{ParentLabelLayout: &attrValString{Val: stringPtr("overlapping")}}
+		case Waterfall:
+			data.NumDim.Type = "val"
+			ser.DataPt = drawChartExWaterfallDataPt(series, data.NumDim.Lvl)
+			ser.DataLabels = &cxDataLabels{Pos: "outEnd", Visibility: &cxVisibility{Value: true}}
+			ser.LayoutPr = &cxLayoutPr{Subtotals: &cxSubtotals{}}
+			for _, idx := range series.SubTotals {
+				ser.LayoutPr.Subtotals.Idx = append(ser.LayoutPr.Subtotals.Idx, attrValInt{Val: intPtr(idx)})
+			}
+			chartSpace.Chart.PlotArea.Axis = []cxAxis{
+				{ID: 0, CatScaling: &cxCatScaling{GapWidth: "0.5"}, TickLabels: &cxTickLabels{}},
+				{ID: 1, ValScaling: &cxValScaling{}, MajorGridlines: &cxGridlines{}, TickLabels: &cxTickLabels{}},
+			}
+		}
+		if opts.PlotArea.ShowCatName || opts.PlotArea.ShowSerName || opts.PlotArea.ShowVal {
+			ser.DataLabels.Visibility = &cxVisibility{
+				SeriesName:   opts.PlotArea.ShowSerName,
+				CategoryName: opts.PlotArea.ShowCatName,
+				Value:        opts.PlotArea.ShowVal,
+			}
+		}
+		chartSpace.ChartData.Data = []cxData{data}
+		chartSpace.Chart.PlotArea.PlotAreaRegion.Series = []cxSeries{ser}
+	}
+	chart, _ := xml.Marshal(chartSpace)
+	f.saveFileList("xl/charts/chartEx"+strconv.Itoa(chartID)+".xml", chart)
+}
+
+// drawChartExWaterfallDataPt provides a function to draw the data points of
+// the waterfall chart series by given series and the cached levels of the
+// series values. The increase, decrease and total columns will be filled with
+// the accent 1, 2 and 3 color of the theme, and the color of each column
+// could be overridden by the data points settings of the series.
+func drawChartExWaterfallDataPt(series ChartSeries, levels []cxLvl) []cxDataPt {
+	var dPts []cxDataPt
+	if len(levels) == 0 {
+		return dPts
+	}
+	values := make(map[int]string, len(levels[0].Pt))
+	for _, pt := range levels[0].Pt {
+		values[pt.Idx] = pt.V
+	}
+	points, subTotals := make(map[int]ChartDataPoint), make(map[int]bool)
+	for _, point := range getChartDataPoints(series) {
+		points[point.Index] = point
+	}
+	for _, idx := range series.SubTotals {
+		subTotals[idx] = true
+	}
+	for idx := 0; idx < levels[0].PtCount; idx++ {
+		accent := "accent1"
+		if val, err := strconv.ParseFloat(values[idx], 64); err == nil && val < 0 {
+			accent = "accent2"
+		}
+		if subTotals[idx] {
+			accent = "accent3"
+		}
+		dPt := cxDataPt{Idx: idx, SpPr: &cSpPr{SolidFill: &aSolidFill{SchemeClr: &aSchemeClr{Val: accent}}}}
+		if point, ok := points[idx]; ok {
+			if len(point.Fill.Color) == 1 {
+				dPt.SpPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(point.Fill.Color[0], "#"))}}
+			}
+			if point.BorderColor != "" || point.BorderWidth > 0 {
+				dPt.SpPr.Ln = &aLn{W: int(point.BorderWidth * 12700)}
+				if point.BorderColor != "" {
+					dPt.SpPr.Ln.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(point.BorderColor, "#"))}}
+				}
+			}
+		}
+		dPts = append(dPts, dPt)
+	}
+	return dPts
+}
+
+// getChartExDimLevels provides a function to get the cached values of the
+// extended chart data dimension by given reference. For the string
+// dimension, each column of the reference range will be a level, which
+// ordered from the leaf level in the last column to the root level in the
+// first column. For the numeric dimension, all cells of the reference range
+// will be a single level. The empty cells will be skipped, and nil will be
+// returned if the reference is invalid.
+func (f *File) getChartExDimLevels(ref string, numeric bool) []cxLvl {
+	idx := strings.LastIndex(ref, "!")
+	if idx == -1 {
+		return nil
+	}
+	sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+	rng := ref[idx+1:]
+	if !strings.Contains(rng, ":") {
+		rng += ":" + rng
+	}
+	coordinates, err := rangeRefToCoordinates(rng)
+	if err != nil {
+		return nil
+	}
+	_ = sortCoordinates(coordinates)
+	var cells [][]string
+	if numeric {
+		cells = append(cells, nil)
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			for col := coordinates[0]; col <= coordinates[2]; col++ {
+				cell, _ := CoordinatesToCellName(col, row)
+				cells[0] = append(cells[0], cell)
+			}
+		}
+	}
+	for col := coordinates[2]; !numeric && col >= coordinates[0]; col-- {
+		var column []string
+		for row := coordinates[1]; row <= coordinates[3]; row++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			column = append(column, cell)
+		}
+		cells = append(cells, column)
+	}
+	var levels []cxLvl
+	for _, column := range cells {
+		lvl := cxLvl{PtCount: len(column)}
+		if numeric {
+			lvl.FormatCode = "General"
+		}
+		for i, cell := range column {
+			val, err := f.GetCellValue(sheet, cell, Options{RawCellValue: true})
+			if err != nil {
+				return nil
+			}
+			if val != "" {
+				lvl.Pt = append(lvl.Pt, cxPt{Idx: i, V: val})
+			}
+		}
+		levels = append(levels, lvl)
+	}
+	return levels
+}
+
+// drawPlotAreaSecondaryAxis provides a function to move the chart groups of
+// the given combo chart plot area onto the secondary axes, the horizontal
+// secondary axis will be hidden and the vertical secondary axis will be placed
+// at the right side of the main plot area.
+func drawPlotAreaSecondaryAxis(main, combo *cPlotArea) {
+	if len(combo.CatAx) == 0 || len(combo.ValAx) == 0 {
+		return
+	}
+	catAxID, valAxID := 754001154, 753999906
+	immutable := reflect.ValueOf(combo).Elem()
+	for i := 0; i < immutable.NumField(); i++ {
+		if charts, ok := immutable.Field(i).Interface().(*cCharts); ok && charts != nil && len(charts.AxID) == 2 {
+			charts.AxID = []*attrValInt{{Val: intPtr(catAxID)}, {Val: intPtr(valAxID)}}
+		}
+	}
+	catAx, valAx := combo.CatAx[0], combo.ValAx[0]
+	catAx.AxID, catAx.CrossAx = &attrValInt{Val: intPtr(catAxID)}, &attrValInt{Val: intPtr(valAxID)}
+	catAx.Delete = &attrValBool{Val: boolPtr(true)}
+	valAx.AxID, valAx.CrossAx = &attrValInt{Val: intPtr(valAxID)}, &attrValInt{Val: intPtr(catAxID)}
+	valAx.AxPos = &attrValString{Val: stringPtr("r")}
+	valAx.Crosses = &attrValString{Val: stringPtr("max")}
+	valAx.MajorGridlines, valAx.MinorGridlines = nil, nil
+	combo.CatAx, combo.ValAx = main.CatAx, main.ValAx
+	for _, ax := range main.CatAx {
+		if *ax.AxID.Val == catAxID {
+			return
+		}
+	}
+	combo.CatAx = append(main.CatAx, catAx)
+	combo.ValAx = append(main.ValAx, valAx)
+}
+
+// getPlotAreaFuncs provides a function to get the functions to draw the
+// c:plotArea element for each supported chart types.
+func (f *File) getPlotAreaFuncs() map[ChartType]func(*Chart) *cPlotArea {
+	return map[ChartType]func(*Chart) *cPlotArea{
 		Area:                        f.drawBaseChart,
 		AreaStacked:                 f.drawBaseChart,
 		AreaPercentStacked:          f.drawBaseChart,
@@ -237,29 +511,6 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		Bubble:                      f.drawBubbleChart,
 		Bubble3D:                    f.drawBubbleChart,
 	}
-	if opts.Legend.Position == "none" {
-		xlsxChartSpace.Chart.Legend = nil
-	}
-	addChart := func(c, p *cPlotArea) {
-		immutable, mutable := reflect.ValueOf(c).Elem(), reflect.ValueOf(p).Elem()
-		for i := 0; i < mutable.NumField(); i++ {
-			field := mutable.Field(i)
-			if field.IsNil() {
-				continue
-			}
-			immutable.FieldByName(mutable.Type().Field(i).Name).Set(field)
-		}
-	}
-	addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[opts.Type](opts))
-	order := len(opts.Series)
-	for idx := range comboCharts {
-		comboCharts[idx].order = order
-		addChart(xlsxChartSpace.Chart.PlotArea, plotAreaFunc[comboCharts[idx].Type](comboCharts[idx]))
-		order += len(comboCharts[idx].Series)
-	}
-	chart, _ := xml.Marshal(xlsxChartSpace)
-	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
-	f.saveFileList(media, chart)
 }
 
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
@@ -505,7 +756,16 @@ func (f *File) drawBaseChart(opts *Chart) *cPlotArea {
 			ValAx:       valAx,
 		},
 	}
-	return charts[opts.Type]
+	plotArea := charts[opts.Type]
+	if plotArea != nil && (plotArea.BarChart != nil || plotArea.Bar3DChart != nil) {
+		if opts.GapWidth != nil {
+			c.GapWidth = &attrValInt{Val: intPtr(*opts.GapWidth)}
+		}
+		if opts.Overlap != nil && plotArea.BarChart != nil {
+			c.Overlap = &attrValInt{Val: intPtr(*opts.Overlap)}
+		}
+	}
+	return plotArea
 }
 
 // drawDoughnutChart provides a function to draw the c:plotArea element for
@@ -801,8 +1061,10 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 			SpPr:             f.drawChartSeriesSpPr(k, opts),
 			Marker:           f.drawChartSeriesMarker(k, opts),
 			DPt:              f.drawChartSeriesDPt(k, opts),
-			DLbls:            f.drawChartSeriesDLbls(opts),
+			DLbls:            f.drawChartSeriesDLbls(k, opts),
 			InvertIfNegative: &attrValBool{Val: boolPtr(false)},
+			Trendline:        f.drawChartSeriesTrendline(opts.Series[k]),
+			ErrBars:          f.drawChartSeriesErrBars(opts.Series[k], opts),
 			Cat:              f.drawChartSeriesCat(opts.Series[k], opts),
 			Smooth:           &attrValBool{Val: boolPtr(opts.Series[k].Line.Smooth)},
 			Val:              f.drawChartSeriesVal(opts.Series[k], opts),
@@ -815,6 +1077,64 @@ func (f *File) drawChartSeries(opts *Chart) *[]cSer {
 	return &ser
 }
 
+// drawChartSeriesTrendline provides a function to draw the c:trendline element
+// by given data series format sets.
+func (f *File) drawChartSeriesTrendline(series ChartSeries) *cTrendline {
+	opts := series.Trendline
+	if opts.Type == "" {
+		return nil
+	}
+	trendline := &cTrendline{TrendlineType: &attrValString{Val: stringPtr(opts.Type)}}
+	if opts.Type == "poly" {
+		trendline.Order = &attrValInt{Val: intPtr(opts.Order)}
+	}
+	if opts.Type == "movingAvg" {
+		trendline.Period = &attrValInt{Val: intPtr(opts.Period)}
+	} else {
+		if opts.Forward > 0 {
+			trendline.Forward = &attrValFloat{Val: float64Ptr(opts.Forward)}
+		}
+		if opts.Backward > 0 {
+			trendline.Backward = &attrValFloat{Val: float64Ptr(opts.Backward)}
+		}
+		trendline.DispRSqr = &attrValBool{Val: boolPtr(opts.DisplayRSquared)}
+		trendline.DispEq = &attrValBool{Val: boolPtr(opts.DisplayEquation)}
+	}
+	return trendline
+}
+
+// drawChartSeriesErrBars provides a function to draw the c:errBars element by
+// given data series and format sets.
+func (f *File) drawChartSeriesErrBars(series ChartSeries, opts *Chart) *cErrBars {
+	errorBars := series.ErrorBars
+	if errorBars.Type == "" {
+		return nil
+	}
+	if errorBars.Direction == "" {
+		errorBars.Direction = "both"
+	}
+	errBars := &cErrBars{
+		ErrBarType: &attrValString{Val: stringPtr(errorBars.Direction)},
+		ErrValType: &attrValString{Val: stringPtr(errorBars.Type)},
+		NoEndCap:   &attrValBool{Val: boolPtr(errorBars.NoEndCap)},
+	}
+	if _, ok := map[ChartType]bool{Scatter: true, Bubble: true, Bubble3D: true}[opts.Type]; ok {
+		errBars.ErrDir = &attrValString{Val: stringPtr("y")}
+	}
+	switch errorBars.Type {
+	case "cust":
+		if errorBars.Plus != "" {
+			errBars.Plus = &cVal{NumRef: &cNumRef{F: errorBars.Plus}}
+		}
+		if errorBars.Minus != "" {
+			errBars.Minus = &cVal{NumRef: &cNumRef{F: errorBars.Minus}}
+		}
+	case "fixedVal", "percentage", "stdDev":
+		errBars.Val = &attrValFloat{Val: float64Ptr(errorBars.Value)}
+	}
+	return errBars
+}
+
 // drawChartSeriesSpPr provides a function to draw the c:spPr element by given
 // format sets.
 func (f *File) drawChartSeriesSpPr(i int, opts *Chart) *cSpPr {
@@ -881,7 +1201,61 @@ func (f *File) drawChartSeriesDPt(i int, opts *Chart) []*cDPt {
 		},
 	}}
 	chartSeriesDPt := map[ChartType][]*cDPt{Pie: dpt, Pie3D: dpt}
-	return chartSeriesDPt[opts.Type]
+	dPts := chartSeriesDPt[opts.Type]
+	for _, point := range getChartDataPoints(opts.Series[i]) {
+		if len(point.Fill.Color) != 1 && point.BorderColor == "" && point.BorderWidth <= 0 {
+			continue
+		}
+		dPt := &cDPt{
+			IDx:      &attrValInt{Val: intPtr(point.Index)},
+			Bubble3D: &attrValBool{Val: boolPtr(false)},
+			SpPr:     &cSpPr{},
+		}
+		if len(point.Fill.Color) == 1 {
+			dPt.SpPr.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(point.Fill.Color[0], "#"))}}
+		}
+		if point.BorderColor != "" || point.BorderWidth > 0 {
+			dPt.SpPr.Ln = &aLn{W: int(point.BorderWidth * 12700)}
+			if point.BorderColor != "" {
+				dPt.SpPr.Ln.SolidFill = &aSolidFill{SrgbClr: &attrValString{Val: stringPtr(strings.TrimPrefix(point.BorderColor, "#"))}}
+			}
+		}
+		for idx := 0; idx < len(dPts); idx++ {
+			if *dPts[idx].IDx.Val == point.Index {
+				dPts = append(dPts[:idx], dPts[idx+1:]...)
+				idx--
+			}
+		}
+		dPts = append(dPts, dPt)
+	}
+	return dPts
+}
+
+// getChartDataPoints provides a function to get the data points settings of
+// the chart series, the data points with the index beyond the length of the
+// series values will be ignored.
+func getChartDataPoints(series ChartSeries) []ChartDataPoint {
+	length := getChartSeriesValuesCount(series)
+	var points []ChartDataPoint
+	for _, point := range series.DataPoints {
+		if point.Index < 0 || (length != -1 && point.Index >= length) {
+			continue
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+// getChartSeriesValuesCount provides a function to get the count of the
+// values in the chart series by the reference range, -1 will be returned if
+// the reference range is invalid.
+func getChartSeriesValuesCount(series ChartSeries) int {
+	if ref := strings.Split(series.Values, "!"); len(ref) > 0 {
+		if coordinates, err := rangeRefToCoordinates(ref[len(ref)-1]); err == nil {
+			return (coordinates[2] - coordinates[0] + 1) * (coordinates[3] - coordinates[1] + 1)
+		}
+	}
+	return -1
 }
 
 // drawChartSeriesCat provides a function to draw the c:cat element by given
@@ -1024,8 +1398,8 @@ func (f *File) drawChartDLbls(opts *Chart) *cDLbls {
 }
 
 // drawChartSeriesDLbls provides a function to draw the c:dLbls element by
-// given format sets.
-func (f *File) drawChartSeriesDLbls(opts *Chart) *cDLbls {
+// given series index and format sets.
+func (f *File) drawChartSeriesDLbls(i int, opts *Chart) *cDLbls {
 	dLbls := f.drawChartDLbls(opts)
 	chartSeriesDLbls := map[ChartType]*cDLbls{
 		Scatter: nil, Surface3D: nil, WireframeSurface3D: nil, Contour: nil, WireframeContour: nil, Bubble: nil, Bubble3D: nil,
@@ -1033,6 +1407,21 @@ func (f *File) drawChartSeriesDLbls(opts *Chart) *cDLbls {
 	if _, ok := chartSeriesDLbls[opts.Type]; ok {
 		return nil
 	}
+	for _, point := range getChartDataPoints(opts.Series[i]) {
+		if point.ShowLabel == nil {
+			continue
+		}
+		dLbl := &cDLbl{IDx: &attrValInt{Val: intPtr(point.Index)}}
+		if *point.ShowLabel {
+			dLbl.ShowLegendKey = dLbls.ShowLegendKey
+			dLbl.ShowVal = &attrValBool{Val: boolPtr(true)}
+			dLbl.ShowCatName, dLbl.ShowSerName = dLbls.ShowCatName, dLbls.ShowSerName
+			dLbl.ShowPercent, dLbl.ShowBubbleSize = dLbls.ShowPercent, dLbls.ShowBubbleSize
+		} else {
+			dLbl.Delete = &attrValBool{Val: boolPtr(true)}
+		}
+		dLbls.DLbl = append(dLbls.DLbl, dLbl)
+	}
 	return dLbls
 }
 
@@ -1202,7 +1591,7 @@ func (f *File) drawPlotAreaTxPr(opts *ChartAxis) *cTxPr {
 		},
 		P: aP{
 			PPr: &aPPr{
-				DefRPr: aRPr{
+				DefRPr: &aRPr{
 					Sz:       900,
 					B:        false,
 					I:        false,
@@ -1293,6 +1682,64 @@ func (f *File) drawingParser(path string) (*xlsxWsDr, int, error) {
 // addDrawingChart provides a function to add chart graphic frame by given
 // sheet, drawingXML, cell, width, height, relationship index and format sets.
 func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+	return f.addDrawingGraphicFrame(sheet, drawingXML, cell, width, height, opts, func(cNvPr *xlsxCNvPr) string {
+		graphic, _ := xml.Marshal(xlsxGraphicFrame{
+			NvGraphicFramePr: xlsxNvGraphicFramePr{CNvPr: cNvPr},
+			Graphic: &xlsxGraphic{
+				GraphicData: &xlsxGraphicData{
+					URI: NameSpaceDrawingMLChart.Value,
+					Chart: &xlsxChart{
+						C:   NameSpaceDrawingMLChart.Value,
+						R:   SourceRelationship.Value,
+						RID: "rId" + strconv.Itoa(rID),
+					},
+				},
+			},
+		})
+		return string(graphic)
+	})
+}
+
+// addDrawingChartEx provides a function to add extended chart graphic frame
+// by given sheet, drawingXML, cell, width, height, relationship index and
+// format sets. The graphic frame will be wrapped in the alternate content with
+// a fallback shape for the applications which not support the extended chart.
+func (f *File) addDrawingChartEx(sheet, drawingXML, cell string, width, height, rID int, opts *GraphicOptions) error {
+	return f.addDrawingGraphicFrame(sheet, drawingXML, cell, width, height, opts, func(cNvPr *xlsxCNvPr) string {
+		graphic, _ := xml.Marshal(xlsxGraphicFrame{
+			NvGraphicFramePr: xlsxNvGraphicFramePr{CNvPr: cNvPr},
+			Graphic: &xlsxGraphic{
+				GraphicData: &xlsxGraphicData{
+					URI: NameSpaceDrawingMLChartEx,
+					ChartEx: &xlsxChartEx{
+						CX:  NameSpaceDrawingMLChartEx,
+						R:   SourceRelationship.Value,
+						RID: "rId" + strconv.Itoa(rID),
+					},
+				},
+			},
+		})
+		fallback, _ := xml.Marshal(struct {
+			XMLName xml.Name `xml:"xdr:sp"`
+			xdrSp
+		}{xdrSp: xdrSp{
+			NvSpPr: &xdrNvSpPr{CNvPr: cNvPr, CNvSpPr: &xdrCNvSpPr{}},
+			SpPr:   &xlsxSpPr{PrstGeom: xlsxPrstGeom{Prst: "rect"}},
+			TxBody: &xdrTxBody{
+				BodyPr: &aBodyPr{},
+				P:      []*aP{{R: &aR{T: "This chart isn't available in your version of Excel."}}},
+			},
+		}})
+		return "<mc:AlternateContent xmlns:mc=\"" + SourceRelationshipCompatibility.Value + "\">" +
+			"<mc:Choice xmlns:cx1=\"" + NameSpaceDrawingMLChartEx201509 + "\" Requires=\"cx1\">" + string(graphic) + "</mc:Choice>" +
+			"<mc:Fallback>" + string(fallback) + "</mc:Fallback></mc:AlternateContent>"
+	})
+}
+
+// addDrawingGraphicFrame provides a function to add the two cell anchor with
+// the graphic frame created by given function by given sheet, drawingXML,
+// cell, width, height and format sets.
+func (f *File) addDrawingGraphicFrame(sheet, drawingXML, cell string, width, height int, opts *GraphicOptions, graphicFrame func(cNvPr *xlsxCNvPr) string) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -1321,27 +1768,10 @@ func (f *File) addDrawingChart(sheet, drawingXML, cell string, width, height, rI
 	to.RowOff = y2 * EMU
 	twoCellAnchor.From = &from
 	twoCellAnchor.To = &to
-
-	graphicFrame := xlsxGraphicFrame{
-		NvGraphicFramePr: xlsxNvGraphicFramePr{
-			CNvPr: &xlsxCNvPr{
-				ID:   cNvPrID,
-				Name: "Chart " + strconv.Itoa(cNvPrID),
-			},
-		},
-		Graphic: &xlsxGraphic{
-			GraphicData: &xlsxGraphicData{
-				URI: NameSpaceDrawingMLChart.Value,
-				Chart: &xlsxChart{
-					C:   NameSpaceDrawingMLChart.Value,
-					R:   SourceRelationship.Value,
-					RID: "rId" + strconv.Itoa(rID),
-				},
-			},
-		},
-	}
-	graphic, _ := xml.Marshal(graphicFrame)
-	twoCellAnchor.GraphicFrame = string(graphic)
+	twoCellAnchor.GraphicFrame = graphicFrame(&xlsxCNvPr{
+		ID:   cNvPrID,
+		Name: "Chart " + strconv.Itoa(cNvPrID),
+	})
 	twoCellAnchor.ClientData = &xdrClientData{
 		FLocksWithSheet:  *opts.Locked,
 		FPrintsWithSheet: *opts.PrintObject,
@@ -1403,12 +1833,16 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) error
 		deTwoCellAnchor *decodeTwoCellAnchor
 	)
 	xdrCellAnchorFuncs := map[string]func(anchor *xdrCellAnchor) bool{
-		"Chart": func(anchor *xdrCellAnchor) bool { return anchor.Pic == nil },
+		"Chart": func(anchor *xdrCellAnchor) bool { return anchor.Pic == nil && anchor.Sp == nil && anchor.CxnSp == nil },
 		"Pic":   func(anchor *xdrCellAnchor) bool { return anchor.Pic != nil },
+		"Shape": func(anchor *xdrCellAnchor) bool { return anchor.Sp != nil },
 	}
 	decodeTwoCellAnchorFuncs := map[string]func(anchor *decodeTwoCellAnchor) bool{
-		"Chart": func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic == nil },
+		"Chart": func(anchor *decodeTwoCellAnchor) bool {
+			return anchor.Pic == nil && anchor.Sp == nil && anchor.CxnSp == nil
+		},
 		"Pic":   func(anchor *decodeTwoCellAnchor) bool { return anchor.Pic != nil },
+		"Shape": func(anchor *decodeTwoCellAnchor) bool { return anchor.Sp != nil },
 	}
 	if wsDr, _, err = f.drawingParser(drawingXML); err != nil {
 		return err
@@ -1437,3 +1871,158 @@ func (f *File) deleteDrawing(col, row int, drawingXML, drawingType string) error
 	f.Drawings.Store(drawingXML, wsDr)
 	return err
 }
+
+// sheetDrawingParser provides a function to get the drawing part of the
+// worksheet by given worksheet name, the drawing part will be nil if the
+// worksheet doesn't have any drawing objects.
+func (f *File) sheetDrawingParser(sheet string) (*xlsxWsDr, string, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, "", err
+	}
+	f.mu.Unlock()
+	if ws.Drawing == nil {
+		return nil, "", err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	return wsDr, drawingXML, err
+}
+
+// GetDrawingObjects provides a function to get all drawing objects in a
+// worksheet by given worksheet name, the returned objects are sorted by the
+// z-order from back to front. For example, get all drawing objects in Sheet1:
+//
+//	objects, err := f.GetDrawingObjects("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, object := range objects {
+//	    fmt.Println(object.ZOrder, object.Type, object.Name, object.Cell)
+//	}
+func (f *File) GetDrawingObjects(sheet string) ([]DrawingObject, error) {
+	var objects []DrawingObject
+	wsDr, _, err := f.sheetDrawingParser(sheet)
+	if err != nil || wsDr == nil {
+		return objects, err
+	}
+	wsDr.mu.Lock()
+	anchors := make([]*xdrCellAnchor, 0, len(wsDr.OneCellAnchor)+len(wsDr.TwoCellAnchor))
+	anchors = append(append(anchors, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for zOrder, anchor := range anchors {
+		output, _ := xml.Marshal(anchor)
+		deAnchor := new(decodeTwoCellAnchor)
+		if err = f.xmlNewDecoder(bytes.NewReader(output)).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return nil, err
+		}
+		err = nil
+		object := DrawingObject{ZOrder: zOrder}
+		if deAnchor.From != nil {
+			object.Cell, _ = CoordinatesToCellName(deAnchor.From.Col+1, deAnchor.From.Row+1)
+		}
+		switch {
+		case deAnchor.Pic != nil:
+			object.Type, object.Name = "picture", deAnchor.Pic.NvPicPr.CNvPr.Name
+		case deAnchor.Sp != nil:
+			object.Type = "shape"
+			if deAnchor.Sp.NvSpPr != nil && deAnchor.Sp.NvSpPr.CNvPr != nil {
+				object.Name = deAnchor.Sp.NvSpPr.CNvPr.Name
+			}
+		case deAnchor.CxnSp != nil:
+			object.Type = "connector"
+		case deAnchor.GrpSp != nil:
+			object.Type = "group"
+		case deAnchor.GraphicFrame != nil, deAnchor.AlternateContent != nil:
+			object.Type = "chart"
+		}
+		objects = append(objects, object)
+	}
+	return objects, err
+}
+
+// BringDrawingObjectToFront provides a function to bring the drawing object
+// to the front of all other drawing objects in a worksheet by given worksheet
+// name and z-order of the object, which could be get by the GetDrawingObjects
+// function. The content of the object will be kept. Note that the objects
+// anchored by the one cell anchor are always drawn behind the objects
+// anchored by the two cell anchor, so they could be only reordered among the
+// objects with the same anchor type. For example, bring the picture with
+// z-order 0 in Sheet1 to the front of the overlapped shapes:
+//
+//	err := f.BringDrawingObjectToFront("Sheet1", 0)
+func (f *File) BringDrawingObjectToFront(sheet string, zOrder int) error {
+	return f.moveDrawingObject(sheet, zOrder, true)
+}
+
+// SendDrawingObjectToBack provides a function to send the drawing object to
+// the back of all other drawing objects in a worksheet by given worksheet name
+// and z-order of the object. The same as the BringDrawingObjectToFront
+// function, the objects could be only reordered among the objects with the
+// same anchor type. For example, send the object with z-order 1 in Sheet1 to
+// the back:
+//
+//	err := f.SendDrawingObjectToBack("Sheet1", 1)
+func (f *File) SendDrawingObjectToBack(sheet string, zOrder int) error {
+	return f.moveDrawingObject(sheet, zOrder, false)
+}
+
+// moveDrawingObject provides a function to move the cell anchor of the
+// drawing object to the end or the beginning of the cell anchors with the
+// same anchor type by given worksheet name and z-order of the object.
+func (f *File) moveDrawingObject(sheet string, zOrder int, front bool) error {
+	wsDr, drawingXML, err := f.sheetDrawingParser(sheet)
+	if err != nil {
+		return err
+	}
+	if wsDr == nil {
+		return newNoExistDrawingObjectError(sheet, zOrder)
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	if zOrder < 0 || zOrder >= len(wsDr.OneCellAnchor)+len(wsDr.TwoCellAnchor) {
+		return newNoExistDrawingObjectError(sheet, zOrder)
+	}
+	anchors, idx := &wsDr.OneCellAnchor, zOrder
+	if idx >= len(wsDr.OneCellAnchor) {
+		anchors, idx = &wsDr.TwoCellAnchor, idx-len(wsDr.OneCellAnchor)
+	}
+	anchor := (*anchors)[idx]
+	rest := append((*anchors)[:idx:idx], (*anchors)[idx+1:]...)
+	if front {
+		*anchors = append(rest, anchor)
+	} else {
+		*anchors = append([]*xdrCellAnchor{anchor}, rest...)
+	}
+	f.Drawings.Store(drawingXML, wsDr)
+	return err
+}
+
+// deleteEmptyDrawing provides a function to remove the drawing part, the
+// relationships of the drawing part and the worksheet drawing relationship by
+// given worksheet name and drawing part path if the drawing part doesn't
+// contain any drawing element.
+func (f *File) deleteEmptyDrawing(sheet, drawingXML string, wsDr *xlsxWsDr) error {
+	if len(wsDr.OneCellAnchor)+len(wsDr.TwoCellAnchor)+len(wsDr.AlternateContent) > 0 {
+		return nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Drawing != nil {
+		f.deleteSheetRelationships(sheet, ws.Drawing.RID)
+		ws.Drawing = nil
+	}
+	drawingRels := strings.ReplaceAll(strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	f.Drawings.Delete(drawingXML)
+	f.Pkg.Delete(drawingXML)
+	f.Pkg.Delete(drawingRels)
+	f.Relationships.Delete(drawingRels)
+	return f.deleteSheetFromContentTypes("/" + drawingXML)
+}
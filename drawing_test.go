@@ -13,6 +13,7 @@ package excelize
 
 import (
 	"encoding/xml"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -38,3 +39,69 @@ func TestDrawingParser(t *testing.T) {
 	_, _, err = f.drawingParser("wsDr")
 	assert.NoError(t, err)
 }
+
+func TestDrawingObjectZOrder(t *testing.T) {
+	f := NewFile()
+	// Test get drawing objects on the worksheet without drawing
+	objects, err := f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	assert.EqualError(t, f.BringDrawingObjectToFront("Sheet1", 0), newNoExistDrawingObjectError("Sheet1", 0).Error())
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddShape("Sheet1", "B2", &Shape{Type: "rect", Fill: Fill{Color: []string{"4286F4"}}, FillFormat: ShapeFill{Transparency: 50}}))
+	assert.NoError(t, f.AddChart("Sheet1", "C3", &Chart{Type: Col, Series: []ChartSeries{{Values: "Sheet1!$A$1:$A$2"}}}))
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []DrawingObject{
+		{Cell: "A1", Type: "picture", Name: "Picture 2", ZOrder: 0},
+		{Cell: "B2", Type: "shape", Name: "Shape 3", ZOrder: 1},
+		{Cell: "C3", Type: "chart", ZOrder: 2},
+	}, objects)
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	// Test send the chart to back and bring the picture to front
+	assert.NoError(t, f.SendDrawingObjectToBack("Sheet1", 2))
+	assert.NoError(t, f.BringDrawingObjectToFront("Sheet1", 1))
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	for zOrder, typ := range []string{"chart", "shape", "picture"} {
+		assert.Equal(t, typ, objects[zOrder].Type)
+		assert.Equal(t, zOrder, objects[zOrder].ZOrder)
+	}
+	// Test the content of the objects will be kept after reorder
+	reordered, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, shapes, reordered)
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDrawingObjectZOrder.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestDrawingObjectZOrder.xlsx"))
+	assert.NoError(t, err)
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []DrawingObject{
+		{Cell: "C3", Type: "chart", ZOrder: 0},
+		{Cell: "B2", Type: "shape", Name: "Shape 3", ZOrder: 1},
+		{Cell: "A1", Type: "picture", Name: "Picture 2", ZOrder: 2},
+	}, objects)
+	assert.NoError(t, f.SendDrawingObjectToBack("Sheet1", 2))
+	objects, err = f.GetDrawingObjects("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "picture", objects[0].Type)
+	// Test move drawing object with invalid z-order
+	assert.EqualError(t, f.BringDrawingObjectToFront("Sheet1", 3), newNoExistDrawingObjectError("Sheet1", 3).Error())
+	assert.EqualError(t, f.SendDrawingObjectToBack("Sheet1", -1), newNoExistDrawingObjectError("Sheet1", -1).Error())
+	// Test with invalid sheet name
+	_, err = f.GetDrawingObjects("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	assert.EqualError(t, f.BringDrawingObjectToFront("Sheet:1", 0), ErrSheetNameInvalid.Error())
+	// Test with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetDrawingObjects("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
@@ -40,6 +40,18 @@ func newInvalidExcelDateError(dateValue float64) error {
 	return fmt.Errorf("invalid date value %f, negative values are not supported", dateValue)
 }
 
+// newCellValueConvertError defined the error message on the value of the cell
+// could not be converted to the given type.
+func newCellValueConvertError(cell, value, typ string) error {
+	return fmt.Errorf("cannot convert value %q of cell %s to %s", value, cell, typ)
+}
+
+// newIconThresholdsError defined the error message on receiving the number of
+// the custom thresholds not equal to the number of the icons in the icon set.
+func newIconThresholdsError(iconStyle string, icons int) error {
+	return fmt.Errorf("the icon set %s requires %d thresholds", iconStyle, icons)
+}
+
 // newInvalidNameError defined the error message on receiving the invalid
 // defined name or table name.
 func newInvalidNameError(name string) error {
@@ -52,6 +64,31 @@ func newUnsupportedChartType(chartType ChartType) error {
 	return fmt.Errorf("unsupported chart type %d", chartType)
 }
 
+// newUnsupportedChartTrendlineError defined the error message on receiving
+// the trendline for the series of the chart type which doesn't support it.
+func newUnsupportedChartTrendlineError(chartType ChartType) error {
+	return fmt.Errorf("trendline is not supported for chart type %d", chartType)
+}
+
+// newUnsupportedChartErrorBarsError defined the error message on receiving
+// the error bars for the series of the chart type which doesn't support it.
+func newUnsupportedChartErrorBarsError(chartType ChartType) error {
+	return fmt.Errorf("error bars is not supported for chart type %d", chartType)
+}
+
+// newIncompatibleComboChartError defined the error message on receiving the
+// chart types which could not be combined in a combo chart.
+func newIncompatibleComboChartError(chartType, comboType ChartType) error {
+	return fmt.Errorf("chart type %d could not be combined with chart type %d", chartType, comboType)
+}
+
+// newUnsupportedChartSecondaryAxisError defined the error message on
+// receiving the secondary axis for the combo chart type which doesn't support
+// it.
+func newUnsupportedChartSecondaryAxisError(chartType ChartType) error {
+	return fmt.Errorf("secondary axis is not supported for chart type %d", chartType)
+}
+
 // newUnzipSizeLimitError defined the error message on unzip size exceeds the
 // limit.
 func newUnzipSizeLimitError(unzipSizeLimit int64) error {
@@ -82,6 +119,54 @@ func newNoExistSheetError(name string) error {
 	return fmt.Errorf("sheet %s does not exist", name)
 }
 
+// newNoExistShapeError defined the error message on receiving the cell
+// reference which doesn't have a shape anchored.
+func newNoExistShapeError(sheet, cell string) error {
+	return fmt.Errorf("no shape anchored at cell %s in sheet %s", cell, sheet)
+}
+
+// newNoExistChartError defined the error message on receiving the cell
+// reference which doesn't have a chart anchored.
+func newNoExistChartError(sheet, cell string) error {
+	return fmt.Errorf("no chart anchored at cell %s in sheet %s", cell, sheet)
+}
+
+// newNoExistDrawingObjectError defined the error message on receiving the
+// z-order which doesn't have a drawing object.
+func newNoExistDrawingObjectError(sheet string, zOrder int) error {
+	return fmt.Errorf("no drawing object at z-order %d in sheet %s", zOrder, sheet)
+}
+
+// newNoExistPivotTableError defined the error message on receiving the non
+// existing pivot table range reference.
+func newNoExistPivotTableError(rangeRef string) error {
+	return fmt.Errorf("pivot table on %s does not exist", rangeRef)
+}
+
+// newNoExistTableError defined the error message on receiving the non
+// existing table name.
+func newNoExistTableError(name string) error {
+	return fmt.Errorf("table %s does not exist", name)
+}
+
+// newNoExistTableColumnError defined the error message on receiving the non
+// existing table column name.
+func newNoExistTableColumnError(name string) error {
+	return fmt.Errorf("table column %s does not exist", name)
+}
+
+// newNoExistNamedStyleError defined the error message on receiving the non
+// existing named cell style.
+func newNoExistNamedStyleError(name string) error {
+	return fmt.Errorf("named style %s does not exist", name)
+}
+
+// newNamedStyleExistError defined the error message on receiving the
+// duplicate named cell style.
+func newNamedStyleExistError(name string) error {
+	return fmt.Errorf("named style %s already exists", name)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
@@ -100,13 +185,46 @@ func newViewIdxError(viewIndex int) error {
 	return fmt.Errorf("view index %d out of range", viewIndex)
 }
 
+// newDuplicateAutoFilterColumnError defined the error message on receiving
+// more than one filter criteria for the same auto filter column.
+func newDuplicateAutoFilterColumnError(column string) error {
+	return fmt.Errorf("duplicate filter criteria of column '%s'", column)
+}
+
+// newInvalidAutoFilterDynamicError defined the error message on receiving
+// the invalid dynamic filter type of the auto filter.
+func newInvalidAutoFilterDynamicError(typ string) error {
+	return fmt.Errorf("unsupported dynamic filter type '%s'", typ)
+}
+
+// newInvalidTableTotalsRowFunctionError defined the error message on
+// receiving the invalid totals row function of the table column.
+func newInvalidTableTotalsRowFunctionError(function string) error {
+	return fmt.Errorf("unsupported totals row function '%s'", function)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
 	return fmt.Errorf("unknown operator: %s", token)
 }
 
+// newFetchPictureError defined the error message on receiving the unexpected
+// HTTP response status on fetching the picture.
+func newFetchPictureError(url, status string) error {
+	return fmt.Errorf("failed to fetch picture %s: %s", url, status)
+}
+
+// newPictureContentTypeError defined the error message on receiving the
+// unsupported content type of the fetched picture.
+func newPictureContentTypeError(url, contentType string) error {
+	return fmt.Errorf("unsupported picture content type %q of %s", contentType, url)
+}
+
 var (
+	// ErrCircularReference defined the error message on the formula cell in
+	// the circular references.
+	ErrCircularReference = errors.New("circular reference")
 	// ErrStreamSetColWidth defined the error message on set column width in
 	// stream writing mode.
 	ErrStreamSetColWidth = errors.New("must call the SetColWidth function before the SetRow function")
@@ -144,6 +262,9 @@ var (
 	// ErrImgExt defined the error message on receive an unsupported image
 	// extension.
 	ErrImgExt = errors.New("unsupported image extension")
+	// ErrMaxFetchPictureSize defined the error message on the size of the
+	// fetched picture exceeds the maximum limit.
+	ErrMaxFetchPictureSize = fmt.Errorf("the size of the fetched picture exceeds maximum limit %d bytes", MaxFetchPictureSize)
 	// ErrWorkbookFileFormat defined the error message on receive an
 	// unsupported workbook file format.
 	ErrWorkbookFileFormat = errors.New("unsupported workbook file format")
@@ -168,6 +289,9 @@ var (
 	// ErrParameterRequired defined the error message on receive the empty
 	// parameter.
 	ErrParameterRequired = errors.New("parameter is required")
+	// ErrArrayFormulaRef defined the error message on receive the invalid
+	// reference of the array formula.
+	ErrArrayFormulaRef = errors.New("the reference of the array formula must be a range starts with the formula cell")
 	// ErrParameterInvalid defined the error message on receive the invalid
 	// parameter.
 	ErrParameterInvalid = errors.New("parameter is invalid")
@@ -179,6 +303,9 @@ var (
 	ErrDefinedNameDuplicate = errors.New("the same name already exists on the scope")
 	// ErrCustomNumFmt defined the error message on receive the empty custom number format.
 	ErrCustomNumFmt = errors.New("custom number format can not be empty")
+	// ErrFractionNumFmt defined the error message on receive the invalid
+	// fraction number format settings.
+	ErrFractionNumFmt = errors.New("exactly one of the denominator digits between 1 and 5 or the fixed denominator between 2 and 99999 must be specified")
 	// ErrFontLength defined the error message on the length of the font
 	// family name overflow.
 	ErrFontLength = fmt.Errorf("the length of the font family name must be less than or equal to %d", MaxFontFamilyLength)
@@ -255,4 +382,66 @@ var (
 	// ErrUnprotectWorkbookPassword defined the error message on remove workbook
 	// protection with password verification failed.
 	ErrUnprotectWorkbookPassword = errors.New("workbook protect password not match")
+	// ErrPageSetupAdjustTo defined the error message for receiving a page setup
+	// adjust to value exceeds limit.
+	ErrPageSetupAdjustTo = errors.New("adjust to value must be between 10 and 400")
+	// ErrChartTrendlineType defined the error message on receiving the invalid
+	// chart series trendline type.
+	ErrChartTrendlineType = errors.New("parameter 'Type' of the trendline must be 'exp', 'linear', 'log', 'movingAvg', 'poly' or 'power'")
+	// ErrChartTrendlinePeriod defined the error message on receiving the
+	// invalid moving average trendline period.
+	ErrChartTrendlinePeriod = errors.New("moving average trendline period must be an integer between 2 and 255")
+	// ErrChartTrendlineOrder defined the error message on receiving the
+	// invalid polynomial trendline order.
+	ErrChartTrendlineOrder = errors.New("polynomial trendline order must be an integer between 2 and 6")
+	// ErrChartErrorBarsType defined the error message on receiving the invalid
+	// chart series error bars type.
+	ErrChartErrorBarsType = errors.New("parameter 'Type' of the error bars must be 'cust', 'fixedVal', 'percentage', 'stdDev' or 'stdErr'")
+	// ErrChartErrorBarsDirection defined the error message on receiving the
+	// invalid chart series error bars direction.
+	ErrChartErrorBarsDirection = errors.New("parameter 'Direction' of the error bars must be 'both', 'minus' or 'plus'")
+	// ErrChartErrorBarsCustom defined the error message on receiving the
+	// custom value ranges with the built-in error bars type, or the custom
+	// error bars without any value range.
+	ErrChartErrorBarsCustom = errors.New("parameter 'Plus' or 'Minus' of the error bars is required and only works with the 'cust' type")
+	// ErrChartAxisLogBase defined the error message on receiving the base of
+	// the logarithmic scale of the chart axis less than or equal to 1.
+	ErrChartAxisLogBase = errors.New("the base of the logarithmic scale of the chart axis must be greater than 1")
+	// ErrChartWaterfallSubTotal defined the error message on receiving the
+	// subtotal index of the waterfall chart series out of the range of the
+	// series values.
+	ErrChartWaterfallSubTotal = errors.New("the subtotal index of the waterfall chart series is out of range")
+	// ErrChartGapWidth defined the error message on receiving the gap width of
+	// the bar or column chart out of the range.
+	ErrChartGapWidth = errors.New("the gap width of the bar or column chart must be between 0 and 500")
+	// ErrChartOverlap defined the error message on receiving the overlap of the
+	// bar or column chart out of the range.
+	ErrChartOverlap = errors.New("the overlap of the bar or column chart must be between -100 and 100")
+	// ErrChartLegendPosition defined the error message on receiving the
+	// invalid position of the chart legend.
+	ErrChartLegendPosition = errors.New("parameter 'Position' of the chart legend must be 'none', 'top', 'bottom', 'left', 'right' or 'top_right'")
+	// ErrChartLegendEntry defined the error message on receiving the negative
+	// index of the hidden chart legend entry.
+	ErrChartLegendEntry = errors.New("the index of the hidden chart legend entry must be greater than or equal to 0")
+	// ErrWorkbookTheme defined the error message on the workbook without
+	// theme part.
+	ErrWorkbookTheme = errors.New("the workbook doesn't contain a theme")
+	// ErrCondFmtRank defined the error message on receiving the rank of the
+	// top or bottom conditional formatting rule out of the range.
+	ErrCondFmtRank = errors.New("the rank of the top or bottom conditional format must be between 1 and 1000")
+	// ErrCondFmtStdDev defined the error message on receiving the standard
+	// deviation of the average conditional formatting rule out of the range.
+	ErrCondFmtStdDev = errors.New("the standard deviation of the average conditional format must be between 1 and 3")
+	// ErrCondFmtTimePeriod defined the error message on receiving the invalid
+	// time period of the dates occurring conditional formatting rule.
+	ErrCondFmtTimePeriod = errors.New("the time period of the conditional format must be one of 'yesterday', 'today', 'tomorrow', 'last 7 days', 'last week', 'this week', 'next week', 'last month', 'this month' or 'next month'")
+	// ErrAutoFilterCriteria defined the error message on receiving more than
+	// one kind of filter criteria for an auto filter column.
+	ErrAutoFilterCriteria = errors.New("only one of expression, values, top 10 or dynamic filter can be set for an auto filter column")
+	// ErrAutoFilterTop10 defined the error message on receiving the top 10
+	// filter value out of the range.
+	ErrAutoFilterTop10 = errors.New("the top 10 filter value must be between 1 and 500, or between 1 and 100 in percent")
+	// ErrRangeTooLarge defined the error message on receiving the range
+	// reference which contains too many cells to expand.
+	ErrRangeTooLarge = fmt.Errorf("the number of cells in the range must be less than or equal to %d", MaxExpandRangeCells)
 )
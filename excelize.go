@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -26,34 +27,46 @@ import (
 	"golang.org/x/net/html/charset"
 )
 
-// File define a populated spreadsheet file struct.
+// File define a populated spreadsheet file struct. Worksheets are parsed
+// lazily on first access, the parsing is guarded by the file-level lock, and
+// each parsed worksheet has its own lock. So that the cell value getters, such
+// as GetCellValue, GetCellType, GetCellFormula, GetCellStyle, GetRows, GetCols
+// and SearchSheet are safe to be called concurrently from multiple goroutines,
+// on the same or distinct worksheets, and mixed with the functions which are
+// documented as concurrency safe. Adding, deleting, renaming, copying or
+// moving worksheets, and saving the workbook are not concurrency safe, which
+// should not be performed in parallel with any other operation on the file.
 type File struct {
-	mu               sync.Mutex
-	options          *Options
-	xmlAttr          map[string][]xml.Attr
-	checked          map[string]bool
-	sheetMap         map[string]string
-	streams          map[string]*StreamWriter
-	tempFiles        sync.Map
-	sharedStringsMap map[string]int
-	sharedStringItem [][]uint
-	sharedStringTemp *os.File
-	CalcChain        *xlsxCalcChain
-	Comments         map[string]*xlsxComments
-	ContentTypes     *xlsxTypes
-	Drawings         sync.Map
-	Path             string
-	SharedStrings    *xlsxSST
-	Sheet            sync.Map
-	SheetCount       int
-	Styles           *xlsxStyleSheet
-	Theme            *xlsxTheme
-	DecodeVMLDrawing map[string]*decodeVmlDrawing
-	VMLDrawing       map[string]*vmlDrawing
-	WorkBook         *xlsxWorkbook
-	Relationships    sync.Map
-	Pkg              sync.Map
-	CharsetReader    charsetTranscoderFn
+	mu                  sync.Mutex
+	options             *Options
+	xmlAttr             map[string][]xml.Attr
+	checked             map[string]bool
+	formulaGraph        *formulaGraph
+	numFmtCache         sync.Map
+	sheetMap            map[string]string
+	streams             map[string]*StreamWriter
+	tempFiles           sync.Map
+	sharedStringsMap    map[string]int
+	sharedStringItem    [][]uint
+	sharedStringTemp    *os.File
+	sharedStringSource  *os.File
+	sharedStringDecoder *xml.Decoder
+	CalcChain           *xlsxCalcChain
+	Comments            map[string]*xlsxComments
+	ContentTypes        *xlsxTypes
+	Drawings            sync.Map
+	Path                string
+	SharedStrings       *xlsxSST
+	Sheet               sync.Map
+	SheetCount          int
+	Styles              *xlsxStyleSheet
+	Theme               *xlsxTheme
+	DecodeVMLDrawing    map[string]*decodeVmlDrawing
+	VMLDrawing          map[string]*vmlDrawing
+	WorkBook            *xlsxWorkbook
+	Relationships       sync.Map
+	Pkg                 sync.Map
+	CharsetReader       charsetTranscoderFn
 }
 
 // charsetTranscoderFn set user-defined codepage transcoder function for open
@@ -80,6 +93,18 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 // should be less than or equal to UnzipSizeLimit, the default value is
 // 16MB.
 //
+// LazySharedStrings specifies if parse the shared string table on demand
+// instead of loading the whole table into memory on open the spreadsheet. If
+// enabled, the shared string table will be extracted to the system temporary
+// directory like the table over the UnzipXMLSizeLimit does, and the shared
+// string items will be parsed only up to the largest index that has been
+// read, so reading a few cells doesn't require to parse all of the items in a
+// huge table. The parsed items are indexed in the system temporary directory
+// and the subsequent lookups for them are constant time, but reading a cell
+// which refers to an item at the end of the table still requires to parse all
+// the items before it, and setting a string cell value will load the whole
+// table into memory.
+//
 // ShortDatePattern specifies the short date number format code. In the
 // spreadsheet applications, date formats display date and time serial numbers
 // as date values. Date formats that begin with an asterisk (*) respond to
@@ -94,16 +119,37 @@ type charsetTranscoderFn func(charset string, input io.Reader) (rdr io.Reader, e
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// Language specifies the language tag, such as "de-DE", "fr-FR" and "ja-JP",
+// for rendering the month and weekday names, AM/PM and the decimal and
+// thousands separators when applying number format to the cell values. The
+// language specified in the number format code, such as [$-407], takes
+// precedence over it for the month and weekday names and AM/PM. The default
+// language is en-US, and it will be used if the language tag is unsupported.
+//
+// KeepUnknownElements specifies if retain the elements which not supported by
+// the library in the workbook and worksheets parts, such as the vendor-specific
+// extensions, and re-emit them on save. The unsupported parts of the
+// spreadsheet, such as custom XML parts and add-in data, and the relationships
+// to them are always retained as is.
+//
+// HTTPClient specifies the HTTP client for fetching the pictures by the
+// AddPictureFromURL function, the default HTTP client with 30 seconds timeout
+// will be used if it was not specified.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations   uint
+	Password            string
+	RawCellValue        bool
+	UnzipSizeLimit      int64
+	UnzipXMLSizeLimit   int64
+	LazySharedStrings   bool
+	ShortDatePattern    string
+	LongDatePattern     string
+	LongTimePattern     string
+	CultureInfo         CultureName
+	Language            string
+	KeepUnknownElements bool
+	HTTPClient          *http.Client
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -135,6 +181,7 @@ func newFile() *File {
 		options:          &Options{UnzipSizeLimit: UnzipSizeLimit, UnzipXMLSizeLimit: StreamChunkSize},
 		xmlAttr:          make(map[string][]xml.Attr),
 		checked:          make(map[string]bool),
+		numFmtCache:      sync.Map{},
 		sheetMap:         make(map[string]string),
 		tempFiles:        sync.Map{},
 		Comments:         make(map[string]*xlsxComments),
@@ -251,7 +298,8 @@ func (f *File) setDefaultTimeStyle(sheet, cell string, format int) error {
 }
 
 // workSheetReader provides a function to get the pointer to the structure
-// after deserialization by given worksheet name.
+// after deserialization by given worksheet name. The caller should hold the
+// file-level lock when the worksheet may be accessed concurrently.
 func (f *File) workSheetReader(sheet string) (ws *xlsxWorksheet, err error) {
 	var (
 		name string
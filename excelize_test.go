@@ -209,6 +209,50 @@ func TestSaveFile(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestKeepUnknownElements(t *testing.T) {
+	f := NewFile()
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	f.WorkBook = nil
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" xmlns:v="urn:vendor" mc:Ignorable="v"><v:first/><dimension ref="A1"/><sheetData/><v:data r:id="rId1" v:ver="2" xml:lang="en"><v:item>a &amp; b</v:item><item/></v:data><v:flag xmlns:w="urn:other" w:on="1" x:y="1" xmlns:x="urn:x"/><phoneticPr fontId="1"/><v:last/><pageMargins left="0.7" right="0.7" top="0.75" bottom="0.75" header="0.3" footer="0.3"/></worksheet>`))
+	output, err := xml.Marshal(wb)
+	assert.NoError(t, err)
+	f.Pkg.Store(defaultXMLPathWorkbook, bytes.Replace(output, []byte(`<sheets>`), []byte(`<ns0:vendorPr xmlns:ns0="urn:vendor" ns0:ver="1"/><sheets>`), 1))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	for _, keep := range []bool{true, false} {
+		f, err := OpenReader(bytes.NewReader(buf.Bytes()), Options{KeepUnknownElements: keep})
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1))
+		_, err = f.NewSheet("Sheet2")
+		assert.NoError(t, err)
+		_, err = f.WriteToBuffer()
+		assert.NoError(t, err)
+		ws, wb := string(f.readXML("xl/worksheets/sheet1.xml")), string(f.readXML(defaultXMLPathWorkbook))
+		assert.Contains(t, ws, `<c r="A1"><v>1</v></c>`)
+		assert.Contains(t, wb, `<sheet name="Sheet2"`)
+		for _, s := range []string{
+			`<v:first></v:first><dimension ref="A1"></dimension>`,
+			`</sheetData><v:data r:id="rId1" v:ver="2" xml:lang="en"><v:item>a &amp; b</v:item><item/></v:data><v:flag xmlns:w="urn:other" w:on="1" x:y="1" xmlns:x="urn:x"></v:flag><phoneticPr fontId="1"></phoneticPr><v:last></v:last><pageMargins`,
+		} {
+			assert.Equal(t, keep, strings.Contains(ws, s), s)
+		}
+		assert.Equal(t, keep, strings.Contains(wb, `<ns0:vendorPr xmlns:ns0="urn:vendor" ns0:ver="1"></ns0:vendorPr><sheets>`))
+		assert.NoError(t, f.Close())
+	}
+	// Test re-emit unknown elements with undeclared namespace
+	output, err = xml.Marshal(&xlsxWorksheet{UnknownElements: []xlsxUnknownElement{{
+		XMLName: xml.Name{Space: "urn:vendor", Local: "data"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Space: "xmlns", Local: "ns0"}, Value: "urn:other"},
+			{Name: xml.Name{Space: "urn:x", Local: "a"}, Value: "1"},
+		},
+	}}})
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<ns1:data xmlns:ns1="urn:vendor" xmlns:ns0="urn:other" xmlns:ns2="urn:x" ns2:a="1"></ns1:data>`)
+}
+
 func TestSaveAsWrongPath(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -386,10 +430,10 @@ func TestNewFile(t *testing.T) {
 func TestAddDrawingVML(t *testing.T) {
 	// Test addDrawingVML with illegal cell reference
 	f := NewFile()
-	assert.EqualError(t, f.addDrawingVML(0, "", "*", 0, 0), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
+	assert.EqualError(t, f.addDrawingVML(0, "", "*", "", ""), newCellNameToCoordinatesError("*", newInvalidCellNameError("*")).Error())
 
 	f.Pkg.Store("xl/drawings/vmlDrawing1.vml", MacintoshCyrillicCharset)
-	assert.EqualError(t, f.addDrawingVML(0, "xl/drawings/vmlDrawing1.vml", "A1", 0, 0), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.addDrawingVML(0, "xl/drawings/vmlDrawing1.vml", "A1", "", ""), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestSetCellHyperLink(t *testing.T) {
@@ -491,6 +535,61 @@ func TestGetCellHyperLink(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestGetCellHyperLinkFormula(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "https://github.com/xuri/excelize"))
+	assert.NoError(t, f.SetCellValue("Sheet 2", "B2", "https://github.com"))
+	for cell, expected := range map[string]struct {
+		formula, target, display string
+		link                     bool
+	}{
+		"B1":  {formula: `=HYPERLINK("https://github.com/xuri/excelize", "Excelize")`, target: "https://github.com/xuri/excelize", display: "Excelize", link: true},
+		"B2":  {formula: `hyperlink("#Sheet1!A1")`, target: "#Sheet1!A1", display: "#Sheet1!A1", link: true},
+		"B3":  {formula: `HYPERLINK($A$1,"Excelize")`, target: "https://github.com/xuri/excelize", display: "Excelize", link: true},
+		"B4":  {formula: `HYPERLINK('Sheet 2'!B2,UPPER("GitHub"))`, target: "https://github.com", link: true},
+		"B5":  {formula: `HYPERLINK("https://"&"github.com","GitHub")`},
+		"B6":  {formula: `HYPERLINK(A1:A2)`},
+		"B7":  {formula: `HYPERLINK(Name)`},
+		"B8":  {formula: `HYPERLINK(C1)`},
+		"B9":  {formula: `SUM(1,2)`},
+		"B10": {formula: `HYPERLINK("https://github.com")&"A"`},
+		"B11": {formula: `IF(TRUE,HYPERLINK("https://github.com"))`},
+		"B12": {formula: `HYPERLINK(SheetN!A1,'Sheet 2'!B2)`},
+		"B13": {formula: `HYPERLINK("https://github.com",SheetN!A1)`, target: "https://github.com", link: true},
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, expected.formula))
+		link, target, err := f.GetCellHyperLink("Sheet1", cell)
+		assert.NoError(t, err, expected.formula)
+		assert.Equal(t, expected.link, link, expected.formula)
+		assert.Equal(t, expected.target, target, expected.formula)
+		display, err := f.GetCellHyperLinkDisplay("Sheet1", cell)
+		assert.NoError(t, err, expected.formula)
+		assert.Equal(t, expected.display, display, expected.formula)
+	}
+	// Test get the display text of the HYPERLINK formula from the cached value
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", `HYPERLINK("https://github.com",UPPER("GitHub"))`))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[2].V, ws.SheetData.Row[0].C[2].T = "GITHUB", "str"
+	display, err := f.GetCellHyperLinkDisplay("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GITHUB", display)
+	// Test the hyperlink has higher priority than the HYPERLINK formula
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "B1", "https://github.com", "External", HyperlinkOpts{Display: stringPtr("GitHub")}))
+	link, target, err := f.GetCellHyperLink("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com", target)
+	display, err = f.GetCellHyperLinkDisplay("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GitHub", display)
+	// Test get cell hyperlink display with invalid cell reference
+	_, err = f.GetCellHyperLinkDisplay("Sheet1", "A")
+	assert.EqualError(t, err, newInvalidCellNameError("A").Error())
+}
+
 func TestSetSheetBackground(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -750,10 +849,10 @@ func TestSetCellStyleNumberFormat(t *testing.T) {
 	idxTbl := []int{0, 1, 2, 3, 4, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49}
 	value := []string{"37947.7500001", "-37947.7500001", "0.007", "2.1", "String"}
 	expected := [][]string{
-		{"37947.7500001", "37948", "37947.75", "37,948", "37,947.75", "3794775%", "3794775.00%", "3.79E+04", "37947.7500001", "37947.7500001", "11-22-03", "22-Nov-03", "22-Nov", "Nov-03", "6:00 PM", "6:00:00 PM", "18:00", "18:00:00", "11/22/03 18:00", "37,948 ", "37,948 ", "37,947.75 ", "37,947.75 ", "37947.7500001", "37947.7500001", "37947.7500001", "37947.7500001", "00:00", "910746:00:00", "00:00.0", "37947.7500001", "37947.7500001"},
-		{"-37947.7500001", "-37948", "-37947.75", "-37,948", "-37,947.75", "-3794775%", "-3794775.00%", "-3.79E+04", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "(37,948)", "(37,948)", "(37,947.75)", "(37,947.75)", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001"},
-		{"0.007", "0", "0.01", "0", "0.01", "1%", "0.70%", "7.00E-03", "0.007", "0.007", "12-30-99", "30-Dec-99", "30-Dec", "Dec-99", "12:10 AM", "12:10:05 AM", "00:10", "00:10:05", "12/30/99 00:10", "0 ", "0 ", "0.01 ", "0.01 ", "0.007", "0.007", "0.007", "0.007", "10:05", "0:10:05", "10:04.8", "0.007", "0.007"},
-		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2.1", "2.1", "01-01-00", "1-Jan-00", "1-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/1/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", "2.1", "2.1", "2.1", "2.1", "24:00", "50:24:00", "24:00.0", "2.1", "2.1"},
+		{"37947.7500001", "37948", "37947.75", "37,948", "37,947.75", "3794775%", "3794775.00%", "3.79E+04", "37947 3/4", "37947  3/4 ", "11-22-03", "22-Nov-03", "22-Nov", "Nov-03", "6:00 PM", "6:00:00 PM", "18:00", "18:00:00", "11/22/03 18:00", "37,948 ", "37,948 ", "37,947.75 ", "37,947.75 ", "37947.7500001", "37947.7500001", "37947.7500001", "37947.7500001", "00:00", "910746:00:00", "00:00.0", "37947.7500001", "37947.7500001"},
+		{"-37947.7500001", "-37948", "-37947.75", "-37,948", "-37,947.75", "-3794775%", "-3794775.00%", "-3.79E+04", "-37947 3/4", "-37947  3/4 ", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "(37,948)", "(37,948)", "(37,947.75)", "(37,947.75)", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001", "-37947.7500001"},
+		{"0.007", "0", "0.01", "0", "0.01", "1%", "0.70%", "7.00E-03", "0    ", "  1/99", "12-30-99", "30-Dec-99", "30-Dec", "Dec-99", "12:10 AM", "12:10:05 AM", "00:10", "00:10:05", "12/30/99 00:10", "0 ", "0 ", "0.01 ", "0.01 ", "0.007", "0.007", "0.007", "0.007", "10:05", "0:10:05", "10:04.8", "0.007", "0.007"},
+		{"2.1", "2", "2.10", "2", "2.10", "210%", "210.00%", "2.10E+00", "2 1/9", "2  1/10", "01-01-00", "1-Jan-00", "1-Jan", "Jan-00", "2:24 AM", "2:24:00 AM", "02:24", "02:24:00", "1/1/00 02:24", "2 ", "2 ", "2.10 ", "2.10 ", "2.1", "2.1", "2.1", "2.1", "24:00", "50:24:00", "24:00.0", "2.1", "2.1"},
 		{"String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String", "String"},
 	}
 
@@ -82,23 +82,47 @@ func (f *File) SaveAs(name string, opts ...Options) error {
 	return f.Write(file, opts...)
 }
 
-// Close closes and cleanup the open temporary file for the spreadsheet.
+// Close closes and cleanup the open temporary file for the spreadsheet. The
+// Close function is required to be called after the spreadsheet was opened by
+// OpenFile or OpenReader, or created by NewFile, once it's no longer needed.
+// It removes all temporary files unzipped from the spreadsheet or created by
+// the shared string table reader and the stream writers, releases the stream
+// writers' buffers, and clears the number format, picture and formula caches
+// of the spreadsheet. The parsed worksheets and package parts are kept, so
+// the spreadsheet can still be saved after Close. It is safe to call Close
+// more than once.
 func (f *File) Close() error {
 	var err error
 	if f.sharedStringTemp != nil {
 		if err := f.sharedStringTemp.Close(); err != nil {
 			return err
 		}
+		f.sharedStringTemp = nil
 	}
+	if f.sharedStringSource != nil {
+		_ = f.sharedStringSource.Close()
+		f.sharedStringSource = nil
+	}
+	f.sharedStringItem, f.sharedStringDecoder = nil, nil
+	for _, stream := range f.streams {
+		_ = stream.rawData.Close()
+	}
+	f.streams = nil
 	f.tempFiles.Range(func(k, v interface{}) bool {
 		if err = os.Remove(v.(string)); err != nil {
 			return false
 		}
+		f.tempFiles.Delete(k)
 		return true
 	})
-	for _, stream := range f.streams {
-		_ = stream.rawData.Close()
+	if err != nil {
+		return err
 	}
+	f.numFmtCache.Range(func(k, v interface{}) bool {
+		f.numFmtCache.Delete(k)
+		return true
+	})
+	f.formulaGraph = nil
 	return err
 }
 
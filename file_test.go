@@ -3,6 +3,7 @@ package excelize
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -94,4 +95,66 @@ func TestClose(t *testing.T) {
 	f := NewFile()
 	f.tempFiles.Store("/d/", "/d/")
 	require.Error(t, f.Close())
+
+	// Test close the workbook with temporary files for many times
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	f = NewFile()
+	for r := 1; r <= 100; r++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", r), fmt.Sprintf("Text%d", r)))
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	for i := 0; i < 1000; i++ {
+		f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{UnzipXMLSizeLimit: 128})
+		assert.NoError(t, err)
+		rows, err := f.Rows("Sheet1")
+		assert.NoError(t, err)
+		for rows.Next() {
+			_, err = rows.Columns()
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, rows.Close())
+		assert.NoError(t, f.Close())
+		// Test close the workbook again
+		assert.NoError(t, f.Close())
+	}
+	entries, err := os.ReadDir(tempDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	// Test close the workbook with stream writer
+	f = NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	for r := 1; r <= StreamChunkSize/TotalCellChars+1; r++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", r), []interface{}{strings.Repeat("s", TotalCellChars)}))
+	}
+	assert.NotNil(t, sw.rawData.tmp)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, f.Close())
+	entries, err = os.ReadDir(tempDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	// Test close the workbook will clear the caches and keep the parsed parts
+	f = NewFile()
+	assert.NoError(t, f.SetCellFormula("Sheet1", "A1", "=1+1"))
+	assert.NoError(t, f.CalcAll())
+	_, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	f.numFmtCache.Range(func(k, v interface{}) bool {
+		t.Errorf("unexpected %v after close", k)
+		return true
+	})
+	assert.Nil(t, f.formulaGraph)
+	// Test save the workbook after close
+	buf, err = f.WriteToBuffer()
+	assert.NoError(t, err)
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	formula, err := f.GetCellFormula("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "=1+1", formula)
+	assert.NoError(t, f.Close())
 }
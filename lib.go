@@ -18,9 +18,12 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"os"
+	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -47,7 +50,7 @@ func (f *File) ReadZipReader(r *zip.Reader) (map[string][]byte, int, error) {
 		if partName, ok := docPart[strings.ToLower(fileName)]; ok {
 			fileName = partName
 		}
-		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && fileSize > f.options.UnzipXMLSizeLimit {
+		if strings.EqualFold(fileName, defaultXMLPathSharedStrings) && (fileSize > f.options.UnzipXMLSizeLimit || f.options.LazySharedStrings) {
 			if tempFile, err := f.unzipToTemp(v); err == nil {
 				f.tempFiles.Store(fileName, tempFile)
 				continue
@@ -273,6 +276,257 @@ func CoordinatesToCellName(col, row int, abs ...bool) (string, error) {
 	return sign + colName + sign + strconv.Itoa(row), err
 }
 
+// CellNamesToCoordinates converts a list of alphanumeric cell names to the
+// list of [X, Y] coordinates, or returns an error if any cell name is invalid.
+//
+// Example:
+//
+//	excelize.CellNamesToCoordinates([]string{"A1", "Z3"}) // returns [][]int{{1, 1}, {26, 3}}, nil
+func CellNamesToCoordinates(cells []string) ([][]int, error) {
+	coordinates := make([][]int, len(cells))
+	for i, cell := range cells {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return nil, err
+		}
+		coordinates[i] = []int{col, row}
+	}
+	return coordinates, nil
+}
+
+// CoordinatesToCellNames converts a list of [X, Y] coordinates to the list of
+// alpha-numeric cell names, or returns an error if any coordinates is
+// invalid.
+//
+// Example:
+//
+//	excelize.CoordinatesToCellNames([][]int{{1, 1}, {26, 3}}) // returns []string{"A1", "Z3"}, nil
+//	excelize.CoordinatesToCellNames([][]int{{1, 1}}, true) // returns []string{"$A$1"}, nil
+func CoordinatesToCellNames(coordinates [][]int, abs ...bool) ([]string, error) {
+	cells := make([]string, len(coordinates))
+	for i, coordinate := range coordinates {
+		if len(coordinate) != 2 {
+			return nil, ErrParameterInvalid
+		}
+		cell, err := CoordinatesToCellName(coordinate[0], coordinate[1], abs...)
+		if err != nil {
+			return nil, err
+		}
+		cells[i] = cell
+	}
+	return cells, nil
+}
+
+// ExpandRange provides a function to get every cell name in the range
+// reference by row order, or returns an error if the range reference is
+// invalid or out of the worksheet bounds. The reference can be a single cell
+// or a range with absolute or reversed cells. Note that a string will be
+// allocated for each cell in the range, so the range should contain at most
+// MaxExpandRangeCells cells, otherwise the ErrRangeTooLarge error will be
+// returned.
+//
+// Example:
+//
+//	excelize.ExpandRange("A1:B2") // returns []string{"A1", "B1", "A2", "B2"}, nil
+//	excelize.ExpandRange("$C$3") // returns []string{"C3"}, nil
+func ExpandRange(ref string) ([]string, error) {
+	coordinates, err := refToSortedCoordinates(ref)
+	if err != nil {
+		return nil, err
+	}
+	size := (coordinates[2] - coordinates[0] + 1) * (coordinates[3] - coordinates[1] + 1)
+	if size > MaxExpandRangeCells {
+		return nil, ErrRangeTooLarge
+	}
+	cells := make([]string, 0, size)
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			cells = append(cells, cell)
+		}
+	}
+	return cells, nil
+}
+
+// JoinCells provides a function to join a list of cell names into a
+// reference sequence, the adjacent cells will be merged into ranges. The
+// cells in the same columns of consecutive rows will be merged as a range, and
+// the duplicate, invalid or out of the worksheet bounds cell names will be
+// ignored. The ranges in the reference sequence are ordered by the top-left
+// cell by row order.
+//
+// Example:
+//
+//	excelize.JoinCells([]string{"A1", "B1", "A2", "B2", "D4"}) // returns "A1:B2 D4"
+func JoinCells(cells []string) string {
+	rows, exists := make(map[int][]int), make(map[[2]int]struct{})
+	for _, cell := range cells {
+		col, row, err := CellNameToCoordinates(strings.ReplaceAll(cell, "$", ""))
+		if err != nil {
+			continue
+		}
+		if _, ok := exists[[2]int{col, row}]; ok {
+			continue
+		}
+		exists[[2]int{col, row}] = struct{}{}
+		rows[row] = append(rows[row], col)
+	}
+	ranges := mergeCoordinates(rows)
+	refs := make([]string, len(ranges))
+	for i, rng := range ranges {
+		refs[i] = coordinatesToRef(rng)
+	}
+	return strings.Join(refs, " ")
+}
+
+// RangeIntersect provides a function to get the intersection of two range
+// references, the reference can be a single cell or a range with absolute or
+// reversed cells. This function returns false if the references are invalid
+// or not overlapped.
+//
+// Example:
+//
+//	excelize.RangeIntersect("A1:C3", "B2:D4") // returns "B2:C3", true
+//	excelize.RangeIntersect("A1:B2", "C3:D4") // returns "", false
+func RangeIntersect(a, b string) (string, bool) {
+	x, err := refToSortedCoordinates(a)
+	if err != nil {
+		return "", false
+	}
+	y, err := refToSortedCoordinates(b)
+	if err != nil {
+		return "", false
+	}
+	coordinates := []int{
+		int(math.Max(float64(x[0]), float64(y[0]))), int(math.Max(float64(x[1]), float64(y[1]))),
+		int(math.Min(float64(x[2]), float64(y[2]))), int(math.Min(float64(x[3]), float64(y[3]))),
+	}
+	if coordinates[0] > coordinates[2] || coordinates[1] > coordinates[3] {
+		return "", false
+	}
+	return coordinatesToRef(coordinates), true
+}
+
+// RangeUnion provides a function to get a covering set of non-overlapping
+// ranges for the union of the range references, the reference can be a single
+// cell or a range with absolute or reversed cells, and the invalid references
+// will be ignored. The ranges in the result are ordered by the top-left cell
+// by row order.
+//
+// Example:
+//
+//	excelize.RangeUnion([]string{"A1:B2", "B2:C3"}) // returns []string{"A1:B1", "A2:C2", "B3:C3"}
+func RangeUnion(ranges []string) []string {
+	var refs [][]int
+	cols, rows := make(map[int]struct{}), make(map[int]struct{})
+	for _, ref := range ranges {
+		coordinates, err := refToSortedCoordinates(ref)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, coordinates)
+		cols[coordinates[0]], cols[coordinates[2]+1] = struct{}{}, struct{}{}
+		rows[coordinates[1]], rows[coordinates[3]+1] = struct{}{}, struct{}{}
+	}
+	// Compress the coordinates into the grid of the edges of the ranges, the
+	// adjacent indexes in the grid are adjacent in the worksheet
+	colEdges, rowEdges := sortedKeys(cols), sortedKeys(rows)
+	grid, exists := make(map[int][]int), make(map[[2]int]struct{})
+	for _, coordinates := range refs {
+		for r := sort.SearchInts(rowEdges, coordinates[1]); rowEdges[r] <= coordinates[3]; r++ {
+			for c := sort.SearchInts(colEdges, coordinates[0]); colEdges[c] <= coordinates[2]; c++ {
+				if _, ok := exists[[2]int{c, r}]; !ok {
+					exists[[2]int{c, r}] = struct{}{}
+					grid[r] = append(grid[r], c)
+				}
+			}
+		}
+	}
+	result := make([]string, 0, len(refs))
+	for _, rng := range mergeCoordinates(grid) {
+		result = append(result, coordinatesToRef([]int{
+			colEdges[rng[0]], rowEdges[rng[1]], colEdges[rng[2]+1] - 1, rowEdges[rng[3]+1] - 1,
+		}))
+	}
+	return result
+}
+
+// refToSortedCoordinates provides a function to convert a single cell or range
+// reference to a pair of sorted coordinates.
+func refToSortedCoordinates(ref string) ([]int, error) {
+	rng := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+	if len(rng) == 1 {
+		rng = append(rng, rng[0])
+	}
+	if len(rng) != 2 {
+		return nil, ErrParameterInvalid
+	}
+	coordinates, err := cellRefsToCoordinates(rng[0], rng[1])
+	if err != nil {
+		return nil, err
+	}
+	_ = sortCoordinates(coordinates)
+	return coordinates, err
+}
+
+// mergeCoordinates provides a function to merge the coordinates which indexed
+// by the row number into ranges, the adjacent columns in the same row will be
+// merged as a range, and the ranges with the same columns in consecutive rows
+// will be merged. The ranges are ordered by the top-left cell by row order.
+func mergeCoordinates(rows map[int][]int) [][]int {
+	var ranges, lastRanges [][]int
+	rowNums := make([]int, 0, len(rows))
+	for row := range rows {
+		rowNums = append(rowNums, row)
+	}
+	sort.Ints(rowNums)
+	for _, row := range rowNums {
+		cols, currentRanges := rows[row], [][]int{}
+		sort.Ints(cols)
+		for i := 0; i < len(cols); i++ {
+			j := i
+			for j+1 < len(cols) && cols[j+1] == cols[j]+1 {
+				j++
+			}
+			rng := []int{cols[i], row, cols[j], row}
+			for _, lastRange := range lastRanges {
+				if lastRange[0] == cols[i] && lastRange[2] == cols[j] && lastRange[3] == row-1 {
+					lastRange[3], rng = row, lastRange
+					break
+				}
+			}
+			if rng[1] == row {
+				ranges = append(ranges, rng)
+			}
+			currentRanges, i = append(currentRanges, rng), j
+		}
+		lastRanges = currentRanges
+	}
+	return ranges
+}
+
+// coordinatesToRef provides a function to convert a pair of valid coordinates
+// to the range reference, or the cell reference if the range is a single
+// cell.
+func coordinatesToRef(coordinates []int) string {
+	firstCell, _ := CoordinatesToCellName(coordinates[0], coordinates[1])
+	if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+		return firstCell
+	}
+	lastCell, _ := CoordinatesToCellName(coordinates[2], coordinates[3])
+	return firstCell + ":" + lastCell
+}
+
+// sortedKeys returns the sorted keys of the integer set.
+func sortedKeys(set map[int]struct{}) []int {
+	keys := make([]int, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
 // rangeRefToCoordinates provides a function to convert range reference to a
 // pair of coordinates.
 func rangeRefToCoordinates(ref string) ([]int, error) {
@@ -332,12 +586,12 @@ func (f *File) coordinatesToRangeRef(coordinates []int, abs ...bool) (string, er
 func (f *File) getDefinedNameRefTo(definedNameName string, currentSheet string) (refTo string) {
 	var workbookRefTo, worksheetRefTo string
 	for _, definedName := range f.GetDefinedName() {
-		if definedName.Name == definedNameName {
+		if strings.EqualFold(definedName.Name, definedNameName) {
 			// worksheet scope takes precedence over scope workbook when both definedNames exist
 			if definedName.Scope == "Workbook" {
 				workbookRefTo = definedName.RefersTo
 			}
-			if definedName.Scope == currentSheet {
+			if strings.EqualFold(definedName.Scope, currentSheet) {
 				worksheetRefTo = definedName.RefersTo
 			}
 		}
@@ -378,6 +632,85 @@ func (f *File) flatSqref(sqref string) (cells map[int][][]int, err error) {
 	return
 }
 
+// expandSqref provides a function to convert the whole column and whole row
+// references in the reference sequence to the cell range references. For
+// example, the reference sequence "A:B 3:4 D5" will be converted to
+// "A1:B1048576 A3:XFD4 D5".
+func expandSqref(sqref string) (string, error) {
+	refs := strings.Fields(sqref)
+	for i, ref := range refs {
+		rng := strings.Split(strings.ReplaceAll(ref, "$", ""), ":")
+		if len(rng) != 2 {
+			continue
+		}
+		if first, err := strconv.Atoi(rng[0]); err == nil {
+			last, err := strconv.Atoi(rng[1])
+			if err != nil {
+				return sqref, newInvalidCellNameError(ref)
+			}
+			if first > last {
+				first, last = last, first
+			}
+			if first < 1 || last > TotalRows {
+				return sqref, ErrMaxRows
+			}
+			lastName, _ := ColumnNumberToName(MaxColumns)
+			refs[i] = fmt.Sprintf("A%d:%s%d", first, lastName, last)
+			continue
+		}
+		if strings.ContainsAny(rng[0]+rng[1], "0123456789") {
+			continue
+		}
+		first, err := ColumnNameToNumber(rng[0])
+		if err != nil {
+			return sqref, err
+		}
+		last, err := ColumnNameToNumber(rng[1])
+		if err != nil {
+			return sqref, err
+		}
+		if first > last {
+			first, last = last, first
+		}
+		firstName, _ := ColumnNumberToName(first)
+		lastName, _ := ColumnNumberToName(last)
+		refs[i] = fmt.Sprintf("%s1:%s%d", firstName, lastName, TotalRows)
+	}
+	return strings.Join(refs, " "), nil
+}
+
+// compactSqref provides a function to convert the cell range references which
+// cover the whole columns or whole rows in the reference sequence to the
+// column or row references. For example, the reference sequence
+// "A1:B1048576 A3:XFD4 D5" will be converted to "A:B 3:4 D5".
+func compactSqref(sqref string) string {
+	refs := strings.Fields(sqref)
+	for i, ref := range refs {
+		rng := strings.Split(ref, ":")
+		if len(rng) != 2 {
+			continue
+		}
+		firstCol, firstRow, err := CellNameToCoordinates(rng[0])
+		if err != nil {
+			continue
+		}
+		lastCol, lastRow, err := CellNameToCoordinates(rng[1])
+		if err != nil {
+			continue
+		}
+		if firstRow == 1 && lastRow == TotalRows {
+			firstName, _ := ColumnNumberToName(firstCol)
+			lastName, _ := ColumnNumberToName(lastCol)
+			refs[i] = firstName + ":" + lastName
+			continue
+		}
+		if firstCol == 1 && lastCol == MaxColumns {
+			refs[i] = fmt.Sprintf("%d:%d", firstRow, lastRow)
+		}
+	}
+	return strings.Join(refs, " ")
+}
+
 // inCoordinates provides a method to check if a coordinate is present in
 // coordinates array, and return the index of its location, otherwise
 // return -1.
@@ -404,6 +737,17 @@ func inStrSlice(a []string, x string, caseSensitive bool) int {
 	return -1
 }
 
+// getMapKeyByValue provides a method to get the key of the given value in a
+// string map, return empty string if the value can not be found.
+func getMapKeyByValue(m map[string]string, x string) string {
+	for key, value := range m {
+		if value == x {
+			return key
+		}
+	}
+	return ""
+}
+
 // inFloat64Slice provides a method to check if an element is present in a
 // float64 array, and return the index of its location, otherwise return -1.
 func inFloat64Slice(a []float64, x float64) int {
@@ -493,6 +837,62 @@ func (avb *attrValBool) UnmarshalXML(d *xml.Decoder, start xml.StartElement) err
 	return nil
 }
 
+// UnmarshalXML retain the name, attributes and inner XML content of the
+// unsupported element on deserialization.
+func (ue *xlsxUnknownElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var inner xlsxInnerXML
+	if err := d.DecodeElement(&inner, &start); err != nil {
+		return err
+	}
+	ue.XMLName, ue.Attr, ue.Content = start.Name, start.Attr, inner.Content
+	return nil
+}
+
+// MarshalXML re-emit the unsupported element on serialization, the namespace
+// of the element and attributes will be written with the prefix which
+// declared in the element or the root element of the part.
+func (ue xlsxUnknownElement) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var attrs []xml.Attr
+	prefixes := map[string]string{NameSpaceXML: "xml", NameSpaceSpreadSheet.Value: ""}
+	for _, ns := range [][]xml.Attr{ue.namespaces, ue.Attr} {
+		for _, attr := range ns {
+			if attr.Name.Space == "xmlns" {
+				prefixes[attr.Value] = attr.Name.Local
+			}
+		}
+	}
+	qualify := func(name xml.Name) string {
+		if name.Space == "" || name.Space == "xmlns" {
+			return name.Local
+		}
+		prefix, ok := prefixes[name.Space]
+		for idx := 0; !ok; idx++ {
+			prefix, ok = fmt.Sprintf("ns%d", idx), true
+			for _, declared := range prefixes {
+				ok = ok && declared != prefix
+			}
+		}
+		if _, ok = prefixes[name.Space]; !ok {
+			prefixes[name.Space] = prefix
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xmlns:" + prefix}, Value: name.Space})
+		}
+		if prefix == "" {
+			return name.Local
+		}
+		return prefix + ":" + name.Local
+	}
+	start = xml.StartElement{Name: xml.Name{Local: qualify(ue.XMLName)}}
+	for _, attr := range ue.Attr {
+		name := qualify(attr.Name)
+		if attr.Name.Space == "xmlns" {
+			name = "xmlns:" + attr.Name.Local
+		}
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: attr.Value})
+	}
+	start.Attr = attrs
+	return e.EncodeElement(xlsxInnerXML{Content: ue.Content}, start)
+}
+
 // namespaceStrictToTransitional provides a method to convert Strict and
 // Transitional namespaces.
 func namespaceStrictToTransitional(content []byte) []byte {
@@ -629,6 +1029,103 @@ func (f *File) replaceNameSpaceBytes(path string, contentMarshal []byte) []byte
 	return bytesReplace(contentMarshal, sourceXmlns, bytes.ReplaceAll(targetXmlns, []byte(" mc:Ignorable=\"r\""), []byte{}), -1)
 }
 
+// insertUnknownElements provides a function to insert the unsupported
+// elements of the component part into the serialized content by given part
+// path, serialized content, unsupported elements and the structure of the
+// part. Each element will be inserted after the supported element which
+// precedes it in the original part, or the nearest supported element before
+// that in the schema order if it doesn't exist. The elements will be
+// discarded if the KeepUnknownElements option is disabled.
+func (f *File) insertUnknownElements(path string, content []byte, elements []xlsxUnknownElement, v interface{}) []byte {
+	if !f.options.KeepUnknownElements || len(elements) == 0 {
+		return content
+	}
+	order := make(map[string]int)
+	typ := reflect.TypeOf(v).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("xml"), ",")[0]
+		name = name[strings.LastIndexAny(name, " :")+1:]
+		if _, ok := order[name]; !ok && name != "" && name != "-" {
+			order[name] = i
+		}
+	}
+	// find the supported element which precedes each element in the original
+	// part
+	after := make([]string, len(elements))
+	d, depth, prev, next := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readBytes(path)))), 0, "", 0
+	for next < len(elements) {
+		token, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth++; depth != 2 {
+				continue
+			}
+			if t.Name == elements[next].XMLName {
+				after[next], next = prev, next+1
+				continue
+			}
+			prev = t.Name.Local
+		case xml.EndElement:
+			depth--
+		}
+	}
+	// find the end offset of the root element start tag and each child element
+	// in the serialized content
+	type position struct{ index, offset int }
+	var positions []position
+	d, depth = xml.NewDecoder(bytes.NewReader(content)), 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth++; depth == 1 {
+				positions = append(positions, position{index: -1, offset: int(d.InputOffset())})
+			}
+		case xml.EndElement:
+			if depth--; depth == 1 {
+				if index, ok := order[t.Name.Local]; ok {
+					positions = append(positions, position{index: index, offset: int(d.InputOffset())})
+				}
+			}
+		}
+	}
+	if len(positions) == 0 {
+		return content
+	}
+	insertions := make(map[int][]byte)
+	for idx, element := range elements {
+		index, ok := order[after[idx]]
+		if !ok {
+			index = -1
+		}
+		offset := positions[0].offset
+		for _, pos := range positions {
+			if pos.index <= index {
+				offset = pos.offset
+			}
+		}
+		element.namespaces = f.xmlAttr[path]
+		output, _ := xml.Marshal(element)
+		insertions[offset] = append(insertions[offset], output...)
+	}
+	var result []byte
+	last := 0
+	for _, pos := range positions {
+		if output, ok := insertions[pos.offset]; ok {
+			result = append(append(result, content[last:pos.offset]...), output...)
+			last = pos.offset
+			delete(insertions, pos.offset)
+		}
+	}
+	return append(result, content[last:]...)
+}
+
 // addNameSpaces provides a function to add an XML attribute by the given
 // component part path.
 func (f *File) addNameSpaces(path string, ns xml.Attr) {
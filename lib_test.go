@@ -217,6 +217,181 @@ func TestCoordinatesToCellName_Error(t *testing.T) {
 	}
 }
 
+func TestCellNamesToCoordinates(t *testing.T) {
+	coordinates, err := CellNamesToCoordinates([]string{"A1", "Z3", "$AK$74"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 1}, {26, 3}, {37, 74}}, coordinates)
+	coordinates, err = CellNamesToCoordinates(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, coordinates)
+	// Test convert cell names to coordinates with invalid cell name
+	_, err = CellNamesToCoordinates([]string{"A1", "-"})
+	assert.EqualError(t, err, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")).Error())
+	_, err = CellNamesToCoordinates([]string{"A1048577"})
+	assert.EqualError(t, err, ErrMaxRows.Error())
+}
+
+func TestCoordinatesToCellNames(t *testing.T) {
+	cells, err := CoordinatesToCellNames([][]int{{1, 1}, {26, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A1", "Z3"}, cells)
+	cells, err = CoordinatesToCellNames([][]int{{1, 1}}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"$A$1"}, cells)
+	// Test convert coordinates to cell names with invalid coordinates
+	_, err = CoordinatesToCellNames([][]int{{1}})
+	assert.EqualError(t, err, ErrParameterInvalid.Error())
+	_, err = CoordinatesToCellNames([][]int{{1, 1}, {0, 1}})
+	assert.EqualError(t, err, "invalid cell reference [0, 1]")
+	_, err = CoordinatesToCellNames([][]int{{MaxColumns + 1, 1}})
+	assert.EqualError(t, err, ErrColumnNumber.Error())
+}
+
+func TestExpandRange(t *testing.T) {
+	for ref, expected := range map[string][]string{
+		"A1:B2":                 {"A1", "B1", "A2", "B2"},
+		"B2:A1":                 {"A1", "B1", "A2", "B2"},
+		"$C$3":                  {"C3"},
+		"C3:C3":                 {"C3"},
+		"$A1:A$3":               {"A1", "A2", "A3"},
+		"XFD1048576:XFC1048576": {"XFC1048576", "XFD1048576"},
+	} {
+		cells, err := ExpandRange(ref)
+		assert.NoError(t, err, ref)
+		assert.Equal(t, expected, cells, ref)
+	}
+	// Test expand range with invalid reference
+	for ref, expected := range map[string]string{
+		"":            newCellNameToCoordinatesError("", newInvalidCellNameError("")).Error(),
+		"A1:B2:C3":    ErrParameterInvalid.Error(),
+		"A1:-":        newCellNameToCoordinatesError("-", newInvalidCellNameError("-")).Error(),
+		"A1:A1048577": ErrMaxRows.Error(),
+		"A1:XFE1":     ErrColumnNumber.Error(),
+		"A1:B1048576": ErrRangeTooLarge.Error(),
+	} {
+		cells, err := ExpandRange(ref)
+		assert.EqualError(t, err, expected, ref)
+		assert.Nil(t, cells, ref)
+	}
+	// Test expand range with the maximum number of cells
+	cells, err := ExpandRange("A1:A1048576")
+	assert.NoError(t, err)
+	assert.Len(t, cells, MaxExpandRangeCells)
+}
+
+func TestJoinCells(t *testing.T) {
+	for _, item := range []struct {
+		cells    []string
+		expected string
+	}{
+		{nil, ""},
+		{[]string{"A1"}, "A1"},
+		{[]string{"A1", "B1", "A2", "B2", "D4"}, "A1:B2 D4"},
+		{[]string{"B2", "A2", "B1", "A1", "$A$1", "a1"}, "A1:B2"},
+		{[]string{"A1", "B1", "C1", "A2", "C2", "A3", "B3", "C3"}, "A1:C1 A2 C2 A3:C3"},
+		{[]string{"A1", "A2", "A4", "B4"}, "A1:A2 A4:B4"},
+		{[]string{"A1", "C1", "A2", "C2"}, "A1:A2 C1:C2"},
+		{[]string{"A1", "-", "A0", "XFE1", "A2"}, "A1:A2"},
+	} {
+		assert.Equal(t, item.expected, JoinCells(item.cells), item.cells)
+	}
+	cells, err := ExpandRange("B2:D5")
+	assert.NoError(t, err)
+	assert.Equal(t, "B2:D5", JoinCells(cells))
+}
+
+func TestRangeIntersect(t *testing.T) {
+	for _, item := range [][]string{
+		{"A1:C3", "B2:D4", "B2:C3"},
+		{"C3:A1", "$D$4:$B$2", "B2:C3"},
+		{"A1:C3", "B2", "B2"},
+		{"A1:C3", "C3:D4", "C3"},
+		{"A1:D4", "B2:C3", "B2:C3"},
+		{"A1:XFD1048576", "B2:C3", "B2:C3"},
+	} {
+		ref, ok := RangeIntersect(item[0], item[1])
+		assert.True(t, ok, item)
+		assert.Equal(t, item[2], ref, item)
+	}
+	for _, item := range [][]string{
+		{"A1:B2", "C3:D4"},
+		{"A1:B2", "C1:D2"},
+		{"A1:B2", "A3:B4"},
+		{"A1:B2", "-"},
+		{"-", "A1:B2"},
+		{"A1:B2:C3", "A1"},
+	} {
+		ref, ok := RangeIntersect(item[0], item[1])
+		assert.False(t, ok, item)
+		assert.Empty(t, ref, item)
+	}
+}
+
+func TestExpandSqref(t *testing.T) {
+	for _, item := range []struct {
+		sqref, expected, compacted string
+	}{
+		{"A1", "A1", "A1"},
+		{"A1:B2  C3", "A1:B2 C3", "A1:B2 C3"},
+		{"A:A", "A1:A1048576", "A:A"},
+		{"$B:$A 2:1 $3:$3", "A1:B1048576 A1:XFD2 A3:XFD3", "A:B 1:2 3:3"},
+	} {
+		sqref, err := expandSqref(item.sqref)
+		assert.NoError(t, err, item.sqref)
+		assert.Equal(t, item.expected, sqref, item.sqref)
+		assert.Equal(t, item.compacted, compactSqref(sqref), item.sqref)
+	}
+	for sqref, err := range map[string]error{
+		"A:XFE":   ErrColumnNumber,
+		"XFE:A":   ErrColumnNumber,
+		"0:1":     ErrMaxRows,
+		"1:A":     newInvalidCellNameError("1:A"),
+		"A1 -1:1": ErrMaxRows,
+	} {
+		_, e := expandSqref(sqref)
+		assert.Equal(t, err, e, sqref)
+	}
+	assert.Equal(t, "A:XFD 1:1 A1:B", compactSqref("A1:XFD1048576 A1:XFD1 A1:B"))
+}
+
+func TestRangeUnion(t *testing.T) {
+	for _, item := range []struct {
+		ranges   []string
+		expected []string
+	}{
+		{nil, []string{}},
+		{[]string{"A1:B2"}, []string{"A1:B2"}},
+		{[]string{"A1:B2", "A1:B2", "B1:A2"}, []string{"A1:B2"}},
+		{[]string{"A1:B2", "B2:C3"}, []string{"A1:B1", "A2:C2", "B3:C3"}},
+		{[]string{"A1:B2", "C1:D2"}, []string{"A1:D2"}},
+		{[]string{"A1:B2", "A3:B4"}, []string{"A1:B4"}},
+		{[]string{"A1:D4", "B2:C3"}, []string{"A1:D4"}},
+		{[]string{"A1", "C3", "-", "A1:B2:C3"}, []string{"A1", "C3"}},
+		{[]string{"A1:A1048576", "B1:XFD1048576"}, []string{"A1:XFD1048576"}},
+	} {
+		assert.Equal(t, item.expected, RangeUnion(item.ranges), item.ranges)
+	}
+	// Test the union ranges are not overlapped and cover the same cells
+	ranges := []string{"B2:E5", "D4:G8", "A7:C9", "F1:F10"}
+	result := RangeUnion(ranges)
+	var expected, actual []string
+	for _, ref := range ranges {
+		cells, err := ExpandRange(ref)
+		assert.NoError(t, err)
+		expected = append(expected, cells...)
+	}
+	for i, ref := range result {
+		for j := i + 1; j < len(result); j++ {
+			_, ok := RangeIntersect(ref, result[j])
+			assert.False(t, ok, ref, result[j])
+		}
+		cells, err := ExpandRange(ref)
+		assert.NoError(t, err)
+		actual = append(actual, cells...)
+	}
+	assert.Equal(t, JoinCells(expected), JoinCells(actual))
+}
+
 func TestCoordinatesToRangeRef(t *testing.T) {
 	f := NewFile()
 	_, err := f.coordinatesToRangeRef([]int{})
@@ -24,9 +24,11 @@ import (
 // languageInfo defined the required fields of localization support for number
 // format.
 type languageInfo struct {
-	apFmt      string
-	tags       []string
-	localMonth func(t time.Time, abbr int) string
+	apFmt                    string
+	tags                     []string
+	localMonth               func(t time.Time, abbr int) string
+	weekdays, weekdaysAbbr   []string
+	decimalSep, thousandsSep string
 }
 
 // numberFormat directly maps the number format parser runtime required
@@ -40,10 +42,11 @@ type numberFormat struct {
 	date1904, isNumeric, hours, seconds, useMillisecond         bool
 	number                                                      float64
 	ap, localCode, result, value, valueSectionType              string
-	switchArgument, currencyString                              string
+	switchArgument, currencyString, decimalSep, thousandsSep    string
 	fracHolder, fracPadding, intHolder, intPadding, expBaseLen  int
 	percent                                                     int
 	useCommaSep, usePointer, usePositive, useScientificNotation bool
+	useFraction                                                 bool
 }
 
 // CultureName is the type of supported language country codes types for apply
@@ -679,19 +682,37 @@ var (
 		633: "[$ZWN]\\ #,##0.00",
 		634: "[$ZWR]\\ #,##0.00",
 	}
+	// numFmtColors defined the RGB color values of the colors name which can be
+	// used in a section of the number format.
+	numFmtColors = map[string]string{
+		"black":   "000000",
+		"blue":    "0000FF",
+		"cyan":    "00FFFF",
+		"green":   "00FF00",
+		"magenta": "FF00FF",
+		"red":     "FF0000",
+		"white":   "FFFFFF",
+		"yellow":  "FFFF00",
+	}
 	// supportedTokenTypes list the supported number format token types currently.
 	supportedTokenTypes = []string{
 		nfp.TokenSubTypeCurrencyString,
 		nfp.TokenSubTypeLanguageInfo,
 		nfp.TokenTypeColor,
+		nfp.TokenTypeCondition,
 		nfp.TokenTypeCurrencyLanguage,
 		nfp.TokenTypeDateTimes,
 		nfp.TokenTypeDecimalPoint,
+		nfp.TokenTypeDenominator,
+		nfp.TokenTypeDigitalPlaceHolder,
 		nfp.TokenTypeElapsedDateTimes,
 		nfp.TokenTypeExponential,
+		nfp.TokenTypeFraction,
 		nfp.TokenTypeGeneral,
 		nfp.TokenTypeHashPlaceHolder,
 		nfp.TokenTypeLiteral,
+		nfp.TokenTypeOperand,
+		nfp.TokenTypeOperator,
 		nfp.TokenTypePercent,
 		nfp.TokenTypeSwitchArgument,
 		nfp.TokenTypeTextPlaceHolder,
@@ -700,11 +721,21 @@ var (
 	}
 	// supportedNumberTokenTypes list the supported number token types.
 	supportedNumberTokenTypes = []string{
+		nfp.TokenTypeDenominator,
+		nfp.TokenTypeDigitalPlaceHolder,
 		nfp.TokenTypeExponential,
+		nfp.TokenTypeFraction,
 		nfp.TokenTypeHashPlaceHolder,
 		nfp.TokenTypePercent,
 		nfp.TokenTypeZeroPlaceHolder,
 	}
+	// supportedFractionPlaceHolderTypes list the supported digit placeholder
+	// token types for the integer, numerator and denominator parts of fraction.
+	supportedFractionPlaceHolderTypes = []string{
+		nfp.TokenTypeDigitalPlaceHolder,
+		nfp.TokenTypeHashPlaceHolder,
+		nfp.TokenTypeZeroPlaceHolder,
+	}
 	// supportedDateTimeTokenTypes list the supported date and time token types.
 	supportedDateTimeTokenTypes = []string{
 		nfp.TokenTypeDateTimes,
@@ -714,14 +745,14 @@ var (
 	supportedLanguageInfo = map[string]languageInfo{
 		"36":   {tags: []string{"af"}, localMonth: localMonthsNameAfrikaans, apFmt: apFmtAfrikaans},
 		"445":  {tags: []string{"bn-IN"}, localMonth: localMonthsNameBangla, apFmt: nfp.AmPm[0]},
-		"4":    {tags: []string{"zh-Hans"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2]},
-		"7804": {tags: []string{"zh"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2]},
-		"804":  {tags: []string{"zh-CN"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2]},
-		"1004": {tags: []string{"zh-SG"}, localMonth: localMonthsNameChinese2, apFmt: nfp.AmPm[2]},
-		"7C04": {tags: []string{"zh-Hant"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2]},
-		"C04":  {tags: []string{"zh-HK"}, localMonth: localMonthsNameChinese2, apFmt: nfp.AmPm[2]},
-		"1404": {tags: []string{"zh-MO"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2]},
-		"404":  {tags: []string{"zh-TW"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2]},
+		"4":    {tags: []string{"zh-Hans"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"7804": {tags: []string{"zh"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"804":  {tags: []string{"zh-CN"}, localMonth: localMonthsNameChinese1, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"1004": {tags: []string{"zh-SG"}, localMonth: localMonthsNameChinese2, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"7C04": {tags: []string{"zh-Hant"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"C04":  {tags: []string{"zh-HK"}, localMonth: localMonthsNameChinese2, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"1404": {tags: []string{"zh-MO"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
+		"404":  {tags: []string{"zh-TW"}, localMonth: localMonthsNameChinese3, apFmt: nfp.AmPm[2], weekdays: weekdayNamesChinese, weekdaysAbbr: weekdayNamesChineseAbbr},
 		"9":    {tags: []string{"en"}, localMonth: localMonthsNameEnglish, apFmt: nfp.AmPm[0]},
 		"1000": {tags: []string{
 			"aa", "aa-DJ", "aa-ER", "aa-ER", "aa-NA", "agq", "agq-CM", "ak", "ak-GH", "sq-ML",
@@ -786,24 +817,27 @@ var (
 		"809":  {tags: []string{"en-GB"}, localMonth: localMonthsNameEnglish, apFmt: strings.ToLower(nfp.AmPm[0])},
 		"409":  {tags: []string{"en-US"}, localMonth: localMonthsNameEnglish, apFmt: nfp.AmPm[0]},
 		"3009": {tags: []string{"en-ZW"}, localMonth: localMonthsNameEnglish, apFmt: nfp.AmPm[0]},
-		"C":    {tags: []string{"fr"}, localMonth: localMonthsNameFrench, apFmt: nfp.AmPm[0]},
-		"7":    {tags: []string{"de"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0]},
-		"C07":  {tags: []string{"de-AT"}, localMonth: localMonthsNameAustria, apFmt: nfp.AmPm[0]},
-		"407":  {tags: []string{"de-DE"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0]},
+		"C":    {tags: []string{"fr"}, localMonth: localMonthsNameFrench, apFmt: nfp.AmPm[0], weekdays: weekdayNamesFrench, weekdaysAbbr: weekdayNamesFrenchAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"40C":  {tags: []string{"fr-FR"}, localMonth: localMonthsNameFrench, apFmt: nfp.AmPm[0], weekdays: weekdayNamesFrench, weekdaysAbbr: weekdayNamesFrenchAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"7":    {tags: []string{"de"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0], weekdays: weekdayNamesGerman, weekdaysAbbr: weekdayNamesGermanAbbr, decimalSep: ",", thousandsSep: "."},
+		"C07":  {tags: []string{"de-AT"}, localMonth: localMonthsNameAustria, apFmt: nfp.AmPm[0], weekdays: weekdayNamesGerman, weekdaysAbbr: weekdayNamesGermanAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"407":  {tags: []string{"de-DE"}, localMonth: localMonthsNameGerman, apFmt: nfp.AmPm[0], weekdays: weekdayNamesGerman, weekdaysAbbr: weekdayNamesGermanAbbr, decimalSep: ",", thousandsSep: "."},
 		"3C":   {tags: []string{"ga"}, localMonth: localMonthsNameIrish, apFmt: apFmtIrish},
 		"83C":  {tags: []string{"ga-IE"}, localMonth: localMonthsNameIrish, apFmt: apFmtIrish},
-		"10":   {tags: []string{"it"}, localMonth: localMonthsNameItalian, apFmt: nfp.AmPm[0]},
-		"11":   {tags: []string{"ja"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese},
-		"411":  {tags: []string{"ja-JP"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese},
-		"12":   {tags: []string{"ko"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean},
-		"412":  {tags: []string{"ko-KR"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean},
+		"10":   {tags: []string{"it"}, localMonth: localMonthsNameItalian, apFmt: nfp.AmPm[0], weekdays: weekdayNamesItalian, weekdaysAbbr: weekdayNamesItalianAbbr, decimalSep: ",", thousandsSep: "."},
+		"410":  {tags: []string{"it-IT"}, localMonth: localMonthsNameItalian, apFmt: nfp.AmPm[0], weekdays: weekdayNamesItalian, weekdaysAbbr: weekdayNamesItalianAbbr, decimalSep: ",", thousandsSep: "."},
+		"11":   {tags: []string{"ja"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese, weekdays: weekdayNamesJapanese, weekdaysAbbr: weekdayNamesJapaneseAbbr},
+		"411":  {tags: []string{"ja-JP"}, localMonth: localMonthsNameChinese3, apFmt: apFmtJapanese, weekdays: weekdayNamesJapanese, weekdaysAbbr: weekdayNamesJapaneseAbbr},
+		"12":   {tags: []string{"ko"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean, weekdays: weekdayNamesKorean, weekdaysAbbr: weekdayNamesKoreanAbbr},
+		"412":  {tags: []string{"ko-KR"}, localMonth: localMonthsNameKorean, apFmt: apFmtKorean, weekdays: weekdayNamesKorean, weekdaysAbbr: weekdayNamesKoreanAbbr},
 		"7C50": {tags: []string{"mn-Mong"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
 		"850":  {tags: []string{"mn-Mong-CN"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
 		"C50":  {tags: []string{"mn-Mong-MN"}, localMonth: localMonthsNameTraditionalMongolian, apFmt: nfp.AmPm[0]},
-		"19":   {tags: []string{"ru"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0]},
-		"819":  {tags: []string{"ru-MD"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0]},
-		"419":  {tags: []string{"ru-RU"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0]},
-		"A":    {tags: []string{"es"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
+		"19":   {tags: []string{"ru"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0], weekdays: weekdayNamesRussian, weekdaysAbbr: weekdayNamesRussianAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"819":  {tags: []string{"ru-MD"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0], weekdays: weekdayNamesRussian, weekdaysAbbr: weekdayNamesRussianAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"419":  {tags: []string{"ru-RU"}, localMonth: localMonthsNameRussian, apFmt: nfp.AmPm[0], weekdays: weekdayNamesRussian, weekdaysAbbr: weekdayNamesRussianAbbr, decimalSep: ",", thousandsSep: "\u00a0"},
+		"A":    {tags: []string{"es"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish, weekdays: weekdayNamesSpanish, weekdaysAbbr: weekdayNamesSpanishAbbr, decimalSep: ",", thousandsSep: "."},
+		"C0A":  {tags: []string{"es-ES"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish, weekdays: weekdayNamesSpanish, weekdaysAbbr: weekdayNamesSpanishAbbr, decimalSep: ",", thousandsSep: "."},
 		"2C0A": {tags: []string{"es-AR"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"200A": {tags: []string{"es-VE"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
 		"400A": {tags: []string{"es-BO"}, localMonth: localMonthsNameSpanish, apFmt: apFmtSpanish},
@@ -833,6 +867,62 @@ var (
 		"35":   {tags: []string{"zu"}, localMonth: localMonthsNameZulu, apFmt: nfp.AmPm[0]},
 		"435":  {tags: []string{"zu-ZA"}, localMonth: localMonthsNameZulu, apFmt: nfp.AmPm[0]},
 	}
+	// supportedLanguageTags directly maps the language tags in lowercase and
+	// the language ID.
+	supportedLanguageTags = func() map[string]string {
+		tags := make(map[string]string)
+		for langID, info := range supportedLanguageInfo {
+			for _, tag := range info.tags {
+				if _, ok := tags[strings.ToLower(tag)]; !ok || langID != "1000" {
+					tags[strings.ToLower(tag)] = langID
+				}
+			}
+		}
+		return tags
+	}()
+	// weekdayNamesChinese list the weekday names for the Chinese locales, such
+	// as zh-CN, zh-SG, zh-HK, zh-MO and zh-TW.
+	weekdayNamesChinese = []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"}
+	// weekdayNamesChineseAbbr list the abbreviated weekday names used by the
+	// ddd token for the Chinese locales.
+	weekdayNamesChineseAbbr = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+	// weekdayNamesFrench list the weekday names for the fr and fr-FR locales.
+	weekdayNamesFrench = []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"}
+	// weekdayNamesFrenchAbbr list the abbreviated weekday names for the fr and
+	// fr-FR locales, each of them ends with a period.
+	weekdayNamesFrenchAbbr = []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."}
+	// weekdayNamesGerman list the weekday names for the de, de-AT and de-DE
+	// locales.
+	weekdayNamesGerman = []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"}
+	// weekdayNamesGermanAbbr list the two letters weekday names for the German
+	// locales.
+	weekdayNamesGermanAbbr = []string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"}
+	// weekdayNamesItalian list the weekday names for the it and it-IT locales.
+	weekdayNamesItalian = []string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"}
+	// weekdayNamesItalianAbbr list the three letters weekday names for the
+	// Italian locales.
+	weekdayNamesItalianAbbr = []string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"}
+	// weekdayNamesJapanese list the weekday names for the ja and ja-JP locales.
+	weekdayNamesJapanese = []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"}
+	// weekdayNamesJapaneseAbbr list the single kanji weekday names for the
+	// Japanese locales.
+	weekdayNamesJapaneseAbbr = []string{"日", "月", "火", "水", "木", "金", "土"}
+	// weekdayNamesKorean list the weekday names for the ko and ko-KR locales.
+	weekdayNamesKorean = []string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"}
+	// weekdayNamesKoreanAbbr list the single syllable weekday names for the
+	// Korean locales.
+	weekdayNamesKoreanAbbr = []string{"일", "월", "화", "수", "목", "금", "토"}
+	// weekdayNamesRussian list the weekday names for the ru, ru-MD and ru-RU
+	// locales.
+	weekdayNamesRussian = []string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"}
+	// weekdayNamesRussianAbbr list the two letters weekday names for the
+	// Russian locales.
+	weekdayNamesRussianAbbr = []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
+	// weekdayNamesSpanish list the weekday names for the es and es-ES locales.
+	weekdayNamesSpanish = []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+	// weekdayNamesSpanishAbbr list the abbreviated weekday names for the
+	// Spanish locales, each of them ends with a period.
+	weekdayNamesSpanishAbbr = []string{"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."}
 	// monthNamesBangla list the month names in the Bangla.
 	monthNamesBangla = []string{
 		"\u099C\u09BE\u09A8\u09C1\u09AF\u09BC\u09BE\u09B0\u09C0",
@@ -989,44 +1079,144 @@ var (
 	apFmtYi = "\ua3b8\ua111/\ua06f\ua2d2"
 	// apFmtWelsh defined the AM/PM name in the Welsh.
 	apFmtWelsh = "yb/yh"
-	// switchArgumentFunc defined the switch argument printer function
-	switchArgumentFunc = map[string]func(s string) string{
-		"[DBNum1]": func(s string) string {
-			r := strings.NewReplacer(
-				"0", "\u25cb", "1", "\u4e00", "2", "\u4e8c", "3", "\u4e09", "4", "\u56db",
-				"5", "\u4e94", "6", "\u516d", "7", "\u4e03", "8", "\u516b", "9", "\u4e5d",
-			)
-			return r.Replace(s)
-		},
-		"[DBNum2]": func(s string) string {
-			r := strings.NewReplacer(
-				"0", "\u96f6", "1", "\u58f9", "2", "\u8d30", "3", "\u53c1", "4", "\u8086",
-				"5", "\u4f0d", "6", "\u9646", "7", "\u67d2", "8", "\u634c", "9", "\u7396",
-			)
-			return r.Replace(s)
+	// dbNumNumerals defined the numerals of the switch arguments in the Chinese
+	// and Japanese, the Chinese numerals will be used if the language of the
+	// number format is not Japanese.
+	dbNumNumerals = map[string]map[string]dbNumNumeral{
+		"zh": {
+			"[DBNUM1]": {
+				digits:         []string{"\u25cb", "\u4e00", "\u4e8c", "\u4e09", "\u56db", "\u4e94", "\u516d", "\u4e03", "\u516b", "\u4e5d"},
+				units:          []string{"\u5341", "\u767e", "\u5343", "\u4e07", "\u4ebf"},
+				omitLeadingOne: true,
+			},
+			"[DBNUM2]": {
+				digits: []string{"\u96f6", "\u58f9", "\u8d30", "\u53c1", "\u8086", "\u4f0d", "\u9646", "\u67d2", "\u634c", "\u7396"},
+				units:  []string{"\u62fe", "\u4f70", "\u4edf", "\u4e07", "\u4ebf"},
+			},
+			"[DBNUM3]": {
+				digits: []string{"\uff10", "\uff11", "\uff12", "\uff13", "\uff14", "\uff15", "\uff16", "\uff17", "\uff18", "\uff19"},
+				units:  []string{"\u5341", "\u767e", "\u5343", "\u4e07", "\u4ebf"},
+			},
 		},
-		"[DBNum3]": func(s string) string {
-			r := strings.NewReplacer(
-				"0", "\uff10", "1", "\uff11", "2", "\uff12", "3", "\uff13", "4", "\uff14",
-				"5", "\uff15", "6", "\uff16", "7", "\uff17", "8", "\uff18", "9", "\uff19",
-			)
-			return r.Replace(s)
+		"ja": {
+			"[DBNUM1]": {
+				digits:   []string{"\u3007", "\u4e00", "\u4e8c", "\u4e09", "\u56db", "\u4e94", "\u516d", "\u4e03", "\u516b", "\u4e5d"},
+				units:    []string{"\u5341", "\u767e", "\u5343", "\u4e07", "\u5104"},
+				omitOne:  true,
+				omitZero: true,
+			},
+			"[DBNUM2]": {
+				digits:   []string{"\u3007", "\u58f1", "\u5f10", "\u53c2", "\u56db", "\u4f0d", "\u516d", "\u4e03", "\u516b", "\u4e5d"},
+				units:    []string{"\u62fe", "\u767e", "\u9621", "\u842c", "\u5104"},
+				omitZero: true,
+			},
+			"[DBNUM3]": {
+				digits:   []string{"\uff10", "\uff11", "\uff12", "\uff13", "\uff14", "\uff15", "\uff16", "\uff17", "\uff18", "\uff19"},
+				units:    []string{"\u5341", "\u767e", "\u5343", "\u4e07", "\u5104"},
+				omitZero: true,
+			},
 		},
 	}
 )
 
-// applyBuiltInNumFmt provides a function to returns a value after formatted
-// with built-in number format code, or specified sort date format code.
-func (f *File) applyBuiltInNumFmt(c *xlsxC, fmtCode string, numFmtID int, date1904 bool, cellType CellType) string {
-	if f.options != nil && f.options.ShortDatePattern != "" {
-		if numFmtID == 14 {
-			fmtCode = f.options.ShortDatePattern
+// dbNumNumeral directly maps the numerals of a switch argument, the digits
+// contains the numerals from 0 to 9, and the units contains the ten, hundred,
+// thousand, ten thousand and hundred million. The omitLeadingOne specifies
+// if omit the one before the ten at the beginning of the numerals, the
+// omitOne specifies if omit the one before the ten, hundred and thousand,
+// the omitZero specifies if omit the zero between the numerals with units.
+type dbNumNumeral struct {
+	digits, units                     []string
+	omitLeadingOne, omitOne, omitZero bool
+}
+
+// styleNumFmt directly maps the number format code and the parsed number
+// format sections of a cell style, and the style sheet, number formats and
+// options which it was resolved by.
+type styleNumFmt struct {
+	styles   *xlsxStyleSheet
+	numFmts  *xlsxNumFmts
+	opts     *Options
+	numFmtID int
+	code     string
+	section  []nfp.Section
+}
+
+// getStyleNumFmt provides a function to get the number format applier by given
+// style sheet, cell style index and number format ID. The number format code
+// will be parsed once and cached by the style index, the cache will be
+// invalidated if the style sheet, number formats, number format ID of the
+// style or the options has been changed. This function returns nil if the
+// number format ID can not be found.
+func (f *File) getStyleNumFmt(styleSheet *xlsxStyleSheet, styleIdx, numFmtID int) *styleNumFmt {
+	if cache, ok := f.numFmtCache.Load(styleIdx); ok {
+		if numFmt := cache.(*styleNumFmt); numFmt.styles == styleSheet &&
+			numFmt.numFmts == styleSheet.NumFmts && numFmt.opts == f.options &&
+			numFmt.numFmtID == numFmtID {
+			return numFmt
 		}
-		if numFmtID == 22 {
-			fmtCode = fmt.Sprintf("%s hh:mm", f.options.ShortDatePattern)
+	}
+	numFmt := styleNumFmt{styles: styleSheet, numFmts: styleSheet.NumFmts, opts: f.options, numFmtID: numFmtID}
+	if fmtCode, ok := f.getBuiltInNumFmtCode(numFmtID); ok {
+		if f.options != nil && f.options.ShortDatePattern != "" {
+			if numFmtID == 14 {
+				fmtCode = f.options.ShortDatePattern
+			}
+			if numFmtID == 22 {
+				fmtCode = fmt.Sprintf("%s hh:mm", f.options.ShortDatePattern)
+			}
+		}
+		numFmt.code = fmtCode
+	} else {
+		if styleSheet.NumFmts == nil {
+			return nil
+		}
+		var ok bool
+		for _, xlsxFmt := range styleSheet.NumFmts.NumFmt {
+			if xlsxFmt.NumFmtID == numFmtID {
+				numFmt.code, ok = xlsxFmt.FormatCode, true
+				break
+			}
+		}
+		if !ok {
+			return nil
+		}
+	}
+	numFmt.section = parseNumFmtCode(numFmt.code)
+	f.numFmtCache.Store(styleIdx, &numFmt)
+	return &numFmt
+}
+
+// format provides a function to return a string parse by the cached number
+// format sections of the cell style.
+func (numFmt *styleNumFmt) format(value string, date1904 bool, cellType CellType, opts *Options) string {
+	return formatSection(value, numFmt.section, date1904, cellType, opts)
+}
+
+// isDateTime provides a function to check if the first section of the cached
+// number format contains date or time tokens.
+func (numFmt *styleNumFmt) isDateTime() bool {
+	if len(numFmt.section) == 0 {
+		return false
+	}
+	for _, token := range numFmt.section[0].Items {
+		if token.TType == nfp.TokenTypeDateTimes || token.TType == nfp.TokenTypeElapsedDateTimes {
+			return true
 		}
 	}
-	return format(c.V, fmtCode, date1904, cellType, f.options)
+	return false
+}
+
+// color provides a function to return the RGB color of the cached number
+// format section which applies to the value.
+func (numFmt *styleNumFmt) color(value string, cellType CellType) string {
+	nf := numberFormat{section: numFmt.section, value: value, cellType: cellType}
+	nf.number, nf.valueSectionType = nf.getValueSectionType(value)
+	nf.prepareNumberic(value)
+	if !nf.selectSection() {
+		return ""
+	}
+	return nf.sectionColor()
 }
 
 // langNumFmtFuncEnUS returns number format code by given date and time pattern
@@ -1103,32 +1293,280 @@ func (nf *numberFormat) prepareNumberic(value string) {
 	}
 }
 
+// parseNumFmtCode provides a function to parse the number format code into
+// sections, the indexed color tokens such as [Color10] will be parsed as the
+// color tokens.
+func parseNumFmtCode(numFmtCode string) []nfp.Section {
+	p := nfp.NumberFormatParser()
+	section := p.Parse(numFmtCode)
+	for i := range section {
+		for j, token := range section[i].Items {
+			if token.TType == nfp.TokenTypeUnknown && getNumFmtColor(token.TValue) != "" {
+				section[i].Items[j].TType = nfp.TokenTypeColor
+			}
+		}
+	}
+	return section
+}
+
+// getNumFmtColor returns the RGB color value by given color name or indexed
+// color token in the number format, the indexed colors from [Color1] to
+// [Color56] map to the indexed color palette. This function returns an empty
+// string if the color is invalid.
+func getNumFmtColor(color string) string {
+	color = strings.ToLower(color)
+	if rgb, ok := numFmtColors[color]; ok {
+		return rgb
+	}
+	if !strings.HasPrefix(color, "color") {
+		return ""
+	}
+	if idx, err := strconv.Atoi(strings.TrimPrefix(color, "color")); err == nil && idx >= 1 && idx <= 56 {
+		return IndexedColorMapping[idx+7]
+	}
+	return ""
+}
+
 // format provides a function to return a string parse by number format
 // expression. If the given number format is not supported, this will return
 // the original cell value.
 func format(value, numFmt string, date1904 bool, cellType CellType, opts *Options) string {
-	p := nfp.NumberFormatParser()
-	nf := numberFormat{opts: opts, section: p.Parse(numFmt), value: value, date1904: date1904, cellType: cellType}
-	nf.number, nf.valueSectionType = nf.getValueSectionType(value)
-	nf.prepareNumberic(value)
+	return formatSection(value, parseNumFmtCode(numFmt), date1904, cellType, opts)
+}
+
+// FormatValue provides a function to apply the number format code to the raw
+// value, and returns the formatted value the same as the spreadsheet
+// application displays a cell value with the number format, without a
+// workbook. The date1904 specifies if the date and time codes based on the
+// 1904 date system. It supports the general, text (@), number, percentage,
+// fraction, scientific, currency, date and time number format codes. The raw
+// value and ErrUnsupportedNumberFormat will be returned if the number format
+// code contains unsupported tokens. For example, format a raw serial number
+// value with a date and time number format code:
+//
+//	result, err := excelize.FormatValue("43528.5", "yyyy-mm-dd hh:mm", false)
+//
+// The result will be "2019-03-04 12:00".
+func FormatValue(value, numFmtCode string, date1904 bool) (string, error) {
+	result, _, err := FormatValueWithColor(value, numFmtCode, date1904)
+	return result, err
+}
+
+// FractionNumFmtCode provides a function to build the fraction number format
+// code by given fraction number format settings, which could be used as the
+// custom number format of the cell style. The fraction will be approximated by
+// the denominator with the maximum number of digits, or be rounded to the
+// fixed denominator, such as halves, quarters and sixteenths. For example,
+// build the number format code for the imperial measurement in sixteenths of
+// an inch:
+//
+//	code, err := excelize.FractionNumFmtCode(excelize.FractionNumFmtOptions{
+//	    Denominator: 16,
+//	})
+//
+// The code will be "# ??/16", and the value 3.3125 will be displayed as
+// "3  5/16". Set the DenominatorDigits as 2 to get the code "# ??/??".
+func FractionNumFmtCode(opts FractionNumFmtOptions) (string, error) {
+	var numerator, denominator string
+	switch {
+	case opts.DenominatorDigits != 0 && opts.Denominator != 0:
+		return "", ErrFractionNumFmt
+	case opts.DenominatorDigits >= 1 && opts.DenominatorDigits <= 5:
+		numerator = strings.Repeat("?", opts.DenominatorDigits)
+		denominator = numerator
+	case opts.Denominator >= 2 && opts.Denominator <= 99999:
+		denominator = strconv.Itoa(opts.Denominator)
+		numerator = strings.Repeat("?", len(denominator))
+	default:
+		return "", ErrFractionNumFmt
+	}
+	if opts.ImproperFraction {
+		return numerator + "/" + denominator, nil
+	}
+	return "# " + numerator + "/" + denominator, nil
+}
+
+// FormatValueWithColor provides a function to apply the number format code to
+// the raw value, and returns the formatted value and the RGB color of the
+// number format section which applies to the value. The section will be
+// selected by the sign of the numeric value for the positive, negative, zero
+// sections, or by the conditions in the sections such as [>=100], and the
+// text section for the non-numeric value. The color will be empty if the
+// applied section doesn't contain color directive. For example, get the
+// formatted value and color of a negative value:
+//
+//	result, color, err := excelize.FormatValueWithColor("-1234.5", "#,##0.00;[Red]-#,##0.00", false)
+//
+// The result will be "-1,234.50" and the color will be "FF0000".
+func FormatValueWithColor(value, numFmtCode string, date1904 bool) (string, string, error) {
+	section := parseNumFmtCode(numFmtCode)
+	for _, s := range section {
+		for _, token := range s.Items {
+			if inStrSlice(supportedTokenTypes, token.TType, true) == -1 {
+				return value, "", ErrUnsupportedNumberFormat
+			}
+			for _, part := range token.Parts {
+				if inStrSlice(supportedTokenTypes, part.Token.TType, true) == -1 {
+					return value, "", ErrUnsupportedNumberFormat
+				}
+			}
+		}
+	}
+	nf := numberFormat{section: section, value: value, date1904: date1904, cellType: CellTypeNumber}
+	result := nf.formatSection()
+	return result, nf.sectionColor(), nil
+}
+
+// formatSection provides a function to return a string parse by the parsed
+// number format sections.
+func formatSection(value string, section []nfp.Section, date1904 bool, cellType CellType, opts *Options) string {
+	nf := numberFormat{opts: opts, section: section, value: value, date1904: date1904, cellType: cellType}
+	return nf.formatSection()
+}
+
+// formatSection provides a function to select the number format section which
+// applies to the value, and return the value formatted by the section.
+func (nf *numberFormat) formatSection() string {
+	nf.prepareLanguage()
+	nf.number, nf.valueSectionType = nf.getValueSectionType(nf.value)
+	nf.prepareNumberic(nf.value)
+	if nf.sectionIdx = -1; !nf.selectSection() {
+		return nf.value
+	}
+	if !nf.isNumeric {
+		return nf.textHandler()
+	}
+	if nf.number < 0 && !nf.usePositive {
+		return nf.negativeHandler()
+	}
+	return nf.positiveHandler()
+}
+
+// prepareLanguage provides a function to set the default language ID and the
+// decimal and thousands separators by the language tag in the options. The
+// language specified in the number format code by [$-xxx] takes precedence
+// over the default language ID. The separators of the en-US will be used if
+// the language tag is empty or unsupported.
+func (nf *numberFormat) prepareLanguage() {
+	nf.decimalSep, nf.thousandsSep = ".", ","
+	if nf.opts == nil || nf.opts.Language == "" {
+		return
+	}
+	langID, ok := supportedLanguageTags[strings.ToLower(nf.opts.Language)]
+	if !ok {
+		return
+	}
+	nf.localCode = langID
+	if languageInfo := supportedLanguageInfo[langID]; languageInfo.decimalSep != "" {
+		nf.decimalSep, nf.thousandsSep = languageInfo.decimalSep, languageInfo.thousandsSep
+	}
+}
+
+// localNumber replace the decimal point and thousands separators in the
+// pre-formatted number text with the separators of the language.
+func (nf *numberFormat) localNumber(text string) string {
+	if nf.decimalSep == "" || (nf.decimalSep == "." && nf.thousandsSep == ",") {
+		return text
+	}
+	return strings.NewReplacer(".", nf.decimalSep, ",", nf.thousandsSep).Replace(text)
+}
+
+// selectSection provides a function to select the number format section
+// which applies to the value. The section will be selected by the conditions
+// if any numeric section contains condition, the section without condition
+// applies to the values which not meet the conditions of other sections.
+// Otherwise, the section will be selected by the sign of the number, the zero
+// and negative number use the first section if the number format has no zero
+// or negative section. This function returns false if there is no applicable
+// section for the value.
+func (nf *numberFormat) selectSection() bool {
+	var numSections []int
+	var useCondition bool
 	for i, section := range nf.section {
-		nf.sectionIdx = i
-		if section.Type != nf.valueSectionType {
+		if section.Type == nfp.TokenSectionText {
+			if nf.valueSectionType == nfp.TokenSectionText {
+				nf.sectionIdx = i
+				return true
+			}
 			continue
 		}
-		if nf.isNumeric {
-			switch section.Type {
-			case nfp.TokenSectionPositive:
-				return nf.positiveHandler()
-			case nfp.TokenSectionNegative:
-				return nf.negativeHandler()
-			default:
-				return nf.zeroHandler()
+		numSections = append(numSections, i)
+		if _, ok := getSectionCondition(section); ok {
+			useCondition = true
+		}
+	}
+	if nf.valueSectionType == nfp.TokenSectionText || len(numSections) == 0 {
+		return false
+	}
+	if useCondition {
+		nf.usePositive = nf.number < 0
+		for _, i := range numSections {
+			if condition, ok := getSectionCondition(nf.section[i]); !ok || nf.matchCondition(condition) {
+				nf.sectionIdx = i
+				return true
 			}
 		}
-		return nf.textHandler()
+		return false
+	}
+	switch {
+	case nf.number > 0, nf.number == 0 && len(numSections) < 3:
+		nf.sectionIdx = numSections[0]
+	case nf.number < 0 && len(numSections) > 1:
+		nf.sectionIdx = numSections[1]
+	case nf.number == 0:
+		nf.sectionIdx = numSections[2]
+	default:
+		nf.sectionIdx, nf.usePositive = numSections[0], true
+	}
+	return true
+}
+
+// getSectionCondition returns the condition token of the number format
+// section, and if the section contains condition.
+func getSectionCondition(section nfp.Section) (nfp.Token, bool) {
+	for _, token := range section.Items {
+		if token.TType == nfp.TokenTypeCondition && len(token.Parts) == 2 {
+			return token, true
+		}
+	}
+	return nfp.Token{}, false
+}
+
+// matchCondition returns if the number meets the condition of a number format
+// section.
+func (nf *numberFormat) matchCondition(condition nfp.Token) bool {
+	operand, err := strconv.ParseFloat(condition.Parts[1].Token.TValue, 64)
+	if err != nil {
+		return false
+	}
+	switch condition.Parts[0].Token.TValue {
+	case "<":
+		return nf.number < operand
+	case "<=":
+		return nf.number <= operand
+	case ">":
+		return nf.number > operand
+	case ">=":
+		return nf.number >= operand
+	case "<>":
+		return nf.number != operand
+	default:
+		return nf.number == operand
+	}
+}
+
+// sectionColor returns the RGB color of the selected number format section,
+// this function returns an empty string if the section doesn't contain color.
+func (nf *numberFormat) sectionColor() string {
+	if nf.sectionIdx < 0 || nf.sectionIdx >= len(nf.section) {
+		return ""
 	}
-	return value
+	for _, token := range nf.section[nf.sectionIdx].Items {
+		if token.TType == nfp.TokenTypeColor {
+			return getNumFmtColor(token.TValue)
+		}
+	}
+	return ""
 }
 
 // getNumberPartLen returns the length of integer and fraction parts for the
@@ -1167,6 +1605,9 @@ func (nf *numberFormat) getNumberFmtConf() {
 		if token.TType == nfp.TokenTypeSwitchArgument {
 			nf.switchArgument = token.TValue
 		}
+		if token.TType == nfp.TokenTypeFraction {
+			nf.useFraction = true
+		}
 		if token.TType == nfp.TokenTypeZeroPlaceHolder {
 			if nf.usePointer {
 				if nf.useScientificNotation {
@@ -1239,12 +1680,74 @@ func (nf *numberFormat) printSwitchArgument(text string) string {
 	if nf.switchArgument == "" {
 		return text
 	}
-	if fn, ok := switchArgumentFunc[nf.switchArgument]; ok {
-		return fn(text)
+	if numeral, ok := nf.getDBNumNumeral(); ok {
+		var oldNew []string
+		for i, digit := range numeral.digits {
+			oldNew = append(oldNew, strconv.Itoa(i), digit)
+		}
+		return strings.NewReplacer(oldNew...).Replace(text)
 	}
 	return nf.value
 }
 
+// getDBNumNumeral returns the numerals of the switch argument by the language
+// of the number format, and if the switch argument is supported.
+func (nf *numberFormat) getDBNumNumeral() (dbNumNumeral, bool) {
+	lang := "zh"
+	if nf.localCode == "11" || nf.localCode == "411" {
+		lang = "ja"
+	}
+	numeral, ok := dbNumNumerals[lang][strings.ToUpper(nf.switchArgument)]
+	return numeral, ok
+}
+
+// printDBNumUnits returns the non-negative integer in the numerals with units
+// of the switch argument, for example, 1234 will be printed as "一千二百三十四"
+// in the Chinese by the [DBNum1] switch argument.
+func printDBNumUnits(n uint64, numeral dbNumNumeral) string {
+	var (
+		result   string
+		expUnits = []uint64{1e8, 1e4}
+	)
+	for i, exp := range expUnits {
+		if n >= exp {
+			result = printDBNumUnits(n/exp, numeral) + numeral.units[len(numeral.units)-1-i]
+			if n %= exp; n == 0 {
+				return result
+			}
+			if n < exp/10 && !numeral.omitZero {
+				result += numeral.digits[0]
+			}
+			numeral.omitLeadingOne = false
+			return result + printDBNumUnits(n, numeral)
+		}
+	}
+	if n == 0 {
+		return numeral.digits[0]
+	}
+	var useZero bool
+	for i, exp := range []uint64{1000, 100, 10, 1} {
+		digit := n / exp % 10
+		if digit == 0 {
+			useZero = result != ""
+			continue
+		}
+		if useZero && !numeral.omitZero {
+			result += numeral.digits[0]
+		}
+		useZero = false
+		if unit := 3 - i; unit > 0 {
+			if digit != 1 || !(numeral.omitOne || numeral.omitLeadingOne && result == "" && unit == 1) {
+				result += numeral.digits[digit]
+			}
+			result += numeral.units[unit-1]
+			continue
+		}
+		result += numeral.digits[digit]
+	}
+	return result
+}
+
 // printBigNumber format number which precision great than 15 with fraction
 // zero padding and percentage symbol.
 func (nf *numberFormat) printBigNumber(decimal float64, fracLen int) string {
@@ -1285,6 +1788,9 @@ func (nf *numberFormat) numberHandler() string {
 		result            string
 	)
 	nf.getNumberFmtConf()
+	if nf.useFraction {
+		return nf.fractionHandler()
+	}
 	if intLen = intPart; nf.intPadding > intPart {
 		intLen = nf.intPadding
 	}
@@ -1296,7 +1802,7 @@ func (nf *numberFormat) numberHandler() string {
 	}
 	if isNum, precision, decimal := isNumeric(nf.value); isNum {
 		if precision > 15 && intLen+fracLen > 15 {
-			return nf.printNumberLiteral(nf.printBigNumber(decimal, fracLen))
+			return nf.printNumberLiteral(nf.localNumber(nf.printBigNumber(decimal, fracLen)))
 		}
 	}
 	paddingLen := intLen + fracLen
@@ -1317,7 +1823,163 @@ func (nf *numberFormat) numberHandler() string {
 	if result = fmt.Sprintf(fmtCode, math.Abs(num)); nf.useCommaSep {
 		result = printCommaSep(result)
 	}
-	return nf.printNumberLiteral(result)
+	return nf.printNumberLiteral(nf.localNumber(result))
+}
+
+// fractionHandler handling fraction number format expression for positive and
+// negative numeric, the fraction format consists of an optional integer part,
+// the numerator placeholders, a slash and the denominator placeholders or a
+// fixed denominator, for example: # ?/?, # ??/??, ?/? and # ?/8.
+func (nf *numberFormat) fractionHandler() string {
+	var (
+		items             = nf.section[nf.sectionIdx].Items
+		fracIdx, intIdx   = -1, -1
+		intHolders        []nfp.Token
+		numerator, denom  int
+		intPart, fracPart float64
+		result            string
+	)
+	for i, token := range items {
+		if token.TType == nfp.TokenTypeFraction {
+			fracIdx = i
+			break
+		}
+	}
+	if fracIdx < 1 || fracIdx+1 >= len(items) ||
+		inStrSlice(supportedFractionPlaceHolderTypes, items[fracIdx-1].TType, true) == -1 {
+		return nf.value
+	}
+	for i, token := range items[:fracIdx-1] {
+		if inStrSlice(supportedFractionPlaceHolderTypes, token.TType, true) != -1 {
+			if intIdx == -1 {
+				intIdx = i
+			}
+			intHolders = append(intHolders, token)
+		}
+	}
+	useIntPart := intIdx != -1
+	if fracPart = math.Abs(nf.number); useIntPart {
+		intPart = math.Floor(fracPart)
+		fracPart -= intPart
+	}
+	numeratorToken, denomToken := items[fracIdx-1], items[fracIdx+1]
+	switch {
+	case denomToken.TType == nfp.TokenTypeDenominator:
+		if denom, _ = strconv.Atoi(denomToken.TValue); denom < 1 {
+			return nf.value
+		}
+		numerator = int(math.Round(fracPart * float64(denom)))
+	case inStrSlice(supportedFractionPlaceHolderTypes, denomToken.TType, true) != -1:
+		numerator, denom = approxFraction(fracPart, int(math.Pow10(len(denomToken.TValue)))-1)
+	default:
+		return nf.value
+	}
+	if useIntPart && numerator == denom {
+		intPart, numerator = intPart+1, 0
+	}
+	if nf.usePositive {
+		result += "-"
+	}
+	fraction := fmt.Sprintf("%s/%s", padFractionPart(strconv.Itoa(numerator), numeratorToken, true),
+		padFractionPart(strconv.Itoa(denom), denomToken, false))
+	if useIntPart && numerator == 0 {
+		fraction = strings.Repeat(" ", len(fraction))
+	}
+	for i, token := range items {
+		switch {
+		case i == fracIdx-1:
+			result += fraction
+		case i == fracIdx || i == fracIdx+1:
+			continue
+		case token.TType == nfp.TokenTypeCurrencyLanguage:
+			if err, changeNumFmtCode := nf.currencyLanguageHandler(token); err != nil || changeNumFmtCode {
+				return nf.value
+			}
+			result += nf.currencyString
+		case token.TType == nfp.TokenTypeLiteral:
+			result += token.TValue
+		case i == intIdx:
+			result += nf.printFractionIntPart(intPart, numerator, intHolders)
+		}
+	}
+	return nf.printSwitchArgument(result)
+}
+
+// printFractionIntPart format the integer part of the fraction number format
+// expression by given integer part value, numerator and the placeholders.
+func (nf *numberFormat) printFractionIntPart(intPart float64, numerator int, holders []nfp.Token) string {
+	var zeros, digits int
+	for _, token := range holders {
+		if token.TType == nfp.TokenTypeZeroPlaceHolder {
+			zeros += len(token.TValue)
+		}
+		if token.TType == nfp.TokenTypeDigitalPlaceHolder {
+			digits += len(token.TValue)
+		}
+	}
+	text := strconv.FormatFloat(intPart, 'f', 0, 64)
+	if text == "0" && zeros == 0 {
+		if text = ""; numerator == 0 {
+			text = "0"
+		}
+	}
+	if len(text) < zeros {
+		text = strings.Repeat("0", zeros-len(text)) + text
+	}
+	if nf.useCommaSep {
+		text = nf.localNumber(printCommaSep(text))
+	}
+	if len(text) < zeros+digits {
+		text = strings.Repeat(" ", zeros+digits-len(text)) + text
+	}
+	return text
+}
+
+// padFractionPart pad the numerator or denominator of the fraction by given
+// placeholder token, the numerator will be right-aligned and the denominator
+// will be left-aligned by the question mark placeholder.
+func padFractionPart(text string, token nfp.Token, alignRight bool) string {
+	if len(text) >= len(token.TValue) {
+		return text
+	}
+	padding := len(token.TValue) - len(text)
+	switch token.TType {
+	case nfp.TokenTypeZeroPlaceHolder:
+		return strings.Repeat("0", padding) + text
+	case nfp.TokenTypeDigitalPlaceHolder:
+		if alignRight {
+			return strings.Repeat(" ", padding) + text
+		}
+		return text + strings.Repeat(" ", padding)
+	}
+	return text
+}
+
+// approxFraction returns the numerator and denominator of the closest fraction
+// to the given non-negative number, and the denominator of the fraction will
+// not greater than the given maximum denominator.
+func approxFraction(x float64, maxDenom int) (int, int) {
+	if maxDenom < 1 {
+		maxDenom = 1
+	}
+	p0, q0, p1, q1 := 0, 1, 1, 0
+	for v := x; ; {
+		a := math.Floor(v)
+		p2, q2 := int(a)*p1+p0, int(a)*q1+q0
+		if q2 > maxDenom {
+			k := (maxDenom - q0) / q1
+			p, q := p0+k*p1, q0+k*q1
+			if math.Abs(x-float64(p)/float64(q)) < math.Abs(x-float64(p1)/float64(q1)) {
+				return p, q
+			}
+			return p1, q1
+		}
+		p0, q0, p1, q1 = p1, q1, p2, q2
+		if v-a < 1e-10 {
+			return p1, q1
+		}
+		v = 1 / (v - a)
+	}
 }
 
 // dateTimeHandler handling data and time number format expression for a
@@ -1342,7 +2004,7 @@ func (nf *numberFormat) dateTimeHandler() string {
 			continue
 		}
 		if token.TType == nfp.TokenTypeDecimalPoint {
-			nf.result += "."
+			nf.result += nf.localNumber(".")
 		}
 		if token.TType == nfp.TokenTypeSwitchArgument {
 			nf.switchArgument = token.TValue
@@ -1363,9 +2025,12 @@ func (nf *numberFormat) dateTimeHandler() string {
 func (nf *numberFormat) positiveHandler() string {
 	var fmtNum bool
 	for _, token := range nf.section[nf.sectionIdx].Items {
-		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 || token.TType == nfp.TokenTypeGeneral {
+		if inStrSlice(supportedTokenTypes, token.TType, true) == -1 {
 			return nf.value
 		}
+		if token.TType == nfp.TokenTypeGeneral {
+			return nf.generalHandler()
+		}
 		if inStrSlice(supportedNumberTokenTypes, token.TType, true) != -1 {
 			fmtNum = true
 		}
@@ -1395,9 +2060,61 @@ func (nf *numberFormat) positiveHandler() string {
 	return nf.numberHandler()
 }
 
+// generalHandler will be handling general number format expression with the
+// switch arguments for a numeric, such as [DBNum1][$-804]General, the integer
+// part of the number will be printed in the numerals with units. The
+// original value will be returned if the section doesn't contain supported
+// switch argument.
+func (nf *numberFormat) generalHandler() string {
+	var result string
+	for _, token := range nf.section[nf.sectionIdx].Items {
+		if token.TType == nfp.TokenTypeSwitchArgument {
+			nf.switchArgument = token.TValue
+		}
+		if token.TType == nfp.TokenTypeCurrencyLanguage {
+			if err, changeNumFmtCode := nf.currencyLanguageHandler(token); err != nil || changeNumFmtCode {
+				return nf.value
+			}
+		}
+	}
+	numeral, ok := nf.getDBNumNumeral()
+	if !ok {
+		return nf.value
+	}
+	parts := strings.Split(strconv.FormatFloat(math.Abs(nf.number), 'f', -1, 64), ".")
+	intPart, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return nf.value
+	}
+	if nf.number < 0 {
+		result += "-"
+	}
+	for _, token := range nf.section[nf.sectionIdx].Items {
+		if token.TType == nfp.TokenTypeCurrencyLanguage {
+			result += nf.currencyString
+		}
+		if token.TType == nfp.TokenTypeLiteral {
+			result += token.TValue
+		}
+		if token.TType == nfp.TokenTypeGeneral {
+			result += printDBNumUnits(intPart, numeral)
+			if len(parts) == 2 {
+				result += "." + nf.printSwitchArgument(parts[1])
+			}
+		}
+	}
+	return result
+}
+
 // currencyLanguageHandler will be handling currency and language types tokens
 // for a number format expression.
 func (nf *numberFormat) currencyLanguageHandler(token nfp.Token) (error, bool) {
+	if tag := strings.TrimSuffix(strings.TrimPrefix(token.TValue, "[$-"), "]"); tag != token.TValue {
+		if langID, ok := supportedLanguageTags[strings.ToLower(tag)]; ok { // [$-zh-CN]
+			nf.localCode, nf.currencyString = langID, ""
+			return nil, false
+		}
+	}
 	for _, part := range token.Parts {
 		if inStrSlice(supportedTokenTypes, part.Token.TType, true) == -1 {
 			return ErrUnsupportedNumberFormat, false
@@ -1722,7 +2439,7 @@ func (nf *numberFormat) dateTimesHandler(i int, token nfp.Token) {
 	if strings.Contains(strings.ToUpper(token.TValue), "M") {
 		l := len(token.TValue)
 		if l == 1 && !nf.hours && !nf.secondsNext(i) {
-			nf.result += strconv.Itoa(int(nf.t.Month()))
+			nf.result += nf.printDBNumDateTime(int(nf.t.Month()))
 			return
 		}
 		if l == 2 && !nf.hours && !nf.secondsNext(i) {
@@ -1769,21 +2486,46 @@ func (nf *numberFormat) daysHandler(token nfp.Token) {
 	if strings.Contains(strings.ToUpper(token.TValue), "D") {
 		switch len(token.TValue) {
 		case 1:
-			nf.result += strconv.Itoa(nf.t.Day())
+			nf.result += nf.printDBNumDateTime(nf.t.Day())
 			return
 		case 2:
 			nf.result += fmt.Sprintf("%02d", nf.t.Day())
 			return
 		case 3:
-			nf.result += nf.t.Weekday().String()[:3]
+			nf.result += nf.localWeekdayName(true)
 			return
 		default:
-			nf.result += nf.t.Weekday().String()
+			nf.result += nf.localWeekdayName(false)
 			return
 		}
 	}
 }
 
+// printDBNumDateTime returns the month or day number in the numerals with
+// units if the number format contains supported switch argument, for example,
+// the 19th day will be printed as "十九" by the [DBNum1] switch argument.
+func (nf *numberFormat) printDBNumDateTime(n int) string {
+	if numeral, ok := nf.getDBNumNumeral(); ok {
+		return printDBNumUnits(uint64(n), numeral)
+	}
+	return strconv.Itoa(n)
+}
+
+// localWeekdayName returns the name of the weekday in the language, the
+// English name will be returned if the language hasn't weekday names.
+func (nf *numberFormat) localWeekdayName(abbr bool) string {
+	if languageInfo, ok := supportedLanguageInfo[nf.localCode]; ok && languageInfo.weekdays != nil {
+		if abbr {
+			return languageInfo.weekdaysAbbr[nf.t.Weekday()]
+		}
+		return languageInfo.weekdays[nf.t.Weekday()]
+	}
+	if abbr {
+		return nf.t.Weekday().String()[:3]
+	}
+	return nf.t.Weekday().String()
+}
+
 // hoursHandler will be handling hours in the date and times types tokens for a
 // number format expression.
 func (nf *numberFormat) hoursHandler(i int, token nfp.Token) {
@@ -1924,11 +2666,6 @@ func (nf *numberFormat) negativeHandler() (result string) {
 	return nf.numberHandler()
 }
 
-// zeroHandler will be handling zero selection for a number format expression.
-func (nf *numberFormat) zeroHandler() string {
-	return nf.value
-}
-
 // textHandler will be handling text selection for a number format expression.
 func (nf *numberFormat) textHandler() (result string) {
 	for _, token := range nf.section[nf.sectionIdx].Items {
@@ -1957,16 +2694,6 @@ func (nf *numberFormat) getValueSectionType(value string) (float64, string) {
 		return number, nfp.TokenSectionPositive
 	}
 	if number < 0 {
-		var hasNeg bool
-		for _, sec := range nf.section {
-			if sec.Type == nfp.TokenSectionNegative {
-				hasNeg = true
-			}
-		}
-		if !hasNeg {
-			nf.usePositive = true
-			return number, nfp.TokenSectionPositive
-		}
 		return number, nfp.TokenSectionNegative
 	}
 	return number, nfp.TokenSectionZero
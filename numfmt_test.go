@@ -1,6 +1,9 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -64,7 +67,7 @@ func TestNumFmt(t *testing.T) {
 		{"0.97952546296296295", "h:m", "23:30"},
 		{"43528", "mmmm", "March"},
 		{"43528", "dddd", "Monday"},
-		{"0", ";;;", "0"},
+		{"0", ";;;", ""},
 		{"43528", "[$-409]MM/DD/YYYY", "03/04/2019"},
 		{"43528", "[$-409]MM/DD/YYYY am/pm", "03/04/2019 AM"},
 		{"43528", "[$-111]MM/DD/YYYY", "43528"},
@@ -1032,6 +1035,34 @@ func TestNumFmt(t *testing.T) {
 		{"1234567890", "[DBNum1][$-804]0.00", "\u4e00\u4e8c\u4e09\u56db\u4e94\u516d\u4e03\u516b\u4e5d\u25cb.\u25cb\u25cb"},
 		{"1234567890", "[DBNum2][$-804]0.00", "\u58f9\u8d30\u53c1\u8086\u4f0d\u9646\u67d2\u634c\u7396\u96f6.\u96f6\u96f6"},
 		{"1234567890", "[DBNum3][$-804]0.00", "\uff11\uff12\uff13\uff14\uff15\uff16\uff17\uff18\uff19\uff10.\uff10\uff10"},
+		{"0", "[DBNum1][$-804]General", "○"},
+		{"15", "[DBNum1][$-804]General", "十五"},
+		{"115", "[DBNum1][$-804]General", "一百一十五"},
+		{"1004", "[DBNum1][$-804]General", "一千○四"},
+		{"1234", "[DBNum1][$-804]General", "一千二百三十四"},
+		{"100005", "[DBNum1][$-804]General", "十万○五"},
+		{"1234.56", "[DBNum1][$-804]General", "一千二百三十四.五六"},
+		{"-1234", "[DBNum1][$-804]General", "-一千二百三十四"},
+		{"1234567890", "[DBNum1][$-804]General", "十二亿三千四百五十六万七千八百九十"},
+		{"1234", "[DBNum2][$-804]General", "壹仟贰佰叁拾肆"},
+		{"1234", "[DBNum2][$-804]General\"元\"", "壹仟贰佰叁拾肆元"},
+		{"100000000", "[DBNum2][$-804]General", "壹亿"},
+		{"1234", "[DBNum3][$-804]General", "１千２百３十４"},
+		{"1234", "[DBNum1][$-zh-CN]General", "一千二百三十四"},
+		{"1234", "[DBNum1]General", "一千二百三十四"},
+		{"1234", "[dbnum1][$-804]General", "一千二百三十四"},
+		{"1234", "[DBNum1][$-411]General", "千二百三十四"},
+		{"10000", "[DBNum1][$-411]General", "一万"},
+		{"1004", "[DBNum1][$-ja-JP]General", "千四"},
+		{"1234", "[DBNum2][$-411]General\"円\"", "壱阡弐百参拾四円"},
+		{"1234", "[DBNum3][$-411]General", "１千２百３十４"},
+		{"1234", "[DBNum1][$-411]0", "一二三四"},
+		{"43543.503206018519", "[DBNum1][$-804]yyyy\"年\"m\"月\"d\"日\"", "二○一九年三月十九日"},
+		{"43814", "[DBNum1][$-804]yyyy\"年\"m\"月\"d\"日\"", "二○一九年十二月十五日"},
+		{"43543.503206018519", "[DBNum2][$-804]m\"月\"d\"日\"", "叁月壹拾玖日"},
+		{"43543.503206018519", "[DBNum1][$-411]yyyy\"年\"m\"月\"d\"日\"", "二〇一九年三月十九日"},
+		{"1234", "[DBNum4][$-804]General", "1234"},
+		{"1234", "[$-804]General", "1234"},
 		{"1234.5678", "0.00###", "1234.5678"},
 		{"1234.5678", "00000.00###", "01234.5678"},
 		{"-1234.5678", "00000.00###;;", ""},
@@ -1078,6 +1109,33 @@ func TestNumFmt(t *testing.T) {
 		})
 		assert.Equal(t, item[2], result, item)
 	}
+	// Test format number with language
+	for _, item := range [][]string{
+		{"en-US", "1234567.891", "#,##0.00", "1,234,567.89"},
+		{"", "43543.503206018519", "dddd, mmmm dd, yyyy h:mm AM/PM", "Tuesday, March 19, 2019 12:04 PM"},
+		{"unknown", "1234567.891", "#,##0.00", "1,234,567.89"},
+		{"de-DE", "1234567.891", "#,##0.00", "1.234.567,89"},
+		{"de-DE", "-1234.5", "#,##0.00;(#,##0.00)", "(1.234,50)"},
+		{"de-DE", "1234.5", "0.00E+00", "1,23E+03"},
+		{"de-DE", "12345.5", "#,##0 ?/?", "12.345 1/2"},
+		{"de-DE", "43543.503206018519", "ddd, dd mmm yyyy hh:mm:ss.000", "Di, 19 Mär 2019 12:04:37,000"},
+		{"de-DE", "43543.503206018519", "dddd, d mmmm yyyy", "Dienstag, 19 März 2019"},
+		{"DE-de", "43543.503206018519", "[$-409]dddd, mmmm d", "Tuesday, March 19"},
+		{"fr-FR", "1234567.891", "#,##0.00", "1\u00a0234\u00a0567,89"},
+		{"fr-FR", "43543.503206018519", "dddd d mmmm yyyy", "mardi 19 mars 2019"},
+		{"fr-FR", "43543.503206018519", "ddd d mmm", "mar. 19 mars"},
+		{"ja-JP", "1234567.891", "#,##0.00", "1,234,567.89"},
+		{"ja-JP", "43543.503206018519", "yyyy\"年\"m\"月\"d\"日\" dddd AM/PM h:mm", "2019年3月19日 火曜日 午後 12:04"},
+		{"ja-JP", "43543.503206018519", "(ddd)", "(火)"},
+		{"zh-CN", "43543.503206018519", "dddd ddd", "星期二 周二"},
+		{"ko-KR", "43543.503206018519", "dddd", "화요일"},
+		{"ru-RU", "43543.503206018519", "dddd ddd", "вторник Вт"},
+		{"es-ES", "43543.503206018519", "dddd ddd", "martes mar."},
+		{"it-IT", "43543.503206018519", "dddd ddd", "martedì mar"},
+	} {
+		result := format(item[1], item[2], false, CellTypeNumber, &Options{Language: item[0]})
+		assert.Equal(t, item[3], result, item)
+	}
 	// Test format number with string data type cell value
 	for _, cellType := range []CellType{CellTypeSharedString, CellTypeInlineString} {
 		for _, item := range [][]string{
@@ -1097,3 +1155,223 @@ func TestNumFmt(t *testing.T) {
 	assert.Equal(t, ErrUnsupportedNumberFormat, err)
 	assert.False(t, changeNumFmtCode)
 }
+
+func TestStyleNumFmtCache(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{NumFmt: 14})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 43528))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	for i := 0; i < 2; i++ {
+		result, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, "03-04-19", result)
+	}
+	cache, ok := f.numFmtCache.Load(styleID)
+	assert.True(t, ok)
+	assert.Equal(t, "mm-dd-yy", cache.(*styleNumFmt).code)
+	// Test get cell value after the number format of the style has been changed
+	numFmtID := 22
+	f.Styles.CellXfs.Xf[styleID].NumFmtID = &numFmtID
+	result, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3/4/19 00:00", result)
+	// Test get cell value after the options has been changed
+	f.options = &Options{ShortDatePattern: "yyyy/m/d"}
+	result, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2019/3/4 00:00", result)
+	// Test get cell value after the custom number format has been changed
+	customNumFmt := "yyyy-mm-dd"
+	styleID, err = f.NewStyle(&Style{CustomNumFmt: &customNumFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	result, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-03-04", result)
+	f.Styles.NumFmts = &xlsxNumFmts{NumFmt: []*xlsxNumFmt{{NumFmtID: *f.Styles.CellXfs.Xf[styleID].NumFmtID, FormatCode: "d/m/yyyy"}}}
+	result, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4/3/2019", result)
+	// Test get cell value after the style sheet has been reloaded
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, []byte(xml.Header+templateStyles))
+	result, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "43528", result)
+	assert.NoError(t, f.Close())
+}
+
+func TestFormatValue(t *testing.T) {
+	for _, item := range [][]string{
+		{"1234.5678", "General", "1234.5678"},
+		{"1234.5678", "@", "1234.5678"},
+		{"text", "\"Text: \"@", "Text: text"},
+		{"1234.5678", "#,##0.00", "1,234.57"},
+		{"0.125", "0.00%", "12.50%"},
+		{"1234.5678", "0.00E+00", "1.23E+03"},
+		{"43528.5", "yyyy-mm-dd hh:mm", "2019-03-04 12:00"},
+		{"1234.5", "# ?/?", "1234 1/2"},
+		{"0.75", "# ??/??", "  3/4 "},
+		{"1.5", "?/?", "3/2"},
+		{"3.25", "# ?/4", "3 1/4"},
+		{"3.3", "# ?/4", "3 1/4"},
+		{"3.9", "# ?/4", "4    "},
+		{"3", "# ?/?", "3    "},
+		{"2.9999", "# ?/?", "3    "},
+		{"0.333", "0 ?/?", "0 1/3"},
+		{"3.14159265", "# ???/???", "3  16/113"},
+		{"12345.5", "#,##0 ?/?", "12,345 1/2"},
+		{"0.5", "?/8", "4/8"},
+		{"-1.25", "# ?/?", "-1 1/4"},
+		{"-1.25", "# ?/?;(# ?/?)", "(1 1/4)"},
+		{"0.25", "\"$\"# ?/?", "$ 1/4"},
+	} {
+		result, err := FormatValue(item[0], item[1], false)
+		assert.NoError(t, err, item)
+		assert.Equal(t, item[2], result, item)
+	}
+	result, err := FormatValue("43528.5", "yyyy-mm-dd", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "2023-03-05", result)
+	// Test format value with unsupported number format code
+	result, err = FormatValue("1234.5", "0.00*-", false)
+	assert.EqualError(t, err, ErrUnsupportedNumberFormat.Error())
+	assert.Equal(t, "1234.5", result)
+	result, err = FormatValue("1234.5", "[$-ZZZ]0.00", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "1234.5", result)
+	// Test format value with invalid fraction number format
+	for _, numFmt := range []string{"# ?/0", "# ?/"} {
+		result, err = FormatValue("1234.5", numFmt, false)
+		assert.NoError(t, err, numFmt)
+		assert.Equal(t, "1234.5", result, numFmt)
+	}
+}
+
+func TestFractionNumFmtCode(t *testing.T) {
+	f := NewFile()
+	for i, item := range []struct {
+		opts          FractionNumFmtOptions
+		code, value   string
+		expectedValue string
+	}{
+		{FractionNumFmtOptions{DenominatorDigits: 1}, "# ?/?", "3.5", "3 1/2"},
+		{FractionNumFmtOptions{DenominatorDigits: 3}, "# ???/???", "3.14159265", "3  16/113"},
+		{FractionNumFmtOptions{DenominatorDigits: 2, ImproperFraction: true}, "??/??", "1.5", " 3/2 "},
+		{FractionNumFmtOptions{Denominator: 2}, "# ?/2", "12.5", "12 1/2"},
+		{FractionNumFmtOptions{Denominator: 4}, "# ?/4", "-3.75", "-3 3/4"},
+		{FractionNumFmtOptions{Denominator: 16}, "# ??/16", "3.3125", "3  5/16"},
+		{FractionNumFmtOptions{Denominator: 16, ImproperFraction: true}, "??/16", "1.25", "20/16"},
+	} {
+		code, err := FractionNumFmtCode(item.opts)
+		assert.NoError(t, err)
+		assert.Equal(t, item.code, code)
+		// Test get cell value with the fraction number format
+		style, err := f.NewStyle(&Style{CustomNumFmt: &code})
+		assert.NoError(t, err)
+		cell := fmt.Sprintf("A%d", i+1)
+		assert.NoError(t, f.SetCellStyle("Sheet1", cell, cell, style))
+		value, err := strconv.ParseFloat(item.value, 64)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, item.expectedValue, result, item.code)
+	}
+	// Test build fraction number format code with invalid options
+	for _, opts := range []FractionNumFmtOptions{
+		{}, {DenominatorDigits: 6}, {Denominator: 1}, {Denominator: 100000}, {DenominatorDigits: 1, Denominator: 2},
+	} {
+		code, err := FractionNumFmtCode(opts)
+		assert.Equal(t, ErrFractionNumFmt, err)
+		assert.Empty(t, code)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestFormatValueWithColor(t *testing.T) {
+	for _, item := range [][]string{
+		{"1234.5", "#,##0.00;[Red]-#,##0.00", "1,234.50", ""},
+		{"-1234.5", "#,##0.00;[Red]-#,##0.00", "-1,234.50", "FF0000"},
+		{"-1234.5", "[Blue]#,##0.00;[Red](#,##0.00)", "(1,234.50)", "FF0000"},
+		{"0", "0.00;-0.00", "0.00", ""},
+		{"0", "0.00", "0.00", ""},
+		{"0", "[Green]0.00;[Red]-0.00;[Blue]\"zero\"", "zero", "0000FF"},
+		{"-5", "[Red]0.00", "-5.00", "FF0000"},
+		{"text", "0.00;-0.00;0;[magenta]@", "text", "FF00FF"},
+		{"text", "[Red]0.00", "text", ""},
+		{"150", "[>=100][Red]0;[Blue]0.0", "150", "FF0000"},
+		{"50", "[>=100][Red]0;[Blue]0.0", "50.0", "0000FF"},
+		{"-5", "[>=100][Red]0;[Blue]0.0", "-5.0", "0000FF"},
+		{"150", "[Red][>100]0;[Blue][<0]0;[Green]0.00", "150", "FF0000"},
+		{"-150", "[Red][>100]0;[Blue][<0]0;[Green]0.00", "-150", "0000FF"},
+		{"50", "[Red][>100]0;[Blue][<0]0;[Green]0.00", "50.00", "00FF00"},
+		{"50", "[<=50]0.0;[>50]0", "50.0", ""},
+		{"51", "[<=50]0.0;[>50]0", "51", ""},
+		{"1", "[=1]\"one\";[<>1]0", "one", ""},
+		{"2", "[=1]\"one\";[<>1]0", "2", ""},
+		{"50", "[>100]0;[<0]0", "50", ""},
+		{"-5", "0;[Color10]0", "5", "008000"},
+		{"5", "[color1]0;[Color56]0", "5", "000000"},
+		{"-5", "[color1]0;[Color56]0", "5", "333333"},
+	} {
+		result, color, err := FormatValueWithColor(item[0], item[1], false)
+		assert.NoError(t, err, item)
+		assert.Equal(t, item[2], result, item)
+		assert.Equal(t, item[3], color, item)
+	}
+	// Test format value with color with unsupported number format code
+	for _, numFmt := range []string{"[Red]0.00*-", "[Color57]0", "[Color0]0"} {
+		result, color, err := FormatValueWithColor("1234.5", numFmt, false)
+		assert.EqualError(t, err, ErrUnsupportedNumberFormat.Error(), numFmt)
+		assert.Equal(t, "1234.5", result, numFmt)
+		assert.Empty(t, color, numFmt)
+	}
+}
+
+func TestGetCellValueWithLanguage(t *testing.T) {
+	f := NewFile(Options{Language: "de-DE"})
+	numFmt := "dddd, d mmmm yyyy"
+	dateStyle, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	numStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 43528))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", dateStyle))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1234567.891))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", numStyle))
+	result, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Montag, 4 März 2019", result)
+	result, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.234.567,89", result)
+}
+
+func TestGetCellValueWithDBNum(t *testing.T) {
+	f := NewFile()
+	numFmt := "[DBNum2][$-804]General\"元\""
+	style, err := f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1234))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	result, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "壹仟贰佰叁拾肆元", result)
+	// Test get cell value with DBNum and the Japanese language in the options
+	f.options.Language = "ja-JP"
+	numFmt = "[DBNum1]General"
+	style, err = f.NewStyle(&Style{CustomNumFmt: &numFmt})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	result, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "千二百三十四", result)
+	// Test get cell value with DBNum and the integer overflow
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 1e20))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", style))
+	result, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "1E+20", result)
+}
@@ -13,14 +13,21 @@ package excelize
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"encoding/xml"
 	"image"
 	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // parseGraphicOptions provides a function to parse the format settings of
@@ -162,8 +169,11 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 // AddPictureFromBytes provides the method to add picture in a sheet by given
 // picture format set (such as offset, scale, aspect ratio setting and print
 // settings), file base name, extension name and file bytes, supported image
-// types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. For
-// example:
+// types: EMF, EMZ, GIF, JPEG, JPG, PNG, SVG, TIF, TIFF, WMF, and WMZ. The
+// SVG picture will be stored with a fallback raster picture specified by the
+// Fallback field, which will be displayed by the applications which don't
+// support SVG, a transparent PNG picture will be used if it was not specified.
+// For example:
 //
 //	package main
 //
@@ -200,17 +210,23 @@ func (f *File) AddPicture(sheet, cell, name string, opts *GraphicOptions) error
 //	    }
 //	}
 func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
-	var drawingHyperlinkRID int
-	var hyperlinkType string
+	var drawingHyperlinkRID, drawingSVGRID int
+	var hyperlinkType, fallbackExt string
 	ext, ok := supportedImageTypes[strings.ToLower(pic.Extension)]
 	if !ok {
 		return ErrImgExt
 	}
 	options := parseGraphicOptions(pic.Format)
-	img, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
+	img, err := getPictureConfig(ext, pic.File)
 	if err != nil {
 		return err
 	}
+	fallback := pic.Fallback
+	if ext == ".svg" {
+		if fallback, fallbackExt, err = getSVGFallback(fallback); err != nil {
+			return err
+		}
+	}
 	// Read sheet data.
 	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
@@ -227,6 +243,12 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
 	mediaStr := ".." + strings.TrimPrefix(f.addMedia(pic.File, ext), "xl")
 	drawingRID := f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
+	// Add the SVG picture with fallback raster picture.
+	if ext == ".svg" {
+		drawingSVGRID = drawingRID
+		mediaStr = ".." + strings.TrimPrefix(f.addMedia(fallback, fallbackExt), "xl")
+		drawingRID = f.addRels(drawingRels, SourceRelationshipImage, mediaStr, hyperlinkType)
+	}
 	// Add picture with hyperlink.
 	if options.Hyperlink != "" && options.HyperlinkType != "" {
 		if options.HyperlinkType == "External" {
@@ -235,7 +257,7 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 		drawingHyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, options.Hyperlink, hyperlinkType)
 	}
 	ws.mu.Unlock()
-	err = f.addDrawingPicture(sheet, drawingXML, cell, ext, drawingRID, drawingHyperlinkRID, img, options)
+	err = f.addDrawingPicture(sheet, drawingXML, cell, drawingRID, drawingSVGRID, drawingHyperlinkRID, img, options)
 	if err != nil {
 		return err
 	}
@@ -246,6 +268,175 @@ func (f *File) AddPictureFromBytes(sheet, cell string, pic *Picture) error {
 	return err
 }
 
+// getPictureConfig provides a function to get the color model and dimensions
+// of the picture by given extension name and picture file bytes. The
+// dimensions of the SVG picture will be parsed from the root element.
+func getPictureConfig(ext string, file []byte) (image.Config, error) {
+	if ext == ".svg" {
+		return getSVGConfig(file)
+	}
+	img, _, err := image.DecodeConfig(bytes.NewReader(file))
+	return img, err
+}
+
+// getSVGConfig provides a function to get the dimensions of the SVG picture in
+// pixels by the width, height and viewBox attributes of the root element. The
+// default dimensions 300 x 150 pixels will be used if which can't be
+// determined by the attributes.
+func getSVGConfig(file []byte) (image.Config, error) {
+	var root struct {
+		XMLName xml.Name
+		Width   string `xml:"width,attr"`
+		Height  string `xml:"height,attr"`
+		ViewBox string `xml:"viewBox,attr"`
+	}
+	img := image.Config{Width: 300, Height: 150}
+	if err := xml.NewDecoder(bytes.NewReader(file)).Decode(&root); err != nil {
+		return img, err
+	}
+	if root.XMLName.Local != "svg" {
+		return img, image.ErrFormat
+	}
+	if viewBox := strings.Fields(strings.ReplaceAll(root.ViewBox, ",", " ")); len(viewBox) == 4 {
+		width, _ := strconv.ParseFloat(viewBox[2], 64)
+		height, _ := strconv.ParseFloat(viewBox[3], 64)
+		if width > 0 && height > 0 {
+			img.Width, img.Height = int(math.Round(width)), int(math.Round(height))
+		}
+	}
+	if width := parseSVGLength(root.Width); width > 0 {
+		img.Width = width
+	}
+	if height := parseSVGLength(root.Height); height > 0 {
+		img.Height = height
+	}
+	return img, nil
+}
+
+// parseSVGLength provides a function to convert the SVG length with absolute
+// units to pixels, and returns 0 for the relative units or invalid length.
+func parseSVGLength(length string) int {
+	length = strings.TrimSpace(length)
+	units := map[string]float64{
+		"px": 1, "pt": 96.0 / 72, "pc": 16, "in": 96, "cm": 96 / 2.54, "mm": 96 / 25.4,
+	}
+	scale := 1.0
+	if len(length) > 2 {
+		if s, ok := units[length[len(length)-2:]]; ok {
+			scale, length = s, length[:len(length)-2]
+		}
+	}
+	val, err := strconv.ParseFloat(length, 64)
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(val * scale))
+}
+
+// getSVGFallback provides a function to get the fallback raster picture and
+// the extension name for the SVG picture. A transparent PNG picture will be
+// generated if the fallback picture was not specified.
+func getSVGFallback(fallback []byte) ([]byte, string, error) {
+	if fallback == nil {
+		return []byte(templatePictureFallback), ".png", nil
+	}
+	_, format, err := image.DecodeConfig(bytes.NewReader(fallback))
+	if err != nil {
+		return nil, "", err
+	}
+	ext, ok := supportedImageTypes["."+format]
+	if !ok || ext == ".svg" {
+		return nil, "", ErrImgExt
+	}
+	return fallback, ext, err
+}
+
+// AddPictureFromURL provides the method to add picture in a sheet by given
+// worksheet name, cell reference, picture URL and format set. The picture
+// will be fetched by the HTTP client specified in the HTTPClient field of
+// options for opening or creating the workbook, the default HTTP client with
+// 30 seconds timeout will be used if it was not specified. The picture format
+// is detected by the Content-Type header of the response, or the content and
+// the extension name of the URL path if the header does not specify an image
+// type. The size of the fetched picture should be less than or equal to
+// MaxFetchPictureSize bytes. The picture will be fetched on each call, and the
+// same picture file will be stored in the workbook only once. For example:
+//
+//	err := f.AddPictureFromURL("Sheet1", "A2", "https://example.com/logo.png",
+//	    &excelize.GraphicOptions{AltText: "Logo"})
+func (f *File) AddPictureFromURL(sheet, cell, url string, opts *GraphicOptions) error {
+	return f.AddPictureFromURLContext(context.Background(), sheet, cell, url, opts)
+}
+
+// AddPictureFromURLContext provides the method to add picture in a sheet by
+// given worksheet name, cell reference, picture URL and format set, just like
+// the AddPictureFromURL function, the fetching will be canceled when the given
+// context is done.
+func (f *File) AddPictureFromURLContext(ctx context.Context, sheet, cell, url string, opts *GraphicOptions) error {
+	pic, err := f.fetchPicture(ctx, url)
+	if err != nil {
+		return err
+	}
+	return f.AddPictureFromBytes(sheet, cell, &Picture{Extension: pic.Extension, File: pic.File, Format: opts})
+}
+
+// fetchPicture provides a function to fetch the picture by given URL, and
+// returns the picture with extension name and file bytes.
+func (f *File) fetchPicture(ctx context.Context, rawURL string) (*Picture, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := f.options.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, newFetchPictureError(rawURL, resp.Status)
+	}
+	file, err := io.ReadAll(io.LimitReader(resp.Body, MaxFetchPictureSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(file) > MaxFetchPictureSize {
+		return nil, ErrMaxFetchPictureSize
+	}
+	contentType := resp.Header.Get("Content-Type")
+	ext, ok := getPictureExtension(rawURL, contentType, file)
+	if !ok {
+		return nil, newPictureContentTypeError(rawURL, contentType)
+	}
+	return &Picture{Extension: ext, File: file}, err
+}
+
+// getPictureExtension provides a function to detect the picture extension
+// name by given picture URL, content type of the HTTP response and the
+// picture file bytes.
+func getPictureExtension(rawURL, contentType string, file []byte) (string, bool) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(file))
+	}
+	if ext, ok := supportedImageContentTypes[mediaType]; ok {
+		return ext, ok
+	}
+	// Fall back to the extension name of the URL path for the vector images,
+	// which can't be detected by the content.
+	switch mediaType {
+	case "application/octet-stream", "text/plain", "text/xml":
+		if u, err := url.Parse(rawURL); err == nil {
+			ext, ok := supportedImageTypes[strings.ToLower(path.Ext(u.Path))]
+			return ext, ok
+		}
+	}
+	return "", false
+}
+
 // deleteSheetRelationships provides a function to delete relationships in
 // xl/worksheets/_rels/sheet%d.xml.rels by given worksheet name and
 // relationship index.
@@ -300,32 +491,29 @@ func (f *File) addSheetPicture(sheet string, rID int) error {
 	return err
 }
 
-// countDrawings provides a function to get drawing files count storage in the
-// folder xl/drawings.
+// countDrawings provides a function to get the maximum index of the drawing
+// files storage in the folder xl/drawings, the index of the deleted drawing
+// parts will not be reused by the new drawing parts of other worksheets.
 func (f *File) countDrawings() int {
-	var c1, c2 int
-	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/drawings/drawing") {
-			c1++
-		}
-		return true
-	})
-	f.Drawings.Range(func(rel, value interface{}) bool {
-		if strings.Contains(rel.(string), "xl/drawings/drawing") {
-			c2++
+	var count int
+	counter := func(k, v interface{}) bool {
+		if name := k.(string); strings.HasPrefix(strings.TrimPrefix(name, "/"), "xl/drawings/drawing") {
+			idx, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(name, "/"), "xl/drawings/drawing"), ".xml"))
+			if idx > count {
+				count = idx
+			}
 		}
 		return true
-	})
-	if c1 < c2 {
-		return c2
 	}
-	return c1
+	f.Pkg.Range(counter)
+	f.Drawings.Range(counter)
+	return count
 }
 
 // addDrawingPicture provides a function to add picture by given sheet,
 // drawingXML, cell, file name, width, height relationship index and format
 // sets.
-func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyperlinkRID int, img image.Config, opts *GraphicOptions) error {
+func (f *File) addDrawingPicture(sheet, drawingXML, cell string, rID, svgRID, hyperlinkRID int, img image.Config, opts *GraphicOptions) error {
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -374,19 +562,20 @@ func (f *File) addDrawingPicture(sheet, drawingXML, cell, ext string, rID, hyper
 	}
 	pic.BlipFill.Blip.R = SourceRelationship.Value
 	pic.BlipFill.Blip.Embed = "rId" + strconv.Itoa(rID)
-	if ext == ".svg" {
+	if svgRID != 0 {
 		pic.BlipFill.Blip.ExtList = &xlsxEGOfficeArtExtensionList{
 			Ext: []xlsxCTOfficeArtExtension{
 				{
 					URI: ExtURISVG,
 					SVGBlip: xlsxCTSVGBlip{
 						XMLNSaAVG: NameSpaceDrawing2016SVG.Value,
-						Embed:     pic.BlipFill.Blip.Embed,
+						Embed:     "rId" + strconv.Itoa(svgRID),
 					},
 				},
 			},
 		}
 	}
+	pic.SpPr.Xfrm.Ext.Cx, pic.SpPr.Xfrm.Ext.Cy = width*EMU, height*EMU
 	pic.SpPr.PrstGeom.Prst = "rect"
 
 	twoCellAnchor.Pic = &pic
@@ -434,17 +623,61 @@ func (f *File) addMedia(file []byte, ext string) string {
 		return name
 	}
 	media := "xl/media/image" + strconv.Itoa(count+1) + ext
+	for _, ok := f.Pkg.Load(media); ok; _, ok = f.Pkg.Load(media) {
+		count++
+		media = "xl/media/image" + strconv.Itoa(count+1) + ext
+	}
 	f.Pkg.Store(media, file)
 	return media
 }
 
+// deleteMedia provides a function to delete the picture in the folder
+// xl/media by given path and the content type of the picture, if the picture
+// isn't referenced by any relationships in the workbook.
+func (f *File) deleteMedia(media string) error {
+	relsPaths := map[string]struct{}{}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasSuffix(k.(string), ".rels") {
+			relsPaths[k.(string)] = struct{}{}
+		}
+		return true
+	})
+	f.Relationships.Range(func(k, v interface{}) bool {
+		relsPaths[k.(string)] = struct{}{}
+		return true
+	})
+	for relsPath := range relsPaths {
+		rels, err := f.relsReader(relsPath)
+		if err != nil {
+			return err
+		}
+		if rels == nil {
+			continue
+		}
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			target := path.Join(path.Dir(path.Dir(relsPath)), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if rel.TargetMode != "External" && target == media {
+				rels.mu.Unlock()
+				return err
+			}
+		}
+		rels.mu.Unlock()
+	}
+	f.Pkg.Delete(media)
+	return f.deleteSheetFromContentTypes("/" + media)
+}
+
 // setContentTypePartImageExtensions provides a function to set the content
 // type for relationship parts and the Main Document part.
 func (f *File) setContentTypePartImageExtensions() error {
 	imageTypes := map[string]string{
-		"bmp": "image/", "jpeg": "image/", "png": "image/", "gif": "image/",
-		"svg": "image/", "tiff": "image/", "emf": "image/x-", "wmf": "image/x-",
-		"emz": "image/x-", "wmz": "image/x-",
+		"bmp": "image/bmp", "jpeg": "image/jpeg", "png": "image/png", "gif": "image/gif",
+		"svg": "image/svg+xml", "tiff": "image/tiff", "emf": "image/x-emf", "wmf": "image/x-wmf",
+		"emz": "image/x-emz", "wmz": "image/x-wmz",
 	}
 	content, err := f.contentTypesReader()
 	if err != nil {
@@ -452,13 +685,16 @@ func (f *File) setContentTypePartImageExtensions() error {
 	}
 	content.mu.Lock()
 	defer content.mu.Unlock()
-	for _, file := range content.Defaults {
+	for idx, file := range content.Defaults {
+		if file.Extension == "svg" {
+			content.Defaults[idx].ContentType = imageTypes[file.Extension]
+		}
 		delete(imageTypes, file.Extension)
 	}
-	for extension, prefix := range imageTypes {
+	for extension, contentType := range imageTypes {
 		content.Defaults = append(content.Defaults, xlsxDefault{
 			Extension:   extension,
-			ContentType: prefix + extension,
+			ContentType: contentType,
 		})
 	}
 	return err
@@ -496,24 +732,32 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":         "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":    "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":      "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"drawings":      "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":         "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":    "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":    "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings": "/xl/sharedStrings.xml",
+		"chart":            "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartEx":          "/xl/charts/chartEx" + strconv.Itoa(index) + ".xml",
+		"chartsheet":       "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":         "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"drawings":         "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"table":            "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":       "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":       "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"sharedStrings":    "/xl/sharedStrings.xml",
+		"sheetMetadata":    "/" + defaultXMLPathMetadata,
+		"persons":          "/" + defaultXMLPathPersons,
+		"threadedComments": "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":         ContentTypeDrawingML,
-		"chartsheet":    ContentTypeSpreadSheetMLChartsheet,
-		"comments":      ContentTypeSpreadSheetMLComments,
-		"drawings":      ContentTypeDrawing,
-		"table":         ContentTypeSpreadSheetMLTable,
-		"pivotTable":    ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":    ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings": ContentTypeSpreadSheetMLSharedStrings,
+		"chart":            ContentTypeDrawingML,
+		"chartEx":          ContentTypeDrawingMLChartEx,
+		"chartsheet":       ContentTypeSpreadSheetMLChartsheet,
+		"comments":         ContentTypeSpreadSheetMLComments,
+		"drawings":         ContentTypeDrawing,
+		"table":            ContentTypeSpreadSheetMLTable,
+		"pivotTable":       ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":       ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"sharedStrings":    ContentTypeSpreadSheetMLSharedStrings,
+		"sheetMetadata":    ContentTypeSpreadSheetMLSheetMetadata,
+		"persons":          ContentTypeSpreadSheetMLPerson,
+		"threadedComments": ContentTypeSpreadSheetMLThreadedComments,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
@@ -612,6 +856,182 @@ func (f *File) GetPictures(sheet, cell string) ([]Picture, error) {
 	return f.getPicture(row, col, drawingXML, drawingRelationships)
 }
 
+// GetInCellImages provides a function to get the pictures placed in the cells
+// by given worksheet name, and returns the raw content of the pictures keyed
+// by the cell reference. The pictures placed in the cells are stored as the
+// rich values of the cells, for example, by the "Place in Cell" in Excel. For
+// example, get all pictures placed in the cells of the worksheet named
+// Sheet1:
+//
+//	images, err := f.GetInCellImages("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for cell, file := range images {
+//	    if err := os.WriteFile(cell+".png", file, 0644); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) GetInCellImages(sheet string) (map[string][]byte, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	images := make(map[string][]byte)
+	var cells []xlsxC
+	ws.mu.Lock()
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.Vm != nil {
+				cells = append(cells, c)
+			}
+		}
+	}
+	ws.mu.Unlock()
+	if len(cells) == 0 {
+		return images, err
+	}
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return images, err
+	}
+	richValues, err := f.richValueImagesReader()
+	if err != nil {
+		return images, err
+	}
+	for _, c := range cells {
+		if idx, ok := metadata.getRichValueIndex(*c.Vm); ok {
+			if file, ok := richValues[idx]; ok {
+				images[c.R] = file
+			}
+		}
+	}
+	return images, err
+}
+
+// metadataReader provides a function to get the pointer to the structure after
+// deserialization of xl/metadata.xml.
+func (f *File) metadataReader() (*xlsxMetadata, error) {
+	var metadata xlsxMetadata
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathMetadata)))).
+		Decode(&metadata); err != nil && err != io.EOF {
+		return &metadata, err
+	}
+	return &metadata, nil
+}
+
+// metadataWriter provides a function to save xl/metadata.xml after serialize
+// structure, the namespaces of the root element in the original part will be
+// retained.
+func (f *File) metadataWriter(metadata *xlsxMetadata) {
+	if _, ok := f.xmlAttr[defaultXMLPathMetadata]; !ok {
+		d := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(defaultXMLPathMetadata))))
+		attrs := getRootElement(d)
+		var ns bool
+		for _, attr := range attrs {
+			ns = ns || (attr.Name.Space == "" && attr.Name.Local == NameSpaceSpreadSheet.Name.Local)
+		}
+		if !ns {
+			attrs = append([]xml.Attr{NameSpaceSpreadSheet}, attrs...)
+		}
+		f.xmlAttr[defaultXMLPathMetadata] = attrs
+	}
+	if metadata.MetadataTypes != nil {
+		metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	}
+	for i := range metadata.FutureMetadata {
+		metadata.FutureMetadata[i].Count = len(metadata.FutureMetadata[i].Bk)
+	}
+	for _, blocks := range []*xlsxMetadataBlocks{metadata.CellMetadata, metadata.ValueMetadata} {
+		if blocks != nil {
+			blocks.Count = len(blocks.Bk)
+		}
+	}
+	output, _ := xml.Marshal(metadata)
+	f.saveFileList(defaultXMLPathMetadata, f.replaceNameSpaceBytes(defaultXMLPathMetadata, output))
+}
+
+// getRichValueIndex provides a function to get the rich value index by given
+// 1-based value metadata index of the cell.
+func (metadata *xlsxMetadata) getRichValueIndex(vm uint) (int, bool) {
+	if metadata.ValueMetadata == nil || metadata.MetadataTypes == nil ||
+		vm < 1 || int(vm) > len(metadata.ValueMetadata.Bk) {
+		return -1, false
+	}
+	for _, rc := range metadata.ValueMetadata.Bk[vm-1].Rc {
+		if rc.T < 1 || rc.T > len(metadata.MetadataTypes.MetadataType) {
+			continue
+		}
+		name := metadata.MetadataTypes.MetadataType[rc.T-1].Name
+		for _, futureMetadata := range metadata.FutureMetadata {
+			if futureMetadata.Name != name || rc.V < 0 || rc.V >= len(futureMetadata.Bk) {
+				continue
+			}
+			if extLst := futureMetadata.Bk[rc.V].ExtLst; extLst != nil {
+				var decodeExtLst decodeFutureMetadataExtLst
+				if err := xml.Unmarshal([]byte("<extLst>"+extLst.Content+"</extLst>"), &decodeExtLst); err == nil && decodeExtLst.RichValueBlock != nil {
+					return decodeExtLst.RichValueBlock.I, true
+				}
+			}
+		}
+	}
+	return -1, false
+}
+
+// richValueImagesReader provides a function to get the raw content of the
+// local image rich values keyed by the rich value index.
+func (f *File) richValueImagesReader() (map[int][]byte, error) {
+	images := make(map[int][]byte)
+	var (
+		richValueData       xlsxRichValueData
+		richValueStructures xlsxRichValueStructures
+		richValueRels       xlsxRichValueRels
+	)
+	for path, v := range map[string]interface{}{
+		defaultXMLPathRichValue:          &richValueData,
+		defaultXMLPathRichValueStructure: &richValueStructures,
+		defaultXMLPathRichValueRel:       &richValueRels,
+	} {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(path)))).
+			Decode(v); err != nil && err != io.EOF {
+			return images, err
+		}
+	}
+	rels, err := f.relsReader(defaultXMLPathRichValueRelRels)
+	if err != nil || rels == nil {
+		return images, err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for idx, rv := range richValueData.Rv {
+		if rv.S < 0 || rv.S >= len(richValueStructures.S) {
+			continue
+		}
+		for i, k := range richValueStructures.S[rv.S].K {
+			if k.N != "_rvRel:LocalImageIdentifier" || i >= len(rv.V) {
+				continue
+			}
+			relIdx, err := strconv.Atoi(rv.V[i])
+			if err != nil || relIdx < 0 || relIdx >= len(richValueRels.Rels) {
+				continue
+			}
+			for _, rel := range rels.Relationships {
+				if rel.ID != richValueRels.Rels[relIdx].ID {
+					continue
+				}
+				if buffer, _ := f.Pkg.Load(strings.ReplaceAll(rel.Target, "..", "xl")); buffer != nil {
+					images[idx] = buffer.([]byte)
+				}
+			}
+		}
+	}
+	return images, err
+}
+
 // DeletePicture provides a function to delete all pictures in a cell by given
 // worksheet name and cell reference. Note that the image file won't be deleted
 // from the document currently.
@@ -664,11 +1084,21 @@ func (f *File) getPicture(row, col int, drawingXML, drawingRelationships string)
 		}
 		if err = nil; deTwoCellAnchor.From != nil && deTwoCellAnchor.Pic != nil {
 			if deTwoCellAnchor.From.Col == col && deTwoCellAnchor.From.Row == row {
-				drawRel = f.getDrawingRelationships(drawingRelationships, deTwoCellAnchor.Pic.BlipFill.Blip.Embed)
+				embed := deTwoCellAnchor.Pic.BlipFill.Blip.Embed
+				if extLst := deTwoCellAnchor.Pic.BlipFill.Blip.ExtList; extLst != nil {
+					for _, ext := range extLst.Ext {
+						if ext.URI == ExtURISVG && ext.SVGBlip != nil {
+							embed = ext.SVGBlip.Embed
+						}
+					}
+				}
+				drawRel = f.getDrawingRelationships(drawingRelationships, embed)
 				if _, ok = supportedImageTypes[strings.ToLower(filepath.Ext(drawRel.Target))]; ok {
 					pic := Picture{Extension: filepath.Ext(drawRel.Target), Format: &GraphicOptions{}}
 					if buffer, _ := f.Pkg.Load(strings.ReplaceAll(drawRel.Target, "..", "xl")); buffer != nil {
 						pic.File = buffer.([]byte)
+						pic.Width, pic.Height, pic.DPI = getPictureDimensions(pic.Extension, pic.File)
+						pic.ExtentCx, pic.ExtentCy = deTwoCellAnchor.Pic.SpPr.Xfrm.Ext.Cx, deTwoCellAnchor.Pic.SpPr.Xfrm.Ext.Cy
 						pic.Format.AltText = deTwoCellAnchor.Pic.NvPicPr.CNvPr.Descr
 						pics = append(pics, pic)
 					}
@@ -693,12 +1123,21 @@ func (f *File) getPicturesFromWsDr(row, col int, drawingRelationships string, ws
 	for _, anchor = range wsDr.TwoCellAnchor {
 		if anchor.From != nil && anchor.Pic != nil {
 			if anchor.From.Col == col && anchor.From.Row == row {
-				if drawRel = f.getDrawingRelationships(drawingRelationships,
-					anchor.Pic.BlipFill.Blip.Embed); drawRel != nil {
+				embed := anchor.Pic.BlipFill.Blip.Embed
+				if extLst := anchor.Pic.BlipFill.Blip.ExtList; extLst != nil {
+					for _, ext := range extLst.Ext {
+						if ext.URI == ExtURISVG {
+							embed = ext.SVGBlip.Embed
+						}
+					}
+				}
+				if drawRel = f.getDrawingRelationships(drawingRelationships, embed); drawRel != nil {
 					if _, ok = supportedImageTypes[strings.ToLower(filepath.Ext(drawRel.Target))]; ok {
 						pic := Picture{Extension: filepath.Ext(drawRel.Target), Format: &GraphicOptions{}}
 						if buffer, _ := f.Pkg.Load(strings.ReplaceAll(drawRel.Target, "..", "xl")); buffer != nil {
 							pic.File = buffer.([]byte)
+							pic.Width, pic.Height, pic.DPI = getPictureDimensions(pic.Extension, pic.File)
+							pic.ExtentCx, pic.ExtentCy = anchor.Pic.SpPr.Xfrm.Ext.Cx, anchor.Pic.SpPr.Xfrm.Ext.Cy
 							pic.Format.AltText = anchor.Pic.NvPicPr.CNvPr.Descr
 							pics = append(pics, pic)
 						}
@@ -710,6 +1149,110 @@ func (f *File) getPicturesFromWsDr(row, col int, drawingRelationships string, ws
 	return
 }
 
+// getPictureDimensions provides a function to get the width and height in
+// pixels and the horizontal resolution in dots per inch of the picture by
+// given extension name and picture file bytes. The PNG, JPEG and GIF headers
+// will be read directly, and the other formats will be decoded by the
+// registered image decoders, the resolution will be 0 if it's unknown.
+func getPictureDimensions(ext string, file []byte) (width, height int, dpi float64) {
+	switch strings.ToLower(ext) {
+	case ".png":
+		return getPNGDimensions(file)
+	case ".jpg", ".jpeg":
+		return getJPEGDimensions(file)
+	case ".gif":
+		if len(file) >= 10 && bytes.HasPrefix(file, []byte("GIF8")) {
+			return int(binary.LittleEndian.Uint16(file[6:8])), int(binary.LittleEndian.Uint16(file[8:10])), 0
+		}
+		return
+	}
+	if img, err := getPictureConfig(strings.ToLower(ext), file); err == nil {
+		width, height = img.Width, img.Height
+	}
+	return
+}
+
+// getPNGDimensions provides a function to get the width, height and
+// horizontal resolution of the PNG picture by the IHDR and pHYs chunks.
+func getPNGDimensions(file []byte) (width, height int, dpi float64) {
+	if len(file) < 8 || !bytes.HasPrefix(file, []byte("\x89PNG\r\n\x1a\n")) {
+		return
+	}
+	for idx := 8; idx+8 <= len(file); {
+		length := int(binary.BigEndian.Uint32(file[idx : idx+4]))
+		chunk, data := string(file[idx+4:idx+8]), idx+8
+		if length < 0 || data+length > len(file) {
+			return
+		}
+		switch chunk {
+		case "IHDR":
+			if length >= 8 {
+				width = int(binary.BigEndian.Uint32(file[data : data+4]))
+				height = int(binary.BigEndian.Uint32(file[data+4 : data+8]))
+			}
+		case "pHYs":
+			// The unit specifier 1 indicates the pixels per unit is in meter.
+			if length >= 9 && file[data+8] == 1 {
+				dpi = math.Round(float64(binary.BigEndian.Uint32(file[data:data+4]))*0.0254*100) / 100
+			}
+		case "IDAT", "IEND":
+			return
+		}
+		idx = data + length + 4
+	}
+	return
+}
+
+// getJPEGDimensions provides a function to get the width, height and
+// horizontal resolution of the JPEG picture by the start of frame and JFIF
+// application segments.
+func getJPEGDimensions(file []byte) (width, height int, dpi float64) {
+	if len(file) < 4 || file[0] != 0xFF || file[1] != 0xD8 {
+		return
+	}
+	for idx := 2; idx+4 <= len(file); {
+		if file[idx] != 0xFF {
+			return
+		}
+		marker := file[idx+1]
+		if marker == 0xFF {
+			idx++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			idx += 2
+			continue
+		}
+		length, data := int(binary.BigEndian.Uint16(file[idx+2:idx+4])), idx+4
+		if length < 2 || data+length-2 > len(file) {
+			return
+		}
+		segment := file[data : data+length-2]
+		switch {
+		case marker == 0xE0 && len(segment) >= 12 && bytes.HasPrefix(segment, []byte("JFIF\x00")):
+			// The density units 1 and 2 indicates the dots per inch and the dots
+			// per centimeter.
+			density := float64(binary.BigEndian.Uint16(segment[8:10]))
+			if segment[7] == 1 {
+				dpi = density
+			}
+			if segment[7] == 2 {
+				dpi = math.Round(density*2.54*100) / 100
+			}
+		case marker >= 0xC0 && marker <= 0xCF && marker != 0xC4 && marker != 0xC8 && marker != 0xCC:
+			if len(segment) >= 5 {
+				height = int(binary.BigEndian.Uint16(segment[1:3]))
+				width = int(binary.BigEndian.Uint16(segment[3:5]))
+			}
+			return
+		case marker == 0xDA:
+			return
+		}
+		idx = data + length - 2
+	}
+	return
+}
+
 // getDrawingRelationships provides a function to get drawing relationships
 // from xl/drawings/_rels/drawing%s.xml.rels by given file name and
 // relationship ID.
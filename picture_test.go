@@ -1,12 +1,15 @@
 package excelize
 
 import (
+	"context"
 	"fmt"
 	"image"
 	_ "image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -113,12 +116,114 @@ func TestAddPictureErrors(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestAddSVGPicture(t *testing.T) {
+	f := NewFile()
+	svg, err := os.ReadFile("excelize.svg")
+	assert.NoError(t, err)
+	fallback, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddPictureFromBytes("Sheet1", "A1", &Picture{Extension: ".svg", File: svg, Fallback: fallback}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", "excelize.svg", &GraphicOptions{ScaleX: 0.1, ScaleY: 0.1}))
+	rels, err := f.relsReader("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, err)
+	var targets []string
+	for _, rel := range rels.Relationships {
+		targets = append(targets, rel.Target)
+	}
+	assert.Equal(t, []string{"../media/image1.svg", "../media/image2.png", "../media/image1.svg", "../media/image3.png"}, targets)
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	blip := wsDr.TwoCellAnchor[0].Pic.BlipFill.Blip
+	assert.Equal(t, "rId2", blip.Embed)
+	assert.Equal(t, "rId1", blip.ExtList.Ext[0].SVGBlip.Embed)
+	// Test get the SVG picture
+	for _, cell := range []string{"A1", "A20"} {
+		pics, err := f.GetPictures("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		assert.Equal(t, ".svg", pics[0].Extension)
+		assert.Equal(t, svg, pics[0].File)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddSVGPicture.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestAddSVGPicture.xlsx"))
+	assert.NoError(t, err)
+	content, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	assert.Contains(t, content.Defaults, xlsxDefault{Extension: "svg", ContentType: "image/svg+xml"})
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".svg", pics[0].Extension)
+	assert.Equal(t, svg, pics[0].File)
+	assert.NoError(t, f.Close())
+}
+
+func TestGetSVGConfig(t *testing.T) {
+	for _, c := range []struct {
+		svg           string
+		width, height int
+	}{
+		{`<svg xmlns="http://www.w3.org/2000/svg"/>`, 300, 150},
+		{`<svg viewBox="0 0 5791 2370"/>`, 5791, 2370},
+		{`<svg width="120" height="60px" viewBox="0,0,10,10"/>`, 120, 60},
+		{`<svg width="1in" height="72pt"/>`, 96, 96},
+		{`<svg width="2.54cm" height="25.4mm"/>`, 96, 96},
+		{`<svg width="100%" height="auto" viewBox="0 0 40 30"/>`, 40, 30},
+	} {
+		img, err := getSVGConfig([]byte(c.svg))
+		assert.NoError(t, err, c.svg)
+		assert.Equal(t, c.width, img.Width, c.svg)
+		assert.Equal(t, c.height, img.Height, c.svg)
+	}
+	_, err := getSVGConfig([]byte("<html/>"))
+	assert.Equal(t, image.ErrFormat, err)
+	_, err = getSVGConfig([]byte("<svg"))
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+}
+
+func TestGetPictureDimensions(t *testing.T) {
+	for _, name := range []string{"excel.png", "excel.jpg", "excel.gif", "excel.bmp", "excel.tif"} {
+		file, err := os.ReadFile(filepath.Join("test", "images", name))
+		assert.NoError(t, err)
+		width, height, dpi := getPictureDimensions(filepath.Ext(name), file)
+		assert.Equal(t, []interface{}{200, 128, 0.0}, []interface{}{width, height, dpi}, name)
+	}
+	for _, c := range []struct {
+		ext           string
+		file          string
+		width, height int
+		dpi           float64
+	}{
+		// PNG with pHYs chunk in 3780 pixels per meter
+		{".png", "\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x01\x2c\x00\x00\x00\x96\x08\x06\x00\x00\x00CRC!" +
+			"\x00\x00\x00\x09pHYs\x00\x00\x0e\xc4\x00\x00\x0e\xc4\x01CRC!\x00\x00\x00\x00IEND", 300, 150, 96.01},
+		// JPEG with JFIF density in dots per inch
+		{".jpg", "\xff\xd8\xff\xe0\x00\x10JFIF\x00\x01\x01\x01\x00\x48\x00\x48\x00\x00" +
+			"\xff\xc0\x00\x11\x08\x00\x40\x00\x80\x03\x01\x22\x00\x02\x11\x01\x03\x11\x01", 128, 64, 72},
+		// JPEG with JFIF density in dots per centimeter
+		{".jpeg", "\xff\xd8\xff\xff\xe0\x00\x10JFIF\x00\x01\x01\x02\x00\x76\x00\x76\x00\x00" +
+			"\xff\xc2\x00\x11\x08\x00\x40\x00\x80\x03\x01\x22\x00\x02\x11\x01\x03\x11\x01", 128, 64, 299.72},
+		{".gif", "GIF89a\x0a\x00\x05\x00", 10, 5, 0},
+		{".png", "\x89PNG\r\n\x1a\n\x00\x00\x00\xffIHDR", 0, 0, 0},
+		{".jpg", "\xff\xd8\x00\x00", 0, 0, 0},
+		{".jpg", "\xff\xd8\xff\xe0\x00\xff", 0, 0, 0},
+		{".jpg", "\xff\xd8\xff\xd0\xff\xda\x00\x02", 0, 0, 0},
+		{".gif", "GIF", 0, 0, 0},
+		{".svg", `<svg width="20" height="10"/>`, 20, 10, 0},
+	} {
+		width, height, dpi := getPictureDimensions(c.ext, []byte(c.file))
+		assert.Equal(t, []interface{}{c.width, c.height, c.dpi}, []interface{}{width, height, dpi}, c.file)
+	}
+}
+
 func TestGetPicture(t *testing.T) {
 	f := NewFile()
-	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), &GraphicOptions{ScaleX: 0.5, ScaleY: 2}))
 	pics, err := f.GetPictures("Sheet1", "A1")
 	assert.NoError(t, err)
 	assert.Len(t, pics[0].File, 13233)
+	assert.Equal(t, []int{200, 128, 100 * EMU, 256 * EMU}, []int{pics[0].Width, pics[0].Height, pics[0].ExtentCx, pics[0].ExtentCy})
 	assert.Empty(t, pics[0].Format.AltText)
 
 	f, err = prepareTestBook1()
@@ -169,6 +274,11 @@ func TestGetPicture(t *testing.T) {
 		t.FailNow()
 	}
 
+	assert.NotZero(t, pics[0].Width)
+	assert.NotZero(t, pics[0].Height)
+	assert.NotZero(t, pics[0].ExtentCx)
+	assert.NotZero(t, pics[0].ExtentCy)
+
 	// Try to get picture from a local storage file that doesn't contain an image
 	pics, err = f.GetPictures("Sheet1", "F22")
 	assert.NoError(t, err)
@@ -197,11 +307,11 @@ func TestAddDrawingPicture(t *testing.T) {
 	// Test addDrawingPicture with illegal cell reference
 	f := NewFile()
 	opts := &GraphicOptions{PrintObject: boolPtr(true), Locked: boolPtr(false)}
-	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", "", 0, 0, image.Config{}, opts), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.addDrawingPicture("sheet1", "", "A", 0, 0, 0, image.Config{}, opts), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
 
 	path := "xl/drawings/drawing1.xml"
 	f.Pkg.Store(path, MacintoshCyrillicCharset)
-	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", "", 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.addDrawingPicture("sheet1", path, "A1", 0, 0, 0, image.Config{}, opts), "XML syntax error on line 1: invalid UTF-8")
 }
 
 func TestAddPictureFromBytes(t *testing.T) {
@@ -224,6 +334,124 @@ func TestAddPictureFromBytes(t *testing.T) {
 	assert.EqualError(t, f.AddPictureFromBytes("Sheet:1", fmt.Sprint("A", 1), &Picture{Extension: ".png", File: imgFile, Format: &GraphicOptions{AltText: "logo"}}), ErrSheetNameInvalid.Error())
 }
 
+func TestAddPictureFromURL(t *testing.T) {
+	imgFile, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	var requests int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/excel.png", "/image":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write(imgFile)
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(make([]byte, MaxFetchPictureSize+1))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	f := NewFile(Options{HTTPClient: ts.Client()})
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A1", ts.URL+"/excel.png", &GraphicOptions{AltText: "logo"}))
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A20", ts.URL+"/excel.png", nil))
+	assert.NoError(t, f.AddPictureFromURL("Sheet1", "A40", ts.URL+"/image", nil))
+	assert.Equal(t, 3, requests)
+	var media int
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/media/") {
+			media++
+		}
+		return true
+	})
+	assert.Equal(t, 1, media)
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.Equal(t, ".png", pics[0].Extension)
+	assert.Equal(t, imgFile, pics[0].File)
+	assert.Equal(t, "logo", pics[0].Format.AltText)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPictureFromURL.xlsx")))
+	// Test add picture from URL with non-image content
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "C1", ts.URL+"/page.html", nil),
+		newPictureContentTypeError(ts.URL+"/page.html", "text/html; charset=utf-8").Error())
+	// Test add picture from URL with the picture size exceeds the limit
+	assert.Equal(t, ErrMaxFetchPictureSize, f.AddPictureFromURL("Sheet1", "C1", ts.URL+"/large.png", nil))
+	// Test add picture from URL with unexpected response status
+	assert.EqualError(t, f.AddPictureFromURL("Sheet1", "C1", ts.URL+"/missing.png", nil),
+		newFetchPictureError(ts.URL+"/missing.png", "404 Not Found").Error())
+	// Test add picture from URL with invalid URL
+	assert.Error(t, f.AddPictureFromURL("Sheet1", "C1", "://", nil))
+	// Test add picture from URL with canceled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, f.AddPictureFromURLContext(ctx, "Sheet1", "C1", ts.URL+"/image.png", nil), context.Canceled)
+	// Test add picture from URL on not exists worksheet
+	assert.EqualError(t, f.AddPictureFromURL("SheetN", "A1", ts.URL+"/excel.png", nil), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetPictureExtension(t *testing.T) {
+	for _, c := range []struct {
+		url, contentType, ext string
+		ok                    bool
+	}{
+		{"https://example.com/a", "image/jpeg", ".jpeg", true},
+		{"https://example.com/a.svg", "image/svg+xml; charset=utf-8", ".svg", true},
+		{"https://example.com/a.svg?v=1", "text/plain", ".svg", true},
+		{"https://example.com/a.emf", "", ".emf", true},
+		{"https://example.com/a.txt", "text/plain", "", false},
+		{"https://example.com/a.png", "application/json", "", false},
+		{"%", "application/octet-stream", "", false},
+	} {
+		ext, ok := getPictureExtension(c.url, c.contentType, []byte{1, 2, 3})
+		assert.Equal(t, c.ext, ext, c.url)
+		assert.Equal(t, c.ok, ok, c.url)
+	}
+}
+
+func TestGetInCellImages(t *testing.T) {
+	f := NewFile()
+	images, err := f.GetInCellImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, images)
+	imgFile, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/media/image1.png", imgFile)
+	f.Pkg.Store("xl/media/image2.jpeg", []byte("JPEG"))
+	f.Pkg.Store(defaultXMLPathMetadata, []byte(`<metadata xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:xlrd="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata"><metadataTypes count="1"><metadataType name="XLRICHVALUE" minSupportedVersion="120000"/></metadataTypes><futureMetadata name="XLRICHVALUE" count="2"><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="1"/></ext></extLst></bk><bk><extLst><ext uri="{3e2802c4-a4d2-4d8b-9148-e3be6c30e623}"><xlrd:rvb i="0"/></ext></extLst></bk></futureMetadata><valueMetadata count="3"><bk><rc t="1" v="0"/></bk><bk><rc t="1" v="1"/></bk><bk><rc t="2" v="0"/></bk></valueMetadata></metadata>`))
+	f.Pkg.Store(defaultXMLPathRichValue, []byte(`<rvData xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="2"><rv s="0"><v>0</v><v>5</v></rv><rv s="0"><v>1</v><v>5</v></rv></rvData>`))
+	f.Pkg.Store(defaultXMLPathRichValueStructure, []byte(`<rvStructures xmlns="http://schemas.microsoft.com/office/spreadsheetml/2017/richdata" count="1"><s t="_localImage"><k n="_rvRel:LocalImageIdentifier" t="i"/><k n="CalcOrigin" t="i"/></s></rvStructures>`))
+	f.Pkg.Store(defaultXMLPathRichValueRel, []byte(`<richValueRels xmlns="http://schemas.microsoft.com/office/spreadsheetml/2022/richvaluerel" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><rel r:id="rId1"/><rel r:id="rId2"/></richValueRels>`))
+	f.Pkg.Store(defaultXMLPathRichValueRelRels, []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/image1.png"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/image2.jpeg"/></Relationships>`))
+	for _, cell := range []string{"A1", "B2", "C3", "D4"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, "#VALUE!"))
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].Vm = uintPtr(2)
+	ws.(*xlsxWorksheet).SheetData.Row[1].C[1].Vm = uintPtr(1)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2].Vm = uintPtr(3)
+	ws.(*xlsxWorksheet).SheetData.Row[3].C[3].Vm = uintPtr(4)
+	images, err = f.GetInCellImages("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"A1": imgFile, "B2": []byte("JPEG")}, images)
+	// Test get in-cell images on not exists worksheet
+	_, err = f.GetInCellImages("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get in-cell images with unsupported charset rich value parts
+	for _, path := range []string{defaultXMLPathRichValueRelRels, defaultXMLPathRichValue, defaultXMLPathMetadata} {
+		f.Pkg.Store(path, MacintoshCyrillicCharset)
+		f.Relationships.Delete(path)
+		_, err = f.GetInCellImages("Sheet1")
+		assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8", path)
+	}
+	assert.NoError(t, f.Close())
+}
+
 func TestDeletePicture(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	assert.NoError(t, err)
@@ -279,3 +507,24 @@ func TestAddContentTypePart(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.addContentTypePart(0, "unknown"), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestDeleteMedia(t *testing.T) {
+	f := NewFile()
+	media := f.addMedia([]byte("image1"), ".png")
+	assert.Equal(t, "xl/media/image1.png", media)
+	assert.Equal(t, "xl/media/image2.png", f.addMedia([]byte("image2"), ".png"))
+	// Test delete the picture which is referenced by the relationships
+	f.addRels("xl/drawings/_rels/drawing1.xml.rels", SourceRelationshipImage, "../media/image1.png", "")
+	assert.NoError(t, f.deleteMedia(media))
+	_, ok := f.Pkg.Load(media)
+	assert.True(t, ok)
+	// Test add picture after the picture has been deleted
+	f.Relationships.Delete("xl/drawings/_rels/drawing1.xml.rels")
+	assert.NoError(t, f.deleteMedia(media))
+	_, ok = f.Pkg.Load(media)
+	assert.False(t, ok)
+	assert.Equal(t, "xl/media/image3.png", f.addMedia([]byte("image3"), ".png"))
+	// Test delete the picture with unsupported charset relationships
+	f.Pkg.Store("xl/drawings/_rels/drawing1.xml.rels", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.deleteMedia(media), "XML syntax error on line 1: invalid UTF-8")
+}
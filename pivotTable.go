@@ -12,8 +12,10 @@
 package excelize
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -719,3 +721,98 @@ func (f *File) addWorkbookPivotCache(RID int) int {
 	})
 	return cacheID
 }
+
+// getPivotTableByRange provides a function to get the worksheet name, part
+// path and definition of the pivot table by given pivot table range
+// reference.
+func (f *File) getPivotTableByRange(rangeRef string) (string, string, *xlsxPivotTableDefinition, error) {
+	sheet, coordinates, err := f.adjustRange(rangeRef)
+	if err != nil {
+		return sheet, "", nil, fmt.Errorf("parameter 'PivotTableRange' parsing error: %s", err.Error())
+	}
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return sheet, "", nil, newNoExistSheetError(sheet)
+	}
+	ref, _ := f.coordinatesToRangeRef(coordinates)
+	rels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	sheetRels, err := f.relsReader(rels)
+	if err != nil {
+		return sheet, "", nil, err
+	}
+	if sheetRels == nil {
+		sheetRels = &xlsxRelationships{}
+	}
+	sheetRels.mu.Lock()
+	defer sheetRels.mu.Unlock()
+	for _, rel := range sheetRels.Relationships {
+		if rel.Type != SourceRelationshipPivotTable {
+			continue
+		}
+		pivotTableXML := strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+		pt := new(xlsxPivotTableDefinition)
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(pivotTableXML)))).
+			Decode(pt); err != nil && err != io.EOF {
+			return sheet, pivotTableXML, nil, err
+		}
+		if pt.Location != nil && strings.EqualFold(pt.Location.Ref, ref) {
+			return sheet, pivotTableXML, pt, nil
+		}
+	}
+	return sheet, "", nil, newNoExistPivotTableError(rangeRef)
+}
+
+// setPivotTableChartFormat provides a function to set the chartFormat
+// attribute of the pivot table definition by given pivot table part path and
+// chart format ID. Only the start element of the pivot table definition will
+// be rewritten by the XML tokens, and the other elements and attributes of the
+// pivot table definition will be kept as is.
+func (f *File) setPivotTableChartFormat(pivotTableXML string, chartFormat int) {
+	content := f.readXML(pivotTableXML)
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err != nil {
+			return
+		}
+		startElement, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if startElement.Name.Local != "pivotTableDefinition" {
+			return
+		}
+		end := decoder.InputOffset()
+		var buf bytes.Buffer
+		buf.WriteString("<" + getXMLQualifiedName(startElement.Name) + ` chartFormat="` + strconv.Itoa(chartFormat) + `"`)
+		for _, attr := range startElement.Attr {
+			if attr.Name.Space == "" && attr.Name.Local == "chartFormat" {
+				continue
+			}
+			buf.WriteString(" " + getXMLQualifiedName(attr.Name) + `="`)
+			_ = xml.EscapeText(&buf, []byte(attr.Value))
+			buf.WriteString(`"`)
+		}
+		if bytes.HasSuffix(content[offset:end], []byte("/>")) {
+			buf.WriteString("/")
+		}
+		buf.WriteString(">")
+		result := make([]byte, 0, len(content)+buf.Len())
+		result = append(result, content[:offset]...)
+		result = append(result, buf.Bytes()...)
+		result = append(result, content[end:]...)
+		f.Pkg.Store(pivotTableXML, result)
+		return
+	}
+}
+
+// getXMLQualifiedName provides a function to get the qualified name of the
+// element or attribute by given name which returned by the RawToken function
+// of the XML decoder, the space of the name is the prefix.
+func getXMLQualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
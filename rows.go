@@ -19,6 +19,8 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/mohae/deepcopy"
 )
@@ -29,7 +31,7 @@ import (
 // the applied value will be used, otherwise the original value will be used.
 // GetRows fetched the rows with value or formula cells, the continually blank
 // cells in the tail of each row will be skipped, so the length of each row
-// may be inconsistent.
+// may be inconsistent. This function is concurrency safe.
 //
 // For example, get and traverse the value of all cells by rows on a worksheet
 // named 'Sheet1':
@@ -65,6 +67,201 @@ func (f *File) GetRows(sheet string, opts ...Options) ([][]string, error) {
 	return results[:max], rows.Close()
 }
 
+// GetRowsRange return the rows between the given start and end row number
+// (both inclusive) in a sheet by given worksheet name, returned as a
+// two-dimensional array like the GetRows function does. The worksheet will be
+// read as a stream, and the parsing stops after reached the end row, so the
+// rows after the end row will not be parsed. This is useful to preview the
+// first rows of a worksheet with huge amounts of data. For example, get the
+// first 100 rows on a worksheet named 'Sheet1':
+//
+//	rows, err := f.GetRowsRange("Sheet1", 1, 100)
+func (f *File) GetRowsRange(sheet string, startRow, endRow int, opts ...Options) ([][]string, error) {
+	if startRow < 1 {
+		return nil, newInvalidRowNumberError(startRow)
+	}
+	if endRow < startRow {
+		return nil, newInvalidRowNumberError(endRow)
+	}
+	if endRow > TotalRows {
+		return nil, ErrMaxRows
+	}
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	results, cur, max := make([][]string, 0, 64), 0, 0
+	for rows.Next() {
+		if cur++; cur < startRow {
+			continue
+		}
+		if cur > endRow {
+			break
+		}
+		row, err := rows.Columns(opts...)
+		if err != nil {
+			break
+		}
+		results = append(results, row)
+		if len(row) > 0 {
+			max = len(results)
+		}
+	}
+	return results[:max], rows.Close()
+}
+
+// GetRowsAsMaps return the rows below the header row in a sheet by given
+// worksheet name and header row number, returned as a slice of records, where
+// the values of the header row are used as the keys of each record, and the
+// value of the cell is converted to the string type like the GetRows function
+// does. The empty rows will be skipped, the duplicate header names will be
+// disambiguated by appending a suffix with the number of occurrences, such
+// as "Name_2", and the empty header will be named by its column name. For
+// example, get the records of the table with the header in the first row on
+// a worksheet named 'Sheet1':
+//
+//	records, err := f.GetRowsAsMaps("Sheet1", 1)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	data, err := json.Marshal(records)
+func (f *File) GetRowsAsMaps(sheet string, headerRow int, opts ...Options) ([]map[string]string, error) {
+	headers, rows, err := f.getRowsWithHeader(sheet, headerRow, opts...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		if isEmptyRecord(row) {
+			continue
+		}
+		record := make(map[string]string, len(headers))
+		for colIdx, header := range headers {
+			if record[header] = ""; colIdx < len(row) {
+				record[header] = row[colIdx]
+			}
+		}
+		results = append(results, record)
+	}
+	return results, err
+}
+
+// GetRowsAsTypedMaps return the rows below the header row in a sheet by given
+// worksheet name and header row number, returned as a slice of records like
+// the GetRowsAsMaps function does, but the value of the cell is converted to
+// the typed value by the data type and number format of the cell: float64 for
+// numbers, bool for booleans, time.Time for dates and string for others. The
+// value of the empty cell will be nil. For example:
+//
+//	records, err := f.GetRowsAsTypedMaps("Sheet1", 1)
+func (f *File) GetRowsAsTypedMaps(sheet string, headerRow int) ([]map[string]interface{}, error) {
+	headers, rows, err := f.getRowsWithHeader(sheet, headerRow, Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]map[string]interface{}, 0, len(rows))
+	for rowIdx, row := range rows {
+		if isEmptyRecord(row) {
+			continue
+		}
+		record := make(map[string]interface{}, len(headers))
+		for colIdx, header := range headers {
+			if record[header] = nil; colIdx >= len(row) || row[colIdx] == "" {
+				continue
+			}
+			cell, _ := CoordinatesToCellName(colIdx+1, headerRow+rowIdx+1)
+			if record[header], err = f.getTypedCellValue(sheet, cell); err != nil {
+				return results, err
+			}
+		}
+		results = append(results, record)
+	}
+	return results, err
+}
+
+// getRowsWithHeader provides a function to get the disambiguated header names
+// of the header row and the rows below the header row by given worksheet name
+// and header row number.
+func (f *File) getRowsWithHeader(sheet string, headerRow int, opts ...Options) ([]string, [][]string, error) {
+	if headerRow < 1 {
+		return nil, nil, newInvalidRowNumberError(headerRow)
+	}
+	if headerRow > TotalRows {
+		return nil, nil, ErrMaxRows
+	}
+	rows, err := f.GetRows(sheet, opts...)
+	if err != nil || len(rows) < headerRow {
+		return nil, nil, err
+	}
+	headers, counts := make([]string, len(rows[headerRow-1])), map[string]int{}
+	for colIdx, value := range rows[headerRow-1] {
+		header := strings.TrimSpace(value)
+		if header == "" {
+			header, _ = ColumnNumberToName(colIdx + 1)
+		}
+		name := header
+		for counts[name] > 0 {
+			counts[header]++
+			name = header + "_" + strconv.Itoa(counts[header])
+		}
+		counts[name]++
+		headers[colIdx] = name
+	}
+	return headers, rows[headerRow:], err
+}
+
+// isEmptyRecord returns true if all values in the given row are empty.
+func isEmptyRecord(row []string) bool {
+	for _, value := range row {
+		if value != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// getTypedCellValue provides a function to get the value of the cell converted
+// to the typed value by the data type and number format of the cell.
+func (f *File) getTypedCellValue(sheet, cell string) (interface{}, error) {
+	var value interface{}
+	_, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+		switch c.T {
+		case "b":
+			value = c.V == "1"
+		case "d":
+			if t, err := time.Parse(time.RFC3339Nano, c.V); err == nil {
+				value = t
+				break
+			}
+			value = c.V
+		case "", "n":
+			num, err := strconv.ParseFloat(c.V, 64)
+			if err != nil {
+				value = c.V
+				break
+			}
+			numFmt, date1904, err := f.getCellNumFmt(c)
+			if err != nil {
+				return "", false, err
+			}
+			if value = num; numFmt != nil && numFmt.isDateTime() {
+				value = timeFromExcelTime(num, date1904)
+			}
+		default:
+			sst, err := f.sharedStringsReader()
+			if err != nil {
+				return "", false, err
+			}
+			val, err := c.getValueFrom(f, sst, true)
+			value = val
+			return "", true, err
+		}
+		return "", true, nil
+	})
+	return value, err
+}
+
 // Rows defines an iterator to a sheet.
 type Rows struct {
 	err                     error
@@ -270,10 +467,12 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
 		ws := worksheet.(*xlsxWorksheet)
 		ws.mu.Lock()
-		defer ws.mu.Unlock()
 		// Flush data
 		output, _ := xml.Marshal(ws)
+		ws.mu.Unlock()
+		f.mu.Lock()
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		f.mu.Unlock()
 	}
 	var err error
 	rows := Rows{f: f, sheet: name}
@@ -281,54 +480,148 @@ func (f *File) Rows(sheet string) (*Rows, error) {
 	return &rows, err
 }
 
-// getFromStringItem build shared string item offset list from system temporary
-// file at one time, and return value by given to string index.
-func (f *File) getFromStringItem(index int) string {
-	if f.sharedStringTemp != nil {
-		if len(f.sharedStringItem) <= index {
-			return strconv.Itoa(index)
-		}
-		offsetRange := f.sharedStringItem[index]
-		buf := make([]byte, offsetRange[1]-offsetRange[0])
-		if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
-			return strconv.Itoa(index)
-		}
-		return string(buf)
-	}
-	needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
-	if needClose && err == nil {
-		defer func() {
-			err = tempFile.Close()
-		}()
-	}
-	f.sharedStringItem = [][]uint{}
-	f.sharedStringTemp, _ = os.CreateTemp(os.TempDir(), "excelize-")
-	f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
+// SheetStats directly maps the usage statistics of the worksheet.
+type SheetStats struct {
+	Cells    int
+	Rows     int
+	Cols     int
+	Formulas int
+}
+
+// GetSheetStats provides a function to get the usage statistics of the
+// worksheet by given worksheet name, including the number of non-empty cells,
+// used rows, used columns and formula cells. The cell without value and
+// formula, such as the cell only with style, will not be counted. This
+// function fetches the worksheet data as a stream in a single pass, and
+// doesn't build the rows matrix, so it's fast for the worksheet with a large
+// data. For example, get the usage statistics of the worksheet Sheet1:
+//
+//	stats, err := f.GetSheetStats("Sheet1")
+func (f *File) GetSheetStats(sheet string) (SheetStats, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return SheetStats{}, err
+	}
+	stats, err := rows.stats()
+	if closeErr := rows.Close(); err == nil {
+		err = closeErr
+	}
+	return stats, err
+}
+
+// stats provides a function to tally the usage statistics of the worksheet
+// by parsing the worksheet XML as a stream.
+func (rows *Rows) stats() (SheetStats, error) {
 	var (
-		inElement string
-		i, offset uint
+		stats                   SheetStats
+		rowNum, colNum, lastRow int
+		cols                    = map[int]struct{}{}
+		err                     error
 	)
 	for {
-		token, _ := decoder.Token()
+		token, _ := rows.decoder.Token()
 		if token == nil {
 			break
 		}
 		switch xmlElement := token.(type) {
 		case xml.StartElement:
-			inElement = xmlElement.Name.Local
-			if inElement == "si" {
-				si := xlsxSI{}
-				_ = decoder.DecodeElement(&si, &xmlElement)
-
-				startIdx := offset
-				n, _ := f.sharedStringTemp.WriteString(si.String())
-				offset += uint(n)
-				f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
-				i++
+			if xmlElement.Name.Local == "row" {
+				if num, _ := attrValToInt("r", xmlElement.Attr); num != 0 {
+					rowNum = num
+				} else {
+					rowNum++
+				}
+				colNum = 0
+			}
+			if xmlElement.Name.Local != "c" {
+				continue
+			}
+			colNum++
+			var c xlsxC
+			if err = rows.decoder.DecodeElement(&c, &xmlElement); err != nil {
+				return stats, err
+			}
+			if c.R != "" {
+				if colNum, rowNum, err = CellNameToCoordinates(c.R); err != nil {
+					return stats, err
+				}
+			}
+			if c.F != nil {
+				stats.Formulas++
+			} else if c.V == "" && c.IS == nil {
+				continue
+			}
+			stats.Cells++
+			if rowNum != lastRow {
+				stats.Rows++
+				lastRow = rowNum
+			}
+			cols[colNum] = struct{}{}
+		case xml.EndElement:
+			if xmlElement.Name.Local == "sheetData" {
+				stats.Cols = len(cols)
+				return stats, err
 			}
 		}
 	}
-	return f.getFromStringItem(index)
+	stats.Cols = len(cols)
+	return stats, err
+}
+
+// getFromStringItem build shared string item offset list from system temporary
+// file on demand, and return value by given to string index. The shared
+// string table will be parsed until the item of the given index, and the
+// parsed items will be indexed for subsequent lookups.
+func (f *File) getFromStringItem(index int) string {
+	if f.sharedStringTemp == nil {
+		needClose, decoder, tempFile, err := f.xmlDecoder(defaultXMLPathSharedStrings)
+		if needClose && err == nil {
+			f.sharedStringSource = tempFile
+		}
+		f.sharedStringDecoder = decoder
+		f.sharedStringItem = [][]uint{}
+		f.sharedStringTemp, _ = os.CreateTemp(os.TempDir(), "excelize-")
+		f.tempFiles.Store(defaultTempFileSST, f.sharedStringTemp.Name())
+	}
+	f.parseStringItems(index)
+	if len(f.sharedStringItem) <= index {
+		return strconv.Itoa(index)
+	}
+	offsetRange := f.sharedStringItem[index]
+	buf := make([]byte, offsetRange[1]-offsetRange[0])
+	if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
+		return strconv.Itoa(index)
+	}
+	return string(buf)
+}
+
+// parseStringItems continue parsing the shared string table and append the
+// shared string items to the system temporary file until the item of the
+// given index has been parsed, or reached the end of the shared string table.
+func (f *File) parseStringItems(index int) {
+	var offset uint
+	if l := len(f.sharedStringItem); l > 0 {
+		offset = f.sharedStringItem[l-1][1]
+	}
+	for f.sharedStringDecoder != nil && len(f.sharedStringItem) <= index {
+		token, _ := f.sharedStringDecoder.Token()
+		if token == nil {
+			f.sharedStringDecoder = nil
+			if f.sharedStringSource != nil {
+				_ = f.sharedStringSource.Close()
+				f.sharedStringSource = nil
+			}
+			break
+		}
+		if xmlElement, ok := token.(xml.StartElement); ok && xmlElement.Name.Local == "si" {
+			si := xlsxSI{}
+			_ = f.sharedStringDecoder.DecodeElement(&si, &xmlElement)
+			startIdx := offset
+			n, _ := f.sharedStringTemp.WriteString(si.String())
+			offset += uint(n)
+			f.sharedStringItem = append(f.sharedStringItem, []uint{startIdx, offset})
+		}
+	}
 }
 
 // xmlDecoder creates XML decoder by given path in the zip from memory data
@@ -500,6 +793,72 @@ func (f *File) GetRowVisible(sheet string, row int) (bool, error) {
 	return !ws.SheetData.Row[row-1].Hidden, nil
 }
 
+// HiddenReason is the type of the reasons why the rows or columns are hidden.
+type HiddenReason byte
+
+// This section defines the currently supported hidden reason types
+// enumeration.
+const (
+	HiddenReasonManual HiddenReason = iota
+	HiddenReasonFilter
+	HiddenReasonOutline
+)
+
+// GetHiddenRows provides a function to get the hidden rows number in
+// ascending order by given worksheet name. Note that the rows hidden by the
+// zero height default row settings will not be included. This function is
+// concurrency safe. For example, get hidden rows in Sheet1:
+//
+//	rows, err := f.GetHiddenRows("Sheet1")
+func (f *File) GetHiddenRows(sheet string) ([]int, error) {
+	reasons, err := f.GetHiddenRowReasons(sheet)
+	rows := make(map[int]struct{}, len(reasons))
+	for row := range reasons {
+		rows[row] = struct{}{}
+	}
+	return sortedKeys(rows), err
+}
+
+// GetHiddenRowReasons provides a function to get the reasons of the hidden
+// rows by given worksheet name, the returned map is keyed by the row number.
+// The hidden row in the auto filter range but the header row is considered
+// as hidden by the filter, and the hidden row with outline level is
+// considered as hidden by the collapsed outline, otherwise it is hidden
+// manually.
+func (f *File) GetHiddenRowReasons(sheet string) (map[int]HiddenReason, error) {
+	reasons := make(map[int]HiddenReason)
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return reasons, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var filterRows []int
+	if ws.AutoFilter != nil {
+		if coordinates, err := rangeRefToCoordinates(ws.AutoFilter.Ref); err == nil {
+			_ = sortCoordinates(coordinates)
+			filterRows = []int{coordinates[1], coordinates[3]}
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if !row.Hidden {
+			continue
+		}
+		reasons[row.R] = HiddenReasonManual
+		if filterRows != nil && filterRows[0] < row.R && row.R <= filterRows[1] {
+			reasons[row.R] = HiddenReasonFilter
+			continue
+		}
+		if row.OutlineLevel > 0 {
+			reasons[row.R] = HiddenReasonOutline
+		}
+	}
+	return reasons, err
+}
+
 // SetRowOutlineLevel provides a function to set outline level number of a
 // single row by given worksheet name and Excel row number. The value of
 // parameter 'level' is 1-7. For example, outline row 2 in Sheet1 to level 1:
@@ -841,6 +1200,157 @@ func (f *File) SetRowStyle(sheet string, start, end, styleID int) error {
 	return nil
 }
 
+// RowBuilder directly maps the builder for writing the cells in a row of the
+// worksheet with chained calls, it was created by the NewRowBuilder function.
+// The cells will be written from the first column of the row when calling the
+// Build function. Note that the RowBuilder is not concurrency safe.
+type RowBuilder struct {
+	f     *File
+	sheet string
+	row   int
+	cells []rowBuilderCell
+	err   error
+}
+
+// rowBuilderCell directly maps the value, style and the number of merged
+// columns of a cell in the RowBuilder.
+type rowBuilderCell struct {
+	value    interface{}
+	styleID  int
+	hasStyle bool
+	span     int
+}
+
+// NewRowBuilder provides a function to create a RowBuilder for writing cells
+// in a row by given worksheet name and row number. The AddCell appends a cell
+// with the given value, the WithStyle and Merge apply the style and merge the
+// columns for the last added cell. All cells will be written in one worksheet
+// pass when calling the Build function. For example, write a header row in
+// Sheet1 with a title merged across A1:C1, and two styled cells in D1 and E1:
+//
+//	err := f.NewRowBuilder("Sheet1", 1).
+//	    AddCell("Title").Merge(3).
+//	    AddCell("Name").WithStyle(styleID).
+//	    AddCell(100).WithStyle(styleID).
+//	    Build()
+func (f *File) NewRowBuilder(sheet string, row int) *RowBuilder {
+	b := &RowBuilder{f: f, sheet: sheet, row: row}
+	if row < 1 {
+		b.err = newInvalidRowNumberError(row)
+	}
+	return b
+}
+
+// AddCell provides a function to append a cell with the given value to the
+// row, the supported data types of the value are the same as SetCellValue.
+func (b *RowBuilder) AddCell(value interface{}) *RowBuilder {
+	b.cells = append(b.cells, rowBuilderCell{value: value, span: 1})
+	return b
+}
+
+// WithStyle provides a function to set the style for the last added cell by
+// given style index, the style will be applied to all merged columns of the
+// cell.
+func (b *RowBuilder) WithStyle(styleID int) *RowBuilder {
+	if len(b.cells) == 0 {
+		if b.err == nil {
+			b.err = ErrParameterInvalid
+		}
+		return b
+	}
+	b.cells[len(b.cells)-1].styleID = styleID
+	b.cells[len(b.cells)-1].hasStyle = true
+	return b
+}
+
+// Merge provides a function to merge the last added cell across the given
+// number of columns, the next added cell will be placed after the merged
+// columns.
+func (b *RowBuilder) Merge(n int) *RowBuilder {
+	if len(b.cells) == 0 || n < 1 {
+		if b.err == nil {
+			b.err = ErrParameterInvalid
+		}
+		return b
+	}
+	b.cells[len(b.cells)-1].span = n
+	return b
+}
+
+// Build provides a function to write all added cells to the worksheet in one
+// pass of the row, the styles will be validated before writing any cell. The
+// first error occurred in the chained calls will be returned.
+func (b *RowBuilder) Build() error {
+	if b.err != nil || len(b.cells) == 0 {
+		return b.err
+	}
+	var lastCol int
+	for _, cell := range b.cells {
+		lastCol += cell.span
+	}
+	if _, err := CoordinatesToCellName(lastCol, b.row); err != nil {
+		return err
+	}
+	f := b.f
+	ws, date1904, err := f.prepareSetCells(b.sheet)
+	if err != nil {
+		return err
+	}
+	var styleIDs []int
+	for _, cell := range b.cells {
+		if cell.hasStyle {
+			styleIDs = append(styleIDs, cell.styleID)
+		}
+	}
+	if err = f.checkStyleIDs(styleIDs...); err != nil {
+		return err
+	}
+	timeCells := map[string]int{}
+	ws.mu.Lock()
+	ws.prepareSheetXML(lastCol, b.row)
+	ws.makeContiguousColumns(b.row, b.row, lastCol)
+	col := 1
+	for _, cell := range b.cells {
+		name, _ := CoordinatesToCellName(col, b.row)
+		c, _, _, err := ws.prepareCell(name)
+		if err != nil {
+			ws.mu.Unlock()
+			return err
+		}
+		c.S = ws.prepareCellStyle(col, b.row, c.S)
+		numFmt, err := f.setCellValue(ws, b.sheet, c, cell.value, date1904)
+		if err != nil {
+			ws.mu.Unlock()
+			return err
+		}
+		if numFmt != 0 && c.S == 0 && !cell.hasStyle {
+			timeCells[c.R] = numFmt
+		}
+		for k := col; cell.hasStyle && k < col+cell.span; k++ {
+			ws.SheetData.Row[b.row-1].C[k-1].S = cell.styleID
+		}
+		col += cell.span
+	}
+	ws.mu.Unlock()
+	for cell, numFmt := range timeCells {
+		if err = f.setDefaultTimeStyle(b.sheet, cell, numFmt); err != nil {
+			return err
+		}
+	}
+	col = 1
+	for _, cell := range b.cells {
+		if cell.span > 1 {
+			start, _ := CoordinatesToCellName(col, b.row)
+			end, _ := CoordinatesToCellName(col+cell.span-1, b.row)
+			if err = f.MergeCell(b.sheet, start, end); err != nil {
+				return err
+			}
+		}
+		col += cell.span
+	}
+	return err
+}
+
 // convertRowHeightToPixels provides a function to convert the height of a
 // cell from user's units to pixels. If the height hasn't been set by the user
 // we use the default value. If the row is hidden it has a value of zero.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,6 +22,97 @@ func TestGetRows(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetRowsRange(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		if row == 5 {
+			continue
+		}
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row, fmt.Sprintf("R%d", row)}))
+	}
+	rows, err := f.GetRowsRange("Sheet1", 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1", "R1"}, {"2", "R2"}, {"3", "R3"}}, rows)
+	rows, err = f.GetRowsRange("Sheet1", 4, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"4", "R4"}, nil, {"6", "R6"}}, rows)
+	rows, err = f.GetRowsRange("Sheet1", 9, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"9", "R9"}, {"10", "R10"}}, rows)
+	rows, err = f.GetRowsRange("Sheet1", 5, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	rows, err = f.GetRowsRange("Sheet1", 11, 20)
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	// Test get rows range with invalid row number
+	_, err = f.GetRowsRange("Sheet1", 0, 1)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowsRange("Sheet1", 3, 2)
+	assert.EqualError(t, err, newInvalidRowNumberError(2).Error())
+	_, err = f.GetRowsRange("Sheet1", 1, TotalRows+1)
+	assert.EqualError(t, err, ErrMaxRows.Error())
+	// Test get rows range with not exist worksheet
+	_, err = f.GetRowsRange("SheetN", 1, 2)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetRowsAsMaps(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	for cell, row := range map[string]*[]interface{}{
+		"A2": {"Name", "Score", "Name", "", "Passed", "Date", "Name_2"},
+		"A3": {"Alice", 90.5, "A", "x", true, date, "B"},
+		"A5": {"Bob", 60},
+	} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, row))
+	}
+	records, err := f.GetRowsAsMaps("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"Name": "Alice", "Score": "90.5", "Name_2": "A", "D": "x", "Passed": "TRUE", "Date": "1/2/23 00:00", "Name_2_2": "B"},
+		{"Name": "Bob", "Score": "60", "Name_2": "", "D": "", "Passed": "", "Date": "", "Name_2_2": ""},
+	}, records)
+	typedRecords, err := f.GetRowsAsTypedMaps("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"Name": "Alice", "Score": 90.5, "Name_2": "A", "D": "x", "Passed": true, "Date": date, "Name_2_2": "B"},
+		{"Name": "Bob", "Score": 60.0, "Name_2": nil, "D": nil, "Passed": nil, "Date": nil, "Name_2_2": nil},
+	}, typedRecords)
+	// Test get rows as maps with the header row beyond the last row
+	records, err = f.GetRowsAsMaps("Sheet1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+	// Test get rows as maps with invalid header row number
+	_, err = f.GetRowsAsMaps("Sheet1", 0)
+	assert.EqualError(t, err, newInvalidRowNumberError(0).Error())
+	_, err = f.GetRowsAsTypedMaps("Sheet1", TotalRows+1)
+	assert.EqualError(t, err, ErrMaxRows.Error())
+	// Test get rows as maps with not exist worksheet
+	_, err = f.GetRowsAsMaps("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetRowsAsTypedMaps("SheetN", 1)
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get rows as typed maps with the date type and formula string cell
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[1] = xlsxC{R: "B3", T: "d", V: "2023-01-02T00:00:00Z"}
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[2] = xlsxC{R: "C3", T: "str", V: "A"}
+	ws.(*xlsxWorksheet).SheetData.Row[2].C[3] = xlsxC{R: "D3", T: "d", V: "x"}
+	typedRecords, err = f.GetRowsAsTypedMaps("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, date, typedRecords[0]["Score"])
+	assert.Equal(t, "A", typedRecords[0]["Name_2"])
+	assert.Equal(t, "x", typedRecords[0]["D"])
+	// Test get rows as typed maps with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetRowsAsTypedMaps("Sheet1", 2)
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestRows(t *testing.T) {
 	const sheet2 = "Sheet2"
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
@@ -267,6 +359,63 @@ func TestSharedStringsReader(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestLazySharedStrings(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 100; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), fmt.Sprintf("Text%d", row)))
+	}
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{LazySharedStrings: true})
+	assert.NoError(t, err)
+	_, ok := f.tempFiles.Load(defaultXMLPathSharedStrings)
+	assert.True(t, ok)
+	// Test get cell value only parse the shared string items on demand
+	val, err := f.GetCellValue("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, "Text3", val)
+	assert.Len(t, f.sharedStringItem, 3)
+	assert.NotNil(t, f.sharedStringDecoder)
+	val, err = f.GetCellValue("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Text2", val)
+	assert.Len(t, f.sharedStringItem, 3)
+	val, err = f.GetCellValue("Sheet1", "A50")
+	assert.NoError(t, err)
+	assert.Equal(t, "Text50", val)
+	assert.Len(t, f.sharedStringItem, 50)
+	// Test get cell value with shared string index out of range
+	assert.Equal(t, "100", f.getFromStringItem(100))
+	assert.Len(t, f.sharedStringItem, 100)
+	assert.Nil(t, f.sharedStringDecoder)
+	assert.Nil(t, f.sharedStringSource)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 100)
+	assert.Equal(t, []string{"Text100"}, rows[99])
+	// Test set cell value after parse shared string items on demand
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "Text101"))
+	assert.Nil(t, f.sharedStringTemp)
+	for cell, expected := range map[string]string{"A1": "Text1", "A100": "Text100", "B1": "Text101"} {
+		val, err = f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val)
+	}
+	assert.NoError(t, f.Close())
+
+	// Test close the workbook before parsed all shared string items
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()), Options{LazySharedStrings: true})
+	assert.NoError(t, err)
+	val, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Text1", val)
+	assert.NotNil(t, f.sharedStringSource)
+	assert.NoError(t, f.Close())
+	assert.Nil(t, f.sharedStringSource)
+}
+
 func TestRowVisibility(t *testing.T) {
 	f, err := prepareTestBook1()
 	assert.NoError(t, err)
@@ -296,6 +445,38 @@ func TestRowVisibility(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowVisibility.xlsx")))
 }
 
+func TestGetHiddenRows(t *testing.T) {
+	f := NewFile()
+	rows, err := f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+	assert.NoError(t, f.AutoFilter("Sheet1", "D6:A3", nil))
+	for _, row := range []int{2, 3, 5, 8, 9} {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	assert.NoError(t, f.SetRowVisible("Sheet1", 7, true))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 5, 1))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 9, 2))
+	rows, err = f.GetHiddenRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3, 5, 8, 9}, rows)
+	reasons, err := f.GetHiddenRowReasons("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]HiddenReason{
+		2: HiddenReasonManual,
+		3: HiddenReasonManual,
+		5: HiddenReasonFilter,
+		8: HiddenReasonManual,
+		9: HiddenReasonOutline,
+	}, reasons)
+	// Test get hidden rows on not exists worksheet
+	_, err = f.GetHiddenRows("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get hidden rows with invalid sheet name
+	_, err = f.GetHiddenRows("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
+
 func TestRemoveRow(t *testing.T) {
 	f := NewFile()
 	sheet1 := f.GetSheetName(0)
@@ -981,6 +1162,56 @@ func TestSetRowStyle(t *testing.T) {
 	assert.EqualError(t, f.SetRowStyle("Sheet1", 1, 1, cellStyleID), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestRowBuilder(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "F2", "keep"))
+	assert.NoError(t, f.NewRowBuilder("Sheet1", 2).
+		AddCell("Title").Merge(3).WithStyle(style).
+		AddCell(100).
+		AddCell(true).WithStyle(style).
+		Build())
+	for cell, expected := range map[string]string{"A2": "Title", "B2": "Title", "D2": "100", "E2": "TRUE", "F2": "keep"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	for cell, expected := range map[string]int{"A2": style, "C2": style, "D2": 0, "E2": style} {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, styleID, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A2", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "C2", mergeCells[0].GetEndAxis())
+	// Test build an empty row builder
+	assert.NoError(t, f.NewRowBuilder("Sheet1", 3).Build())
+	// Test build row builder with date and time values
+	assert.NoError(t, f.NewRowBuilder("Sheet1", 4).AddCell(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)).AddCell(time.Hour*36).Build())
+	for cell, expected := range map[string]string{"A4": "1/2/23 00:00", "B4": "12:00:00"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRowBuilder.xlsx")))
+	// Test build row builder with invalid parameters
+	assert.EqualError(t, f.NewRowBuilder("Sheet1", 0).AddCell(1).Build(), newInvalidRowNumberError(0).Error())
+	assert.Equal(t, ErrParameterInvalid, f.NewRowBuilder("Sheet1", 1).WithStyle(style).Build())
+	assert.Equal(t, ErrParameterInvalid, f.NewRowBuilder("Sheet1", 1).Merge(2).Build())
+	assert.Equal(t, ErrParameterInvalid, f.NewRowBuilder("Sheet1", 1).AddCell(1).Merge(0).WithStyle(-1).Build())
+	assert.EqualError(t, f.NewRowBuilder("Sheet1", 1).AddCell(1).WithStyle(10).Build(), newInvalidStyleID(10).Error())
+	assert.EqualError(t, f.NewRowBuilder("Sheet1", 1).AddCell(1).Merge(MaxColumns+1).Build(), ErrColumnNumber.Error())
+	assert.EqualError(t, f.NewRowBuilder("SheetN", 1).AddCell(1).Build(), "sheet SheetN does not exist")
+	assert.EqualError(t, f.NewRowBuilder("Sheet:1", 1).AddCell(1).Build(), ErrSheetNameInvalid.Error())
+	// Test build row builder with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.NewRowBuilder("Sheet1", 1).AddCell(1).Build(), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestNumberFormats(t *testing.T) {
 	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
 	if !assert.NoError(t, err) {
@@ -1111,3 +1342,51 @@ func trimSliceSpace(s []string) []string {
 	}
 	return s
 }
+
+func TestGetSheetStats(t *testing.T) {
+	f := NewFile()
+	stats, err := f.GetSheetStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetStats{}, stats)
+
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Excelize"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C1", 1))
+	assert.NoError(t, f.SetCellValue("Sheet1", "C3", true))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E3", "SUM(C1,C3)"))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A5", "F6", style))
+	assert.NoError(t, f.SetCellRichText("Sheet1", "B7", []RichTextRun{{Text: "Rich"}}))
+	expected := SheetStats{Cells: 5, Rows: 3, Cols: 4, Formulas: 1}
+	stats, err = f.GetSheetStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, stats)
+
+	// Test get sheet stats with the worksheet parsed from the temporary file
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetSheetStats.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestGetSheetStats.xlsx"), Options{UnzipXMLSizeLimit: 128})
+	assert.NoError(t, err)
+	stats, err = f.GetSheetStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, stats)
+	assert.NoError(t, f.Close())
+
+	// Test get sheet stats with the cells without reference
+	f = NewFile()
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row><c><v>1</v></c><c/><c><v>2</v></c></row><row r="3"><c r="B3"><v>3</v></c></row><row><c><f>B3</f></c></row></sheetData></worksheet>`))
+	stats, err = f.GetSheetStats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetStats{Cells: 4, Rows: 3, Cols: 3, Formulas: 1}, stats)
+	// Test get sheet stats with invalid cell reference
+	f.Pkg.Store("xl/worksheets/sheet1.xml", []byte(xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="-"><v>1</v></c></row></sheetData></worksheet>`))
+	_, err = f.GetSheetStats("Sheet1")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("-", newInvalidCellNameError("-")).Error())
+	// Test get sheet stats with not exist worksheet
+	_, err = f.GetSheetStats("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get sheet stats with invalid sheet name
+	_, err = f.GetSheetStats("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
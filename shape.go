@@ -12,6 +12,9 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"io"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -43,9 +46,105 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 	if opts.Line.Width == nil {
 		opts.Line.Width = float64Ptr(defaultShapeLineWidth)
 	}
+	if err := parseShapeLineOptions(&opts.Line); err != nil {
+		return opts, err
+	}
+	if opts.FillFormat.Transparency < 0 || opts.FillFormat.Transparency > 100 {
+		return opts, ErrParameterInvalid
+	}
+	if opts.Hyperlink != "" && opts.HyperlinkType != "External" && opts.HyperlinkType != "Location" {
+		return opts, ErrParameterInvalid
+	}
+	if opts.TextVAlign == "" {
+		opts.TextVAlign = "top"
+	}
+	if _, ok := shapeTextVerticalAlignments[opts.TextVAlign]; !ok {
+		return opts, ErrParameterInvalid
+	}
+	if _, ok := shapeTextAlignments[opts.TextAlign]; !ok && opts.TextAlign != "" {
+		return opts, ErrParameterInvalid
+	}
+	if opts.Shadow != nil {
+		if opts.Shadow.Transparency < 0 || opts.Shadow.Transparency > 100 {
+			return opts, ErrParameterInvalid
+		}
+		if opts.Shadow.Color == "" {
+			opts.Shadow.Color = "000000"
+		}
+		if opts.Shadow.Blur == 0 && opts.Shadow.Distance == 0 &&
+			opts.Shadow.Direction == 0 && opts.Shadow.Transparency == 0 {
+			opts.Shadow.Blur = defaultShapeShadowBlur
+			opts.Shadow.Distance = defaultShapeShadowDistance
+			opts.Shadow.Direction = defaultShapeShadowDirection
+			opts.Shadow.Transparency = defaultShapeShadowAlpha
+		}
+	}
 	return opts, nil
 }
 
+// parseShapeLineOptions provides a function to validate the dash type and the
+// line end types and sizes of the line settings.
+func parseShapeLineOptions(line *ShapeLine) error {
+	if line.Transparency < 0 || line.Transparency > 100 {
+		return ErrParameterInvalid
+	}
+	if line.DashType != "" && inStrSlice(supportedLineDashTypes, line.DashType, true) == -1 {
+		return ErrParameterInvalid
+	}
+	for _, lineEnd := range [][]string{{line.HeadType, line.HeadSize}, {line.TailType, line.TailSize}} {
+		if lineEnd[0] != "" && inStrSlice(supportedLineEndTypes, lineEnd[0], true) == -1 {
+			return ErrParameterInvalid
+		}
+		if _, ok := lineEndSizes[lineEnd[1]]; !ok && lineEnd[1] != "" {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
+// newShapeSolidFill provides a function to create the solid fill of the shape
+// by given color and transparency percentage, the alpha value is measured in
+// thousandths of a percent.
+func newShapeSolidFill(color string, transparency int) *aSolidFillAlpha {
+	return &aSolidFillAlpha{
+		SrgbClr: aSrgbClrAlpha{
+			Val:   strings.ReplaceAll(strings.ToUpper(color), "#", ""),
+			Alpha: &attrValInt{Val: intPtr((100 - transparency) * 1000)},
+		},
+	}
+}
+
+// getShapeTransparency provides a function to get the transparency percentage
+// by given RGB color with the alpha value.
+func getShapeTransparency(color *decodeSrgbClr) int {
+	if color.Alpha == nil || color.Alpha.Val == nil {
+		return 0
+	}
+	return 100 - int(math.Round(float64(*color.Alpha.Val)/1000))
+}
+
+// newShapeLineProperties provides a function to create the line properties of
+// the shape by given line settings.
+func (f *File) newShapeLineProperties(line *ShapeLine) xlsxLineProperties {
+	var ln xlsxLineProperties
+	if *line.Width != 1 {
+		ln.W = f.ptToEMUs(*line.Width)
+	}
+	if line.Transparency > 0 && line.Color != "" {
+		ln.SolidFill = newShapeSolidFill(line.Color, line.Transparency)
+	}
+	if line.DashType != "" {
+		ln.PrstDash = &attrValString{Val: stringPtr(line.DashType)}
+	}
+	if line.HeadType != "" {
+		ln.HeadEnd = &aLineEnd{Type: line.HeadType, W: lineEndSizes[line.HeadSize], Len: lineEndSizes[line.HeadSize]}
+	}
+	if line.TailType != "" {
+		ln.TailEnd = &aLineEnd{Type: line.TailType, W: lineEndSizes[line.TailSize], Len: lineEndSizes[line.TailSize]}
+	}
+	return ln
+}
+
 // AddShape provides the method to add shape in a sheet by given worksheet
 // index, shape format set (such as offset, scale, aspect ratio setting and
 // print settings) and properties set. For example, add text box (rect shape)
@@ -75,6 +174,106 @@ func parseShapeOptions(opts *Shape) (*Shape, error) {
 //	    },
 //	)
 //
+// The Shadow field specifies the outer shadow of the shape, the Blur and
+// Distance are measured in points, the Direction is measured in degrees and
+// the Transparency is a percentage in range 0 to 100. When only the Color is
+// set, the shadow will be offset diagonally to the bottom right with the 4
+// points blur, 3 points distance and 60 percent transparency. For example:
+//
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type:   "rect",
+//	    Shadow: &excelize.ShapeShadow{Color: "000000"},
+//	})
+//
+// Set the Type of the Fill field as "gradient" with two or more colors to
+// fill the shape with the linear gradient, the gradient stops are evenly
+// spaced, the Angle of the FillFormat field specifies the direction of the
+// gradient in degrees, default is 90 if it is nil, and the Transparency of
+// the FillFormat will be applied to each gradient stop. For example:
+//
+//	angle := 45.0
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type:       "rect",
+//	    Fill:       excelize.Fill{Type: "gradient", Color: []string{"FFFFFF", "5B9BD5"}},
+//	    FillFormat: excelize.ShapeFill{Angle: &angle},
+//	})
+//
+// The Rotation field specifies the clockwise rotation angle of the shape in
+// degrees, the shape will be rotated around its center. Negative angles and
+// angles greater than 360 degrees will be normalized, for example, -45 is the
+// same as 315.
+//
+// The Hyperlink field specifies the hyperlink of the shape, and the
+// HyperlinkType defines two types of hyperlink "External" for website or
+// "Location" for moving to one of the cells in this workbook, the location
+// need to start with "#". The Tooltip specifies the text which will be
+// displayed when the mouse hovers over the shape. For example, add a shape
+// which links to the cell A1 in Sheet2:
+//
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type:          "rect",
+//	    Hyperlink:     "#Sheet2!A1",
+//	    HyperlinkType: "Location",
+//	    Tooltip:       "Go to Sheet2",
+//	})
+//
+// The Transparency of the FillFormat and Line fields specifies the
+// transparency percentage in range 0 to 100 of the solid fill color and the
+// line color. For example, add a semi-transparent rectangle as the watermark:
+//
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type:       "rect",
+//	    Fill:       excelize.Fill{Color: []string{"4286F4"}},
+//	    FillFormat: excelize.ShapeFill{Transparency: 60},
+//	    Line:       excelize.ShapeLine{Color: "4286F4", Transparency: 30},
+//	})
+//
+// The DashType of the Line field specifies the preset dash style of the line,
+// and the HeadType and TailType specify the arrowheads at the start and the
+// end of the line with the optional HeadSize and TailSize, the possible sizes
+// are "small", "medium" and "large". For example, add a dashed arrow line:
+//
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type: "line",
+//	    Line: excelize.ShapeLine{DashType: "dashDot", TailType: "triangle"},
+//	})
+//
+// The following shows the type of line dash supported by excelize:
+//
+//	solid
+//	dot
+//	dash
+//	lgDash
+//	dashDot
+//	lgDashDot
+//	lgDashDotDot
+//	sysDash
+//	sysDot
+//	sysDashDot
+//	sysDashDotDot
+//
+// The following shows the type of arrowhead supported by excelize:
+//
+//	none
+//	triangle
+//	stealth
+//	diamond
+//	oval
+//	arrow
+//
+// The TextVAlign field specifies the vertical alignment of the text in the
+// shape, the possible values are "top" (default), "center" and "bottom". The
+// TextAlign field specifies the horizontal alignment of each paragraph in the
+// shape, the possible values are "left", "center", "right" and "justify". For
+// example, add a callout with the text centered in both directions:
+//
+//	err := f.AddShape("Sheet1", "G6", &excelize.Shape{
+//	    Type:       "wedgeRectCallout",
+//	    TextVAlign: "center",
+//	    TextAlign:  "center",
+//	    Paragraph:  []excelize.RichTextRun{{Text: "Callout"}},
+//	})
+//
 // The following shows the type of shape supported by excelize:
 //
 //	accentBorderCallout1 (Callout 1 with Border and Accent Shape)
@@ -313,15 +512,25 @@ func (f *File) AddShape(sheet, cell string, opts *Shape) error {
 		f.addSheetDrawing(sheet, rID)
 		f.addSheetNameSpace(sheet, SourceRelationship)
 	}
-	if err = f.addDrawingShape(sheet, drawingXML, cell, options); err != nil {
+	// Add shape with hyperlink.
+	var hyperlinkRID int
+	if options.Hyperlink != "" {
+		var hyperlinkType string
+		if options.HyperlinkType == "External" {
+			hyperlinkType = options.HyperlinkType
+		}
+		drawingRels := "xl/drawings/_rels/drawing" + strconv.Itoa(drawingID) + ".xml.rels"
+		hyperlinkRID = f.addRels(drawingRels, SourceRelationshipHyperLink, options.Hyperlink, hyperlinkType)
+	}
+	if err = f.addDrawingShape(sheet, drawingXML, cell, hyperlinkRID, options); err != nil {
 		return err
 	}
 	return f.addContentTypePart(drawingID, "drawings")
 }
 
 // addDrawingShape provides a function to add preset geometry by given sheet,
-// drawingXMLand format sets.
-func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) error {
+// drawingXML, hyperlink relationship ID and format sets.
+func (f *File) addDrawingShape(sheet, drawingXML, cell string, hyperlinkRID int, opts *Shape) error {
 	fromCol, fromRow, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return err
@@ -368,6 +577,7 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 			},
 		},
 		SpPr: &xlsxSpPr{
+			Xfrm: xlsxXfrm{Rot: angleToRotation(opts.Rotation)},
 			PrstGeom: xlsxPrstGeom{
 				Prst: opts.Type,
 			},
@@ -389,13 +599,37 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 				HorzOverflow: "clip",
 				Wrap:         "none",
 				RtlCol:       false,
-				Anchor:       "t",
+				Anchor:       shapeTextVerticalAlignments[opts.TextVAlign],
 			},
 		},
 	}
-	if *opts.Line.Width != 1 {
-		shape.SpPr.Ln = xlsxLineProperties{
-			W: f.ptToEMUs(*opts.Line.Width),
+	if hyperlinkRID != 0 {
+		shape.NvSpPr.CNvPr.HlinkClick = &xlsxHlinkClick{
+			R:       SourceRelationship.Value,
+			RID:     "rId" + strconv.Itoa(hyperlinkRID),
+			Tooltip: opts.Tooltip,
+		}
+	}
+	if solidColor != "" && opts.FillFormat.Transparency > 0 {
+		shape.SpPr.SolidFill = newShapeSolidFill(solidColor, opts.FillFormat.Transparency)
+	}
+	if len(opts.Fill.Color) > 1 && opts.Fill.Type == "gradient" {
+		shape.SpPr.GradFill = newShapeGradFill(&opts.Fill, &opts.FillFormat)
+	}
+	shape.SpPr.Ln = f.newShapeLineProperties(&opts.Line)
+	if opts.Shadow != nil {
+		shape.SpPr.EffectLst = &aEffectLst{
+			OuterShdw: &aOuterShdw{
+				BlurRad:      int(opts.Shadow.Blur * 12700),
+				Dist:         int(opts.Shadow.Distance * 12700),
+				Dir:          angleToRotation(opts.Shadow.Direction),
+				Algn:         "tl",
+				RotWithShape: "0",
+				SrgbClr: aSrgbClrAlpha{
+					Val:   strings.ReplaceAll(strings.ToUpper(opts.Shadow.Color), "#", ""),
+					Alpha: &attrValInt{Val: intPtr((100 - opts.Shadow.Transparency) * 1000)},
+				},
+			},
 		}
 	}
 	defaultFont, err := f.GetDefaultFont()
@@ -447,6 +681,9 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 				Lang: "en-US",
 			},
 		}
+		if opts.TextAlign != "" {
+			paragraph.PPr = &aPPr{Algn: shapeTextAlignments[opts.TextAlign]}
+		}
 		srgbClr := strings.ReplaceAll(strings.ToUpper(font.Color), "#", "")
 		if len(srgbClr) == 6 {
 			paragraph.R.RPr.SolidFill = &aSolidFill{
@@ -467,6 +704,392 @@ func (f *File) addDrawingShape(sheet, drawingXML, cell string, opts *Shape) erro
 	return err
 }
 
+// parseConnectorOptions provides a function to parse the format settings of
+// the connector with default value.
+func parseConnectorOptions(opts *Connector) (*Connector, error) {
+	if opts == nil {
+		return nil, ErrParameterInvalid
+	}
+	if opts.Type == "" {
+		opts.Type = "straightConnector1"
+	}
+	if inStrSlice(supportedConnectorTypes, opts.Type, true) == -1 {
+		return opts, ErrParameterInvalid
+	}
+	if opts.StartShapeID < 0 || opts.EndShapeID < 0 || opts.StartSite < 0 || opts.EndSite < 0 {
+		return opts, ErrParameterInvalid
+	}
+	if opts.Format.PrintObject == nil {
+		opts.Format.PrintObject = boolPtr(true)
+	}
+	if opts.Format.Locked == nil {
+		opts.Format.Locked = boolPtr(false)
+	}
+	if opts.Line.Width == nil {
+		opts.Line.Width = float64Ptr(defaultShapeLineWidth)
+	}
+	if opts.Line.TailType == "" {
+		opts.Line.TailType = opts.EndArrow
+	}
+	return opts, parseShapeLineOptions(&opts.Line)
+}
+
+// AddConnector provides the method to add a connector shape which stretches
+// between two cells in a sheet by given worksheet name and connector format
+// set. The From and To specify the cells at the top left corner of which the
+// connector starts and ends. The connector could be bound to the existing
+// shapes by specifying the StartShapeID and EndShapeID with the connection
+// site index StartSite and EndSite of the shapes. For example, add a bent
+// connector with an arrow from cell B2 to F10 in Sheet1:
+//
+//	err := f.AddConnector("Sheet1", &excelize.Connector{
+//	    Type: "bentConnector3",
+//	    From: "B2",
+//	    To:   "F10",
+//	    Line: excelize.ShapeLine{Color: "4286F4", TailType: "triangle"},
+//	})
+//
+// The following shows the type of connector supported by excelize:
+//
+//	straightConnector1 (Straight Arrow Connector) - Default
+//	bentConnector2 (Elbow Connector with 2 segments)
+//	bentConnector3 (Elbow Connector)
+//	bentConnector4 (Elbow Connector with 4 segments)
+//	bentConnector5 (Elbow Connector with 5 segments)
+//	curvedConnector2 (Curved Connector with 2 segments)
+//	curvedConnector3 (Curved Connector)
+//	curvedConnector4 (Curved Connector with 4 segments)
+//	curvedConnector5 (Curved Connector with 5 segments)
+//
+// The arrowheads at the start and the end of the connector are specified by
+// the HeadType and TailType of the Line, the same as the line of the shape.
+// The deprecated EndArrow is the same as the TailType of the Line, and it will
+// be ignored if the TailType was specified.
+func (f *File) AddConnector(sheet string, opts *Connector) error {
+	options, err := parseConnectorOptions(opts)
+	if err != nil {
+		return err
+	}
+	fromCol, fromRow, err := CellNameToCoordinates(options.From)
+	if err != nil {
+		return err
+	}
+	toCol, toRow, err := CellNameToCoordinates(options.To)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	// Add first connector for given sheet, create xl/drawings/ and xl/drawings/_rels/ folder.
+	drawingID := f.countDrawings() + 1
+	drawingXML := "xl/drawings/drawing" + strconv.Itoa(drawingID) + ".xml"
+	drawingID, drawingXML = f.prepareDrawing(ws, drawingID, sheet, drawingXML)
+	f.addSheetNameSpace(sheet, SourceRelationship)
+	if err = f.addDrawingConnector(drawingXML, fromCol-1, fromRow-1, toCol-1, toRow-1, options); err != nil {
+		return err
+	}
+	return f.addContentTypePart(drawingID, "drawings")
+}
+
+// addDrawingConnector provides a function to add connector shape by given
+// drawingXML, zero-based coordinates of the start and end cells and format
+// sets.
+func (f *File) addDrawingConnector(drawingXML string, fromCol, fromRow, toCol, toRow int, opts *Connector) error {
+	content, cNvPrID, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	xfrm := xlsxXfrm{FlipH: fromCol > toCol, FlipV: fromRow > toRow}
+	if xfrm.FlipH {
+		fromCol, toCol = toCol, fromCol
+	}
+	if xfrm.FlipV {
+		fromRow, toRow = toRow, fromRow
+	}
+	twoCellAnchor := xdrCellAnchor{
+		EditAs: opts.Format.Positioning,
+		From:   &xlsxFrom{Col: fromCol, Row: fromRow},
+		To:     &xlsxTo{Col: toCol, Row: toRow},
+	}
+	cxnSp := xdrCxnSp{
+		Macro: opts.Macro,
+		NvCxnSpPr: &xdrNvCxnSpPr{
+			CNvPr: &xlsxCNvPr{
+				ID:   cNvPrID,
+				Name: "Connector " + strconv.Itoa(cNvPrID),
+			},
+			CNvCxnSpPr: &xdrCNvCxnSpPr{},
+		},
+		SpPr: &xlsxSpPr{
+			Xfrm: xfrm,
+			PrstGeom: xlsxPrstGeom{
+				Prst: opts.Type,
+			},
+		},
+		Style: &xdrStyle{
+			LnRef:     setShapeRef(opts.Line.Color, 1),
+			FillRef:   setShapeRef("", 0),
+			EffectRef: setShapeRef("", 0),
+			FontRef: &aFontRef{
+				Idx: "minor",
+				SchemeClr: &attrValString{
+					Val: stringPtr("tx1"),
+				},
+			},
+		},
+	}
+	if opts.StartShapeID > 0 {
+		cxnSp.NvCxnSpPr.CNvCxnSpPr.StCxn = &aCxn{ID: opts.StartShapeID, Idx: opts.StartSite}
+	}
+	if opts.EndShapeID > 0 {
+		cxnSp.NvCxnSpPr.CNvCxnSpPr.EndCxn = &aCxn{ID: opts.EndShapeID, Idx: opts.EndSite}
+	}
+	cxnSp.SpPr.Ln = f.newShapeLineProperties(&opts.Line)
+	twoCellAnchor.CxnSp = &cxnSp
+	twoCellAnchor.ClientData = &xdrClientData{
+		FLocksWithSheet:  *opts.Format.Locked,
+		FPrintsWithSheet: *opts.Format.PrintObject,
+	}
+	content.TwoCellAnchor = append(content.TwoCellAnchor, &twoCellAnchor)
+	f.Drawings.Store(drawingXML, content)
+	return err
+}
+
+// GetShapes provides a function to get all shapes in a worksheet by given
+// worksheet name, the pictures and charts in the worksheet will be ignored.
+// For example, get all shapes in Sheet1:
+//
+//	shapes, err := f.GetShapes("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, shape := range shapes {
+//	    fmt.Println(shape.Cell, shape.Type, shape.Width, shape.Height)
+//	}
+func (f *File) GetShapes(sheet string) ([]Shape, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	f.mu.Unlock()
+	var shapes []Shape
+	if ws.Drawing == nil {
+		return shapes, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.mu.Lock()
+	anchors := make([]*xdrCellAnchor, 0, len(wsDr.OneCellAnchor)+len(wsDr.TwoCellAnchor))
+	anchors = append(append(anchors, wsDr.OneCellAnchor...), wsDr.TwoCellAnchor...)
+	wsDr.mu.Unlock()
+	for _, anchor := range anchors {
+		content := "<xdrCellAnchor>" + anchor.GraphicFrame + "</xdrCellAnchor>"
+		if anchor.Sp != nil {
+			output, _ := xml.Marshal(anchor)
+			content = string(output)
+		}
+		deAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader(content)).
+			Decode(deAnchor); err != nil && err != io.EOF {
+			return nil, err
+		}
+		if err = nil; deAnchor.Sp == nil {
+			continue
+		}
+		deAnchor.EditAs = anchor.EditAs
+		shapes = append(shapes, f.newShape(sheet, deAnchor))
+	}
+	return shapes, err
+}
+
+// DeleteShape provides a function to delete all shapes anchored at the given
+// cell in a worksheet by given worksheet name and cell reference, the charts
+// and pictures anchored at the same cell will be kept. An error will be
+// returned if no shape anchored at the cell. The drawing part of the
+// worksheet will be removed when the last drawing element was deleted. For
+// example, delete the shape in Sheet1!$G$6:
+//
+//	err := f.DeleteShape("Sheet1", "G6")
+func (f *File) DeleteShape(sheet, cell string) error {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return err
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	if ws.Drawing == nil {
+		return newNoExistShapeError(sheet, cell)
+	}
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID), "..", "xl"), "/")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return err
+	}
+	count := len(wsDr.TwoCellAnchor)
+	if err = f.deleteDrawing(col-1, row-1, drawingXML, "Shape"); err != nil {
+		return err
+	}
+	if len(wsDr.TwoCellAnchor) == count {
+		return newNoExistShapeError(sheet, cell)
+	}
+	return f.deleteEmptyDrawing(sheet, drawingXML, wsDr)
+}
+
+// newShape provides a function to create the format settings of the shape by
+// given worksheet name and decoded cell anchor of the shape.
+func (f *File) newShape(sheet string, anchor *decodeCellAnchor) Shape {
+	sp := anchor.Sp
+	shape := Shape{
+		Macro: sp.Macro,
+		Format: GraphicOptions{
+			Positioning: anchor.EditAs,
+			ScaleX:      defaultPictureScale,
+			ScaleY:      defaultPictureScale,
+		},
+	}
+	if anchor.ClientData != nil {
+		shape.Format.Locked = anchor.ClientData.FLocksWithSheet
+		shape.Format.PrintObject = anchor.ClientData.FPrintsWithSheet
+	}
+	if anchor.From != nil {
+		shape.Cell, _ = CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+		shape.Format.OffsetX, shape.Format.OffsetY = anchor.From.ColOff/EMU, anchor.From.RowOff/EMU
+	}
+	if sp.SpPr != nil {
+		shape.Type = sp.SpPr.PrstGeom.Prst
+		shape.Rotation = float64(sp.SpPr.Xfrm.Rot) / 60000
+		shape.Width, shape.Height = uint(sp.SpPr.Xfrm.Ext.Cx/EMU), uint(sp.SpPr.Xfrm.Ext.Cy/EMU)
+		if sp.SpPr.SolidFill != nil && sp.SpPr.SolidFill.SrgbClr != nil && sp.SpPr.SolidFill.SrgbClr.Val != nil {
+			shape.Fill.Color = []string{*sp.SpPr.SolidFill.SrgbClr.Val}
+			shape.FillFormat.Transparency = getShapeTransparency(sp.SpPr.SolidFill.SrgbClr)
+		}
+		if gradFill := sp.SpPr.GradFill; gradFill != nil {
+			shape.Fill.Type = "gradient"
+			for _, gs := range gradFill.Gs {
+				if gs.SrgbClr != nil && gs.SrgbClr.Val != nil {
+					shape.Fill.Color = append(shape.Fill.Color, *gs.SrgbClr.Val)
+					shape.FillFormat.Transparency = getShapeTransparency(gs.SrgbClr)
+				}
+			}
+			if gradFill.Lin != nil {
+				shape.FillFormat.Angle = float64Ptr(float64(gradFill.Lin.Ang) / 60000)
+			}
+		}
+		if ln := sp.SpPr.Ln; ln != nil {
+			if ln.W > 0 {
+				shape.Line.Width = float64Ptr(float64(ln.W) / 12700)
+			}
+			if ln.SolidFill != nil && ln.SolidFill.SrgbClr != nil && ln.SolidFill.SrgbClr.Val != nil {
+				shape.Line.Color = *ln.SolidFill.SrgbClr.Val
+				shape.Line.Transparency = getShapeTransparency(ln.SolidFill.SrgbClr)
+			}
+			if ln.PrstDash != nil && ln.PrstDash.Val != nil {
+				shape.Line.DashType = *ln.PrstDash.Val
+			}
+			if ln.HeadEnd != nil {
+				shape.Line.HeadType, shape.Line.HeadSize = ln.HeadEnd.Type, getMapKeyByValue(lineEndSizes, ln.HeadEnd.W)
+			}
+			if ln.TailEnd != nil {
+				shape.Line.TailType, shape.Line.TailSize = ln.TailEnd.Type, getMapKeyByValue(lineEndSizes, ln.TailEnd.W)
+			}
+		}
+	}
+	if shape.Width == 0 && shape.Height == 0 {
+		if anchor.Ext != nil {
+			shape.Width, shape.Height = uint(anchor.Ext.Cx/EMU), uint(anchor.Ext.Cy/EMU)
+		} else if anchor.From != nil && anchor.To != nil {
+			width, height := (anchor.To.ColOff-anchor.From.ColOff)/EMU, (anchor.To.RowOff-anchor.From.RowOff)/EMU
+			for col := anchor.From.Col; col < anchor.To.Col; col++ {
+				width += f.getColWidth(sheet, col+1)
+			}
+			for row := anchor.From.Row; row < anchor.To.Row; row++ {
+				height += f.getRowHeight(sheet, row+1)
+			}
+			shape.Width, shape.Height = uint(math.Max(float64(width), 0)), uint(math.Max(float64(height), 0))
+		}
+	}
+	if sp.Style != nil {
+		if ref := sp.Style.FillRef; ref != nil && ref.SrgbClr != nil && ref.SrgbClr.Val != nil && len(shape.Fill.Color) == 0 {
+			shape.Fill.Color = []string{*ref.SrgbClr.Val}
+		}
+		if ref := sp.Style.LnRef; ref != nil && ref.SrgbClr != nil && ref.SrgbClr.Val != nil && shape.Line.Color == "" {
+			shape.Line.Color = *ref.SrgbClr.Val
+		}
+	}
+	if sp.TxBody != nil {
+		if sp.TxBody.BodyPr != nil {
+			shape.TextVAlign = getMapKeyByValue(shapeTextVerticalAlignments, sp.TxBody.BodyPr.Anchor)
+		}
+		for _, p := range sp.TxBody.P {
+			if p.PPr != nil && shape.TextAlign == "" {
+				shape.TextAlign = getMapKeyByValue(shapeTextAlignments, p.PPr.Algn)
+			}
+			for _, r := range p.R {
+				run := RichTextRun{Text: r.T}
+				if r.RPr != nil {
+					run.Font = &Font{Bold: r.RPr.B, Italic: r.RPr.I, Size: r.RPr.Sz / 100}
+					if r.RPr.U != "none" {
+						run.Font.Underline = r.RPr.U
+					}
+					if r.RPr.Latin != nil {
+						run.Font.Family = r.RPr.Latin.Typeface
+					}
+					if fill := r.RPr.SolidFill; fill != nil && fill.SrgbClr != nil && fill.SrgbClr.Val != nil {
+						run.Font.Color = *fill.SrgbClr.Val
+					}
+				}
+				shape.Paragraph = append(shape.Paragraph, run)
+			}
+		}
+	}
+	return shape
+}
+
+// newShapeGradFill provides a function to create the linear gradient fill of
+// the shape by given fill settings, the gradient stops are evenly spaced.
+func newShapeGradFill(fill *Fill, format *ShapeFill) *aGradFill {
+	angle := float64(defaultShapeGradientAngle)
+	if format.Angle != nil {
+		angle = *format.Angle
+	}
+	gradFill := &aGradFill{
+		RotWithShape: "1",
+		Lin:          &aLin{Ang: angleToRotation(angle), Scaled: true},
+	}
+	for i, color := range fill.Color {
+		gs := aGs{
+			Pos:     i * 100000 / (len(fill.Color) - 1),
+			SrgbClr: aSrgbClrAlpha{Val: strings.ReplaceAll(strings.ToUpper(color), "#", "")},
+		}
+		if format.Transparency > 0 {
+			gs.SrgbClr.Alpha = &attrValInt{Val: intPtr((100 - format.Transparency) * 1000)}
+		}
+		gradFill.GsLst = append(gradFill.GsLst, gs)
+	}
+	return gradFill
+}
+
+// angleToRotation provides a function to convert the rotation angle in
+// degrees to the rotation in 60,000ths of a degree, the result will be
+// normalized in range [0, 21600000).
+func angleToRotation(angle float64) int {
+	if angle = math.Mod(angle, 360); angle < 0 {
+		angle += 360
+	}
+	return int(math.Round(angle*60000)) % 21600000
+}
+
 // setShapeRef provides a function to set color with hex model by given actual
 // color value.
 func setShapeRef(color string, i int) *aRef {
@@ -2,6 +2,7 @@ package excelize
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -85,7 +86,7 @@ func TestAddDrawingShape(t *testing.T) {
 	f := NewFile()
 	path := "xl/drawings/drawing1.xml"
 	f.Pkg.Store(path, MacintoshCyrillicCharset)
-	assert.EqualError(t, f.addDrawingShape("sheet1", path, "A1",
+	assert.EqualError(t, f.addDrawingShape("sheet1", path, "A1", 0,
 		&Shape{
 			Width:  defaultShapeSize,
 			Height: defaultShapeSize,
@@ -96,3 +97,400 @@ func TestAddDrawingShape(t *testing.T) {
 		},
 	), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestAddShapeRotation(t *testing.T) {
+	f := NewFile()
+	for cell, rotation := range map[string]float64{"A1": 45, "A10": 315, "A20": -45, "A30": 405} {
+		assert.NoError(t, f.AddShape("Sheet1", cell, &Shape{Type: "rect", Rotation: rotation}))
+	}
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	rotations := map[string]int{}
+	for _, anchor := range drawing.(*xlsxWsDr).TwoCellAnchor {
+		cell, err := CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+		assert.NoError(t, err)
+		rotations[cell] = anchor.Sp.SpPr.Xfrm.Rot
+	}
+	assert.Equal(t, map[string]int{"A1": 2700000, "A10": 18900000, "A20": 18900000, "A30": 2700000}, rotations)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeRotation.xlsx")))
+	// Test normalize rotation angle
+	for angle, expected := range map[float64]int{0: 0, 360: 0, -360: 0, 720.5: 30000, 359.999999: 0} {
+		assert.Equal(t, expected, angleToRotation(angle))
+	}
+}
+
+func TestAddShapeShadow(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "rect", Shadow: &ShapeShadow{Color: "#4286f4"}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Shadow: &ShapeShadow{Blur: 2, Distance: 5, Direction: 90, Transparency: 100}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	anchors := drawing.(*xlsxWsDr).TwoCellAnchor
+	assert.Nil(t, anchors[0].Sp.SpPr.EffectLst)
+	assert.Equal(t, &aEffectLst{OuterShdw: &aOuterShdw{
+		BlurRad: 50800, Dist: 38100, Dir: 2700000, Algn: "tl", RotWithShape: "0",
+		SrgbClr: aSrgbClrAlpha{Val: "4286F4", Alpha: &attrValInt{Val: intPtr(40000)}},
+	}}, anchors[1].Sp.SpPr.EffectLst)
+	assert.Equal(t, &aEffectLst{OuterShdw: &aOuterShdw{
+		BlurRad: 25400, Dist: 63500, Dir: 5400000, Algn: "tl", RotWithShape: "0",
+		SrgbClr: aSrgbClrAlpha{Val: "000000", Alpha: &attrValInt{Val: intPtr(0)}},
+	}}, anchors[2].Sp.SpPr.EffectLst)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeShadow.xlsx")))
+	// Test the effect list in the drawing XML
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 2, strings.Count(string(drawingXML.([]byte)), "<a:effectLst>"))
+	assert.Contains(t, string(drawingXML.([]byte)), `<a:effectLst><a:outerShdw blurRad="50800" dist="38100" dir="2700000" algn="tl" rotWithShape="0"><a:srgbClr val="4286F4"><a:alpha val="40000"></a:alpha></a:srgbClr></a:outerShdw></a:effectLst>`)
+	// Test add shape with invalid shadow transparency
+	for _, transparency := range []int{-1, 101} {
+		assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A30", &Shape{Type: "rect", Shadow: &ShapeShadow{Transparency: transparency}}))
+	}
+}
+
+func TestGetShapes(t *testing.T) {
+	f := NewFile()
+	lineWidth := 1.2
+	assert.NoError(t, f.AddShape("Sheet1", "B2", &Shape{
+		Type:   "rect",
+		Line:   ShapeLine{Color: "4286F4", Width: &lineWidth},
+		Fill:   Fill{Color: []string{"8EB9FF"}, Pattern: 1},
+		Width:  180,
+		Height: 40,
+		Paragraph: []RichTextRun{{
+			Text: "Rectangle Shape",
+			Font: &Font{Bold: true, Italic: true, Family: "Times New Roman", Size: 18, Color: "777777", Underline: "sng"},
+		}},
+	}))
+	assert.NoError(t, f.AddShape("Sheet1", "H10", &Shape{Type: "ellipse", Rotation: 90, Format: GraphicOptions{OffsetX: 10, OffsetY: 5}}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A20", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "J20", &Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}}))
+	check := func(shapes []Shape) {
+		assert.Len(t, shapes, 2)
+		assert.Equal(t, "B2", shapes[0].Cell)
+		assert.Equal(t, "rect", shapes[0].Type)
+		assert.Equal(t, []uint{180, 40}, []uint{shapes[0].Width, shapes[0].Height})
+		assert.Equal(t, []string{"8EB9FF"}, shapes[0].Fill.Color)
+		assert.Equal(t, "4286F4", shapes[0].Line.Color)
+		assert.Equal(t, 1.2, *shapes[0].Line.Width)
+		assert.Equal(t, []RichTextRun{{
+			Text: "Rectangle Shape",
+			Font: &Font{Bold: true, Italic: true, Family: "Times New Roman", Size: 18, Color: "777777", Underline: "sng"},
+		}}, shapes[0].Paragraph)
+		assert.True(t, *shapes[0].Format.PrintObject)
+		assert.False(t, *shapes[0].Format.Locked)
+		assert.Equal(t, "H10", shapes[1].Cell)
+		assert.Equal(t, "ellipse", shapes[1].Type)
+		assert.Equal(t, []uint{160, 160}, []uint{shapes[1].Width, shapes[1].Height})
+		assert.Equal(t, 90.0, shapes[1].Rotation)
+		assert.Equal(t, []int{10, 5}, []int{shapes[1].Format.OffsetX, shapes[1].Format.OffsetY})
+		assert.Empty(t, shapes[1].Fill.Color)
+		assert.Nil(t, shapes[1].Line.Width)
+	}
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	check(shapes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetShapes.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestGetShapes.xlsx"))
+	assert.NoError(t, err)
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	check(shapes)
+	// Test get shapes without drawing part
+	f.NewSheet("Sheet2")
+	shapes, err = f.GetShapes("Sheet2")
+	assert.NoError(t, err)
+	assert.Empty(t, shapes)
+	// Test get shapes with invalid sheet name
+	_, err = f.GetShapes("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	// Test get shapes with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetShapes("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test get shapes with invalid shape in the drawing part
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{TwoCellAnchor: []*xdrCellAnchor{{GraphicFrame: "<xdr:sp>"}}})
+	_, err = f.GetShapes("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <sp> closed by </xdrCellAnchor>")
+	assert.NoError(t, f.Close())
+	// Test get shapes from one cell anchor
+	f = NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect"}))
+	f.Drawings.Store("xl/drawings/drawing1.xml", &xlsxWsDr{OneCellAnchor: []*xdrCellAnchor{{
+		GraphicFrame: `<xdr:from><xdr:col>1</xdr:col><xdr:row>2</xdr:row></xdr:from><xdr:ext cx="952500" cy="476250"/><xdr:sp><xdr:spPr><a:prstGeom prst="triangle"/><a:solidFill><a:srgbClr val="FF0000"/></a:solidFill><a:ln><a:solidFill><a:srgbClr val="00FF00"/></a:solidFill></a:ln></xdr:spPr></xdr:sp><xdr:clientData/>`,
+	}}})
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	assert.Equal(t, "B3", shapes[0].Cell)
+	assert.Equal(t, "triangle", shapes[0].Type)
+	assert.Equal(t, []uint{100, 50}, []uint{shapes[0].Width, shapes[0].Height})
+	assert.Equal(t, []string{"FF0000"}, shapes[0].Fill.Color)
+	assert.Equal(t, "00FF00", shapes[0].Line.Color)
+	assert.Nil(t, shapes[0].Format.Locked)
+}
+
+func TestDeleteShape(t *testing.T) {
+	f := NewFile()
+	// Test delete shape without drawing part
+	assert.EqualError(t, f.DeleteShape("Sheet1", "A1"), newNoExistShapeError("Sheet1", "A1").Error())
+	// Test delete the only shape in the worksheet
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect"}))
+	assert.NoError(t, f.DeleteShape("Sheet1", "A1"))
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, shapes)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).Drawing)
+	_, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteShape1.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test delete shapes with multiple drawing elements
+	f = NewFile()
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect"}))
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "ellipse"}))
+	assert.NoError(t, f.AddShape("Sheet1", "D10", &Shape{Type: "rect"}))
+	assert.NoError(t, f.AddPicture("Sheet1", "A1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddChart("Sheet1", "D10", &Chart{Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$1", Values: "Sheet1!$B$1:$D$1"}}}))
+	assert.NoError(t, f.AddShape("Sheet2", "A1", &Shape{Type: "rect"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteShape2.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestDeleteShape2.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.DeleteShape("Sheet1", "A1"))
+	assert.EqualError(t, f.DeleteShape("Sheet1", "A1"), newNoExistShapeError("Sheet1", "A1").Error())
+	assert.EqualError(t, f.DeleteShape("Sheet1", "B2"), newNoExistShapeError("Sheet1", "B2").Error())
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	assert.Equal(t, "D10", shapes[0].Cell)
+	pics, err := f.GetPictures("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	// Test delete the shape anchored at the same cell with the chart
+	assert.NoError(t, f.DeleteShape("Sheet1", "D10"))
+	wsDr, _, err := f.drawingParser("xl/drawings/drawing1.xml")
+	assert.NoError(t, err)
+	assert.Len(t, wsDr.TwoCellAnchor, 2)
+	// Test delete the shape in the other worksheet and add a new drawing part
+	assert.NoError(t, f.DeleteShape("Sheet2", "A1"))
+	_, ok = f.Pkg.Load("xl/drawings/drawing2.xml")
+	assert.False(t, ok)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.AddShape("Sheet3", "A1", &Shape{Type: "rect"}))
+	shapes, err = f.GetShapes("Sheet3")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteShape3.xlsx")))
+	// Test delete shape with invalid sheet name
+	assert.EqualError(t, f.DeleteShape("Sheet:1", "A1"), ErrSheetNameInvalid.Error())
+	// Test delete shape with invalid cell reference
+	assert.EqualError(t, f.DeleteShape("Sheet1", "A"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test delete shape with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteShape("Sheet1", "A1"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeGradientFill(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect", Fill: Fill{Type: "gradient", Color: []string{"#FFFFFF", "5b9bd5"}}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "rect", Fill: Fill{Type: "gradient", Color: []string{"FF0000", "00FF00", "0000FF"}}, FillFormat: ShapeFill{Angle: float64Ptr(45)}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Fill: Fill{Type: "gradient", Color: []string{"FF0000"}}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A30", &Shape{Type: "rect", Fill: Fill{Type: "gradient", Color: []string{"FF0000", "0000FF"}}, FillFormat: ShapeFill{Angle: float64Ptr(0)}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeGradientFill.xlsx")))
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	content := string(drawingXML.([]byte))
+	assert.Contains(t, content, `<a:gradFill rotWithShape="1"><a:gsLst><a:gs pos="0"><a:srgbClr val="FFFFFF"></a:srgbClr></a:gs><a:gs pos="100000"><a:srgbClr val="5B9BD5"></a:srgbClr></a:gs></a:gsLst><a:lin ang="5400000" scaled="true"></a:lin></a:gradFill>`)
+	assert.Contains(t, content, `<a:gradFill rotWithShape="1"><a:gsLst><a:gs pos="0"><a:srgbClr val="FF0000"></a:srgbClr></a:gs><a:gs pos="50000"><a:srgbClr val="00FF00"></a:srgbClr></a:gs><a:gs pos="100000"><a:srgbClr val="0000FF"></a:srgbClr></a:gs></a:gsLst><a:lin ang="2700000" scaled="true"></a:lin></a:gradFill>`)
+	assert.Contains(t, content, `<a:lin ang="0" scaled="true"></a:lin>`)
+	assert.Equal(t, 3, strings.Count(content, "<a:gradFill"))
+	// Test get shapes with gradient fill
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 4)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"FFFFFF", "5B9BD5"}}, shapes[0].Fill)
+	assert.Equal(t, ShapeFill{Angle: float64Ptr(90)}, shapes[0].FillFormat)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"FF0000", "00FF00", "0000FF"}}, shapes[1].Fill)
+	assert.Equal(t, ShapeFill{Angle: float64Ptr(45)}, shapes[1].FillFormat)
+	assert.Equal(t, []string{"FF0000"}, shapes[2].Fill.Color)
+	assert.Empty(t, shapes[2].Fill.Type)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"FF0000", "0000FF"}}, shapes[3].Fill)
+	assert.Equal(t, ShapeFill{Angle: float64Ptr(0)}, shapes[3].FillFormat)
+	// Test add shape with gradient fill and transparency
+	assert.NoError(t, f.AddShape("Sheet1", "A40", &Shape{Type: "rect", Fill: Fill{Type: "gradient", Color: []string{"FF0000", "0000FF"}}, FillFormat: ShapeFill{Transparency: 40}}))
+	shapes, err = f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 5)
+	assert.Equal(t, Fill{Type: "gradient", Color: []string{"FF0000", "0000FF"}}, shapes[4].Fill)
+	assert.Equal(t, ShapeFill{Angle: float64Ptr(90), Transparency: 40}, shapes[4].FillFormat)
+}
+
+func TestAddConnector(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect"}))
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{From: "B2", To: "F10", StartShapeID: 2, EndShapeID: 2, EndSite: 3, EndArrow: "triangle"}))
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{Type: "bentConnector3", From: "H2", To: "D10", Line: ShapeLine{Color: "4286F4", Width: float64Ptr(2)}}))
+	drawing, ok := f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	wsDr := drawing.(*xlsxWsDr)
+	assert.Len(t, wsDr.TwoCellAnchor, 3)
+	anchor := wsDr.TwoCellAnchor[1]
+	assert.Equal(t, &xlsxFrom{Col: 1, Row: 1}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 5, Row: 9}, anchor.To)
+	assert.Equal(t, "straightConnector1", anchor.CxnSp.SpPr.PrstGeom.Prst)
+	assert.Equal(t, &aCxn{ID: 2}, anchor.CxnSp.NvCxnSpPr.CNvCxnSpPr.StCxn)
+	assert.Equal(t, &aCxn{ID: 2, Idx: 3}, anchor.CxnSp.NvCxnSpPr.CNvCxnSpPr.EndCxn)
+	assert.Equal(t, &aLineEnd{Type: "triangle"}, anchor.CxnSp.SpPr.Ln.TailEnd)
+	// Test the tail type of the line takes precedence over the end arrow
+	assert.NoError(t, f.AddConnector("Sheet1", &Connector{From: "B12", To: "F20", EndArrow: "triangle", Line: ShapeLine{HeadType: "oval", TailType: "stealth", TailSize: "large"}}))
+	drawing, ok = f.Drawings.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	ln := drawing.(*xlsxWsDr).TwoCellAnchor[3].CxnSp.SpPr.Ln
+	assert.Equal(t, &aLineEnd{Type: "oval"}, ln.HeadEnd)
+	assert.Equal(t, &aLineEnd{Type: "stealth", W: "lg", Len: "lg"}, ln.TailEnd)
+	// Test add connector in reverse direction
+	anchor = wsDr.TwoCellAnchor[2]
+	assert.Equal(t, &xlsxFrom{Col: 3, Row: 1}, anchor.From)
+	assert.Equal(t, &xlsxTo{Col: 7, Row: 9}, anchor.To)
+	assert.True(t, anchor.CxnSp.SpPr.Xfrm.FlipH)
+	assert.False(t, anchor.CxnSp.SpPr.Xfrm.FlipV)
+	assert.Nil(t, anchor.CxnSp.NvCxnSpPr.CNvCxnSpPr.StCxn)
+	assert.Equal(t, 25400, anchor.CxnSp.SpPr.Ln.W)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddConnector.xlsx")))
+	// Test the connectors will be ignored on getting shapes
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 1)
+	// Test add connector with invalid options
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", nil))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{Type: "unknown", From: "A1", To: "B2"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B2", EndArrow: "unknown"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B2", StartShapeID: -1}))
+	// Test add connector with invalid cell reference
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "A", To: "B2"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B"}), newCellNameToCoordinatesError("B", newInvalidCellNameError("B")).Error())
+	// Test add connector on not exists worksheet
+	assert.EqualError(t, f.AddConnector("SheetN", &Connector{From: "A1", To: "B2"}), "sheet SheetN does not exist")
+	// Test add connector with unsupported charset drawing part
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B2"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeHyperlink(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect", Hyperlink: "https://github.com/xuri/excelize", HyperlinkType: "External", Tooltip: "Excelize"}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "rect", Hyperlink: "#Sheet2!A1", HyperlinkType: "Location"}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeHyperlink.xlsx")))
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	content := string(drawingXML.([]byte))
+	assert.Contains(t, content, `<a:hlinkClick xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId1" tooltip="Excelize"></a:hlinkClick>`)
+	assert.Contains(t, content, `<a:hlinkClick xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId2"></a:hlinkClick>`)
+	assert.NotContains(t, content, "action=")
+	drawingRels, ok := f.Pkg.Load("xl/drawings/_rels/drawing1.xml.rels")
+	assert.True(t, ok)
+	rels := string(drawingRels.([]byte))
+	assert.Contains(t, rels, `<Relationship Id="rId1" Target="https://github.com/xuri/excelize" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" TargetMode="External"></Relationship>`)
+	assert.Contains(t, rels, `<Relationship Id="rId2" Target="#Sheet2!A1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"></Relationship>`)
+	// Test add shape with invalid hyperlink type
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Hyperlink: "https://github.com/xuri/excelize"}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeTextAlignment(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "wedgeRectCallout", TextVAlign: "center", TextAlign: "justify", Paragraph: []RichTextRun{{Text: "Justified"}, {Text: "Paragraph"}}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "rect", Paragraph: []RichTextRun{{Text: "Default"}}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeTextAlignment.xlsx")))
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	content := string(drawingXML.([]byte))
+	assert.Contains(t, content, `anchor="ctr"`)
+	assert.Contains(t, content, `anchor="t"`)
+	assert.Contains(t, content, `<a:pPr algn="just"></a:pPr>`)
+	assert.NotContains(t, content, "<a:defRPr")
+	assert.Equal(t, 2, strings.Count(content, `<a:pPr algn="just"></a:pPr>`))
+	assert.Equal(t, 2, strings.Count(content, "<a:pPr"))
+	// Test get shapes with text alignment
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, "center", shapes[0].TextVAlign)
+	assert.Equal(t, "justify", shapes[0].TextAlign)
+	assert.Equal(t, "top", shapes[1].TextVAlign)
+	assert.Empty(t, shapes[1].TextAlign)
+	// Test add shape with invalid text alignment
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", TextVAlign: "middle"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", TextAlign: "distributed"}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeLineStyle(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "line", Line: ShapeLine{Color: "FF0000", DashType: "dashDot", TailType: "triangle"}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "line", Line: ShapeLine{Width: float64Ptr(2), HeadType: "oval", HeadSize: "small", TailType: "arrow", TailSize: "large"}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Line: ShapeLine{Width: float64Ptr(2)}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeLineStyle.xlsx")))
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	content := string(drawingXML.([]byte))
+	assert.Contains(t, content, `<a:ln><a:prstDash val="dashDot"></a:prstDash><a:tailEnd type="triangle"></a:tailEnd></a:ln>`)
+	assert.Contains(t, content, `<a:ln w="25400"><a:headEnd type="oval" w="sm" len="sm"></a:headEnd><a:tailEnd type="arrow" w="lg" len="lg"></a:tailEnd></a:ln>`)
+	assert.Contains(t, content, `<a:ln w="25400"></a:ln>`)
+	// Test get shapes with line style
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 3)
+	assert.Equal(t, "dashDot", shapes[0].Line.DashType)
+	assert.Equal(t, "triangle", shapes[0].Line.TailType)
+	assert.Equal(t, "oval", shapes[1].Line.HeadType)
+	assert.Equal(t, "small", shapes[1].Line.HeadSize)
+	assert.Equal(t, "large", shapes[1].Line.TailSize)
+	assert.Empty(t, shapes[2].Line.DashType)
+	// Test add shape with invalid line style
+	for _, line := range []ShapeLine{{DashType: "unknown"}, {HeadType: "unknown"}, {TailType: "unknown"}, {TailType: "arrow", TailSize: "huge"}} {
+		assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A30", &Shape{Type: "line", Line: line}))
+	}
+	assert.Equal(t, ErrParameterInvalid, f.AddConnector("Sheet1", &Connector{From: "A1", To: "B2", Line: ShapeLine{DashType: "unknown"}}))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddShapeTransparency(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.AddShape("Sheet1", "A1", &Shape{Type: "rect", Fill: Fill{Color: []string{"#4286F4"}}, FillFormat: ShapeFill{Transparency: 60}}))
+	assert.NoError(t, f.AddShape("Sheet1", "A10", &Shape{Type: "rect", Line: ShapeLine{Color: "FF0000", Transparency: 25}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddShapeTransparency.xlsx")))
+	drawingXML, ok := f.Pkg.Load("xl/drawings/drawing1.xml")
+	assert.True(t, ok)
+	content := string(drawingXML.([]byte))
+	assert.Contains(t, content, `</a:prstGeom><a:solidFill><a:srgbClr val="4286F4"><a:alpha val="40000"></a:alpha></a:srgbClr></a:solidFill><a:ln></a:ln>`)
+	assert.Contains(t, content, `<a:ln><a:solidFill><a:srgbClr val="FF0000"><a:alpha val="75000"></a:alpha></a:srgbClr></a:solidFill></a:ln>`)
+	assert.Equal(t, 2, strings.Count(content, "<a:alpha"))
+	// Test get shapes with transparency
+	shapes, err := f.GetShapes("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, shapes, 2)
+	assert.Equal(t, 60, shapes[0].FillFormat.Transparency)
+	assert.Equal(t, 0, shapes[0].Line.Transparency)
+	assert.Equal(t, 0, shapes[1].FillFormat.Transparency)
+	assert.Equal(t, 25, shapes[1].Line.Transparency)
+	// Test add shape with invalid transparency
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Fill: Fill{Color: []string{"4286F4"}}, FillFormat: ShapeFill{Transparency: 101}}))
+	assert.Equal(t, ErrParameterInvalid, f.AddShape("Sheet1", "A20", &Shape{Type: "rect", Line: ShapeLine{Transparency: -1}}))
+	assert.NoError(t, f.Close())
+}
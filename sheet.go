@@ -161,9 +161,13 @@ func (f *File) workSheetWriter() {
 				}
 			}
 			sheet.DecodeAlternateContent = nil
+			unknownElements := sheet.UnknownElements
+			sheet.UnknownElements = nil
 			// reusing buffer
 			_ = encoder.Encode(sheet)
-			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
+			sheet.UnknownElements = unknownElements
+			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string),
+				f.insertUnknownElements(p.(string), buffer.Bytes(), unknownElements, sheet))))
 			ok := f.checked[p.(string)]
 			if ok {
 				f.Sheet.Delete(p.(string))
@@ -1001,10 +1005,15 @@ func (f *File) SearchSheet(sheet, value string, reg ...bool) ([]string, error) {
 	if !ok {
 		return result, ErrSheetNotExist{sheet}
 	}
-	if ws, ok := f.Sheet.Load(name); ok && ws != nil {
+	if worksheet, ok := f.Sheet.Load(name); ok && worksheet != nil {
+		ws := worksheet.(*xlsxWorksheet)
+		ws.mu.Lock()
 		// Flush data
-		output, _ := xml.Marshal(ws.(*xlsxWorksheet))
+		output, _ := xml.Marshal(ws)
+		ws.mu.Unlock()
+		f.mu.Lock()
 		f.saveFileList(name, f.replaceNameSpaceBytes(name, output))
+		f.mu.Unlock()
 	}
 	return f.searchSheet(name, value, regSearch)
 }
@@ -1370,7 +1379,13 @@ func checkSheetName(name string) error {
 	return nil
 }
 
-// SetPageLayout provides a function to sets worksheet page layout.
+// SetPageLayout provides a function to sets worksheet page layout. The print
+// scaling AdjustTo must be between 10 and 400 percent, and setting it will
+// turn off the "Fit to Page" option of the worksheet properties, the same as
+// Excel choosing "Adjust to" in the page setup dialog. If FitToPage has been
+// enabled by SetSheetProps, the FitToHeight and FitToWidth take effect and
+// the scaling will be ignored. Use the Horizontally and Vertically fields of
+// the SetPageMargins function to center the sheet on the printed page.
 //
 // The following shows the paper size sorted by excelize index number:
 //
@@ -1500,8 +1515,7 @@ func (f *File) SetPageLayout(sheet string, opts *PageLayoutOptions) error {
 	if opts == nil {
 		return err
 	}
-	ws.setPageSetUp(opts)
-	return err
+	return ws.setPageSetUp(opts)
 }
 
 // newPageSetUp initialize page setup settings for the worksheet if which not
@@ -1513,7 +1527,10 @@ func (ws *xlsxWorksheet) newPageSetUp() {
 }
 
 // setPageSetUp set page setup settings for the worksheet by given options.
-func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
+func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) error {
+	if opts.AdjustTo != nil && (*opts.AdjustTo < 10 || 400 < *opts.AdjustTo) {
+		return ErrPageSetupAdjustTo
+	}
 	if opts.Size != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.PaperSize = opts.Size
@@ -1527,9 +1544,12 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.PageSetUp.FirstPageNumber = strconv.Itoa(int(*opts.FirstPageNumber))
 		ws.PageSetUp.UseFirstPageNumber = true
 	}
-	if opts.AdjustTo != nil && 10 <= *opts.AdjustTo && *opts.AdjustTo <= 400 {
+	if opts.AdjustTo != nil {
 		ws.newPageSetUp()
 		ws.PageSetUp.Scale = int(*opts.AdjustTo)
+		if ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+			ws.SheetPr.PageSetUpPr.FitToPage = false
+		}
 	}
 	if opts.FitToHeight != nil {
 		ws.newPageSetUp()
@@ -1543,6 +1563,7 @@ func (ws *xlsxWorksheet) setPageSetUp(opts *PageLayoutOptions) {
 		ws.newPageSetUp()
 		ws.PageSetUp.BlackAndWhite = *opts.BlackAndWhite
 	}
+	return nil
 }
 
 // GetPageLayout provides a function to gets worksheet page layout.
@@ -1582,8 +1603,11 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 }
 
 // SetDefinedName provides a function to set the defined names of the workbook
-// or worksheet. If not specified scope, the default scope is workbook.
-// For example:
+// or worksheet. If not specified scope, the default scope is workbook. Set
+// the scope as a worksheet name to create a local name of the worksheet, the
+// same name can be defined once in the workbook scope and once in each
+// worksheet scope. Set the Hidden field to hide the name from the name manager,
+// this is typically used for names referenced by macros. For example:
 //
 //	err := f.SetDefinedName(&excelize.DefinedName{
 //	    Name:     "Amount",
@@ -1591,8 +1615,21 @@ func (f *File) GetPageLayout(sheet string) (PageLayoutOptions, error) {
 //	    Comment:  "defined name comment",
 //	    Scope:    "Sheet2",
 //	})
+//
+// The RefersTo can also be a constant or a formula, the leading equal sign is
+// optional. For example, define a named constant and a named formula:
+//
+//	err := f.SetDefinedName(&excelize.DefinedName{
+//	    Name:     "Rate",
+//	    RefersTo: "3.14",
+//	})
+//	err = f.SetDefinedName(&excelize.DefinedName{
+//	    Name:     "StartDate",
+//	    RefersTo: "TODAY()-30",
+//	})
 func (f *File) SetDefinedName(definedName *DefinedName) error {
-	if definedName.Name == "" || definedName.RefersTo == "" {
+	refersTo := strings.TrimPrefix(definedName.RefersTo, "=")
+	if definedName.Name == "" || refersTo == "" {
 		return ErrParameterInvalid
 	}
 	if err := checkDefinedName(definedName.Name); err != nil {
@@ -1605,20 +1642,24 @@ func (f *File) SetDefinedName(definedName *DefinedName) error {
 	d := xlsxDefinedName{
 		Name:    definedName.Name,
 		Comment: definedName.Comment,
-		Data:    definedName.RefersTo,
+		Hidden:  definedName.Hidden,
+		Data:    refersTo,
 	}
-	if definedName.Scope != "" {
-		if sheetIndex, _ := f.GetSheetIndex(definedName.Scope); sheetIndex >= 0 {
-			d.LocalSheetID = &sheetIndex
+	if definedName.Scope != "" && definedName.Scope != "Workbook" {
+		sheetIndex, err := f.GetSheetIndex(definedName.Scope)
+		if err != nil {
+			return err
 		}
+		if sheetIndex == -1 {
+			return newNoExistSheetError(definedName.Scope)
+		}
+		d.LocalSheetID = &sheetIndex
 	}
 	if wb.DefinedNames != nil {
 		for _, dn := range wb.DefinedNames.DefinedName {
-			var scope string
-			if dn.LocalSheetID != nil {
-				scope = f.GetSheetName(*dn.LocalSheetID)
-			}
-			if scope == definedName.Scope && dn.Name == definedName.Name {
+			sameScope := dn.LocalSheetID == nil && d.LocalSheetID == nil ||
+				dn.LocalSheetID != nil && d.LocalSheetID != nil && *dn.LocalSheetID == *d.LocalSheetID
+			if sameScope && strings.EqualFold(dn.Name, definedName.Name) {
 				return ErrDefinedNameDuplicate
 			}
 		}
@@ -1664,7 +1705,8 @@ func (f *File) DeleteDefinedName(definedName *DefinedName) error {
 }
 
 // GetDefinedName provides a function to get the defined names of the workbook
-// or worksheet.
+// or worksheet. The Scope field of the returned names is "Workbook" or the
+// worksheet name of the local name.
 func (f *File) GetDefinedName() []DefinedName {
 	var definedNames []DefinedName
 	wb, _ := f.workbookReader()
@@ -1675,6 +1717,7 @@ func (f *File) GetDefinedName() []DefinedName {
 				Comment:  dn.Comment,
 				RefersTo: dn.Data,
 				Scope:    "Workbook",
+				Hidden:   dn.Hidden,
 			}
 			if dn.LocalSheetID != nil && *dn.LocalSheetID >= 0 {
 				definedName.Scope = f.GetSheetName(*dn.LocalSheetID)
@@ -1712,12 +1755,14 @@ func (f *File) GroupSheets(sheets []string) error {
 		wss = append(wss, worksheet)
 	}
 	for _, ws := range wss {
-		sheetViews := ws.SheetViews.SheetView
-		if len(sheetViews) > 0 {
-			for idx := range sheetViews {
-				ws.SheetViews.SheetView[idx].TabSelected = true
-			}
-			continue
+		if ws.SheetViews == nil {
+			ws.SheetViews = &xlsxSheetViews{}
+		}
+		if len(ws.SheetViews.SheetView) == 0 {
+			ws.SheetViews.SheetView = append(ws.SheetViews.SheetView, xlsxSheetView{})
+		}
+		for idx := range ws.SheetViews.SheetView {
+			ws.SheetViews.SheetView[idx].TabSelected = true
 		}
 	}
 	return nil
@@ -1730,17 +1775,44 @@ func (f *File) UngroupSheets() error {
 		if activeSheet == index {
 			continue
 		}
-		ws, _ := f.workSheetReader(sheet)
-		sheetViews := ws.SheetViews.SheetView
-		if len(sheetViews) > 0 {
-			for idx := range sheetViews {
-				ws.SheetViews.SheetView[idx].TabSelected = false
-			}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil || ws.SheetViews == nil {
+			// Chartsheet, macrosheet or dialogsheet
+			continue
+		}
+		for idx := range ws.SheetViews.SheetView {
+			ws.SheetViews.SheetView[idx].TabSelected = false
 		}
 	}
 	return nil
 }
 
+// SetSheetsSelected provides a function to select multiple worksheets by
+// given worksheets name, the selected worksheets will be grouped for editing
+// when opening the workbook, and the first worksheet in the list will be set
+// as the active worksheet. The worksheets not in the list will be
+// deselected. It's a shortcut of setting the active worksheet, ungrouping
+// and then grouping the worksheets by GroupSheets. For example, select the
+// worksheets named Sheet1 and Sheet2, and set Sheet1 as active:
+//
+//	err := f.SetSheetsSelected([]string{"Sheet1", "Sheet2"})
+func (f *File) SetSheetsSelected(sheets []string) error {
+	if len(sheets) == 0 {
+		return ErrParameterRequired
+	}
+	for _, sheet := range sheets {
+		if _, err := f.workSheetReader(sheet); err != nil {
+			return err
+		}
+	}
+	index, _ := f.GetSheetIndex(sheets[0])
+	f.SetActiveSheet(index)
+	if err := f.UngroupSheets(); err != nil {
+		return err
+	}
+	return f.GroupSheets(sheets)
+}
+
 // InsertPageBreak create a page break to determine where the printed page
 // ends and where begins the next one by given worksheet name and cell
 // reference, so the content before the page break will be printed on one page
@@ -214,6 +214,19 @@ func TestSetPageLayout(t *testing.T) {
 	opts, err := f.GetPageLayout("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set page layout with adjust to value exceeds limit
+	for _, adjustTo := range []uint{0, 9, 401} {
+		assert.Equal(t, ErrPageSetupAdjustTo, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(adjustTo)}))
+	}
+	// Test set page layout with adjust to will disable fit to page
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{FitToPage: boolPtr(true)}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{AdjustTo: uintPtr(80)}))
+	props, err := f.GetSheetProps("Sheet1")
+	assert.NoError(t, err)
+	assert.False(t, *props.FitToPage)
+	opts, err = f.GetPageLayout("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(80), *opts.AdjustTo)
 	// Test set page layout on not exists worksheet
 	assert.EqualError(t, f.SetPageLayout("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page layout with invalid sheet name
@@ -299,6 +312,39 @@ func TestDefinedName(t *testing.T) {
 	assert.Exactly(t, "Sheet1!$A$2:$D$5", f.GetDefinedName()[0].RefersTo)
 	assert.Len(t, f.GetDefinedName(), 1)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDefinedName.xlsx")))
+	// Test set the same name in the workbook and worksheet scope
+	f = NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name: "Total", RefersTo: "Sheet1!$A$1", Scope: "Workbook",
+	}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{
+		Name: "Total", RefersTo: "Sheet2!$A$1", Scope: "Sheet2", Hidden: true,
+	}))
+	assert.Equal(t, []DefinedName{
+		{Name: "Total", RefersTo: "Sheet1!$A$1", Scope: "Workbook"},
+		{Name: "Total", RefersTo: "Sheet2!$A$1", Scope: "Sheet2", Hidden: true},
+	}, f.GetDefinedName())
+	wb, err := f.workbookReader()
+	assert.NoError(t, err)
+	assert.Nil(t, wb.DefinedNames.DefinedName[0].LocalSheetID)
+	assert.Equal(t, 1, *wb.DefinedNames.DefinedName[1].LocalSheetID)
+	// Test set duplicate name with different case or explicit scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "TOTAL", RefersTo: "Sheet1!$B$1",
+	}), ErrDefinedNameDuplicate.Error())
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "total", RefersTo: "Sheet1!$B$1", Scope: "sheet2",
+	}), ErrDefinedNameDuplicate.Error())
+	// Test set defined name on not exists worksheet scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "Total", RefersTo: "Sheet1!$B$1", Scope: "SheetN",
+	}), "sheet SheetN does not exist")
+	// Test set defined name with invalid worksheet scope
+	assert.EqualError(t, f.SetDefinedName(&DefinedName{
+		Name: "Total", RefersTo: "Sheet1!$B$1", Scope: "Sheet:1",
+	}), ErrSheetNameInvalid.Error())
 	// Test set defined name with unsupported charset workbook
 	f.WorkBook = nil
 	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
@@ -337,6 +383,47 @@ func TestUngroupSheets(t *testing.T) {
 	assert.NoError(t, f.UngroupSheets())
 }
 
+func TestSetSheetsSelected(t *testing.T) {
+	f := NewFile()
+	for _, sheet := range []string{"Sheet2", "Sheet3", "Sheet4"} {
+		_, err := f.NewSheet(sheet)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetSheetsSelected([]string{"Sheet3", "sheet2"}))
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	isSelected := func(sheet string) bool {
+		ws, err := f.workSheetReader(sheet)
+		assert.NoError(t, err)
+		return ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 && ws.SheetViews.SheetView[0].TabSelected
+	}
+	for sheet, expected := range map[string]bool{"Sheet1": false, "Sheet2": true, "Sheet3": true, "Sheet4": false} {
+		assert.Equal(t, expected, isSelected(sheet), sheet)
+	}
+	// Test select worksheets without sheet view
+	ws, err := f.workSheetReader("Sheet4")
+	assert.NoError(t, err)
+	ws.SheetViews = &xlsxSheetViews{}
+	assert.NoError(t, f.SetSheetsSelected([]string{"Sheet1", "Sheet4"}))
+	assert.Equal(t, 0, f.GetActiveSheetIndex())
+	for sheet, expected := range map[string]bool{"Sheet1": true, "Sheet2": false, "Sheet3": false, "Sheet4": true} {
+		assert.Equal(t, expected, isSelected(sheet), sheet)
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetSheetsSelected.xlsx")))
+	// Test select worksheets with empty list
+	assert.Equal(t, ErrParameterRequired, f.SetSheetsSelected(nil))
+	// Test select worksheets with not exists worksheet
+	assert.EqualError(t, f.SetSheetsSelected([]string{"Sheet1", "SheetN"}), "sheet SheetN does not exist")
+	// Test select worksheets with invalid sheet name
+	assert.EqualError(t, f.SetSheetsSelected([]string{"Sheet:1"}), ErrSheetNameInvalid.Error())
+	// Test select worksheets with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	assert.EqualError(t, f.SetSheetsSelected([]string{"Sheet2"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.SetSheetsSelected([]string{"Sheet1"}))
+	assert.NoError(t, f.Close())
+}
+
 func TestInsertPageBreak(t *testing.T) {
 	f := NewFile()
 	assert.NoError(t, f.InsertPageBreak("Sheet1", "A1"))
@@ -13,7 +13,11 @@ package excelize
 
 import "reflect"
 
-// SetPageMargins provides a function to set worksheet page margins.
+// SetPageMargins provides a function to set worksheet page margins, all the
+// margins are measured in inches. The header and footer margins specify the
+// distance from the edge of the page to the header and footer. The unset
+// margins will keep the current value, or use the Excel default value if the
+// worksheet has no page margins settings.
 func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
@@ -24,7 +28,9 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 	}
 	preparePageMargins := func(ws *xlsxWorksheet) {
 		if ws.PageMargins == nil {
-			ws.PageMargins = new(xlsxPageMargins)
+			ws.PageMargins = &xlsxPageMargins{
+				Left: 0.7, Right: 0.7, Top: 0.75, Bottom: 0.75, Header: 0.3, Footer: 0.3,
+			}
 		}
 	}
 	preparePrintOptions := func(ws *xlsxWorksheet) {
@@ -51,7 +57,9 @@ func (f *File) SetPageMargins(sheet string, opts *PageLayoutMarginsOptions) erro
 	return err
 }
 
-// GetPageMargins provides a function to get worksheet page margins.
+// GetPageMargins provides a function to get worksheet page margins in inches.
+// The Excel default margins will be returned if the worksheet has no page
+// margins settings.
 func (f *File) GetPageMargins(sheet string) (PageLayoutMarginsOptions, error) {
 	opts := PageLayoutMarginsOptions{
 		Bottom: float64Ptr(0.75),
@@ -226,3 +234,53 @@ func (f *File) GetSheetProps(sheet string) (SheetPropsOptions, error) {
 	}
 	return opts, err
 }
+
+// GetSheetOutline provides a function to get the outline level of all grouped
+// rows and columns, and the summary rows and columns direction of the outline
+// by given worksheet name. The rows and columns with outline level 0 will not
+// be included. This function is concurrency safe. For example, get the
+// outline settings of Sheet1:
+//
+//	outline, err := f.GetSheetOutline("Sheet1")
+func (f *File) GetSheetOutline(sheet string) (SheetOutline, error) {
+	outline := SheetOutline{
+		Rows:         make(map[int]uint8),
+		Cols:         make(map[string]uint8),
+		SummaryBelow: true,
+		SummaryRight: true,
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return outline, err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetPr != nil && ws.SheetPr.OutlinePr != nil {
+		if ws.SheetPr.OutlinePr.SummaryBelow != nil {
+			outline.SummaryBelow = *ws.SheetPr.OutlinePr.SummaryBelow
+		}
+		if ws.SheetPr.OutlinePr.SummaryRight != nil {
+			outline.SummaryRight = *ws.SheetPr.OutlinePr.SummaryRight
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		if row.OutlineLevel > 0 {
+			outline.Rows[row.R] = row.OutlineLevel
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.OutlineLevel == 0 {
+				continue
+			}
+			for colNum := col.Min; colNum <= col.Max && colNum <= MaxColumns; colNum++ {
+				colName, _ := ColumnNumberToName(colNum)
+				outline.Cols[colName] = col.OutlineLevel
+			}
+		}
+	}
+	return outline, err
+}
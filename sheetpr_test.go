@@ -1,6 +1,7 @@
 package excelize
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,26 @@ func TestSetPageMargins(t *testing.T) {
 	opts, err := f.GetPageMargins("Sheet1")
 	assert.NoError(t, err)
 	assert.Equal(t, expected, opts)
+	// Test set partial page margins on the worksheet without page margins
+	ws.(*xlsxWorksheet).PageMargins = nil
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Left: float64Ptr(0.3937), Footer: float64Ptr(0.19685)}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetPageMargins.xlsx")))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(filepath.Join("test", "TestSetPageMargins.xlsx"))
+	assert.NoError(t, err)
+	opts, err = f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PageLayoutMarginsOptions{
+		Bottom:       float64Ptr(0.75),
+		Footer:       float64Ptr(0.19685),
+		Header:       float64Ptr(0.3),
+		Left:         float64Ptr(0.3937),
+		Right:        float64Ptr(0.7),
+		Top:          float64Ptr(0.75),
+		Horizontally: boolPtr(true),
+		Vertically:   boolPtr(true),
+	}, opts)
+	assert.NoError(t, f.Close())
 	// Test set page margins on not exists worksheet
 	assert.EqualError(t, f.SetPageMargins("SheetN", nil), "sheet SheetN does not exist")
 	// Test set page margins with invalid sheet name
@@ -100,3 +121,44 @@ func TestGetSheetProps(t *testing.T) {
 	_, err = f.GetSheetProps("Sheet:1")
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
+
+func TestGetSheetOutline(t *testing.T) {
+	f := NewFile()
+	outline, err := f.GetSheetOutline("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetOutline{Rows: map[int]uint8{}, Cols: map[string]uint8{}, SummaryBelow: true, SummaryRight: true}, outline)
+
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 2, 1))
+	assert.NoError(t, f.SetRowOutlineLevel("Sheet1", 3, 2))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "B", 1))
+	assert.NoError(t, f.SetColOutlineLevel("Sheet1", "D", 3))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryBelow: boolPtr(false)}))
+	outline, err = f.GetSheetOutline("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, SheetOutline{
+		Rows:         map[int]uint8{2: 1, 3: 2},
+		Cols:         map[string]uint8{"B": 1, "D": 3},
+		SummaryBelow: false,
+		SummaryRight: true,
+	}, outline)
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{OutlineSummaryBelow: boolPtr(true), OutlineSummaryRight: boolPtr(false)}))
+	outline, err = f.GetSheetOutline("Sheet1")
+	assert.NoError(t, err)
+	assert.True(t, outline.SummaryBelow)
+	assert.False(t, outline.SummaryRight)
+
+	// Test get outline settings of the columns range
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).Cols = &xlsxCols{Col: []xlsxCol{{Min: 2, Max: 4, OutlineLevel: 2}, {Min: 6, Max: 6}}}
+	outline, err = f.GetSheetOutline("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]uint8{"B": 2, "C": 2, "D": 2}, outline.Cols)
+
+	// Test get outline settings on not exists worksheet
+	_, err = f.GetSheetOutline("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get outline settings with invalid sheet name
+	_, err = f.GetSheetOutline("Sheet:1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+}
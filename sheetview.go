@@ -11,6 +11,12 @@
 
 package excelize
 
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
 // getSheetView returns the SheetView object
 func (f *File) getSheetView(sheet string, viewIndex int) (*xlsxSheetView, error) {
 	ws, err := f.workSheetReader(sheet)
@@ -127,3 +133,82 @@ func (f *File) GetSheetView(sheet string, viewIndex int) (ViewOptions, error) {
 	}
 	return opts, err
 }
+
+// SetGridlineColor provides a function to set the color of the gridlines in
+// all views of the worksheet by given worksheet name and color in hex model,
+// such as "#FF0000". The gridline color will be stored as the nearest color
+// in the default indexed color palette, and an empty color will reset the
+// gridlines to the default color. Use the ShowGridLines field of the
+// SetSheetView function to show or hide the gridlines. For example, set the
+// gridlines color of Sheet1 to red:
+//
+//	err := f.SetGridlineColor("Sheet1", "#FF0000")
+func (f *File) SetGridlineColor(sheet, color string) error {
+	colorID, err := getIndexedColorID(color)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	if ws.SheetViews == nil {
+		ws.SheetViews = &xlsxSheetViews{
+			SheetView: []xlsxSheetView{{WorkbookViewID: 0}},
+		}
+	}
+	for idx := range ws.SheetViews.SheetView {
+		view := &ws.SheetViews.SheetView[idx]
+		view.DefaultGridColor, view.ColorID = nil, 0
+		if colorID != -1 {
+			view.DefaultGridColor, view.ColorID = boolPtr(false), colorID
+		}
+	}
+	return err
+}
+
+// GetGridlineColor provides a function to get the color of the gridlines in
+// the first view of the worksheet by given worksheet name. The color will be
+// returned in hex model, and an empty string will be returned if the
+// gridlines use the default color.
+func (f *File) GetGridlineColor(sheet string) (string, error) {
+	view, err := f.getSheetView(sheet, 0)
+	if err != nil || view.DefaultGridColor == nil || *view.DefaultGridColor {
+		return "", err
+	}
+	if view.ColorID < 0 || view.ColorID >= len(IndexedColorMapping) {
+		return "", err
+	}
+	return "#" + IndexedColorMapping[view.ColorID], err
+}
+
+// getIndexedColorID provides a function to get the index of the nearest color
+// in the default indexed color palette by given color in hex model, the
+// redundant colors at index 0-7 and the system colors at index 64-65 will be
+// skipped. The -1 will be returned if the color is empty.
+func getIndexedColorID(color string) (int, error) {
+	if color = strings.TrimPrefix(color, "#"); color == "" {
+		return -1, nil
+	}
+	rgb, err := strconv.ParseUint(color, 16, 32)
+	if err != nil || len(color) != 6 {
+		return -1, ErrParameterInvalid
+	}
+	colorID, distance := -1, uint64(math.MaxUint64)
+	for idx := 8; idx < 64; idx++ {
+		val, _ := strconv.ParseUint(IndexedColorMapping[idx], 16, 32)
+		var dist uint64
+		for shift := 0; shift <= 16; shift += 8 {
+			d := int64(rgb>>shift&0xFF) - int64(val>>shift&0xFF)
+			dist += uint64(d * d)
+		}
+		if dist < distance {
+			colorID, distance = idx, dist
+		}
+	}
+	return colorID, err
+}
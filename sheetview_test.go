@@ -48,3 +48,56 @@ func TestGetView(t *testing.T) {
 	_, err = f.GetSheetView("SheetN", 0)
 	assert.EqualError(t, err, "sheet SheetN does not exist")
 }
+
+func TestSetGridlineColor(t *testing.T) {
+	f := NewFile()
+	// Test set gridline color with the color in the indexed color palette
+	assert.NoError(t, f.SetGridlineColor("Sheet1", "#FF0000"))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	view := ws.(*xlsxWorksheet).SheetViews.SheetView[0]
+	assert.False(t, *view.DefaultGridColor)
+	assert.Equal(t, 10, view.ColorID)
+	color, err := f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#FF0000", color)
+	// Test set gridline color with the nearest color in the palette
+	assert.NoError(t, f.SetGridlineColor("Sheet1", "C8C8C8"))
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "#C0C0C0", color)
+	// Test reset gridline color to default
+	assert.NoError(t, f.SetGridlineColor("Sheet1", ""))
+	view = ws.(*xlsxWorksheet).SheetViews.SheetView[0]
+	assert.Nil(t, view.DefaultGridColor)
+	assert.Zero(t, view.ColorID)
+	color, err = f.GetGridlineColor("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+	// Test set gridline color with invalid color
+	for _, color := range []string{"#FF00", "GGGGGG", "#FF000000"} {
+		assert.Equal(t, ErrParameterInvalid, f.SetGridlineColor("Sheet1", color))
+	}
+	// Test set and get gridline color on not exists worksheet
+	assert.EqualError(t, f.SetGridlineColor("SheetN", "#FF0000"), "sheet SheetN does not exist")
+	_, err = f.GetGridlineColor("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+}
+
+func TestSetSheetViewShowFormulas(t *testing.T) {
+	f := NewFile()
+	opts, err := f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.False(t, *opts.ShowFormulas)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetViews = nil
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{ShowFormulas: boolPtr(true)}))
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.True(t, *opts.ShowFormulas)
+	assert.NoError(t, f.SetSheetView("Sheet1", 0, &ViewOptions{ShowFormulas: boolPtr(false)}))
+	opts, err = f.GetSheetView("Sheet1", 0)
+	assert.NoError(t, err)
+	assert.False(t, *opts.ShowFormulas)
+}
@@ -18,6 +18,7 @@ import (
 	"io"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -36,6 +37,14 @@ type StreamWriter struct {
 	mergeCellsCount int
 	mergeCells      strings.Builder
 	tableParts      string
+	appendRows      []xlsxRow
+}
+
+// StreamWriterOptions defines the options for the stream writer. Append
+// specifies if open the stream writer at the next empty row of the worksheet
+// and preserve the existing rows, merged cells and columns settings of it.
+type StreamWriterOptions struct {
+	Append bool
 }
 
 // NewStreamWriter return stream writer struct by given worksheet name for
@@ -110,7 +119,12 @@ type StreamWriter struct {
 //	err := sw.SetRow("A1", []interface{}{
 //	    excelize.Cell{Value: 1}},
 //	    excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
-func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+//
+// Append rows to the worksheet which already has data with stream writer, the
+// row number of the new rows must be greater than the last existing row:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamWriterOptions{Append: true})
+func (f *File) NewStreamWriter(sheet string, opts ...StreamWriterOptions) (*StreamWriter, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -128,6 +142,9 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	if err != nil {
 		return nil, err
 	}
+	if len(opts) > 0 && opts[len(opts)-1].Append {
+		sw.prepareAppend()
+	}
 
 	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	if f.streams == nil {
@@ -140,6 +157,33 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 	return sw, err
 }
 
+// prepareAppend provides a function to retain the existing rows, merged cells
+// and columns settings of the worksheet for the stream writer, the rows will
+// be written before the new rows.
+func (sw *StreamWriter) prepareAppend() {
+	sw.appendRows = trimRow(&sw.worksheet.SheetData)
+	if len(sw.appendRows) > 0 {
+		sw.rows = sw.appendRows[len(sw.appendRows)-1].R
+	}
+	if sw.worksheet.Cols != nil {
+		enc := xml.NewEncoder(&sw.cols)
+		for _, col := range sw.worksheet.Cols.Col {
+			_ = enc.EncodeElement(col, xml.StartElement{Name: xml.Name{Local: "col"}})
+		}
+	}
+	if sw.worksheet.MergeCells != nil {
+		for _, mergeCell := range sw.worksheet.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			sw.mergeCellsCount++
+			_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+			_, _ = sw.mergeCells.WriteString(mergeCell.Ref)
+			_, _ = sw.mergeCells.WriteString(`"/>`)
+		}
+	}
+}
+
 // AddTable creates an Excel table for the StreamWriter using the given
 // cell range and format set. For example, create a table of A1:D5:
 //
@@ -237,7 +281,12 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
 
-	sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	if sw.worksheet.TableParts != nil {
+		sw.worksheet.TableParts.Count++
+		sw.worksheet.TableParts.TableParts = append(sw.worksheet.TableParts.TableParts, &xlsxTablePart{RID: "rId" + strconv.Itoa(rID)})
+	} else {
+		sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	}
 
 	if err = sw.file.addContentTypePart(tableID, "table"); err != nil {
 		return err
@@ -329,6 +378,17 @@ type RowOpts struct {
 	OutlineLevel int
 }
 
+// MapWriteOptions defines the options for writing records by the
+// SetRowsFromMaps function. Columns specifies the keys of the records and the
+// order of the columns, StartCell specifies the top left cell of the header
+// row, default is "A1", and HeaderStyleID specifies the style of the header
+// row.
+type MapWriteOptions struct {
+	Columns       []string
+	StartCell     string
+	HeaderStyleID int
+}
+
 // marshalAttrs prepare attributes of the row.
 func (r *RowOpts) marshalAttrs() (strings.Builder, error) {
 	var (
@@ -431,6 +491,69 @@ func (sw *StreamWriter) SetRow(cell string, values []interface{}, opts ...RowOpt
 	return sw.rawData.Sync()
 }
 
+// SetRowsFromMaps provides a function to write a header row and the records
+// below it on a worksheet by given worksheet name, records and options, the
+// data will be written by the stream writer, so the existing rows of the
+// worksheet will be replaced. The value of each record will be set like the
+// SetCellValue function does, and the missing keys in the records will leave
+// blank cells. If the Columns of the options is empty, the columns will be
+// derived from the keys of all records in ascending order. For example, write
+// records with explicit column order on Sheet1:
+//
+//	err := f.SetRowsFromMaps("Sheet1", []map[string]interface{}{
+//	    {"Name": "Alice", "Score": 90.5},
+//	    {"Name": "Bob", "Score": 60, "Passed": false},
+//	}, excelize.MapWriteOptions{Columns: []string{"Name", "Score", "Passed"}})
+func (f *File) SetRowsFromMaps(sheet string, records []map[string]interface{}, opts MapWriteOptions) error {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		keys := map[string]struct{}{}
+		for _, record := range records {
+			for key := range record {
+				if _, ok := keys[key]; !ok {
+					keys[key] = struct{}{}
+					columns = append(columns, key)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+	if opts.StartCell == "" {
+		opts.StartCell = "A1"
+	}
+	col, row, err := CellNameToCoordinates(opts.StartCell)
+	if err != nil {
+		return err
+	}
+	if col+len(columns)-1 > MaxColumns {
+		return ErrColumnNumber
+	}
+	if row+len(records) > TotalRows {
+		return ErrMaxRows
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	for idx, column := range columns {
+		values[idx] = column
+	}
+	if err = sw.SetRow(opts.StartCell, values, RowOpts{StyleID: opts.HeaderStyleID}); err != nil {
+		return err
+	}
+	for rowIdx, record := range records {
+		for idx, column := range columns {
+			values[idx] = record[column]
+		}
+		cell, _ := CoordinatesToCellName(col, row+rowIdx+1)
+		if err = sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	return sw.Flush()
+}
+
 // SetColWidth provides a function to set the width of a single column or
 // multiple columns for the StreamWriter. Note that you must call
 // the 'SetColWidth' function before the 'SetRow' function. For example set
@@ -648,6 +771,11 @@ func (sw *StreamWriter) writeSheetData() {
 			_, _ = sw.rawData.WriteString("</cols>")
 		}
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
+		enc := xml.NewEncoder(&sw.rawData)
+		for _, row := range sw.appendRows {
+			_ = enc.EncodeElement(row, xml.StartElement{Name: xml.Name{Local: "row"}})
+		}
+		sw.appendRows = nil
 		sw.sheetWritten = true
 	}
 }
@@ -273,6 +273,58 @@ func TestNewStreamWriter(t *testing.T) {
 	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
 }
 
+func TestStreamWriterAppend(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"A", "B", "C"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{1, "text", true}))
+	assert.NoError(t, f.MergeCell("Sheet1", "E1", "F2"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "B", "C", 20))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:C2"}))
+	buf, err := f.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenReader(buf)
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1", StreamWriterOptions{Append: true})
+	assert.NoError(t, err)
+	// Test append rows with the row number not beyond the existing rows
+	assert.EqualError(t, sw.SetRow("A2", []interface{}{2}), newStreamSetRowError(2).Error())
+	assert.NoError(t, sw.SetRow("A3", []interface{}{2, "new", false}))
+	assert.NoError(t, sw.SetRow("A5", []interface{}{3}))
+	assert.NoError(t, sw.MergeCell("E4", "F5"))
+	assert.NoError(t, sw.AddTable(&Table{Range: "H1:I2"}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A", "B", "C"}, {"1", "text", "TRUE"}, {"2", "new", "FALSE"}, nil, {"3"}}, rows)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 2)
+	assert.Equal(t, "E1:F2", mergeCells[0].GetStartAxis()+":"+mergeCells[0].GetEndAxis())
+	assert.Equal(t, "E4:F5", mergeCells[1].GetStartAxis()+":"+mergeCells[1].GetEndAxis())
+	width, err := f.GetColWidth("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ws.TableParts.Count)
+	assert.Len(t, ws.TableParts.TableParts, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamWriterAppend.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test append rows to the empty worksheet
+	f = NewFile()
+	sw, err = f.NewStreamWriter("Sheet1", StreamWriterOptions{Append: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{1}))
+	assert.NoError(t, sw.Flush())
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}}, rows)
+	assert.NoError(t, f.Close())
+}
+
 func TestStreamMarshalAttrs(t *testing.T) {
 	var r *RowOpts
 	attrs, err := r.marshalAttrs()
@@ -400,3 +452,37 @@ func TestStreamWriterOutlineLevel(t *testing.T) {
 	assert.Equal(t, uint8(0), level)
 	assert.NoError(t, file.Close())
 }
+
+func TestSetRowsFromMaps(t *testing.T) {
+	f := NewFile()
+	records := []map[string]interface{}{
+		{"Name": "Alice", "Score": 90.5, "Passed": true},
+		{"Name": "Bob", "Score": 60},
+	}
+	assert.NoError(t, f.SetRowsFromMaps("Sheet1", records, MapWriteOptions{}))
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Passed", "Score"}, {"Alice", "TRUE", "90.5"}, {"Bob", "", "60"}}, rows)
+	// Test set rows from maps with explicit columns and start cell
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetRowsFromMaps("Sheet1", records, MapWriteOptions{Columns: []string{"Score", "Name", "Rank"}, StartCell: "B2", HeaderStyleID: styleID}))
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{nil, {"", "Score", "Name", "Rank"}, {"", "90.5", "Alice"}, {"", "60", "Bob"}}, rows)
+	cellType, err := f.GetCellType("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeUnset, cellType)
+	style, err := f.GetCellStyle("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, style)
+	// Test set rows from maps with invalid options
+	assert.EqualError(t, f.SetRowsFromMaps("Sheet1", records, MapWriteOptions{StartCell: "A"}), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetRowsFromMaps("Sheet1", records, MapWriteOptions{StartCell: "XFD1"}), ErrColumnNumber.Error())
+	assert.EqualError(t, f.SetRowsFromMaps("Sheet1", records, MapWriteOptions{StartCell: "A1048575"}), ErrMaxRows.Error())
+	// Test set rows from maps on not exists worksheet
+	assert.EqualError(t, f.SetRowsFromMaps("SheetN", records, MapWriteOptions{}), "sheet SheetN does not exist")
+	// Test set rows from maps with unsupported value
+	assert.Equal(t, ErrCellCharsLength, f.SetRowsFromMaps("Sheet1", []map[string]interface{}{{"A": []RichTextRun{{Text: strings.Repeat("s", TotalCellChars+1)}}}}, MapWriteOptions{}))
+	assert.NoError(t, f.Close())
+}
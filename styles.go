@@ -21,6 +21,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/xuri/efp"
 )
 
 // validType defined the list of valid validation types.
@@ -33,8 +36,8 @@ var validType = map[string]string{
 	"unique":        "uniqueValues",
 	"top":           "top10",
 	"bottom":        "top10",
-	"text":          "text",              // Doesn't support currently
-	"time_period":   "timePeriod",        // Doesn't support currently
+	"text":          "text",
+	"time_period":   "timePeriod",
 	"blanks":        "containsBlanks",    // Doesn't support currently
 	"no_blanks":     "notContainsBlanks", // Doesn't support currently
 	"errors":        "containsErrors",    // Doesn't support currently
@@ -70,13 +73,16 @@ var criteriaType = map[string]string{
 	"ends with":                "endsWith",
 	"yesterday":                "yesterday",
 	"today":                    "today",
+	"tomorrow":                 "tomorrow",
 	"last 7 days":              "last7Days",
 	"last week":                "lastWeek",
 	"this week":                "thisWeek",
-	"continue week":            "continueWeek",
+	"next week":                "nextWeek",
+	"continue week":            "nextWeek",
 	"last month":               "lastMonth",
 	"this month":               "thisMonth",
-	"continue month":           "continueMonth",
+	"next month":               "nextMonth",
+	"continue month":           "nextMonth",
 }
 
 // operatorType defined the list of valid operator types.
@@ -87,6 +93,7 @@ var operatorType = map[string]string{
 	"greaterThan":        "greater than",
 	"lessThanOrEqual":    "less than or equal to",
 	"today":              "today",
+	"tomorrow":           "tomorrow",
 	"equal":              "equal to",
 	"notContains":        "not containing",
 	"thisWeek":           "this week",
@@ -98,12 +105,76 @@ var operatorType = map[string]string{
 	"thisMonth":          "this month",
 	"containsText":       "containing",
 	"lastWeek":           "last week",
-	"continueWeek":       "continue week",
-	"continueMonth":      "continue month",
+	"nextWeek":           "next week",
+	"nextMonth":          "next month",
 	"notBetween":         "not between",
 	"greaterThanOrEqual": "greater than or equal to",
 }
 
+// styleBorders list all types of the cell border style.
+var styleBorders = []string{
+	"none",
+	"thin",
+	"medium",
+	"dashed",
+	"dotted",
+	"thick",
+	"double",
+	"hair",
+	"mediumDashed",
+	"dashDot",
+	"mediumDashDot",
+	"dashDotDot",
+	"mediumDashDotDot",
+	"slantDashDot",
+}
+
+// styleFillPatterns list all types of the cell fill style.
+var styleFillPatterns = []string{
+	"none",
+	"solid",
+	"mediumGray",
+	"darkGray",
+	"lightGray",
+	"darkHorizontal",
+	"darkVertical",
+	"darkDown",
+	"darkUp",
+	"darkGrid",
+	"darkTrellis",
+	"lightHorizontal",
+	"lightVertical",
+	"lightDown",
+	"lightUp",
+	"lightGrid",
+	"lightTrellis",
+	"gray125",
+	"gray0625",
+}
+
+// styleFillVariants list all preset variants of the fill style.
+func styleFillVariants() []xlsxGradientFill {
+	return []xlsxGradientFill{
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
+		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
+		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
+	}
+}
+
 // stylesReader provides a function to get the pointer to the structure after
 // deserialization of xl/styles.xml.
 func (f *File) stylesReader() (*xlsxStyleSheet, error) {
@@ -1144,6 +1215,246 @@ func (f *File) NewConditionalStyle(style *Style) (int, error) {
 	return s.Dxfs.Count - 1, nil
 }
 
+// GetDxfs provides a function to get the differential formatting records of
+// the workbook, each record decoded into a style definition. The index of the
+// record in the returned slice is the format ID which referenced by the Format
+// field of the conditional format settings returned by GetConditionalFormats,
+// and the ID returned by the NewConditionalStyle function.
+func (f *File) GetDxfs() ([]Style, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var styles []Style
+	s, err := f.stylesReader()
+	if err != nil || s.Dxfs == nil {
+		return styles, err
+	}
+	for _, d := range s.Dxfs.Dxfs {
+		var record dxf
+		if d != nil {
+			if err = xml.Unmarshal([]byte("<dxf>"+d.Dxf+"</dxf>"), &record); err != nil {
+				return styles, err
+			}
+		}
+		styles = append(styles, extractDxf(&record))
+	}
+	return styles, err
+}
+
+// extractDxf provides a function to convert the differential formatting
+// record to the style definition.
+func extractDxf(d *dxf) Style {
+	var style Style
+	if d.Font != nil {
+		style.Font = extractFont(d.Font)
+	}
+	if d.NumFmt != nil {
+		extractNumFmt(&style, d.NumFmt)
+	}
+	if d.Fill != nil {
+		style.Fill = extractFill(d.Fill)
+	}
+	if d.Border != nil {
+		style.Border = extractBorders(d.Border)
+	}
+	extractAlignmentProtection(&style, d.Alignment, d.Protection)
+	return style
+}
+
+// extractNumFmt provides a function to set the built-in or custom number
+// format of the style definition by given number format element.
+func extractNumFmt(style *Style, numFmt *xlsxNumFmt) {
+	if _, ok := builtInNumFmt[numFmt.NumFmtID]; ok || numFmt.FormatCode == "" {
+		style.NumFmt = numFmt.NumFmtID
+		return
+	}
+	style.CustomNumFmt = stringPtr(numFmt.FormatCode)
+}
+
+// extractAlignmentProtection provides a function to set the alignment and
+// protection settings of the style definition by given alignment and
+// protection elements.
+func extractAlignmentProtection(style *Style, alignment *xlsxAlignment, protection *xlsxProtection) {
+	if alignment != nil {
+		style.Alignment = &Alignment{
+			Horizontal:      alignment.Horizontal,
+			Indent:          alignment.Indent,
+			JustifyLastLine: alignment.JustifyLastLine,
+			ReadingOrder:    alignment.ReadingOrder,
+			RelativeIndent:  alignment.RelativeIndent,
+			ShrinkToFit:     alignment.ShrinkToFit,
+			TextRotation:    alignment.TextRotation,
+			Vertical:        alignment.Vertical,
+			WrapText:        alignment.WrapText,
+		}
+	}
+	if protection != nil {
+		style.Protection = &Protection{}
+		if protection.Hidden != nil {
+			style.Protection.Hidden = *protection.Hidden
+		}
+		if protection.Locked != nil {
+			style.Protection.Locked = *protection.Locked
+		}
+	}
+}
+
+// extractStyle provides a function to convert the cell formatting record of
+// the given style index to the style definition.
+func extractStyle(s *xlsxStyleSheet, styleID int) *Style {
+	style := &Style{}
+	if s.CellXfs == nil || styleID < 0 || styleID >= len(s.CellXfs.Xf) {
+		return style
+	}
+	xf := s.CellXfs.Xf[styleID]
+	if xf.FontID != nil && s.Fonts != nil && *xf.FontID < len(s.Fonts.Font) {
+		style.Font = extractFont(s.Fonts.Font[*xf.FontID])
+	}
+	if xf.FillID != nil && s.Fills != nil && *xf.FillID < len(s.Fills.Fill) {
+		fill := *s.Fills.Fill[*xf.FillID]
+		// The foreground color is the fill color of the solid cell fill.
+		if fill.PatternFill != nil && fill.PatternFill.FgColor != nil {
+			patternFill := *fill.PatternFill
+			patternFill.BgColor = nil
+			fill.PatternFill = &patternFill
+		}
+		style.Fill = extractFill(&fill)
+	}
+	if xf.BorderID != nil && s.Borders != nil && *xf.BorderID < len(s.Borders.Border) {
+		style.Border = extractBorders(s.Borders.Border[*xf.BorderID])
+	}
+	if xf.NumFmtID != nil {
+		numFmt := &xlsxNumFmt{NumFmtID: *xf.NumFmtID}
+		if s.NumFmts != nil {
+			for _, nf := range s.NumFmts.NumFmt {
+				if nf.NumFmtID == *xf.NumFmtID {
+					numFmt = nf
+				}
+			}
+		}
+		extractNumFmt(style, numFmt)
+	}
+	extractAlignmentProtection(style, xf.Alignment, xf.Protection)
+	return style
+}
+
+// extractColorRGB returns the hex RGB color value without alpha channel of the
+// given color.
+func extractColorRGB(color *xlsxColor) string {
+	if color == nil {
+		return ""
+	}
+	if len(color.RGB) == 8 {
+		return color.RGB[2:]
+	}
+	return color.RGB
+}
+
+// extractFont provides a function to convert the font element to the font
+// settings.
+func extractFont(fnt *xlsxFont) *Font {
+	isSet := func(v *attrValBool) bool {
+		return v != nil && (v.Val == nil || *v.Val)
+	}
+	font := Font{
+		Bold:   isSet(fnt.B),
+		Italic: isSet(fnt.I),
+		Strike: isSet(fnt.Strike),
+	}
+	if fnt.U != nil {
+		font.Underline = "single"
+		if fnt.U.Val != nil {
+			font.Underline = *fnt.U.Val
+		}
+	}
+	if fnt.Name != nil && fnt.Name.Val != nil {
+		font.Family = *fnt.Name.Val
+	}
+	if fnt.Sz != nil && fnt.Sz.Val != nil {
+		font.Size = *fnt.Sz.Val
+	}
+	if fnt.Color != nil {
+		font.Color = extractColorRGB(fnt.Color)
+		font.ColorIndexed = fnt.Color.Indexed
+		font.ColorTheme = fnt.Color.Theme
+		font.ColorTint = fnt.Color.Tint
+	}
+	return &font
+}
+
+// extractFill provides a function to convert the fill element to the fill
+// settings.
+func extractFill(fill *xlsxFill) Fill {
+	var fl Fill
+	if fill.GradientFill != nil {
+		fl.Type = "gradient"
+		for shading, variant := range styleFillVariants() {
+			if variant.Bottom == fill.GradientFill.Bottom &&
+				variant.Degree == fill.GradientFill.Degree &&
+				variant.Left == fill.GradientFill.Left &&
+				variant.Right == fill.GradientFill.Right &&
+				variant.Top == fill.GradientFill.Top &&
+				variant.Type == fill.GradientFill.Type &&
+				len(variant.Stop) == len(fill.GradientFill.Stop) {
+				fl.Shading = shading
+				break
+			}
+		}
+		for i, stop := range fill.GradientFill.Stop {
+			if i < 2 {
+				fl.Color = append(fl.Color, extractColorRGB(&stop.Color))
+			}
+		}
+		return fl
+	}
+	if fill.PatternFill != nil {
+		fl.Type = "pattern"
+		fl.Pattern = inStrSlice(styleFillPatterns, fill.PatternFill.PatternType, false)
+		if fl.Pattern == -1 {
+			fl.Pattern = 0
+		}
+		color := fill.PatternFill.BgColor
+		if color == nil {
+			color = fill.PatternFill.FgColor
+		}
+		if color != nil {
+			fl.Color = []string{extractColorRGB(color)}
+		}
+	}
+	return fl
+}
+
+// extractBorders provides a function to convert the border element to the
+// borders settings.
+func extractBorders(border *xlsxBorder) []Border {
+	var borders []Border
+	for _, line := range []struct {
+		typ  string
+		line xlsxLine
+		ok   bool
+	}{
+		{"left", border.Left, true},
+		{"right", border.Right, true},
+		{"top", border.Top, true},
+		{"bottom", border.Bottom, true},
+		{"diagonalUp", border.Diagonal, border.DiagonalUp},
+		{"diagonalDown", border.Diagonal, border.DiagonalDown},
+	} {
+		if !line.ok || line.line.Style == "" {
+			continue
+		}
+		styleIdx := inStrSlice(styleBorders, line.line.Style, false)
+		if styleIdx == -1 {
+			continue
+		}
+		borders = append(borders, Border{
+			Type:  line.typ,
+			Color: extractColorRGB(line.line.Color),
+			Style: styleIdx,
+		})
+	}
+	return borders
+}
+
 // GetDefaultFont provides the default font name currently set in the
 // workbook. The spreadsheet generated by excelize default font is Calibri.
 func (f *File) GetDefaultFont() (string, error) {
@@ -1405,47 +1716,7 @@ func getFillID(styleSheet *xlsxStyleSheet, style *Style) (fillID int) {
 // newFills provides a function to add fill elements in the styles.xml by
 // given cell format settings.
 func newFills(style *Style, fg bool) *xlsxFill {
-	patterns := []string{
-		"none",
-		"solid",
-		"mediumGray",
-		"darkGray",
-		"lightGray",
-		"darkHorizontal",
-		"darkVertical",
-		"darkDown",
-		"darkUp",
-		"darkGrid",
-		"darkTrellis",
-		"lightHorizontal",
-		"lightVertical",
-		"lightDown",
-		"lightUp",
-		"lightGrid",
-		"lightTrellis",
-		"gray125",
-		"gray0625",
-	}
-	variants := []xlsxGradientFill{
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 270, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 90, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 180, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 255, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 45, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 315, Stop: []*xlsxGradientFillStop{{}, {Position: 1}}},
-		{Degree: 135, Stop: []*xlsxGradientFillStop{{}, {Position: 0.5}, {Position: 1}}},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path"},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Left: 1, Right: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 1, Left: 1, Right: 1, Top: 1},
-		{Stop: []*xlsxGradientFillStop{{}, {Position: 1}}, Type: "path", Bottom: 0.5, Left: 0.5, Right: 0.5, Top: 0.5},
-	}
-
+	variants := styleFillVariants()
 	var fill xlsxFill
 	switch style.Fill.Type {
 	case "gradient":
@@ -1467,7 +1738,7 @@ func newFills(style *Style, fg bool) *xlsxFill {
 			break
 		}
 		var pattern xlsxPatternFill
-		pattern.PatternType = patterns[style.Fill.Pattern]
+		pattern.PatternType = styleFillPatterns[style.Fill.Pattern]
 		if fg {
 			if pattern.FgColor == nil {
 				pattern.FgColor = new(xlsxColor)
@@ -1536,23 +1807,6 @@ func getBorderID(styleSheet *xlsxStyleSheet, style *Style) (borderID int) {
 // newBorders provides a function to add border elements in the styles.xml by
 // given borders format settings.
 func newBorders(style *Style) *xlsxBorder {
-	styles := []string{
-		"none",
-		"thin",
-		"medium",
-		"dashed",
-		"dotted",
-		"thick",
-		"double",
-		"hair",
-		"mediumDashed",
-		"dashDot",
-		"mediumDashDot",
-		"dashDotDot",
-		"mediumDashDotDot",
-		"slantDashDot",
-	}
-
 	var border xlsxBorder
 	for _, v := range style.Border {
 		if 0 <= v.Style && v.Style < 14 {
@@ -1560,23 +1814,23 @@ func newBorders(style *Style) *xlsxBorder {
 			color.RGB = getPaletteColor(v.Color)
 			switch v.Type {
 			case "left":
-				border.Left.Style = styles[v.Style]
+				border.Left.Style = styleBorders[v.Style]
 				border.Left.Color = &color
 			case "right":
-				border.Right.Style = styles[v.Style]
+				border.Right.Style = styleBorders[v.Style]
 				border.Right.Color = &color
 			case "top":
-				border.Top.Style = styles[v.Style]
+				border.Top.Style = styleBorders[v.Style]
 				border.Top.Color = &color
 			case "bottom":
-				border.Bottom.Style = styles[v.Style]
+				border.Bottom.Style = styleBorders[v.Style]
 				border.Bottom.Color = &color
 			case "diagonalUp":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalUp = true
 			case "diagonalDown":
-				border.Diagonal.Style = styles[v.Style]
+				border.Diagonal.Style = styleBorders[v.Style]
 				border.Diagonal.Color = &color
 				border.DiagonalDown = true
 			}
@@ -1626,17 +1880,20 @@ func setCellXfs(style *xlsxStyleSheet, fontID, numFmtID, fillID, borderID int, a
 // GetCellStyle provides a function to get cell style index by given worksheet
 // name and cell reference.
 func (f *File) GetCellStyle(sheet, cell string) (int, error) {
+	f.mu.Lock()
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
+		f.mu.Unlock()
 		return 0, err
 	}
+	f.mu.Unlock()
 	col, row, err := CellNameToCoordinates(cell)
 	if err != nil {
 		return 0, err
 	}
-	ws.prepareSheetXML(col, row)
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
+	ws.prepareSheetXML(col, row)
 	return ws.prepareCellStyle(col, row, ws.SheetData.Row[row-1].C[col-1].S), err
 }
 
@@ -1799,10 +2056,305 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 	return err
 }
 
+// builtInCellStyle directly maps the built-in ID and the formatting of the
+// built-in named cell style.
+type builtInCellStyle struct {
+	id    int
+	style *Style
+}
+
+// builtInCellStyles defined the built-in named cell styles which can be
+// referenced by name without creating.
+var builtInCellStyles = map[string]builtInCellStyle{
+	"Normal":           {id: 0, style: &Style{}},
+	"Comma":            {id: 3, style: &Style{NumFmt: 43}},
+	"Currency":         {id: 4, style: &Style{NumFmt: 44}},
+	"Percent":          {id: 5, style: &Style{NumFmt: 9}},
+	"Comma [0]":        {id: 6, style: &Style{NumFmt: 41}},
+	"Currency [0]":     {id: 7, style: &Style{NumFmt: 42}},
+	"Note":             {id: 10, style: &Style{Fill: Fill{Type: "pattern", Color: []string{"FFFFCC"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "B2B2B2", Style: 1}, {Type: "right", Color: "B2B2B2", Style: 1}, {Type: "top", Color: "B2B2B2", Style: 1}, {Type: "bottom", Color: "B2B2B2", Style: 1}}}},
+	"Warning Text":     {id: 11, style: &Style{Font: &Font{Color: "FF0000"}}},
+	"Title":            {id: 15, style: &Style{Font: &Font{Family: "Calibri Light", Size: 18, Color: "44546A"}}},
+	"Heading 1":        {id: 16, style: &Style{Font: &Font{Bold: true, Size: 15, Color: "44546A"}, Border: []Border{{Type: "bottom", Color: "4472C4", Style: 5}}}},
+	"Heading 2":        {id: 17, style: &Style{Font: &Font{Bold: true, Size: 13, Color: "44546A"}, Border: []Border{{Type: "bottom", Color: "A2B8E1", Style: 5}}}},
+	"Heading 3":        {id: 18, style: &Style{Font: &Font{Bold: true, Size: 11, Color: "44546A"}, Border: []Border{{Type: "bottom", Color: "8EA9DB", Style: 2}}}},
+	"Heading 4":        {id: 19, style: &Style{Font: &Font{Bold: true, Size: 11, Color: "44546A"}}},
+	"Input":            {id: 20, style: &Style{Font: &Font{Color: "3F3F76"}, Fill: Fill{Type: "pattern", Color: []string{"FFCC99"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "7F7F7F", Style: 1}, {Type: "right", Color: "7F7F7F", Style: 1}, {Type: "top", Color: "7F7F7F", Style: 1}, {Type: "bottom", Color: "7F7F7F", Style: 1}}}},
+	"Output":           {id: 21, style: &Style{Font: &Font{Bold: true, Color: "3F3F3F"}, Fill: Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "3F3F3F", Style: 1}, {Type: "right", Color: "3F3F3F", Style: 1}, {Type: "top", Color: "3F3F3F", Style: 1}, {Type: "bottom", Color: "3F3F3F", Style: 1}}}},
+	"Calculation":      {id: 22, style: &Style{Font: &Font{Bold: true, Color: "FA7D00"}, Fill: Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "7F7F7F", Style: 1}, {Type: "right", Color: "7F7F7F", Style: 1}, {Type: "top", Color: "7F7F7F", Style: 1}, {Type: "bottom", Color: "7F7F7F", Style: 1}}}},
+	"Check Cell":       {id: 23, style: &Style{Font: &Font{Bold: true, Color: "FFFFFF"}, Fill: Fill{Type: "pattern", Color: []string{"A5A5A5"}, Pattern: 1}, Border: []Border{{Type: "left", Color: "3F3F3F", Style: 6}, {Type: "right", Color: "3F3F3F", Style: 6}, {Type: "top", Color: "3F3F3F", Style: 6}, {Type: "bottom", Color: "3F3F3F", Style: 6}}}},
+	"Linked Cell":      {id: 24, style: &Style{Font: &Font{Color: "FA7D00"}, Border: []Border{{Type: "bottom", Color: "FF8001", Style: 6}}}},
+	"Total":            {id: 25, style: &Style{Font: &Font{Bold: true}, Border: []Border{{Type: "top", Color: "4472C4", Style: 1}, {Type: "bottom", Color: "4472C4", Style: 6}}}},
+	"Good":             {id: 26, style: &Style{Font: &Font{Color: "006100"}, Fill: Fill{Type: "pattern", Color: []string{"C6EFCE"}, Pattern: 1}}},
+	"Bad":              {id: 27, style: &Style{Font: &Font{Color: "9C0006"}, Fill: Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1}}},
+	"Neutral":          {id: 28, style: &Style{Font: &Font{Color: "9C5700"}, Fill: Fill{Type: "pattern", Color: []string{"FFEB9C"}, Pattern: 1}}},
+	"Explanatory Text": {id: 53, style: &Style{Font: &Font{Italic: true, Color: "7F7F7F"}}},
+}
+
+// NewNamedStyle provides a function to create a named cell style by given
+// style name and style settings, returns the style index which can be used
+// in the SetCellStyle function. The named cell style will be listed in the
+// cell styles gallery of the spreadsheet application. For example, create a
+// named cell style "Highlight" and apply it to the cell A1 in Sheet1:
+//
+//	styleID, err := f.NewNamedStyle("Highlight", &excelize.Style{
+//	    Fill: excelize.Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1},
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellStyleByName("Sheet1", "A1", "Highlight")
+func (f *File) NewNamedStyle(name string, style *Style) (int, error) {
+	if name == "" || style == nil {
+		return 0, ErrParameterInvalid
+	}
+	if utf8.RuneCountInString(name) > MaxFieldLength {
+		return 0, ErrNameLength
+	}
+	if _, ok := builtInCellStyles[name]; ok {
+		return 0, newNamedStyleExistError(name)
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	_, ok := s.getNamedStyleXfID(name)
+	s.mu.Unlock()
+	if ok {
+		return 0, newNamedStyleExistError(name)
+	}
+	return f.newNamedStyle(s, name, nil, style)
+}
+
+// newNamedStyle provides a function to create a named cell style by given
+// style sheet, style name, built-in ID and style settings, returns the index
+// of the cell formatting record which references the named cell style.
+func (f *File) newNamedStyle(s *xlsxStyleSheet, name string, builtInID *int, style *Style) (int, error) {
+	s.mu.Lock()
+	// reserve the cell formatting records for both the style settings and the
+	// named cell style, to avoid leaving the unused records on failure
+	if s.CellXfs != nil && len(s.CellXfs.Xf)+2 > MaxCellStyles {
+		s.mu.Unlock()
+		return 0, ErrCellStyles
+	}
+	s.mu.Unlock()
+	styleID, err := f.NewStyle(style)
+	if err != nil {
+		return styleID, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.CellStyleXfs == nil {
+		s.CellStyleXfs = &xlsxCellStyleXfs{}
+	}
+	if s.CellStyles == nil {
+		s.CellStyles = &xlsxCellStyles{}
+	}
+	styleXf := s.CellXfs.Xf[styleID]
+	styleXf.XfID = nil
+	s.CellStyleXfs.Xf = append(s.CellStyleXfs.Xf, styleXf)
+	s.CellStyleXfs.Count = len(s.CellStyleXfs.Xf)
+	xfID := s.CellStyleXfs.Count - 1
+	s.CellStyles.CellStyle = append(s.CellStyles.CellStyle, &xlsxCellStyle{
+		Name: name, XfID: xfID, BuiltInID: builtInID,
+	})
+	s.CellStyles.Count = len(s.CellStyles.CellStyle)
+	xf := s.CellXfs.Xf[styleID]
+	xf.XfID = intPtr(xfID)
+	s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+	s.CellXfs.Count = len(s.CellXfs.Xf)
+	return s.CellXfs.Count - 1, err
+}
+
+// getNamedStyleXfID provides a function to get the index of the master
+// formatting record of the named cell style by given style name.
+func (s *xlsxStyleSheet) getNamedStyleXfID(name string) (int, bool) {
+	if s.CellStyles == nil {
+		return 0, false
+	}
+	for _, cellStyle := range s.CellStyles.CellStyle {
+		if cellStyle.Name == name {
+			return cellStyle.XfID, true
+		}
+	}
+	return 0, false
+}
+
+// getNamedStyleID provides a function to get the index of the cell
+// formatting record which references the named cell style by given style
+// name, the built-in named cell style will be created if it doesn't exist.
+func (f *File) getNamedStyleID(s *xlsxStyleSheet, name string) (int, error) {
+	s.mu.Lock()
+	xfID, ok := s.getNamedStyleXfID(name)
+	if ok {
+		defer s.mu.Unlock()
+		if s.CellStyleXfs == nil || xfID >= len(s.CellStyleXfs.Xf) {
+			return 0, newNoExistNamedStyleError(name)
+		}
+		for idx, xf := range s.CellXfs.Xf {
+			if xf.XfID != nil && *xf.XfID == xfID {
+				if xf.XfID = nil; reflect.DeepEqual(xf, s.CellStyleXfs.Xf[xfID]) {
+					return idx, nil
+				}
+			}
+		}
+		if len(s.CellXfs.Xf) == MaxCellStyles {
+			return 0, ErrCellStyles
+		}
+		xf := s.CellStyleXfs.Xf[xfID]
+		xf.XfID = intPtr(xfID)
+		s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+		s.CellXfs.Count = len(s.CellXfs.Xf)
+		return s.CellXfs.Count - 1, nil
+	}
+	s.mu.Unlock()
+	builtIn, ok := builtInCellStyles[name]
+	if !ok {
+		return 0, newNoExistNamedStyleError(name)
+	}
+	return f.newNamedStyle(s, name, intPtr(builtIn.id), builtIn.style)
+}
+
+// SetCellStyleByName provides a function to apply the named cell style for
+// the cell or cell range by given worksheet name, range reference and style
+// name. The built-in named cell styles, such as "Good", "Bad", "Neutral",
+// "Title", "Heading 1" and "Total" can be referenced by name directly, and
+// the custom named cell styles can be created by the NewNamedStyle function.
+// For example, apply the "Good" named cell style for the cells A1:B2 in
+// Sheet1:
+//
+//	err := f.SetCellStyleByName("Sheet1", "A1:B2", "Good")
+func (f *File) SetCellStyleByName(sheet, ref, styleName string) error {
+	cells := strings.Split(ref, ":")
+	if len(cells) > 2 {
+		return ErrParameterInvalid
+	}
+	hCell, vCell := cells[0], cells[len(cells)-1]
+	for _, cell := range cells {
+		if _, _, err := CellNameToCoordinates(cell); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	styleID, err := f.getNamedStyleID(s, styleName)
+	if err != nil {
+		return err
+	}
+	return f.SetCellStyle(sheet, hCell, vCell, styleID)
+}
+
+// GetCellStyleName provides a function to get the name of the named cell
+// style which applied to the cell by given worksheet name and cell reference.
+// For example, get the named cell style of the cell A1 in Sheet1:
+//
+//	name, err := f.GetCellStyleName("Sheet1", "A1")
+func (f *File) GetCellStyleName(sheet, cell string) (string, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var xfID int
+	if s.CellXfs != nil && styleID < len(s.CellXfs.Xf) && s.CellXfs.Xf[styleID].XfID != nil {
+		xfID = *s.CellXfs.Xf[styleID].XfID
+	}
+	if s.CellStyles != nil {
+		for _, cellStyle := range s.CellStyles.CellStyle {
+			if cellStyle.XfID == xfID {
+				return cellStyle.Name, err
+			}
+		}
+	}
+	return "", err
+}
+
+// IndentCell provides a function to increase or decrease the indent of the
+// cell by given worksheet name, cell reference and the indent delta, which
+// works like the "Increase Indent" and "Decrease Indent" buttons in the
+// spreadsheet application. Only the indent of the cell alignment will be
+// changed and the other formatting of the cell will be kept, the indent will
+// not be less than 0 and greater than 250. The left horizontal alignment will
+// be applied for the cell with general or center horizontal alignment when
+// increasing the indent. For example, increase the indent of the cell A2 in
+// Sheet1 by 2 levels:
+//
+//	err := f.IndentCell("Sheet1", "A2", 2)
+func (f *File) IndentCell(sheet, cell string, delta int) error {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	if s.CellXfs == nil || styleID >= len(s.CellXfs.Xf) {
+		s.mu.Unlock()
+		return newInvalidStyleID(styleID)
+	}
+	xf := s.CellXfs.Xf[styleID]
+	alignment := xlsxAlignment{}
+	if xf.Alignment != nil {
+		alignment = *xf.Alignment
+	}
+	if alignment.Indent += delta; alignment.Indent < 0 {
+		alignment.Indent = 0
+	}
+	if alignment.Indent > maxCellIndent {
+		alignment.Indent = maxCellIndent
+	}
+	if alignment.Indent > 0 && inStrSlice([]string{"", "general", "center"}, alignment.Horizontal, true) != -1 {
+		alignment.Horizontal = "left"
+	}
+	xf.Alignment, xf.ApplyAlignment = &alignment, boolPtr(true)
+	if alignment == (xlsxAlignment{}) {
+		xf.Alignment, xf.ApplyAlignment = nil, nil
+	}
+	newStyleID := -1
+	for idx, cellXf := range s.CellXfs.Xf {
+		if reflect.DeepEqual(cellXf, xf) {
+			newStyleID = idx
+			break
+		}
+	}
+	if newStyleID == -1 {
+		if len(s.CellXfs.Xf) == MaxCellStyles {
+			s.mu.Unlock()
+			return ErrCellStyles
+		}
+		s.CellXfs.Xf = append(s.CellXfs.Xf, xf)
+		s.CellXfs.Count = len(s.CellXfs.Xf)
+		newStyleID = s.CellXfs.Count - 1
+	}
+	s.mu.Unlock()
+	return f.SetCellStyle(sheet, cell, cell, newStyleID)
+}
+
 // SetConditionalFormat provides a function to create conditional formatting
 // rule for cell value. Conditional formatting is a feature of Excel which
 // allows you to apply a format to a cell or a range of cells based on certain
-// criteria.
+// criteria. The whole column and whole row references, such as "A:A" and
+// "1:3", will be stored as the cell range references "A1:A1048576" and
+// "A1:XFD3" without creating any cells.
 //
 // The type option is a required parameter and it has no default value.
 // Allowable type values and their associated parameters are:
@@ -1821,12 +2373,16 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	 text          | Criteria
 //	               | Value
 //	 average       | Criteria
+//	               | AboveAverage
+//	               | StdDev
 //	 duplicate     | (none)
 //	 unique        | (none)
 //	 top           | Criteria
 //	               | Value
+//	               | Percent
 //	 bottom        | Criteria
 //	               | Value
+//	               | Percent
 //	 blanks        | (none)
 //	 no_blanks     | (none)
 //	 errors        | (none)
@@ -2014,6 +2570,22 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// The 'StdDev' parameter is used to specify the number of standard deviations
+// above or below the average, the available values are from 1 to 3:
+//
+//	// Top/Bottom rules: 1 Std Dev Above Average...
+//	err := f.SetConditionalFormat("Sheet1", "C1:C10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:         "average",
+//	            Criteria:     "=",
+//	            Format:       format3,
+//	            AboveAverage: true,
+//	            StdDev:       1,
+//	        },
+//	    },
+//	)
+//
 // type: duplicate - The duplicate type is used to highlight duplicate cells in
 // a range:
 //
@@ -2033,8 +2605,33 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
+// type: text - The text type is used to specify Excel's "Specific Text" style
+// conditional format, the 'Criteria' parameter can be "containing", "not
+// containing", "begins with" or "ends with", and the text to search for is
+// specified by the 'Value' parameter:
+//
+//	// Highlight cells rules: Text that Contains...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "text", Criteria: "containing", Format: format, Value: "ERROR"},
+//	    },
+//	)
+//
+// type: time_period - The time_period type is used to specify Excel's "Dates
+// Occurring" style conditional format, the 'Criteria' parameter can be
+// "yesterday", "today", "tomorrow", "last 7 days", "last week", "this week",
+// "next week", "last month", "this month" or "next month":
+//
+//	// Highlight cells rules: A Date Occurring...
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "time_period", Criteria: "last 7 days", Format: format},
+//	    },
+//	)
+//
 // type: top - The top type is used to specify the top n values by number or
-// percentage in a range:
+// percentage in a range, the rank specified by the 'Value' parameter must be
+// between 1 and 1000, and the default rank is 10:
 //
 //	// Top/Bottom rules: Top 10.
 //	err := f.SetConditionalFormat("Sheet1", "H1:H10",
@@ -2048,7 +2645,8 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //	    },
 //	)
 //
-// The criteria can be used to indicate that a percentage condition is required:
+// The 'Percent' parameter can be used to indicate that a percentage condition
+// is required:
 //
 //	err := f.SetConditionalFormat("Sheet1", "A1:A10",
 //	    []excelize.ConditionalFormatOptions{
@@ -2211,7 +2809,39 @@ func (f *File) SetCellStyle(sheet, hCell, vCell string, styleID int) error {
 //
 // IconsOnly - Used for set displayed without the cell value.
 //
-// StopIfTrue - used to set the "stop if true" feature of a conditional
+// IconThresholds - Used for set the custom thresholds of the icons, the
+// number of the thresholds must be equal to the number of the icons in the
+// icon set. The first threshold is the lower bound of the first icon for the
+// lowest values, and each subsequent threshold is the lower bound of the next
+// icon. The percent based thresholds of the icon set will be used if this
+// parameter is empty, and the "3TrafficLights1" icon set will be used if the
+// IconStyle is empty. The available threshold types are:
+//
+//	num
+//	percent
+//	percentile
+//	formula
+//
+// For example, create a reversed 5 arrows icon set with custom thresholds:
+//
+//	err := f.SetConditionalFormat("Sheet1", "A1:A10",
+//	    []excelize.ConditionalFormatOptions{
+//	        {
+//	            Type:         "icon_set",
+//	            IconStyle:    "5Arrows",
+//	            ReverseIcons: true,
+//	            IconThresholds: []excelize.ConditionalFormatIconThreshold{
+//	                {Type: "num", Value: "0"},
+//	                {Type: "num", Value: "10"},
+//	                {Type: "percent", Value: "50"},
+//	                {Type: "percentile", Value: "75", GreaterThan: true},
+//	                {Type: "formula", Value: "$B$1"},
+//	            },
+//	        },
+//	    },
+//	)
+//
+// StopIfTrue - used to set the "stop if true" feature of a conditional
 // formatting rule when more than one rule is applied to a cell or a range of
 // cells. When this parameter is set then subsequent rules are not evaluated
 // if the current rule is true.
@@ -2227,12 +2857,17 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		"dataBar":         drawCondFmtDataBar,
 		"expression":      drawCondFmtExp,
 		"iconSet":         drawCondFmtIconSet,
+		"text":            drawCondFmtText,
+		"timePeriod":      drawCondFmtTimePeriod,
 	}
 
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
+	if rangeRef, err = expandSqref(rangeRef); err != nil {
+		return err
+	}
 	// Create a pseudo GUID for each unique rule.
 	var rules int
 	for _, cf := range ws.ConditionalFormatting {
@@ -2248,13 +2883,21 @@ func (f *File) SetConditionalFormat(sheet, rangeRef string, opts []ConditionalFo
 		if ok {
 			// Check for valid criteria types.
 			ct, ok = criteriaType[v.Criteria]
-			if ok || vt == "expression" || vt == "iconSet" {
+			if ok || vt == "expression" || vt == "iconSet" || vt == "timePeriod" {
 				drawFunc, ok := drawContFmtFunc[vt]
 				if ok {
+					if err = checkCondFmtOptions(vt, &v); err != nil {
+						return err
+					}
 					rule, x14rule := drawFunc(p, ct, GUID, &v)
 					if rule == nil {
 						return ErrParameterInvalid
 					}
+					if vt == "text" || vt == "timePeriod" {
+						if err = setCondFmtRuleFormula(rule, rangeRef); err != nil {
+							return err
+						}
+					}
 					if x14rule != nil {
 						if err = f.appendCfRule(ws, x14rule); err != nil {
 							return err
@@ -2364,6 +3007,7 @@ func extractCondFmtAboveAverage(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFo
 		Criteria:     "=",
 		Format:       *c.DxfID,
 		AboveAverage: *c.AboveAverage,
+		StdDev:       c.StdDev,
 	}
 }
 
@@ -2481,6 +3125,34 @@ func extractCondFmtExp(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptio
 	return format
 }
 
+// extractCondFmtText provides a function to extract conditional format
+// settings for specific text by given conditional formatting rule.
+func extractCondFmtText(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type:       "text",
+		Criteria: operatorType[map[string]string{
+			"containsText":    "containsText",
+			"notContainsText": "notContains",
+			"beginsWith":      "beginsWith",
+			"endsWith":        "endsWith",
+		}[c.Type]],
+		Format: *c.DxfID,
+		Value:  c.Text,
+	}
+}
+
+// extractCondFmtTimePeriod provides a function to extract conditional format
+// settings for dates occurring by given conditional formatting rule.
+func extractCondFmtTimePeriod(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
+	return ConditionalFormatOptions{
+		StopIfTrue: c.StopIfTrue,
+		Type:       "time_period",
+		Criteria:   operatorType[c.TimePeriod],
+		Format:     *c.DxfID,
+	}
+}
+
 // extractCondFmtIconSet provides a function to extract conditional format
 // settings for icon sets by given conditional formatting rule.
 func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions {
@@ -2491,12 +3163,42 @@ func extractCondFmtIconSet(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatO
 		}
 		format.IconStyle = c.IconSet.IconSet
 		format.ReverseIcons = c.IconSet.Reverse
+		preset, _ := drawCondFmtIconSet(0, "", "", &ConditionalFormatOptions{Type: "icon_set", IconStyle: format.IconStyle})
+		if preset == nil || !reflect.DeepEqual(preset.IconSet.Cfvo, c.IconSet.Cfvo) {
+			for _, cfvo := range c.IconSet.Cfvo {
+				format.IconThresholds = append(format.IconThresholds, ConditionalFormatIconThreshold{
+					Type: cfvo.Type, Value: cfvo.Val, GreaterThan: cfvo.Gte != nil && !*cfvo.Gte,
+				})
+			}
+		}
 	}
 	return format
 }
 
 // GetConditionalFormats returns conditional format settings by given worksheet
-// name.
+// name. The range references which cover the whole columns or whole rows will
+// be returned as the column or row references, such as "A:A" and "1:3". The
+// 'Format' of the settings is the index of the differential format of the
+// rule, and the 'FormatStyle' is the style definition of the differential
+// format. The 'FormatPreset' is the name of the built-in style preset which
+// the differential format matches, such as "Light Red Fill with Dark Red
+// Text", "Yellow Fill with Dark Yellow Text", "Green Fill with Dark Green
+// Text", "Light Red Fill", "Red Text" or "Red Border". The returned settings
+// can be used by the SetConditionalFormat function directly. For example, get
+// the fill color of the conditional formats on Sheet1:
+//
+//	formats, err := f.GetConditionalFormats("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for ref, opts := range formats {
+//	    for _, opt := range opts {
+//	        if opt.FormatStyle != nil {
+//	            fmt.Println(ref, opt.FormatPreset, opt.FormatStyle.Fill.Color)
+//	        }
+//	    }
+//	}
 func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalFormatOptions, error) {
 	extractContFmtFunc := map[string]func(c *xlsxCfRule, extLst *xlsxExtLst) ConditionalFormatOptions{
 		"cellIs":          extractCondFmtCellIs,
@@ -2508,6 +3210,11 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 		"dataBar":         extractCondFmtDataBar,
 		"expression":      extractCondFmtExp,
 		"iconSet":         extractCondFmtIconSet,
+		"containsText":    extractCondFmtText,
+		"notContainsText": extractCondFmtText,
+		"beginsWith":      extractCondFmtText,
+		"endsWith":        extractCondFmtText,
+		"timePeriod":      extractCondFmtTimePeriod,
 	}
 
 	conditionalFormats := make(map[string][]ConditionalFormatOptions)
@@ -2515,32 +3222,777 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 	if err != nil {
 		return conditionalFormats, err
 	}
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	f.mu.Unlock()
+	if err != nil {
+		return conditionalFormats, err
+	}
 	for _, cf := range ws.ConditionalFormatting {
 		var opts []ConditionalFormatOptions
 		for _, cr := range cf.CfRule {
 			if extractFunc, ok := extractContFmtFunc[cr.Type]; ok {
-				opts = append(opts, extractFunc(cr, ws.ExtLst))
+				format := extractFunc(cr, ws.ExtLst)
+				if cr.DxfID != nil {
+					if format.FormatStyle, err = getDxfStyle(s, *cr.DxfID); err != nil {
+						return conditionalFormats, err
+					}
+					if format.FormatStyle != nil {
+						format.FormatPreset = getCondFmtStylePreset(format.FormatStyle)
+					}
+				}
+				opts = append(opts, format)
 			}
 		}
-		conditionalFormats[cf.SQRef] = opts
+		conditionalFormats[compactSqref(cf.SQRef)] = opts
 	}
 	return conditionalFormats, err
 }
 
 // UnsetConditionalFormat provides a function to unset the conditional format
-// by given worksheet name and range reference.
+// by given worksheet name and range reference. All the conditional formatting
+// rules and the corresponding extension rules of the range will be removed,
+// and it will be a no-op if there are no rules in the range.
 func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
 	ws, err := f.workSheetReader(sheet)
 	if err != nil {
 		return err
 	}
-	for i, cf := range ws.ConditionalFormatting {
-		if cf.SQRef == rangeRef {
-			ws.ConditionalFormatting = append(ws.ConditionalFormatting[:i], ws.ConditionalFormatting[i+1:]...)
-			return nil
+	if rangeRef, err = expandSqref(rangeRef); err != nil {
+		return err
+	}
+	var (
+		condFmts []*xlsxConditionalFormatting
+		ruleIDs  = map[string]bool{}
+	)
+	for _, cf := range ws.ConditionalFormatting {
+		if cf.SQRef != rangeRef {
+			condFmts = append(condFmts, cf)
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			if rule.ExtLst == nil {
+				continue
+			}
+			ext := decodeX14ConditionalFormattingExt{}
+			if err = f.xmlNewDecoder(strings.NewReader(rule.ExtLst.Ext)).Decode(&ext); err == nil && ext.ID != "" {
+				ruleIDs[ext.ID] = true
+			}
 		}
 	}
-	return nil
+	if len(condFmts) == len(ws.ConditionalFormatting) && ws.ExtLst == nil {
+		return nil
+	}
+	ws.ConditionalFormatting = condFmts
+	return f.deleteX14CondFmts(ws, rangeRef, ruleIDs)
+}
+
+// condFmtCellIsFormulas defined the formula templates of the cell value
+// conditional formatting rules by operator, the %[1]s is the cell reference,
+// and the %[2]s and %[3]s are the formulas of the rule.
+var condFmtCellIsFormulas = map[string]string{
+	"equal":              "%[1]s=%[2]s",
+	"notEqual":           "%[1]s<>%[2]s",
+	"greaterThan":        "%[1]s>%[2]s",
+	"lessThan":           "%[1]s<%[2]s",
+	"greaterThanOrEqual": "%[1]s>=%[2]s",
+	"lessThanOrEqual":    "%[1]s<=%[2]s",
+	"between":            "AND(%[1]s>=MIN(%[2]s,%[3]s),%[1]s<=MAX(%[2]s,%[3]s))",
+	"notBetween":         "OR(%[1]s<MIN(%[2]s,%[3]s),%[1]s>MAX(%[2]s,%[3]s))",
+	"containsText":       "ISNUMBER(SEARCH(%[2]s,%[1]s))",
+	"notContains":        "ISERROR(SEARCH(%[2]s,%[1]s))",
+	"beginsWith":         "LEFT(%[1]s,LEN(%[2]s))=%[2]s",
+	"endsWith":           "RIGHT(%[1]s,LEN(%[2]s))=%[2]s",
+}
+
+// condFmtTextFormulas defined the formula templates of the text, blanks and
+// errors conditional formatting rules without formula by type, the %[1]s is
+// the cell reference, and the %[2]s is the text of the rule.
+var condFmtTextFormulas = map[string]string{
+	"containsText":      `ISNUMBER(SEARCH("%[2]s",%[1]s))`,
+	"notContainsText":   `ISERROR(SEARCH("%[2]s",%[1]s))`,
+	"beginsWith":        `LEFT(%[1]s,LEN("%[2]s"))="%[2]s"`,
+	"endsWith":          `RIGHT(%[1]s,LEN("%[2]s"))="%[2]s"`,
+	"containsBlanks":    `LEN(TRIM(%[1]s))=0`,
+	"notContainsBlanks": `LEN(TRIM(%[1]s))>0`,
+	"containsErrors":    `ISERROR(%[1]s)`,
+	"notContainsErrors": `NOT(ISERROR(%[1]s))`,
+}
+
+// condFmtTimePeriodFormulas defined the formula templates of the time period
+// conditional formatting rules by time period, the %[1]s is the cell
+// reference.
+var condFmtTimePeriodFormulas = map[string]string{
+	"today":     "FLOOR(%[1]s,1)=TODAY()",
+	"yesterday": "FLOOR(%[1]s,1)=TODAY()-1",
+	"tomorrow":  "FLOOR(%[1]s,1)=TODAY()+1",
+	"last7Days": "AND(TODAY()-FLOOR(%[1]s,1)<=6,FLOOR(%[1]s,1)<=TODAY())",
+	"lastWeek":  "AND(TODAY()-ROUNDDOWN(%[1]s,0)>=(WEEKDAY(TODAY())),TODAY()-ROUNDDOWN(%[1]s,0)<(WEEKDAY(TODAY())+7))",
+	"thisWeek":  "AND(TODAY()-ROUNDDOWN(%[1]s,0)<=WEEKDAY(TODAY())-1,ROUNDDOWN(%[1]s,0)-TODAY()<=7-WEEKDAY(TODAY()))",
+	"nextWeek":  "AND(ROUNDDOWN(%[1]s,0)-TODAY()>(7-WEEKDAY(TODAY())),ROUNDDOWN(%[1]s,0)-TODAY()<(15-WEEKDAY(TODAY())))",
+	"lastMonth": "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0-1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0-1)))",
+	"thisMonth": "AND(MONTH(%[1]s)=MONTH(TODAY()),YEAR(%[1]s)=YEAR(TODAY()))",
+	"nextMonth": "AND(MONTH(%[1]s)=MONTH(EDATE(TODAY(),0+1)),YEAR(%[1]s)=YEAR(EDATE(TODAY(),0+1)))",
+}
+
+// condFmtRule defined the conditional formatting rule and the range reference
+// which the rule applies to.
+type condFmtRule struct {
+	sqref string
+	rule  *xlsxCfRule
+}
+
+// GetEffectiveCellStyle provides a function to get the effective style of the
+// cell by given worksheet name and cell reference, which the differential
+// formats of the conditional formatting rules satisfied by the cell were
+// merged over the base style of the cell. The rules will be evaluated in
+// priority order, the format of the rule with the higher priority wins on the
+// conflicting settings, and the rules with lower priority will be skipped
+// after a satisfied rule which stops if true. The formula based rules will be
+// evaluated by the calculation engine, and the color scale rules will be
+// converted to the solid fill with the interpolated color. The data bar and
+// icon set rules don't change the style of the cell and will be ignored. For
+// example, get the effective fill color of the cell A1 on Sheet1:
+//
+//	style, err := f.GetEffectiveCellStyle("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(style.Fill.Color)
+func (f *File) GetEffectiveCellStyle(sheet, cell string) (*Style, error) {
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	col, row, _ := CellNameToCoordinates(cell)
+	f.mu.Lock()
+	s, err := f.stylesReader()
+	if err != nil {
+		f.mu.Unlock()
+		return nil, err
+	}
+	ws, _ := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	s.mu.Lock()
+	style := extractStyle(s, styleID)
+	s.mu.Unlock()
+	var (
+		formats []*Style
+		grid    [][]string
+	)
+	for _, r := range ws.getCondFmtRules(col, row) {
+		if grid, err = f.getCondFmtGrid(sheet, r.rule, grid); err != nil {
+			return style, err
+		}
+		format, ok, err := f.evalCondFmtRule(sheet, cell, col, row, r, grid)
+		if err != nil {
+			return style, err
+		}
+		if !ok {
+			continue
+		}
+		formats = append(formats, format)
+		if r.rule.StopIfTrue {
+			break
+		}
+	}
+	for idx := len(formats) - 1; idx >= 0; idx-- {
+		mergeCondFmtStyle(style, formats[idx])
+	}
+	return style, err
+}
+
+// GetCellConditionalFormatVisual provides a function to get the data bar and
+// icon of the conditional formatting rules which applied to the cell by given
+// worksheet name and cell reference. The length of the data bar and the index
+// of the icon will be computed by the value of the cell and the minimum and
+// maximum values of the rule across the range. The rules will be evaluated in
+// priority order like the GetEffectiveCellStyle function does. For example,
+// get the data bar of the cell A1 on Sheet1:
+//
+//	visual, err := f.GetCellConditionalFormatVisual("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if visual.DataBar {
+//	    fmt.Printf("%s %.2f%%\n", visual.BarColor, visual.BarLength)
+//	}
+func (f *File) GetCellConditionalFormatVisual(sheet, cell string) (ConditionalFormatVisual, error) {
+	visual := ConditionalFormatVisual{ShowValue: true}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return visual, err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return visual, err
+	}
+	var grid [][]string
+	for _, r := range ws.getCondFmtRules(col, row) {
+		if (r.rule.Type == "dataBar" && visual.DataBar) || (r.rule.Type == "iconSet" && visual.IconSet) {
+			continue
+		}
+		if grid, err = f.getCondFmtGrid(sheet, r.rule, grid); err != nil {
+			return visual, err
+		}
+		var value string
+		if row <= len(grid) && col <= len(grid[row-1]) {
+			value = grid[row-1][col-1]
+		}
+		numbers := condFmtNumbers(condFmtRangeValues(grid, r.sqref))
+		switch r.rule.Type {
+		case "dataBar":
+			if visual.BarColor, visual.BarLength, visual.DataBar = condFmtDataBar(r.rule.DataBar, numbers, value); visual.DataBar {
+				visual.ShowValue = visual.ShowValue && (r.rule.DataBar.ShowValue == nil || *r.rule.DataBar.ShowValue)
+			}
+		case "iconSet":
+			if visual.IconIndex, visual.IconSet = condFmtIconSet(r.rule.IconSet, numbers, value); visual.IconSet {
+				visual.IconStyle = r.rule.IconSet.IconSet
+				if visual.IconStyle == "" {
+					visual.IconStyle = "3TrafficLights1"
+				}
+				visual.ShowValue = visual.ShowValue && (r.rule.IconSet.ShowValue == nil || *r.rule.IconSet.ShowValue)
+			}
+		default:
+			if !r.rule.StopIfTrue {
+				continue
+			}
+			_, ok, err := f.evalCondFmtRule(sheet, cell, col, row, r, grid)
+			if err != nil || ok {
+				return visual, err
+			}
+		}
+	}
+	return visual, err
+}
+
+// getCondFmtRules provides a function to get the conditional formatting rules
+// which applied to the cell of the given coordinates in priority order.
+func (ws *xlsxWorksheet) getCondFmtRules(col, row int) []condFmtRule {
+	var rules []condFmtRule
+	ws.mu.Lock()
+	for _, cf := range ws.ConditionalFormatting {
+		if cf == nil || !cellInSqref(col, row, cf.SQRef) {
+			continue
+		}
+		for _, rule := range cf.CfRule {
+			if rule != nil {
+				rules = append(rules, condFmtRule{sqref: cf.SQRef, rule: rule})
+			}
+		}
+	}
+	ws.mu.Unlock()
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].rule.Priority < rules[j].rule.Priority })
+	return rules
+}
+
+// getCondFmtGrid provides a function to read the raw values of the worksheet
+// for the conditional formatting rule which evaluated by the values across
+// the range, the given rows will be returned if they have been read.
+func (f *File) getCondFmtGrid(sheet string, rule *xlsxCfRule, grid [][]string) ([][]string, error) {
+	if grid != nil || inStrSlice([]string{"top10", "aboveAverage", "duplicateValues", "uniqueValues", "colorScale", "dataBar", "iconSet"}, rule.Type, true) == -1 {
+		return grid, nil
+	}
+	return f.GetRows(sheet, Options{RawCellValue: true})
+}
+
+// cellInSqref provides a function to check if the cell of the given
+// coordinates is in the space-separated range references.
+func cellInSqref(col, row int, sqref string) bool {
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			continue
+		}
+		_ = sortCoordinates(coordinates)
+		if cellInRange([]int{col, row}, coordinates) {
+			return true
+		}
+	}
+	return false
+}
+
+// condFmtRangeValues provides a function to get the raw values of the cells
+// in the space-separated range references by given rows of the worksheet.
+func condFmtRangeValues(grid [][]string, sqref string) []string {
+	var values []string
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			continue
+		}
+		_ = sortCoordinates(coordinates)
+		for row := coordinates[1]; row <= coordinates[3] && row <= len(grid); row++ {
+			for col := coordinates[0]; col <= coordinates[2] && col <= len(grid[row-1]); col++ {
+				values = append(values, grid[row-1][col-1])
+			}
+		}
+	}
+	return values
+}
+
+// condFmtNumbers provides a function to get the numeric values in the given
+// values.
+func condFmtNumbers(values []string) []float64 {
+	var numbers []float64
+	for _, value := range values {
+		if num, err := strconv.ParseFloat(value, 64); err == nil {
+			numbers = append(numbers, num)
+		}
+	}
+	return numbers
+}
+
+// evalCondFmtRule provides a function to evaluate the conditional formatting
+// rule for the cell, and returns the differential format of the rule if the
+// cell satisfies the rule.
+func (f *File) evalCondFmtRule(sheet, cell string, col, row int, r condFmtRule, grid [][]string) (*Style, bool, error) {
+	var value string
+	if row <= len(grid) && col <= len(grid[row-1]) {
+		value = grid[row-1][col-1]
+	}
+	if r.rule.Type == "colorScale" {
+		color, ok := condFmtColorScale(r.rule.ColorScale, condFmtNumbers(condFmtRangeValues(grid, r.sqref)), value)
+		return &Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{color}}}, ok, nil
+	}
+	if r.rule.DxfID == nil {
+		return nil, false, nil
+	}
+	var ok bool
+	switch r.rule.Type {
+	case "top10", "aboveAverage", "duplicateValues", "uniqueValues":
+		ok = evalCondFmtRangeRule(r.rule, condFmtRangeValues(grid, r.sqref), value)
+	default:
+		formula, supported := getCondFmtRuleFormula(cell, r)
+		if !supported {
+			return nil, false, nil
+		}
+		ok = f.evalCondFmtFormula(sheet, cell, formula)
+	}
+	if !ok {
+		return nil, ok, nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.stylesReader()
+	if err != nil {
+		return nil, false, err
+	}
+	format, err := getDxfStyle(s, *r.rule.DxfID)
+	return format, format != nil, err
+}
+
+// getDxfStyle provides a function to get the style definition of the
+// differential format by given style sheet and index of the differential
+// format. This function returns nil if the differential format doesn't exist.
+func getDxfStyle(s *xlsxStyleSheet, dxfID int) (*Style, error) {
+	if s.Dxfs == nil || dxfID < 0 || dxfID >= len(s.Dxfs.Dxfs) || s.Dxfs.Dxfs[dxfID] == nil {
+		return nil, nil
+	}
+	var record dxf
+	if err := xml.Unmarshal([]byte("<dxf>"+s.Dxfs.Dxfs[dxfID].Dxf+"</dxf>"), &record); err != nil {
+		return nil, err
+	}
+	format := extractDxf(&record)
+	// The pattern type of the differential fill defaults to solid.
+	if format.Fill.Type == "pattern" && format.Fill.Pattern == 0 && len(format.Fill.Color) > 0 {
+		format.Fill.Pattern = 1
+	}
+	return &format, nil
+}
+
+// condFmtStylePresets defined the font color, fill color and border color of
+// the built-in differential formats of the conditional formatting rules by
+// preset name.
+var condFmtStylePresets = map[string][3]string{
+	"Light Red Fill with Dark Red Text": {"9C0006", "FFC7CE", ""},
+	"Yellow Fill with Dark Yellow Text": {"9C5700", "FFEB9C", ""},
+	"Green Fill with Dark Green Text":   {"006100", "C6EFCE", ""},
+	"Light Red Fill":                    {"", "FFC7CE", ""},
+	"Red Text":                          {"9C0006", "", ""},
+	"Red Border":                        {"", "", "9C0006"},
+}
+
+// getCondFmtStylePreset provides a function to get the preset name of the
+// built-in differential format of the conditional formatting rule by given
+// style definition. This function returns an empty string if the style doesn't
+// match any preset.
+func getCondFmtStylePreset(style *Style) string {
+	var colors [3]string
+	if style.Font != nil {
+		colors[0] = strings.ToUpper(strings.TrimPrefix(style.Font.Color, "#"))
+	}
+	if len(style.Fill.Color) > 0 {
+		colors[1] = strings.ToUpper(strings.TrimPrefix(style.Fill.Color[0], "#"))
+	}
+	for idx, border := range style.Border {
+		color := strings.ToUpper(strings.TrimPrefix(border.Color, "#"))
+		if idx > 0 && color != colors[2] {
+			return ""
+		}
+		colors[2] = color
+	}
+	for name, preset := range condFmtStylePresets {
+		if preset == colors {
+			return name
+		}
+	}
+	return ""
+}
+
+// getCondFmtRuleFormula provides a function to get the formula for evaluating
+// the conditional formatting rule on the cell, the relative references in
+// the formulas of the rule will be shifted from the top-left cell of the range
+// to the cell. This function returns false if the rule can't be evaluated by
+// the formula.
+func getCondFmtRuleFormula(cell string, r condFmtRule) (string, bool) {
+	var formulas []string
+	col, row, _ := CellNameToCoordinates(cell)
+	if refs := strings.Fields(r.sqref); len(refs) > 0 {
+		topLeftCol, topLeftRow, err := CellNameToCoordinates(strings.ReplaceAll(strings.Split(refs[0], ":")[0], "$", ""))
+		if err != nil {
+			return "", false
+		}
+		for _, formula := range r.rule.Formula {
+			orig := []byte(formula)
+			res, start := parseSharedFormula(col-topLeftCol, row-topLeftRow, orig)
+			if start < len(orig) {
+				res += string(orig[start:])
+			}
+			formulas = append(formulas, res)
+		}
+	}
+	switch r.rule.Type {
+	case "cellIs":
+		tmpl, ok := condFmtCellIsFormulas[r.rule.Operator]
+		if !ok || len(formulas) == 0 {
+			return "", false
+		}
+		maxValue := formulas[0]
+		if len(formulas) > 1 {
+			maxValue = formulas[1]
+		}
+		return fmt.Sprintf(tmpl, cell, formulas[0], maxValue), true
+	case "expression":
+		if len(formulas) == 0 {
+			return "", false
+		}
+		return formulas[0], true
+	}
+	if len(formulas) > 0 {
+		return formulas[0], true
+	}
+	if tmpl, ok := condFmtTextFormulas[r.rule.Type]; ok {
+		return fmt.Sprintf(tmpl, cell, strings.ReplaceAll(r.rule.Text, `"`, `""`)), true
+	}
+	return "", false
+}
+
+// evalCondFmtFormula provides a function to evaluate the formula of the
+// conditional formatting rule by the calculation engine in the context of the
+// cell, the rule will be satisfied if the formula result is TRUE or a
+// non-zero number.
+func (f *File) evalCondFmtFormula(sheet, cell, formula string) bool {
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(strings.TrimPrefix(formula, "="))
+	if tokens == nil {
+		return false
+	}
+	result, err := f.evalInfixExp(&calcContext{
+		entry:           fmt.Sprintf("%s!%s", sheet, cell),
+		iterations:      make(map[string]uint),
+		iterationsCache: make(map[string]formulaArg),
+		definedNames:    make(map[string]struct{}),
+	}, sheet, cell, tokens)
+	if err != nil {
+		return false
+	}
+	if result.Type == ArgMatrix && len(result.Matrix) > 0 && len(result.Matrix[0]) > 0 {
+		result = result.Matrix[0][0]
+	}
+	switch result.Type {
+	case ArgNumber:
+		return result.Number != 0
+	case ArgString:
+		return strings.EqualFold(result.String, "TRUE")
+	}
+	return false
+}
+
+// evalCondFmtRangeRule provides a function to evaluate the top N, above or
+// below average, duplicate and unique values conditional formatting rules
+// by given rule, values of the cells in the range and value of the cell.
+func evalCondFmtRangeRule(rule *xlsxCfRule, values []string, value string) bool {
+	if rule.Type == "duplicateValues" || rule.Type == "uniqueValues" {
+		if value == "" {
+			return false
+		}
+		var count int
+		for _, v := range values {
+			if strings.EqualFold(v, value) {
+				count++
+			}
+		}
+		return (count > 1) == (rule.Type == "duplicateValues")
+	}
+	num, err := strconv.ParseFloat(value, 64)
+	numbers := condFmtNumbers(values)
+	if err != nil || len(numbers) == 0 {
+		return false
+	}
+	if rule.Type == "top10" {
+		rank := rule.Rank
+		if rule.Percent {
+			rank = int(float64(len(numbers)*rule.Rank) / 100)
+		}
+		if rank = int(math.Max(float64(rank), 1)); rank > len(numbers) {
+			rank = len(numbers)
+		}
+		sort.Float64s(numbers)
+		if rule.Bottom {
+			return num <= numbers[rank-1]
+		}
+		return num >= numbers[len(numbers)-rank]
+	}
+	var sum, variance float64
+	for _, n := range numbers {
+		sum += n
+	}
+	average := sum / float64(len(numbers))
+	for _, n := range numbers {
+		variance += (n - average) * (n - average)
+	}
+	threshold := average + float64(rule.StdDev)*math.Sqrt(variance/float64(len(numbers)))
+	if rule.AboveAverage != nil && !*rule.AboveAverage {
+		threshold = average - float64(rule.StdDev)*math.Sqrt(variance/float64(len(numbers)))
+		return num < threshold || (rule.EqualAverage && num == threshold)
+	}
+	return num > threshold || (rule.EqualAverage && num == threshold)
+}
+
+// condFmtColorScale provides a function to get the interpolated color of the
+// color scale conditional formatting rule by given color scale settings,
+// numeric values of the cells in the range and value of the cell.
+func condFmtColorScale(colorScale *xlsxColorScale, numbers []float64, value string) (string, bool) {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil || colorScale == nil || len(numbers) == 0 ||
+		len(colorScale.Cfvo) < 2 || len(colorScale.Cfvo) != len(colorScale.Color) {
+		return "", false
+	}
+	sort.Float64s(numbers)
+	points, colors := make([]float64, len(colorScale.Cfvo)), make([][]float64, len(colorScale.Cfvo))
+	for idx, cfvo := range colorScale.Cfvo {
+		points[idx] = condFmtCfvoValue(cfvo, numbers)
+		rgb := extractColorRGB(colorScale.Color[idx])
+		if len(rgb) != 6 {
+			return "", false
+		}
+		colors[idx] = make([]float64, 3)
+		for i := range colors[idx] {
+			c, _ := strconv.ParseUint(rgb[i*2:i*2+2], 16, 8)
+			colors[idx][i] = float64(c)
+		}
+	}
+	result := colors[len(colors)-1]
+	if num <= points[0] {
+		result = colors[0]
+	}
+	for idx := 1; idx < len(points); idx++ {
+		if num > points[idx-1] && num <= points[idx] {
+			ratio := (num - points[idx-1]) / (points[idx] - points[idx-1])
+			result = make([]float64, 3)
+			for i := range result {
+				result[i] = colors[idx-1][i] + (colors[idx][i]-colors[idx-1][i])*ratio
+			}
+			break
+		}
+	}
+	return fmt.Sprintf("%02X%02X%02X", int(math.Round(result[0])), int(math.Round(result[1])), int(math.Round(result[2]))), true
+}
+
+// condFmtCfvoValue provides a function to get the value of the conditional
+// format value object by given sorted numeric values of the cells in the
+// range.
+func condFmtCfvoValue(cfvo *xlsxCfvo, numbers []float64) float64 {
+	minValue, maxValue := numbers[0], numbers[len(numbers)-1]
+	val, _ := strconv.ParseFloat(cfvo.Val, 64)
+	switch cfvo.Type {
+	case "min", "autoMin":
+		val = minValue
+	case "max", "autoMax":
+		val = maxValue
+	case "percent":
+		val = minValue + (maxValue-minValue)*val/100
+	case "percentile":
+		rank := val / 100 * float64(len(numbers)-1)
+		lower := int(math.Max(math.Min(math.Floor(rank), float64(len(numbers)-1)), 0))
+		upper := int(math.Min(float64(lower+1), float64(len(numbers)-1)))
+		val = numbers[lower] + (numbers[upper]-numbers[lower])*(rank-float64(lower))
+	}
+	return val
+}
+
+// condFmtDataBar provides a function to get the color and the length
+// percentage of the data bar conditional formatting rule by given data bar
+// settings, numeric values of the cells in the range and value of the cell.
+func condFmtDataBar(dataBar *xlsxDataBar, numbers []float64, value string) (string, float64, bool) {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil || dataBar == nil || len(numbers) == 0 || len(dataBar.Cfvo) < 2 {
+		return "", 0, false
+	}
+	sort.Float64s(numbers)
+	minLength, maxLength := float64(dataBar.MinLength), float64(dataBar.MaxLength)
+	if minLength == 0 && maxLength == 0 {
+		minLength, maxLength = 10, 90
+	}
+	lower, upper := condFmtCfvoValue(dataBar.Cfvo[0], numbers), condFmtCfvoValue(dataBar.Cfvo[1], numbers)
+	length := maxLength
+	if num <= lower {
+		length = minLength
+	} else if num < upper {
+		length = minLength + (maxLength-minLength)*(num-lower)/(upper-lower)
+	}
+	var color string
+	if len(dataBar.Color) > 0 {
+		color = extractColorRGB(dataBar.Color[0])
+	}
+	return color, length, true
+}
+
+// condFmtIconSet provides a function to get the index of the icon of the icon
+// set conditional formatting rule by given icon set settings, numeric values
+// of the cells in the range and value of the cell, the icons are indexed from
+// the first icon of the set for the lowest values.
+func condFmtIconSet(iconSet *xlsxIconSet, numbers []float64, value string) (int, bool) {
+	num, err := strconv.ParseFloat(value, 64)
+	if err != nil || iconSet == nil || len(numbers) == 0 || len(iconSet.Cfvo) < 2 {
+		return 0, false
+	}
+	sort.Float64s(numbers)
+	var idx int
+	for i := 1; i < len(iconSet.Cfvo); i++ {
+		threshold := condFmtCfvoValue(iconSet.Cfvo[i], numbers)
+		if num > threshold || (num == threshold && (iconSet.Cfvo[i].Gte == nil || *iconSet.Cfvo[i].Gte)) {
+			idx = i
+		}
+	}
+	if iconSet.Reverse {
+		idx = len(iconSet.Cfvo) - 1 - idx
+	}
+	return idx, true
+}
+
+// mergeCondFmtStyle provides a function to merge the differential format of
+// the conditional formatting rule over the style definition.
+func mergeCondFmtStyle(style, format *Style) {
+	if format.Font != nil {
+		if style.Font == nil {
+			style.Font = &Font{}
+		}
+		style.Font.Bold = style.Font.Bold || format.Font.Bold
+		style.Font.Italic = style.Font.Italic || format.Font.Italic
+		style.Font.Strike = style.Font.Strike || format.Font.Strike
+		if format.Font.Underline != "" {
+			style.Font.Underline = format.Font.Underline
+		}
+		if format.Font.Color != "" || format.Font.ColorTheme != nil || format.Font.ColorIndexed != 0 {
+			style.Font.Color, style.Font.ColorIndexed = format.Font.Color, format.Font.ColorIndexed
+			style.Font.ColorTheme, style.Font.ColorTint = format.Font.ColorTheme, format.Font.ColorTint
+		}
+	}
+	if format.Fill.Type != "" {
+		style.Fill = format.Fill
+	}
+	for _, border := range format.Border {
+		var exist bool
+		for idx := range style.Border {
+			if exist = style.Border[idx].Type == border.Type; exist {
+				style.Border[idx] = border
+				break
+			}
+		}
+		if !exist {
+			style.Border = append(style.Border, border)
+		}
+	}
+	if format.NumFmt != 0 || format.CustomNumFmt != nil {
+		style.NumFmt, style.CustomNumFmt = format.NumFmt, format.CustomNumFmt
+	}
+	if format.Alignment != nil {
+		style.Alignment = format.Alignment
+	}
+	if format.Protection != nil {
+		style.Protection = format.Protection
+	}
+}
+
+// deleteX14CondFmts provides a function to remove the conditional formatting
+// extension rules by given worksheet, range reference and the rule IDs.
+func (f *File) deleteX14CondFmts(ws *xlsxWorksheet, rangeRef string, ruleIDs map[string]bool) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	var (
+		deleted      bool
+		exts         []*xlsxWorksheetExt
+		decodeExtLst = new(decodeWorksheetExt)
+	)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI != ExtURIConditionalFormattings {
+			exts = append(exts, ext)
+			continue
+		}
+		decodeCondFmts, rules := new(decodeX14ConditionalFormattings), new(decodeX14ConditionalFormattingRules)
+		if err := f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeCondFmts); err != nil && err != io.EOF {
+			return err
+		}
+		if err := f.xmlNewDecoder(strings.NewReader("<conditionalFormattings>" + decodeCondFmts.Content + "</conditionalFormattings>")).
+			Decode(rules); err != nil && err != io.EOF {
+			return err
+		}
+		var content strings.Builder
+		for _, condFmt := range rules.CondFmt {
+			removed := condFmt.SQRef == rangeRef
+			for _, rule := range condFmt.CfRule {
+				removed = removed || ruleIDs[rule.ID]
+			}
+			if deleted = deleted || removed; !removed {
+				content.WriteString(`<x14:conditionalFormatting xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `">`)
+				content.WriteString(condFmt.Content)
+				content.WriteString(`</x14:conditionalFormatting>`)
+			}
+		}
+		if content.Len() > 0 {
+			condFmtsBytes, _ := xml.Marshal(&xlsxX14ConditionalFormattings{Content: content.String()})
+			ext.Content = string(condFmtsBytes)
+			exts = append(exts, ext)
+		}
+	}
+	if !deleted {
+		return nil
+	}
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	decodeExtLst.Ext = exts
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
 }
 
 // drawCondFmtCellIs provides a function to create conditional formatting rule
@@ -2564,6 +4016,61 @@ func drawCondFmtCellIs(p int, ct, GUID string, format *ConditionalFormatOptions)
 	return c, nil
 }
 
+// drawCondFmtText provides a function to create conditional formatting rule
+// for specific text (include containing, not containing, begins with and ends
+// with) by given priority, criteria type and format settings.
+func drawCondFmtText(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	typ, ok := map[string]string{
+		"containsText": "containsText",
+		"notContains":  "notContainsText",
+		"beginsWith":   "beginsWith",
+		"endsWith":     "endsWith",
+	}[ct]
+	if !ok {
+		return nil, nil
+	}
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       typ,
+		Operator:   ct,
+		Text:       format.Value,
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
+// drawCondFmtTimePeriod provides a function to create conditional formatting
+// rule for dates occurring by given priority, criteria type and format
+// settings.
+func drawCondFmtTimePeriod(p int, ct, GUID string, format *ConditionalFormatOptions) (*xlsxCfRule, *xlsxX14CfRule) {
+	return &xlsxCfRule{
+		Priority:   p + 1,
+		StopIfTrue: format.StopIfTrue,
+		Type:       validType[format.Type],
+		TimePeriod: ct,
+		DxfID:      intPtr(format.Format),
+	}, nil
+}
+
+// setCondFmtRuleFormula provides a function to set the formula of the specific
+// text and dates occurring conditional formatting rule by given rule and range
+// reference, the formula references the top-left cell of the range. It will
+// return an error if the range reference is empty.
+func setCondFmtRuleFormula(rule *xlsxCfRule, rangeRef string) error {
+	refs := strings.Fields(rangeRef)
+	if len(refs) == 0 {
+		return ErrParameterInvalid
+	}
+	cell := strings.ReplaceAll(strings.Split(refs[0], ":")[0], "$", "")
+	if tmpl, ok := condFmtTextFormulas[rule.Type]; ok && rule.Text != "" {
+		rule.Formula = []string{fmt.Sprintf(tmpl, cell, strings.ReplaceAll(rule.Text, `"`, `""`))}
+	}
+	if tmpl, ok := condFmtTimePeriodFormulas[rule.TimePeriod]; ok && rule.Type == "timePeriod" {
+		rule.Formula = []string{fmt.Sprintf(tmpl, cell)}
+	}
+	return nil
+}
+
 // drawCondFmtTop10 provides a function to create conditional formatting rule
 // for top N (default is top 10) by given priority, criteria type and format
 // settings.
@@ -2592,6 +4099,7 @@ func drawCondFmtAboveAverage(p int, ct, GUID string, format *ConditionalFormatOp
 		StopIfTrue:   format.StopIfTrue,
 		Type:         validType[format.Type],
 		AboveAverage: boolPtr(format.AboveAverage),
+		StdDev:       format.StdDev,
 		DxfID:        intPtr(format.Format),
 	}, nil
 }
@@ -2736,18 +4244,78 @@ func drawCondFmtIconSet(p int, ct, GUID string, format *ConditionalFormatOptions
 		"5Quarters":       cfvo5,
 		"5Rating":         cfvo5,
 	}
-	cfRule, ok := presets[format.IconStyle]
+	iconStyle := format.IconStyle
+	if iconStyle == "" {
+		iconStyle = "3TrafficLights1"
+	}
+	cfRule, ok := presets[iconStyle]
 	if !ok {
 		return nil, nil
 	}
+	if len(format.IconThresholds) > 0 {
+		cfRule.IconSet.Cfvo = make([]*xlsxCfvo, len(format.IconThresholds))
+		for i, threshold := range format.IconThresholds {
+			cfRule.IconSet.Cfvo[i] = &xlsxCfvo{Type: threshold.Type, Val: threshold.Value}
+			if threshold.GreaterThan {
+				cfRule.IconSet.Cfvo[i].Gte = boolPtr(false)
+			}
+		}
+	}
 	cfRule.Priority = p + 1
-	cfRule.IconSet.IconSet = format.IconStyle
+	cfRule.IconSet.IconSet = iconStyle
 	cfRule.IconSet.Reverse = format.ReverseIcons
 	cfRule.IconSet.ShowValue = boolPtr(!format.IconsOnly)
 	cfRule.Type = validType[format.Type]
 	return cfRule, nil
 }
 
+// checkCondFmtOptions provides a function to check the conditional formatting
+// rule settings by given conditional formatting type and format settings.
+func checkCondFmtOptions(vt string, format *ConditionalFormatOptions) error {
+	switch vt {
+	case "top10":
+		if rank, err := strconv.Atoi(format.Value); err == nil && (rank < 1 || rank > 1000) {
+			return ErrCondFmtRank
+		}
+	case "aboveAverage":
+		if format.StdDev < 0 || format.StdDev > 3 {
+			return ErrCondFmtStdDev
+		}
+	case "iconSet":
+		return checkCondFmtIconThresholds(format)
+	case "timePeriod":
+		if _, ok := condFmtTimePeriodFormulas[criteriaType[format.Criteria]]; !ok {
+			return ErrCondFmtTimePeriod
+		}
+	}
+	return nil
+}
+
+// checkCondFmtIconThresholds provides a function to check the number and the
+// types of the custom thresholds of the icon set conditional formatting rule.
+func checkCondFmtIconThresholds(format *ConditionalFormatOptions) error {
+	if len(format.IconThresholds) == 0 {
+		return nil
+	}
+	iconStyle := format.IconStyle
+	if iconStyle == "" {
+		iconStyle = "3TrafficLights1"
+	}
+	icons, err := strconv.Atoi(iconStyle[:1])
+	if err != nil {
+		return ErrParameterInvalid
+	}
+	if len(format.IconThresholds) != icons {
+		return newIconThresholdsError(iconStyle, icons)
+	}
+	for _, threshold := range format.IconThresholds {
+		if inStrSlice([]string{"num", "percent", "percentile", "formula"}, threshold.Type, true) == -1 {
+			return ErrParameterInvalid
+		}
+	}
+	return nil
+}
+
 // getPaletteColor provides a function to convert the RBG color by given
 // string.
 func getPaletteColor(color string) string {
@@ -2768,6 +4336,109 @@ func (f *File) themeReader() (*xlsxTheme, error) {
 	return &theme, nil
 }
 
+// GetThemeFontScheme provides a function to get the major and minor fonts of
+// the theme font scheme in the workbook. The major fonts are used by the
+// heading styles, and the minor fonts are used by the body styles. For
+// example, get the Latin typeface of the body fonts:
+//
+//	scheme, err := f.GetThemeFontScheme()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	fmt.Println(scheme.Minor.Latin)
+func (f *File) GetThemeFontScheme() (ThemeFontScheme, error) {
+	var scheme ThemeFontScheme
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Theme == nil {
+		return scheme, ErrWorkbookTheme
+	}
+	fontScheme := f.Theme.ThemeElements.FontScheme
+	for _, fonts := range []struct {
+		collection *xlsxFontCollection
+		fonts      *ThemeFonts
+	}{
+		{&fontScheme.MajorFont, &scheme.Major},
+		{&fontScheme.MinorFont, &scheme.Minor},
+	} {
+		if fonts.collection.Latin != nil {
+			fonts.fonts.Latin = fonts.collection.Latin.Typeface
+		}
+		if fonts.collection.Ea != nil {
+			fonts.fonts.EastAsian = fonts.collection.Ea.Typeface
+		}
+		if fonts.collection.Cs != nil {
+			fonts.fonts.ComplexScript = fonts.collection.Cs.Typeface
+		}
+	}
+	return scheme, nil
+}
+
+// SetThemeFontScheme provides a function to set the major and minor fonts of
+// the theme font scheme in the workbook, this changes the heading and body
+// fonts workbook-wide. The typeface will be kept if the font name is empty.
+// The font name of the styles which reference the major or minor font scheme
+// will be updated to the Latin typeface of the scheme. For example, set the
+// heading font to "Georgia" and the body font to "Arial":
+//
+//	err := f.SetThemeFontScheme(excelize.ThemeFontScheme{
+//	    Major: excelize.ThemeFonts{Latin: "Georgia"},
+//	    Minor: excelize.ThemeFonts{Latin: "Arial"},
+//	})
+func (f *File) SetThemeFontScheme(scheme ThemeFontScheme) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Theme == nil {
+		return ErrWorkbookTheme
+	}
+	s, err := f.stylesReader()
+	if err != nil {
+		return err
+	}
+	fontScheme := &f.Theme.ThemeElements.FontScheme
+	for _, fonts := range []struct {
+		collection *xlsxFontCollection
+		fonts      ThemeFonts
+	}{
+		{&fontScheme.MajorFont, scheme.Major},
+		{&fontScheme.MinorFont, scheme.Minor},
+	} {
+		for _, font := range []struct {
+			textFont **xlsxCTTextFont
+			typeface string
+		}{
+			{&fonts.collection.Latin, fonts.fonts.Latin},
+			{&fonts.collection.Ea, fonts.fonts.EastAsian},
+			{&fonts.collection.Cs, fonts.fonts.ComplexScript},
+		} {
+			if font.typeface == "" {
+				continue
+			}
+			*font.textFont = &xlsxCTTextFont{Typeface: font.typeface}
+		}
+	}
+	if s.Fonts == nil {
+		return err
+	}
+	for _, font := range s.Fonts.Font {
+		if font == nil || font.Scheme == nil || font.Scheme.Val == nil {
+			continue
+		}
+		var latin *xlsxCTTextFont
+		switch *font.Scheme.Val {
+		case "major":
+			latin = fontScheme.MajorFont.Latin
+		case "minor":
+			latin = fontScheme.MinorFont.Latin
+		}
+		if latin != nil && latin.Typeface != "" {
+			font.Name = &attrValString{Val: stringPtr(latin.Typeface)}
+		}
+	}
+	return err
+}
+
 // ThemeColor applied the color with tint value.
 func ThemeColor(baseColor string, tint float64) string {
 	if tint == 0 {
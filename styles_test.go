@@ -1,10 +1,13 @@
 package excelize
 
 import (
+	"encoding/xml"
+	"fmt"
 	"math"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -192,6 +195,26 @@ func TestSetConditionalFormat(t *testing.T) {
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", condFmts), "XML syntax error on line 1: element <conditionalFormattings> closed by </conditionalFormatting>")
 	// Test creating a conditional format with invalid icon set style
 	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "A1:A2", []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "unknown"}}), ErrParameterInvalid.Error())
+	// Test creating a conditional format with whole column and row references
+	f = NewFile()
+	cellFmt := []ConditionalFormatOptions{{Type: "cell", Format: 1, Criteria: "greater than", Value: "6"}}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A:A", cellFmt))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "$D:$C 3:2 E5", cellFmt))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "A1:A1048576", ws.(*xlsxWorksheet).ConditionalFormatting[0].SQRef)
+	assert.Equal(t, "C1:D1048576 A2:XFD3 E5", ws.(*xlsxWorksheet).ConditionalFormatting[1].SQRef)
+	assert.Empty(t, ws.(*xlsxWorksheet).SheetData.Row)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]ConditionalFormatOptions{"A:A": cellFmt, "C:D 2:3 E5": cellFmt}, opts)
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A:A"))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:D1048576 A2:XFD3 E5"))
+	assert.Empty(t, ws.(*xlsxWorksheet).ConditionalFormatting)
+	// Test creating a conditional format with invalid whole column and row references
+	assert.Equal(t, ErrColumnNumber, f.SetConditionalFormat("Sheet1", "A:XFE", cellFmt))
+	assert.Equal(t, ErrMaxRows, f.SetConditionalFormat("Sheet1", "1:1048577", cellFmt))
+	assert.Equal(t, ErrColumnNumber, f.UnsetConditionalFormat("Sheet1", "A:XFE"))
 }
 
 func TestGetConditionalFormats(t *testing.T) {
@@ -201,6 +224,8 @@ func TestGetConditionalFormats(t *testing.T) {
 		{{Type: "top", Format: 1, Criteria: "=", Value: "6"}},
 		{{Type: "bottom", Format: 1, Criteria: "=", Value: "6"}},
 		{{Type: "average", AboveAverage: true, Format: 1, Criteria: "="}},
+		{{Type: "average", AboveAverage: false, StdDev: 2, Format: 1, Criteria: "="}},
+		{{Type: "top", Format: 1, Criteria: "=", Value: "5", Percent: true}},
 		{{Type: "duplicate", Format: 1, Criteria: "="}},
 		{{Type: "unique", Format: 1, Criteria: "="}},
 		{{Type: "3_color_scale", Criteria: "=", MinType: "num", MidType: "num", MaxType: "num", MinValue: "-10", MidValue: "50", MaxValue: "10", MinColor: "#FF0000", MidColor: "#00FF00", MaxColor: "#0000FF"}},
@@ -234,6 +259,45 @@ func TestUnsetConditionalFormat(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Format: format, Value: "6"}}))
 	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Empty(t, opts)
+	// Test unset conditional format with multiple blocks and extension rules
+	dataBar := ConditionalFormatOptions{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarBorderColor: "#0000FF", BarColor: "#638EC6", BarSolid: true}
+	for _, rangeRef := range []string{"A1:A10", "B1:B10", "A1:A10"} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", rangeRef, []ConditionalFormatOptions{dataBar}))
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 3, strings.Count(ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:conditionalFormatting "))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]ConditionalFormatOptions{"B1:B10": {dataBar}}, opts)
+	assert.Equal(t, 1, strings.Count(ws.(*xlsxWorksheet).ExtLst.Ext, "<x14:conditionalFormatting "))
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "B1:B10"))
+	assert.Empty(t, ws.(*xlsxWorksheet).ConditionalFormatting)
+	assert.Nil(t, ws.(*xlsxWorksheet).ExtLst)
+	// Test unset conditional format with extension rules by range reference
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: `<ext uri="` + ExtURISparklineGroups + `"><x14:sparklineGroups xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `"></x14:sparklineGroups></ext><ext uri="` + ExtURIConditionalFormattings + `"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0000-000000000001}"></x14:cfRule><xm:sqref>C1:C10</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`}
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:C10"))
+	assert.Equal(t, `<ext uri="`+ExtURISparklineGroups+`"><x14:sparklineGroups xmlns:xm="`+NameSpaceSpreadSheetExcel2006Main.Value+`"></x14:sparklineGroups></ext>`, ws.(*xlsxWorksheet).ExtLst.Ext)
+	// Test unset conditional format without matched extension rules will keep
+	// the extension list unchanged
+	extLst := `<ext uri="` + ExtURIConditionalFormattings + `" xmlns:x14="http://schemas.microsoft.com/office/spreadsheetml/2009/9/main"><x14:conditionalFormattings><x14:conditionalFormatting xmlns:xm="` + NameSpaceSpreadSheetExcel2006Main.Value + `"><x14:cfRule type="dataBar" id="{00000000-0000-0000-0000-000000000002}"/><xm:sqref>D1:D10</xm:sqref></x14:conditionalFormatting></x14:conditionalFormattings></ext>`
+	ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: extLst}
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:C10"))
+	assert.Equal(t, extLst, ws.(*xlsxWorksheet).ExtLst.Ext)
+	// Test unset conditional format with invalid extension list
+	for _, extLst := range []string{
+		"<ext><x14:conditionalFormattings>",
+		`<ext uri="` + ExtURIConditionalFormattings + `"><</ext>`,
+		`<ext uri="` + ExtURIConditionalFormattings + `"><x14:conditionalFormattings><x14:conditionalFormatting></x14:conditionalFormattings></ext>`,
+	} {
+		ws.(*xlsxWorksheet).ExtLst = &xlsxExtLst{Ext: extLst}
+		assert.Error(t, f.UnsetConditionalFormat("Sheet1", "A1:A10"))
+	}
+	ws.(*xlsxWorksheet).ExtLst = nil
 	// Test unset conditional format on not exists worksheet
 	assert.EqualError(t, f.UnsetConditionalFormat("SheetN", "A1:A10"), "sheet SheetN does not exist")
 	// Test unset conditional format with invalid sheet name
@@ -362,6 +426,88 @@ func TestNewConditionalStyle(t *testing.T) {
 	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestGetDxfs(t *testing.T) {
+	f := NewFile()
+	dxfs, err := f.GetDxfs()
+	assert.NoError(t, err)
+	assert.Empty(t, dxfs)
+	styles := []Style{
+		{
+			Font: &Font{Bold: true, Italic: true, Strike: true, Underline: "double", Family: "Arial", Size: 12, Color: "9A0511"},
+			Fill: Fill{Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1},
+		},
+		{
+			Fill:      Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 5},
+			Alignment: &Alignment{Horizontal: "center", Vertical: "top", WrapText: true, Indent: 1},
+			Border: []Border{
+				{Type: "left", Color: "0000FF", Style: 3},
+				{Type: "right", Color: "FF0000", Style: 6},
+				{Type: "top", Color: "00FF00", Style: 4},
+				{Type: "bottom", Color: "FFFF00", Style: 5},
+				{Type: "diagonalUp", Color: "A020F0", Style: 7},
+			},
+		},
+	}
+	for _, style := range styles {
+		_, err := f.NewConditionalStyle(&style)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: 1, Value: "6"},
+	}))
+	dxfs, err = f.GetDxfs()
+	assert.NoError(t, err)
+	assert.Equal(t, styles, dxfs)
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, styles[1], dxfs[opts["A1:A10"][0].Format])
+	// Test get differential formatting record referenced by the table style
+	tableStyle := Style{Font: &Font{Bold: true, Family: "Calibri", Size: 11, Color: "FFFFFF"}, Fill: Fill{Type: "pattern", Color: []string{"4472C4"}, Pattern: 1}}
+	wb := NewFile()
+	dxfID, err := wb.NewConditionalStyle(&tableStyle)
+	assert.NoError(t, err)
+	wb.Styles.TableStyles = &xlsxTableStyles{Count: 1, TableStyles: []*xlsxTableStyle{
+		{Name: "CustomTableStyle", Count: 1, Table: true, TableStyleElement: fmt.Sprintf(`<tableStyleElement type="headerRow" dxfId="%d"/>`, dxfID)},
+	}}
+	buf, err := wb.WriteToBuffer()
+	assert.NoError(t, err)
+	assert.NoError(t, wb.Close())
+	wb, err = OpenReader(buf)
+	assert.NoError(t, err)
+	s, err := wb.stylesReader()
+	assert.NoError(t, err)
+	var element struct {
+		DxfID int `xml:"dxfId,attr"`
+	}
+	assert.NoError(t, xml.Unmarshal([]byte(s.TableStyles.TableStyles[0].TableStyleElement), &element))
+	dxfs, err = wb.GetDxfs()
+	assert.NoError(t, err)
+	assert.Equal(t, tableStyle, dxfs[element.DxfID])
+	assert.NoError(t, wb.Close())
+	// Test get differential formatting records with number format and protection
+	f.Styles.Dxfs.Dxfs = append(f.Styles.Dxfs.Dxfs,
+		&xlsxDxf{Dxf: `<numFmt numFmtId="164" formatCode="0.00%"/><protection locked="1" hidden="0"/>`},
+		&xlsxDxf{Dxf: `<numFmt numFmtId="14"/><font><b/><u/><color theme="1" tint="0.5"/></font><fill><patternFill patternType="gray125"><fgColor rgb="FF00B050"/></patternFill></fill>`},
+	)
+	dxfs, err = f.GetDxfs()
+	assert.NoError(t, err)
+	assert.Equal(t, Style{CustomNumFmt: stringPtr("0.00%"), Protection: &Protection{Locked: true}}, dxfs[2])
+	assert.Equal(t, Style{
+		NumFmt: 14,
+		Font:   &Font{Bold: true, Underline: "single", ColorTheme: intPtr(1), ColorTint: 0.5},
+		Fill:   Fill{Type: "pattern", Pattern: 17, Color: []string{"00B050"}},
+	}, dxfs[3])
+	// Test get differential formatting records with invalid record
+	f.Styles.Dxfs.Dxfs = append(f.Styles.Dxfs.Dxfs, &xlsxDxf{Dxf: "<font>"})
+	_, err = f.GetDxfs()
+	assert.EqualError(t, err, "XML syntax error on line 1: element <font> closed by </dxf>")
+	// Test get differential formatting records with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetDxfs()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetDefaultFont(t *testing.T) {
 	f := NewFile()
 	s, err := f.GetDefaultFont()
@@ -408,6 +554,55 @@ func TestThemeReader(t *testing.T) {
 	assert.EqualValues(t, &xlsxTheme{XMLNSa: NameSpaceDrawingML.Value, XMLNSr: SourceRelationship.Value}, theme)
 }
 
+func TestThemeFontScheme(t *testing.T) {
+	f := NewFile()
+	scheme, err := f.GetThemeFontScheme()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeFontScheme{Major: ThemeFonts{Latin: "Calibri Light"}, Minor: ThemeFonts{Latin: "Calibri"}}, scheme)
+	// Test set the theme font scheme and the styles which reference the scheme fonts
+	styleID, err := f.NewStyle(&Style{Font: &Font{Family: "Times New Roman"}})
+	assert.NoError(t, err)
+	f.Styles.Fonts.Font = append(f.Styles.Fonts.Font,
+		&xlsxFont{Name: &attrValString{Val: stringPtr("Calibri Light")}, Scheme: &attrValString{Val: stringPtr("major")}},
+		&xlsxFont{Name: &attrValString{Val: stringPtr("Calibri")}, Scheme: &attrValString{Val: stringPtr("minor")}},
+	)
+	assert.NoError(t, f.SetThemeFontScheme(ThemeFontScheme{
+		Major: ThemeFonts{Latin: "Georgia"},
+		Minor: ThemeFonts{Latin: "Arial", EastAsian: "MS Gothic"},
+	}))
+	fonts := f.Styles.Fonts.Font
+	assert.Equal(t, "Georgia", *fonts[len(fonts)-2].Name.Val)
+	assert.Equal(t, "Arial", *fonts[len(fonts)-1].Name.Val)
+	// Test the font of the style without scheme doesn't change
+	fontID := *f.Styles.CellXfs.Xf[styleID].FontID
+	assert.Equal(t, "Times New Roman", *f.Styles.Fonts.Font[fontID].Name.Val)
+	path := filepath.Join("test", "TestThemeFontScheme.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	scheme, err = f.GetThemeFontScheme()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeFontScheme{Major: ThemeFonts{Latin: "Georgia"}, Minor: ThemeFonts{Latin: "Arial", EastAsian: "MS Gothic"}}, scheme)
+	// Test set the theme font scheme with empty font names
+	assert.NoError(t, f.SetThemeFontScheme(ThemeFontScheme{Major: ThemeFonts{ComplexScript: "Tahoma"}}))
+	scheme, err = f.GetThemeFontScheme()
+	assert.NoError(t, err)
+	assert.Equal(t, ThemeFontScheme{Major: ThemeFonts{Latin: "Georgia", ComplexScript: "Tahoma"}, Minor: ThemeFonts{Latin: "Arial", EastAsian: "MS Gothic"}}, scheme)
+	assert.NoError(t, f.Close())
+	// Test get and set the theme font scheme on the workbook without theme
+	f = NewFile()
+	f.Theme = nil
+	_, err = f.GetThemeFontScheme()
+	assert.Equal(t, ErrWorkbookTheme, err)
+	assert.Equal(t, ErrWorkbookTheme, f.SetThemeFontScheme(ThemeFontScheme{}))
+	// Test set the theme font scheme with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetThemeFontScheme(ThemeFontScheme{}), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestSetCellStyle(t *testing.T) {
 	f := NewFile()
 	// Test set cell style on not exists worksheet
@@ -422,6 +617,171 @@ func TestSetCellStyle(t *testing.T) {
 	assert.EqualError(t, f.SetCellStyle("Sheet1", "A1", "A2", 1), "XML syntax error on line 1: invalid UTF-8")
 }
 
+func TestNamedStyle(t *testing.T) {
+	f := NewFile()
+	// Test get the named cell style of the cell with default style
+	name, err := f.GetCellStyleName("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Normal", name)
+	// Test create and apply custom named cell style
+	styleID, err := f.NewNamedStyle("Highlight", &Style{Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "A1:B2", "Highlight"))
+	for _, cell := range []string{"A1", "B2"} {
+		name, err = f.GetCellStyleName("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, "Highlight", name)
+		cellStyleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, cellStyleID)
+	}
+	// Test apply the built-in named cell styles
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "C1", "Good"))
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "C2", "Good"))
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "D1", "Heading 1"))
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "E1", "Normal"))
+	for cell, expected := range map[string]string{"C1": "Good", "C2": "Good", "D1": "Heading 1", "E1": "Normal"} {
+		name, err = f.GetCellStyleName("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, name, cell)
+	}
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	assert.Len(t, styles.CellStyles.CellStyle, 4)
+	assert.Equal(t, 26, *styles.CellStyles.CellStyle[2].BuiltInID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestNamedStyle.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test preserve named cell styles after reopen
+	f, err = OpenFile(filepath.Join("test", "TestNamedStyle.xlsx"))
+	assert.NoError(t, err)
+	name, err = f.GetCellStyleName("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Good", name)
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "F1", "Highlight"))
+	cellStyleID, err := f.GetCellStyle("Sheet1", "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, styleID, cellStyleID)
+	// Test create named cell style with invalid parameters
+	_, err = f.NewNamedStyle("", &Style{})
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.NewNamedStyle("Style", nil)
+	assert.Equal(t, ErrParameterInvalid, err)
+	_, err = f.NewNamedStyle(strings.Repeat("c", MaxFieldLength+1), &Style{})
+	assert.Equal(t, ErrNameLength, err)
+	_, err = f.NewNamedStyle("Highlight", &Style{})
+	assert.EqualError(t, err, newNamedStyleExistError("Highlight").Error())
+	_, err = f.NewNamedStyle("Bad", &Style{})
+	assert.EqualError(t, err, newNamedStyleExistError("Bad").Error())
+	_, err = f.NewNamedStyle("Style", &Style{CustomNumFmt: stringPtr("")})
+	assert.Equal(t, ErrCustomNumFmt, err)
+	// Test apply named cell style with invalid parameters
+	assert.EqualError(t, f.SetCellStyleByName("Sheet1", "A1", "Style1"), newNoExistNamedStyleError("Style1").Error())
+	assert.Equal(t, ErrParameterInvalid, f.SetCellStyleByName("Sheet1", "A1:B2:C3", "Good"))
+	assert.EqualError(t, f.SetCellStyleByName("Sheet1", "A", "Good"), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	assert.EqualError(t, f.SetCellStyleByName("SheetN", "A1", "Good"), "sheet SheetN does not exist")
+	_, err = f.GetCellStyleName("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test apply named cell style which master formatting record doesn't exist
+	styles, err = f.stylesReader()
+	assert.NoError(t, err)
+	styles.CellStyles.CellStyle = append(styles.CellStyles.CellStyle, &xlsxCellStyle{Name: "Broken", XfID: 100})
+	assert.EqualError(t, f.SetCellStyleByName("Sheet1", "A1", "Broken"), newNoExistNamedStyleError("Broken").Error())
+	// Test get the name of the cell style without named cell styles
+	styles.CellStyles = nil
+	name, err = f.GetCellStyleName("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+	// Test create named cell style without named cell styles
+	styles.CellStyleXfs = nil
+	_, err = f.NewNamedStyle("Style2", &Style{})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyleByName("Sheet1", "A1", "Style2"))
+	// Test named cell style with exceeds the cell styles limit
+	styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles)
+	assert.Equal(t, ErrCellStyles, f.SetCellStyleByName("Sheet1", "A1", "Neutral"))
+	_, err = f.NewNamedStyle("Style3", &Style{})
+	assert.Equal(t, ErrCellStyles, err)
+	// Test create named cell style without leaving unused formatting records
+	styles.CellXfs.Xf = make([]xlsxXf, MaxCellStyles-1)
+	cellStyleXfs, cellStyles := len(styles.CellStyleXfs.Xf), len(styles.CellStyles.CellStyle)
+	_, err = f.NewNamedStyle("Style3", &Style{Font: &Font{Bold: true}})
+	assert.Equal(t, ErrCellStyles, err)
+	assert.Len(t, styles.CellXfs.Xf, MaxCellStyles-1)
+	assert.Len(t, styles.CellStyleXfs.Xf, cellStyleXfs)
+	assert.Len(t, styles.CellStyles.CellStyle, cellStyles)
+	assert.NoError(t, f.Close())
+	// Test named cell style with unsupported charset style sheet
+	for _, fn := range []func(f *File) error{
+		func(f *File) error { _, err := f.NewNamedStyle("Style", &Style{}); return err },
+		func(f *File) error { return f.SetCellStyleByName("Sheet1", "A1", "Good") },
+		func(f *File) error { _, err := f.GetCellStyleName("Sheet1", "A1"); return err },
+	} {
+		f = NewFile()
+		f.Styles = nil
+		f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+		assert.EqualError(t, fn(f), "XML syntax error on line 1: invalid UTF-8")
+	}
+}
+
+func TestIndentCell(t *testing.T) {
+	f := NewFile()
+	style, err := f.NewStyle(&Style{Font: &Font{Bold: true}, Alignment: &Alignment{Horizontal: "right", Vertical: "top"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B1", "B1", style))
+	getAlignment := func(cell string) *xlsxAlignment {
+		styleID, err := f.GetCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		return f.Styles.CellXfs.Xf[styleID].Alignment
+	}
+	// Test increase and decrease the indent of the cell without style
+	assert.NoError(t, f.IndentCell("Sheet1", "A1", 2))
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 2}, getAlignment("A1"))
+	assert.NoError(t, f.IndentCell("Sheet1", "A1", -1))
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left", Indent: 1}, getAlignment("A1"))
+	assert.NoError(t, f.IndentCell("Sheet1", "A1", -5))
+	assert.Equal(t, &xlsxAlignment{Horizontal: "left"}, getAlignment("A1"))
+	// Test reuse the exists style with the same indent
+	assert.NoError(t, f.IndentCell("Sheet1", "A2", 1))
+	assert.NoError(t, f.IndentCell("Sheet1", "A3", 1))
+	styleA2, err := f.GetCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	styleA3, err := f.GetCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, styleA2, styleA3)
+	// Test keep the other formatting of the cell
+	assert.NoError(t, f.IndentCell("Sheet1", "B1", 3))
+	assert.Equal(t, &xlsxAlignment{Horizontal: "right", Vertical: "top", Indent: 3}, getAlignment("B1"))
+	styleID, err := f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, *f.Styles.CellXfs.Xf[style].FontID, *f.Styles.CellXfs.Xf[styleID].FontID)
+	assert.NoError(t, f.IndentCell("Sheet1", "B1", -3))
+	styleID, err = f.GetCellStyle("Sheet1", "B1")
+	assert.NoError(t, err)
+	assert.Equal(t, style, styleID)
+	// Test increase the indent exceeds the limit
+	assert.NoError(t, f.IndentCell("Sheet1", "C1", maxCellIndent+1))
+	assert.Equal(t, maxCellIndent, getAlignment("C1").Indent)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestIndentCell.xlsx")))
+	// Test indent cell with invalid parameters
+	assert.EqualError(t, f.IndentCell("SheetN", "A1", 1), "sheet SheetN does not exist")
+	assert.EqualError(t, f.IndentCell("Sheet1", "A", 1), newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test indent cell with invalid style ID
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row[0].C[0].S = 1000
+	assert.EqualError(t, f.IndentCell("Sheet1", "A1", 1), newInvalidStyleID(1000).Error())
+	// Test indent cell with exceeds the cell styles limit
+	f.Styles.CellXfs.Xf = append(f.Styles.CellXfs.Xf, make([]xlsxXf, MaxCellStyles-len(f.Styles.CellXfs.Xf))...)
+	assert.Equal(t, ErrCellStyles, f.IndentCell("Sheet1", "D1", 7))
+	assert.NoError(t, f.Close())
+	// Test indent cell with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.IndentCell("Sheet1", "A1", 1), "XML syntax error on line 1: invalid UTF-8")
+}
+
 func TestGetStyleID(t *testing.T) {
 	f := NewFile()
 	styleID, err := f.getStyleID(&xlsxStyleSheet{}, nil)
@@ -472,3 +832,374 @@ func TestGetNumFmtID(t *testing.T) {
 	assert.NotEqual(t, id1, id2)
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStyleNumFmt.xlsx")))
 }
+
+func TestGetEffectiveCellStyle(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row * 20, row % 2, "item"}))
+	}
+	styleID, err := f.NewStyle(&Style{Font: &Font{Italic: true}, Fill: Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A5", styleID))
+	red, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	bold, err := f.NewConditionalStyle(&Style{Font: &Font{Bold: true, Color: "0000FF"}})
+	assert.NoError(t, err)
+	// Test get effective cell style without conditional formatting
+	style, err := f.GetEffectiveCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}, style.Fill)
+	assert.True(t, style.Font.Italic)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: red, Value: "50"},
+		{Type: "formula", Criteria: "=$B1=1", Format: bold},
+	}))
+	// Test merge formats of multiple satisfied rules
+	style, err = f.GetEffectiveCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}, style.Fill)
+	assert.True(t, style.Font.Italic)
+	assert.True(t, style.Font.Bold)
+	assert.Equal(t, "0000FF", style.Font.Color)
+	// Test the relative reference in the formula rule
+	style, err = f.GetEffectiveCellStyle("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.Equal(t, Fill{Type: "pattern", Color: []string{"FFFF00"}, Pattern: 1}, style.Fill)
+	assert.False(t, style.Font.Bold)
+	// Test the higher priority rule which stops if true
+	assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A5"))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "between", Format: red, MinValue: "50", MaxValue: "70", StopIfTrue: true},
+		{Type: "formula", Criteria: "=$B1=1", Format: bold},
+	}))
+	style, err = f.GetEffectiveCellStyle("Sheet1", "A3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FF0000"}, style.Fill.Color)
+	assert.False(t, style.Font.Bold)
+	style, err = f.GetEffectiveCellStyle("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FFFF00"}, style.Fill.Color)
+	assert.True(t, style.Font.Bold)
+	// Test color scale rule
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "D1:D3", []ConditionalFormatOptions{
+		{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#000000", MaxColor: "#FF8000"},
+	}))
+	for cell, value := range map[string]int{"D1": 0, "D2": 50, "D3": 100} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, color := range map[string]string{"D1": "000000", "D2": "804000", "D3": "FF8000"} {
+		style, err = f.GetEffectiveCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, Fill{Type: "pattern", Color: []string{color}, Pattern: 1}, style.Fill, cell)
+	}
+	// Test top N, above average and duplicate values rules
+	for _, c := range []struct {
+		rangeRef string
+		opts     ConditionalFormatOptions
+		expected []string
+	}{
+		{"A1:A5", ConditionalFormatOptions{Type: "top", Criteria: "=", Format: red, Value: "2"}, []string{"A4", "A5"}},
+		{"A1:A5", ConditionalFormatOptions{Type: "bottom", Criteria: "=", Format: red, Value: "40", Percent: true}, []string{"A1", "A2"}},
+		{"A1:A5", ConditionalFormatOptions{Type: "average", Criteria: "=", Format: red, AboveAverage: true}, []string{"A4", "A5"}},
+		{"A1:A5", ConditionalFormatOptions{Type: "average", Criteria: "=", Format: red}, []string{"A1", "A2"}},
+		{"C1:C5", ConditionalFormatOptions{Type: "duplicate", Criteria: "=", Format: red}, []string{"C1", "C2", "C3", "C4", "C5"}},
+		{"C1:C5", ConditionalFormatOptions{Type: "unique", Criteria: "=", Format: red}, nil},
+	} {
+		assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "A1:A5"))
+		assert.NoError(t, f.UnsetConditionalFormat("Sheet1", "C1:C5"))
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", c.rangeRef, []ConditionalFormatOptions{c.opts}))
+		var matched []string
+		for row := 1; row <= 5; row++ {
+			cell := fmt.Sprintf("%s%d", c.rangeRef[:1], row)
+			style, err = f.GetEffectiveCellStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			if len(style.Fill.Color) > 0 && style.Fill.Color[0] == "FF0000" {
+				matched = append(matched, cell)
+			}
+		}
+		assert.Equal(t, c.expected, matched, c.opts.Type)
+	}
+	// Test text rules without formulas
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ConditionalFormatting = []*xlsxConditionalFormatting{
+		{SQRef: "C1:C5", CfRule: []*xlsxCfRule{{Type: "beginsWith", Text: "it", DxfID: intPtr(red), Priority: 1}}},
+		{SQRef: "C1", CfRule: []*xlsxCfRule{{Type: "iconSet", Priority: 2}, {Type: "timePeriod", DxfID: intPtr(bold), Priority: 3}}},
+		{SQRef: "C2", CfRule: []*xlsxCfRule{{Type: "cellIs", Operator: "unknown", DxfID: intPtr(bold), Priority: 1}}},
+		{SQRef: "C3", CfRule: []*xlsxCfRule{{Type: "expression", Formula: []string{"SUM("}, DxfID: intPtr(bold), Priority: 1}}},
+		{SQRef: "C4", CfRule: []*xlsxCfRule{{Type: "expression", DxfID: intPtr(100), Priority: 1, Formula: []string{"TRUE"}}}},
+	}
+	for _, cell := range []string{"C1", "C2", "C3", "C4"} {
+		style, err = f.GetEffectiveCellStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"FF0000"}, style.Fill.Color, cell)
+		assert.False(t, style.Font.Bold)
+	}
+	// Test get effective cell style with invalid cell reference
+	_, err = f.GetEffectiveCellStyle("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get effective cell style on not exists worksheet
+	_, err = f.GetEffectiveCellStyle("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test get effective cell style with unsupported charset style sheet
+	f = NewFile()
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetEffectiveCellStyle("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetConditionalFormatsStyle(t *testing.T) {
+	f := NewFile()
+	red, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	preset, err := f.NewConditionalStyle(&Style{
+		Font: &Font{Color: "9C0006"},
+		Fill: Fill{Type: "pattern", Color: []string{"FFC7CE"}, Pattern: 1},
+	})
+	assert.NoError(t, err)
+	border, err := f.NewConditionalStyle(&Style{Border: []Border{
+		{Type: "left", Color: "9C0006", Style: 1}, {Type: "right", Color: "9C0006", Style: 1},
+		{Type: "top", Color: "9C0006", Style: 1}, {Type: "bottom", Color: "9C0006", Style: 1},
+	}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: red, Value: "6"},
+		{Type: "cell", Criteria: "<", Format: preset, Value: "0"},
+		{Type: "duplicate", Criteria: "=", Format: border},
+		{Type: "unique", Criteria: "=", Format: 10},
+	}))
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	opts := formats["A1:A10"]
+	assert.Len(t, opts, 4)
+	// Test get the fill color of the red fill rule after read-back
+	assert.Equal(t, red, opts[0].Format)
+	assert.Equal(t, []string{"FF0000"}, opts[0].FormatStyle.Fill.Color)
+	assert.Empty(t, opts[0].FormatPreset)
+	// Test get the preset names of the built-in differential formats
+	assert.Equal(t, "9C0006", opts[1].FormatStyle.Font.Color)
+	assert.Equal(t, "Light Red Fill with Dark Red Text", opts[1].FormatPreset)
+	assert.Equal(t, "Red Border", opts[2].FormatPreset)
+	// Test get the conditional format with not exist differential format
+	assert.Nil(t, opts[3].FormatStyle)
+	assert.Empty(t, opts[3].FormatPreset)
+	// Test the conditional formats round-trip with the SetConditionalFormat
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", opts))
+	formats, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, opts, formats["B1:B10"])
+	// Test get conditional formats with invalid differential format
+	f.Styles.Dxfs.Dxfs[red].Dxf = "<font>"
+	_, err = f.GetConditionalFormats("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: element <font> closed by </dxf>")
+	// Test get conditional formats with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	_, err = f.GetConditionalFormats("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellConditionalFormatVisual(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row * 20, row * 20, row * 20, "item"}))
+	}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A5", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarOnly: true},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B5", []ConditionalFormatOptions{
+		{Type: "icon_set", IconStyle: "3Arrows"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C1:C5", []ConditionalFormatOptions{
+		{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true},
+	}))
+	// Test get data bar of the cells
+	for cell, length := range map[string]float64{"A1": 10, "A3": 50, "A5": 90} {
+		visual, err := f.GetCellConditionalFormatVisual("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, visual.DataBar, cell)
+		assert.False(t, visual.IconSet, cell)
+		assert.False(t, visual.ShowValue, cell)
+		assert.Equal(t, "638EC6", visual.BarColor, cell)
+		assert.InDelta(t, length, visual.BarLength, 1e-9, cell)
+	}
+	// Test get icon of the cells
+	for cell, idx := range map[string]int{"B1": 0, "B3": 1, "B5": 2, "C1": 2, "C5": 0} {
+		visual, err := f.GetCellConditionalFormatVisual("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, visual.IconSet, cell)
+		assert.True(t, visual.ShowValue, cell)
+		assert.Equal(t, "3Arrows", visual.IconStyle, cell)
+		assert.Equal(t, idx, visual.IconIndex, cell)
+	}
+	// Test get visual of the cell without conditional formatting
+	visual, err := f.GetCellConditionalFormatVisual("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, ConditionalFormatVisual{ShowValue: true}, visual)
+	// Test get visual of the cell with stop if true rule
+	red, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule = append([]*xlsxCfRule{
+		{Type: "cellIs", Operator: "greaterThan", DxfID: &red, Priority: 0, StopIfTrue: true, Formula: []string{"50"}},
+	}, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule...)
+	visual, err = f.GetCellConditionalFormatVisual("Sheet1", "A5")
+	assert.NoError(t, err)
+	assert.False(t, visual.DataBar)
+	visual, err = f.GetCellConditionalFormatVisual("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, visual.DataBar)
+	// Test get visual with invalid cell reference
+	_, err = f.GetCellConditionalFormatVisual("Sheet1", "A")
+	assert.EqualError(t, err, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")).Error())
+	// Test get visual on not exists worksheet
+	_, err = f.GetCellConditionalFormatVisual("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetConditionalFormatTopAverage(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "top", Criteria: "=", Format: 1, Value: "5", Percent: true},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{
+		{Type: "average", Criteria: "=", Format: 1, AboveAverage: true, StdDev: 1},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	top10 := ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0]
+	assert.Equal(t, "top10", top10.Type)
+	assert.Equal(t, 5, top10.Rank)
+	assert.True(t, top10.Percent)
+	aboveAverage := ws.(*xlsxWorksheet).ConditionalFormatting[1].CfRule[0]
+	assert.Equal(t, "aboveAverage", aboveAverage.Type)
+	assert.True(t, *aboveAverage.AboveAverage)
+	assert.Equal(t, 1, aboveAverage.StdDev)
+	// Test set the top and average conditional formats with invalid rank and standard deviation
+	for _, rank := range []string{"0", "1001"} {
+		assert.Equal(t, ErrCondFmtRank, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+			{Type: "bottom", Criteria: "=", Format: 1, Value: rank},
+		}))
+	}
+	for _, stdDev := range []int{-1, 4} {
+		assert.Equal(t, ErrCondFmtStdDev, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{
+			{Type: "average", Criteria: "=", Format: 1, StdDev: stdDev},
+		}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestSetConditionalFormatTextTimePeriod(t *testing.T) {
+	f := NewFile()
+	for row, value := range []interface{}{"AN ERROR OCCURRED", "OK", `A<"B"&`, time.Now().AddDate(0, 0, -3), time.Now().AddDate(0, 0, -10)} {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row+1), value))
+	}
+	red, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	format := []ConditionalFormatOptions{{Type: "text", Criteria: "containing", Format: red, Value: "ERROR"}}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A3", format))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A4:A5", []ConditionalFormatOptions{{Type: "time_period", Criteria: "last 7 days", Format: red}}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "$B:$B", []ConditionalFormatOptions{{Type: "text", Criteria: "not containing", Format: red, Value: `A<"B"&`}}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxCfRule{
+		Type: "containsText", DxfID: intPtr(red), Priority: 1, Operator: "containsText", Text: "ERROR",
+		Formula: []string{`ISNUMBER(SEARCH("ERROR",A1))`},
+	}, ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0])
+	assert.Equal(t, &xlsxCfRule{
+		Type: "timePeriod", DxfID: intPtr(red), Priority: 1, TimePeriod: "last7Days",
+		Formula: []string{"AND(TODAY()-FLOOR(A4,1)<=6,FLOOR(A4,1)<=TODAY())"},
+	}, ws.(*xlsxWorksheet).ConditionalFormatting[1].CfRule[0])
+	assert.Equal(t, []string{`ISERROR(SEARCH("A<""B""&",B1))`}, ws.(*xlsxWorksheet).ConditionalFormatting[2].CfRule[0].Formula)
+	// Test the search text in the XML was escaped
+	output, err := xml.Marshal(ws.(*xlsxWorksheet).ConditionalFormatting[2].CfRule[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `text="A&lt;&#34;B&#34;&amp;"`)
+	assert.Contains(t, string(output), `<formula>ISERROR(SEARCH(&#34;A&lt;&#34;&#34;B&#34;&#34;&amp;&#34;,B1))</formula>`)
+	// Test get the conditional formats
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	redStyle := &Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}}
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "text", Criteria: "containing", Format: red, FormatStyle: redStyle, Value: "ERROR"}}, opts["A1:A3"])
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "time_period", Criteria: "last 7 days", Format: red, FormatStyle: redStyle}}, opts["A4:A5"])
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "text", Criteria: "not containing", Format: red, FormatStyle: redStyle, Value: `A<"B"&`}}, opts["B:B"])
+	// Test set the text and dates occurring conditional formats with invalid criteria
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{Type: "text", Criteria: "between", Format: red, Value: "ERROR"}}))
+	for _, criteria := range []string{"", ">", "someday"} {
+		assert.Equal(t, ErrCondFmtTimePeriod, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{Type: "time_period", Criteria: criteria, Format: red}}))
+	}
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatTextTimePeriod.xlsx")))
+	// Test set the conditional formats with empty range reference
+	f = NewFile()
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "", []ConditionalFormatOptions{{Type: "cell", Criteria: ">", Value: "1"}}))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "", format))
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "", []ConditionalFormatOptions{{Type: "time_period", Criteria: "last 7 days", Format: red}}))
+}
+
+func TestSetConditionalFormatIconThresholds(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("A%d", row), row*10))
+	}
+	format := []ConditionalFormatOptions{{
+		Type: "icon_set", IconStyle: "5Arrows", ReverseIcons: true,
+		IconThresholds: []ConditionalFormatIconThreshold{
+			{Type: "num", Value: "0"},
+			{Type: "num", Value: "20"},
+			{Type: "percent", Value: "50"},
+			{Type: "percentile", Value: "75"},
+			{Type: "num", Value: "90", GreaterThan: true},
+		},
+	}}
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", format))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	iconSet := ws.(*xlsxWorksheet).ConditionalFormatting[0].CfRule[0].IconSet
+	assert.Equal(t, "5Arrows", iconSet.IconSet)
+	assert.True(t, iconSet.Reverse)
+	assert.Len(t, iconSet.Cfvo, 5)
+	assert.Equal(t, &xlsxCfvo{Type: "percentile", Val: "75"}, iconSet.Cfvo[3])
+	assert.Equal(t, &xlsxCfvo{Gte: boolPtr(false), Type: "num", Val: "90"}, iconSet.Cfvo[4])
+	opts, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, format, opts["A1:A10"])
+	// Test get icon of the cells with custom thresholds
+	for cell, idx := range map[string]int{"A1": 4, "A2": 3, "A6": 2, "A8": 1, "A9": 1, "A10": 0} {
+		visual, err := f.GetCellConditionalFormatVisual("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.True(t, visual.IconSet, cell)
+		assert.Equal(t, idx, visual.IconIndex, cell)
+	}
+	// Test set icon set with default icon style and thresholds
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B1:B10", []ConditionalFormatOptions{{Type: "icon_set"}}))
+	iconSet = ws.(*xlsxWorksheet).ConditionalFormatting[1].CfRule[0].IconSet
+	assert.Equal(t, "3TrafficLights1", iconSet.IconSet)
+	assert.Equal(t, []*xlsxCfvo{{Type: "percent", Val: "0"}, {Type: "percent", Val: "33"}, {Type: "percent", Val: "67"}}, iconSet.Cfvo)
+	opts, err = f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []ConditionalFormatOptions{{Type: "icon_set", IconStyle: "3TrafficLights1"}}, opts["B1:B10"])
+	// Test set icon set with mismatched number of thresholds
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{
+		Type: "icon_set", IconStyle: "4Arrows", IconThresholds: format[0].IconThresholds,
+	}}), "the icon set 4Arrows requires 4 thresholds")
+	assert.EqualError(t, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{
+		Type: "icon_set", IconThresholds: format[0].IconThresholds[:2],
+	}}), "the icon set 3TrafficLights1 requires 3 thresholds")
+	// Test set icon set with invalid threshold type
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{
+		Type: "icon_set", IconStyle: "3Arrows", IconThresholds: []ConditionalFormatIconThreshold{
+			{Type: "num", Value: "0"}, {Type: "min"}, {Type: "num", Value: "10"},
+		},
+	}}))
+	// Test set icon set with invalid icon style and custom thresholds
+	assert.Equal(t, ErrParameterInvalid, f.SetConditionalFormat("Sheet1", "C1:C10", []ConditionalFormatOptions{{
+		Type: "icon_set", IconStyle: "unknown", IconThresholds: format[0].IconThresholds,
+	}}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetConditionalFormatIconThresholds.xlsx")))
+}
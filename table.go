@@ -28,6 +28,21 @@ var (
 	conditionFormat  = regexp.MustCompile(`(or|\|\|)`)
 	blankFormat      = regexp.MustCompile("blanks|nonblanks")
 	matchFormat      = regexp.MustCompile("[*?]")
+	// autoFilterDynamicTypes defined the list of valid dynamic filter types
+	// of the auto filter.
+	autoFilterDynamicTypes = []string{
+		"aboveAverage", "belowAverage", "today", "yesterday", "tomorrow",
+		"thisWeek", "lastWeek", "nextWeek", "thisMonth", "lastMonth",
+		"nextMonth", "thisQuarter", "lastQuarter", "nextQuarter", "thisYear",
+		"lastYear", "nextYear", "yearToDate", "Q1", "Q2", "Q3", "Q4", "M1",
+		"M2", "M3", "M4", "M5", "M6", "M7", "M8", "M9", "M10", "M11", "M12",
+	}
+	// tableTotalsRowFunctions defined the totals row functions of the table
+	// and the function numbers of the SUBTOTAL function.
+	tableTotalsRowFunctions = map[string]int{
+		"none": 0, "average": 101, "countNums": 102, "count": 103, "max": 104,
+		"min": 105, "stdDev": 107, "sum": 109, "var": 110, "custom": 0,
+	}
 )
 
 // parseTableOptions provides a function to parse the format settings of the
@@ -43,6 +58,14 @@ func parseTableOptions(opts *Table) (*Table, error) {
 	if err = checkDefinedName(opts.Name); err != nil {
 		return opts, err
 	}
+	if !opts.TotalsRow && len(opts.TotalsRowColumns) > 0 {
+		return opts, ErrParameterInvalid
+	}
+	for _, column := range opts.TotalsRowColumns {
+		if column.Label != "" && ((column.Function != "" && column.Function != "none") || column.Formula != "") {
+			return opts, ErrParameterInvalid
+		}
+	}
 	return opts, err
 }
 
@@ -79,6 +102,60 @@ func parseTableOptions(opts *Table) (*Table, error) {
 //	TableStyleLight1 - TableStyleLight21
 //	TableStyleMedium1 - TableStyleMedium28
 //	TableStyleDark1 - TableStyleDark11
+//
+// CalculatedColumns: The calculated columns of the table, the column is
+// specified by the header name, and the formula will be applied to all data
+// rows of the column. The relative references in the formula are relative to
+// the first data row of the table, so each row computes from its own row. For
+// example, create a table of A1:C5 with the calculated column "Total" which
+// multiplies the values of the column A and B in each row:
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range: "A1:C5",
+//	    CalculatedColumns: []excelize.TableCalculatedColumn{
+//	        {Name: "Total", Formula: "A2*B2"},
+//	    },
+//	})
+//
+// TotalsRow: Set as true to show the totals row as the last row of the table
+// range, the totals row should be beneath at least one data row, and the
+// range will be extended if the table doesn't have enough rows.
+//
+// TotalsRowColumns: The totals row settings of the table columns, the column
+// is specified by the header name, and it only works when the TotalsRow is
+// true. The Label sets the text of the totals row cell, and the Function sets
+// the aggregate function, which will be calculated by the SUBTOTAL function
+// with the structured reference of the table column. The Label and Function
+// could not be set for the same column. The Formula is only used by the
+// custom function. The following are the available functions:
+//
+//	none
+//	average
+//	count
+//	countNums
+//	max
+//	min
+//	stdDev
+//	sum
+//	var
+//	custom
+//
+// For example, create a table of A1:C6 with the totals row on the row 6, and
+// sum the column "Sales" and average the column "Price":
+//
+//	err := f.AddTable("Sheet1", &excelize.Table{
+//	    Range:     "A1:C6",
+//	    TotalsRow: true,
+//	    TotalsRowColumns: []excelize.TableTotalsRowColumn{
+//	        {Name: "Region", Label: "Total"},
+//	        {Name: "Sales", Function: "sum"},
+//	        {Name: "Price", Function: "average"},
+//	    },
+//	})
+//
+// AutoFilter: The filter criteria of the table columns, the same as the
+// options of the AutoFilter function, and the columns should be in the range
+// of the table. The auto filter will be ignored if the header row is hidden.
 func (f *File) AddTable(sheet string, table *Table) error {
 	options, err := parseTableOptions(table)
 	if err != nil {
@@ -125,13 +202,15 @@ func (f *File) AddTable(sheet string, table *Table) error {
 	return f.addContentTypePart(tableID, "table")
 }
 
-// countTables provides a function to get table files count storage in the
-// folder xl/tables.
+// countTables provides a function to get the maximum index of the table
+// files storage in the folder xl/tables.
 func (f *File) countTables() int {
 	count := 0
 	f.Pkg.Range(func(k, v interface{}) bool {
-		if strings.Contains(k.(string), "xl/tables/table") {
-			count++
+		if name := k.(string); strings.HasPrefix(name, "xl/tables/table") {
+			if idx, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/tables/table"), ".xml")); idx > count {
+				count = idx
+			}
 		}
 		return true
 	})
@@ -189,6 +268,235 @@ func (f *File) setTableHeader(sheet string, showHeaderRow bool, x1, y1, x2 int)
 	return tableColumns, nil
 }
 
+// setTableCalculatedColumns provides a function to set the formula of the
+// calculated columns for the table columns, and set the shared formula to the
+// cells of the calculated columns in the data rows of the table.
+func (f *File) setTableCalculatedColumns(sheet string, tableColumns []*xlsxTableColumn, x1, y1, y2 int, columns []TableCalculatedColumn) error {
+	for _, column := range columns {
+		idx := -1
+		for i, tableColumn := range tableColumns {
+			if strings.EqualFold(tableColumn.Name, column.Name) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return newNoExistTableColumnError(column.Name)
+		}
+		formula := strings.TrimPrefix(column.Formula, "=")
+		tableColumns[idx].CalculatedColumnFormula = &xlsxTableFormula{Content: formula}
+		ref, err := f.coordinatesToRangeRef([]int{x1 + idx, y1, x1 + idx, y2})
+		if err != nil {
+			return err
+		}
+		cell := strings.Split(ref, ":")[0]
+		formulaType := STCellFormulaTypeShared
+		if err = f.SetCellFormula(sheet, cell, formula, FormulaOpts{Type: &formulaType, Ref: &ref}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setTableTotalsRow provides a function to set the totals row functions and
+// labels for the table columns, and set the formulas and labels to the cells
+// of the totals row of the table.
+func (f *File) setTableTotalsRow(sheet, name string, tableColumns []*xlsxTableColumn, x1, row int, columns []TableTotalsRowColumn) error {
+	for _, column := range columns {
+		idx := -1
+		for i, tableColumn := range tableColumns {
+			if strings.EqualFold(tableColumn.Name, column.Name) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return newNoExistTableColumnError(column.Name)
+		}
+		function := column.Function
+		if function == "" && column.Formula != "" {
+			function = "custom"
+		}
+		num, ok := tableTotalsRowFunctions[function]
+		if function != "" && !ok {
+			return newInvalidTableTotalsRowFunctionError(column.Function)
+		}
+		cell, err := CoordinatesToCellName(x1+idx, row)
+		if err != nil {
+			return err
+		}
+		if column.Label != "" {
+			tableColumns[idx].TotalsRowLabel = column.Label
+			if err = f.SetCellStr(sheet, cell, column.Label); err != nil {
+				return err
+			}
+		}
+		if function == "" || function == "none" {
+			continue
+		}
+		tableColumns[idx].TotalsRowFunction = function
+		formula := fmt.Sprintf("SUBTOTAL(%d,%s[%s])", num, name, escapeTableColumnName(tableColumns[idx].Name))
+		if function == "custom" {
+			if formula = strings.TrimPrefix(column.Formula, "="); formula == "" {
+				return ErrParameterInvalid
+			}
+			tableColumns[idx].TotalsRowFormula = &xlsxTableFormula{Content: formula}
+		}
+		if err = f.SetCellFormula(sheet, cell, formula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// escapeTableColumnName provides a function to escape the special characters
+// of the table column name in the structured reference.
+func escapeTableColumnName(name string) string {
+	var b strings.Builder
+	for _, c := range name {
+		if strings.ContainsRune("[]#'", c) {
+			b.WriteRune('\'')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// GetTables provides the method to get all tables in a worksheet by given
+// worksheet name. The calculated columns of the tables will be returned with
+// the formulas relative to the first data row of the table. The range of the
+// returned table includes the totals row, and the returned table can be used
+// to create the table with the same settings by the AddTable function. The
+// filter criteria of the table will be returned as the AutoFilter options,
+// the single or double equality expressions will be returned as the Values.
+// For example, get the tables on Sheet1:
+//
+//	tables, err := f.GetTables("Sheet1")
+func (f *File) GetTables(sheet string) ([]Table, error) {
+	var tables []Table
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return tables, err
+	}
+	if ws.TableParts == nil {
+		return tables, err
+	}
+	for _, tbl := range ws.TableParts.TableParts {
+		target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
+		tableXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+		content := f.readXML(tableXML)
+		if len(content) == 0 {
+			continue
+		}
+		var t xlsxTable
+		if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+			Decode(&t); err != nil && err != io.EOF {
+			return tables, err
+		}
+		table := Table{
+			Range:         t.Ref,
+			Name:          t.Name,
+			ShowHeaderRow: boolPtr(t.HeaderRowCount == nil || *t.HeaderRowCount != 0),
+		}
+		if t.TableStyleInfo != nil {
+			table.StyleName = t.TableStyleInfo.Name
+			table.ShowColumnStripes = t.TableStyleInfo.ShowColumnStripes
+			table.ShowFirstColumn = t.TableStyleInfo.ShowFirstColumn
+			table.ShowLastColumn = t.TableStyleInfo.ShowLastColumn
+			table.ShowRowStripes = boolPtr(t.TableStyleInfo.ShowRowStripes)
+		}
+		table.TotalsRow = t.TotalsRowCount > 0
+		if t.AutoFilter != nil {
+			if coordinates, err := rangeRefToCoordinates(t.AutoFilter.Ref); err == nil {
+				_ = sortCoordinates(coordinates)
+				table.AutoFilter = getAutoFilterOptions(t.AutoFilter, coordinates[0])
+			}
+		}
+		if t.TableColumns != nil {
+			for _, column := range t.TableColumns.TableColumn {
+				if column.CalculatedColumnFormula != nil {
+					table.CalculatedColumns = append(table.CalculatedColumns, TableCalculatedColumn{
+						Name: column.Name, Formula: column.CalculatedColumnFormula.Content,
+					})
+				}
+				if column.TotalsRowFunction != "" || column.TotalsRowLabel != "" {
+					totalsRowColumn := TableTotalsRowColumn{
+						Name: column.Name, Label: column.TotalsRowLabel, Function: column.TotalsRowFunction,
+					}
+					if column.TotalsRowFormula != nil {
+						totalsRowColumn.Formula = column.TotalsRowFormula.Content
+					}
+					table.TotalsRowColumns = append(table.TotalsRowColumns, totalsRowColumn)
+				}
+			}
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// DeleteTable provides the method to delete the table by given table name,
+// the table name is case-insensitive. The table part, the relationship and
+// content type of the table will be removed, and the cells data of the table
+// will be kept. For example, delete the table named "Table1":
+//
+//	err := f.DeleteTable("Table1")
+func (f *File) DeleteTable(name string) error {
+	if err := checkDefinedName(name); err != nil {
+		return err
+	}
+	content, err := f.contentTypesReader()
+	if err != nil {
+		return err
+	}
+	worksheets := make(map[string]struct{})
+	content.mu.Lock()
+	for _, override := range content.Overrides {
+		if override.ContentType == ContentTypeSpreadSheetMLWorksheet {
+			worksheets[override.PartName] = struct{}{}
+		}
+	}
+	content.mu.Unlock()
+	for _, sheet := range f.GetSheetList() {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		if _, ok := worksheets["/"+sheetXMLPath]; !ok {
+			// Chartsheet, macrosheet or dialogsheet
+			continue
+		}
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			return err
+		}
+		if ws.TableParts == nil {
+			continue
+		}
+		for idx, tbl := range ws.TableParts.TableParts {
+			target := f.getSheetRelationshipsTargetByID(sheet, tbl.RID)
+			tableXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+			content := f.readXML(tableXML)
+			if len(content) == 0 {
+				continue
+			}
+			var t xlsxTable
+			if err = f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content))).
+				Decode(&t); err != nil && err != io.EOF {
+				return err
+			}
+			if !strings.EqualFold(t.Name, name) {
+				continue
+			}
+			ws.TableParts.TableParts = append(ws.TableParts.TableParts[:idx], ws.TableParts.TableParts[idx+1:]...)
+			if ws.TableParts.Count = len(ws.TableParts.TableParts); ws.TableParts.Count == 0 {
+				ws.TableParts = nil
+			}
+			f.deleteSheetRelationships(sheet, tbl.RID)
+			f.Pkg.Delete(tableXML)
+			return f.deleteSheetFromContentTypes("/" + tableXML)
+		}
+	}
+	return newNoExistTableError(name)
+}
+
 // checkDefinedName check whether there are illegal characters in the defined
 // name or table name. Verify that the name:
 // 1. Starts with a letter or underscore (_)
@@ -229,10 +537,33 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		return err
 	}
 	tableColumns, _ := f.setTableHeader(sheet, !hideHeaderRow, x1, y1, x2)
+	dataRow, lastDataRow := y1, y2
+	if !hideHeaderRow {
+		dataRow++
+	}
+	if opts.TotalsRow {
+		// The totals row should be beneath at least one data row.
+		if lastDataRow = y2 - 1; lastDataRow < dataRow {
+			lastDataRow, y2 = dataRow, dataRow+1
+			if ref, err = f.coordinatesToRangeRef([]int{x1, y1, x2, y2}); err != nil {
+				return err
+			}
+		}
+	}
+	if err = f.setTableCalculatedColumns(sheet, tableColumns, x1, dataRow, lastDataRow, opts.CalculatedColumns); err != nil {
+		return err
+	}
 	name := opts.Name
 	if name == "" {
 		name = "Table" + strconv.Itoa(i)
 	}
+	filterRef := ref
+	if opts.TotalsRow {
+		if err = f.setTableTotalsRow(sheet, name, tableColumns, x1, y2, opts.TotalsRowColumns); err != nil {
+			return err
+		}
+		filterRef, _ = f.coordinatesToRangeRef([]int{x1, y1, x2, lastDataRow})
+	}
 	t := xlsxTable{
 		XMLNS:       NameSpaceSpreadSheet.Value,
 		ID:          i,
@@ -240,7 +571,7 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 		DisplayName: name,
 		Ref:         ref,
 		AutoFilter: &xlsxAutoFilter{
-			Ref: ref,
+			Ref: filterRef,
 		},
 		TableColumns: &xlsxTableColumns{
 			Count:       len(tableColumns),
@@ -254,6 +585,12 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 			ShowColumnStripes: opts.ShowColumnStripes,
 		},
 	}
+	if opts.TotalsRow {
+		t.TotalsRowCount, t.TotalsRowShown = 1, true
+	}
+	if err = f.setAutoFilterColumns(t.AutoFilter, x2-x1, x1, opts.AutoFilter); err != nil {
+		return err
+	}
 	if hideHeaderRow {
 		t.AutoFilter = nil
 		t.HeaderRowCount = intPtr(0)
@@ -334,6 +671,54 @@ func (f *File) addTable(sheet, tableXML string, x1, y1, x2, y2, i int, opts *Tab
 //	x     < 2000
 //	col   < 2000
 //	Price < 2000
+//
+// The value which contains spaces or double quotes should be enclosed in
+// double quotes, and the double quotes in the value should be escaped by
+// doubling them, for example:
+//
+//	x == "North East" or x == "Say ""Hi"""
+//
+// Filter data between two dates by the serial number of the dates in the
+// expression, for example, filter the dates in the year 2023:
+//
+//	x >= 44927 and x <= 45291
+//
+// Values defines a list of values to filter by, the same as the check boxes
+// of the filter in Excel, an empty string in the list filters the blank
+// cells. Top10 defines the top or bottom N items or percent filter, and the
+// DynamicFilter defines the filter criteria which changes with the data or
+// the current system date. Only one of the Expression, Values, Top10 and
+// DynamicFilter can be set for each column. Multiple columns filter criteria
+// are combined with the 'and' logic. For example, show the rows where the
+// column B is "East" or "West", and the column C is greater than 2000:
+//
+//	err := f.AutoFilter("Sheet1", "A1:D10", []excelize.AutoFilterOptions{
+//	    {Column: "B", Values: []string{"East", "West"}},
+//	    {Column: "C", Expression: "x > 2000"},
+//	})
+//
+// The following are the available types of the dynamic filter:
+//
+//	aboveAverage
+//	belowAverage
+//	today
+//	yesterday
+//	tomorrow
+//	thisWeek
+//	lastWeek
+//	nextWeek
+//	thisMonth
+//	lastMonth
+//	nextMonth
+//	thisQuarter
+//	lastQuarter
+//	nextQuarter
+//	thisYear
+//	lastYear
+//	nextYear
+//	yearToDate
+//	Q1 - Q4
+//	M1 - M12
 func (f *File) AutoFilter(sheet, rangeRef string, opts []AutoFilterOptions) error {
 	coordinates, err := rangeRefToCoordinates(rangeRef)
 	if err != nil {
@@ -394,10 +779,27 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 		Ref: ref,
 	}
 	ws.AutoFilter = filter
+	return f.setAutoFilterColumns(filter, columns, col, opts)
+}
+
+// setAutoFilterColumns provides a function to set the filter columns of the
+// auto filter by given number of columns, the first column number of the
+// filter range and auto filter options.
+func (f *File) setAutoFilterColumns(filter *xlsxAutoFilter, columns, col int, opts []AutoFilterOptions) error {
+	filterColumns := map[int]bool{}
 	for _, opt := range opts {
-		if opt.Column == "" || opt.Expression == "" {
+		criteria := 0
+		for _, set := range []bool{opt.Expression != "", len(opt.Values) > 0, opt.Top10 != nil, opt.DynamicFilter != ""} {
+			if set {
+				criteria++
+			}
+		}
+		if opt.Column == "" || criteria == 0 {
 			continue
 		}
+		if criteria > 1 {
+			return ErrAutoFilterCriteria
+		}
 		fsCol, err := ColumnNameToNumber(opt.Column)
 		if err != nil {
 			return err
@@ -406,19 +808,116 @@ func (f *File) autoFilter(sheet, ref string, columns, col int, opts []AutoFilter
 		if offset < 0 || offset > columns {
 			return fmt.Errorf("incorrect index of column '%s'", opt.Column)
 		}
-		fc := &xlsxFilterColumn{ColID: offset}
-		token := expressionFormat.FindAllString(opt.Expression, -1)
-		if len(token) != 3 && len(token) != 7 {
-			return fmt.Errorf("incorrect number of tokens in criteria '%s'", opt.Expression)
+		if filterColumns[offset] {
+			return newDuplicateAutoFilterColumnError(opt.Column)
 		}
-		expressions, tokens, err := f.parseFilterExpression(opt.Expression, token)
-		if err != nil {
+		filterColumns[offset] = true
+		fc := &xlsxFilterColumn{ColID: offset}
+		if err = f.writeAutoFilterCriteria(fc, opt); err != nil {
 			return err
 		}
-		f.writeAutoFilter(fc, expressions, tokens)
 		filter.FilterColumn = append(filter.FilterColumn, fc)
 	}
-	ws.AutoFilter = filter
+	return nil
+}
+
+// getAutoFilterOptions provides a function to get the auto filter options by
+// given auto filter and the first column number of the filter range.
+func getAutoFilterOptions(filter *xlsxAutoFilter, col int) []AutoFilterOptions {
+	var opts []AutoFilterOptions
+	operators := map[string]string{
+		"lessThan": "<", "equal": "==", "lessThanOrEqual": "<=",
+		"greaterThan": ">", "notEqual": "!=", "greaterThanOrEqual": ">=",
+	}
+	for _, fc := range filter.FilterColumn {
+		column, err := ColumnNumberToName(col + fc.ColID)
+		if err != nil {
+			continue
+		}
+		opt := AutoFilterOptions{Column: column}
+		if fc.Filters != nil {
+			for _, v := range fc.Filters.Filter {
+				opt.Values = append(opt.Values, v.Val)
+			}
+			if fc.Filters.Blank {
+				opt.Values = append(opt.Values, "")
+			}
+		}
+		if fc.CustomFilters != nil {
+			var expressions []string
+			for _, cf := range fc.CustomFilters.CustomFilter {
+				operator, ok := operators[cf.Operator]
+				if !ok {
+					operator = "=="
+				}
+				expressions = append(expressions, fmt.Sprintf("x %s %s", operator, quoteFilterToken(cf.Val)))
+			}
+			conditional := " or "
+			if fc.CustomFilters.And {
+				conditional = " and "
+			}
+			opt.Expression = strings.Join(expressions, conditional)
+		}
+		if fc.Top10 != nil {
+			opt.Top10 = &AutoFilterTop10{Bottom: !fc.Top10.Top, Percent: fc.Top10.Percent, Val: fc.Top10.Val}
+		}
+		if fc.DynamicFilter != nil {
+			opt.DynamicFilter = fc.DynamicFilter.Type
+		}
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+// quoteFilterToken provides a function to quote the value of the custom
+// filter in the filter expression with double quotes if it is empty, or
+// contains spaces, double quotes or the blanks keywords, so that it can be
+// parsed as a single token.
+func quoteFilterToken(token string) string {
+	if token != "" && !strings.ContainsAny(token, "\" \t\n\r") && !blankFormat.MatchString(strings.ToLower(token)) {
+		return token
+	}
+	return "\"" + strings.ReplaceAll(token, "\"", "\"\"") + "\""
+}
+
+// writeAutoFilterCriteria provides a function to write the filter criteria
+// of the auto filter column by given auto filter options.
+func (f *File) writeAutoFilterCriteria(fc *xlsxFilterColumn, opt AutoFilterOptions) error {
+	if len(opt.Values) > 0 {
+		fc.Filters = &xlsxFilters{}
+		for _, val := range opt.Values {
+			if val == "" {
+				fc.Filters.Blank = true
+				continue
+			}
+			fc.Filters.Filter = append(fc.Filters.Filter, &xlsxFilter{Val: val})
+		}
+		return nil
+	}
+	if opt.Top10 != nil {
+		if opt.Top10.Val < 1 || opt.Top10.Val > 500 || (opt.Top10.Percent && opt.Top10.Val > 100) {
+			return ErrAutoFilterTop10
+		}
+		fc.Top10 = &xlsxTop10{Top: !opt.Top10.Bottom, Percent: opt.Top10.Percent, Val: opt.Top10.Val}
+		return nil
+	}
+	if opt.DynamicFilter != "" {
+		idx := inStrSlice(autoFilterDynamicTypes, opt.DynamicFilter, false)
+		if idx == -1 {
+			return newInvalidAutoFilterDynamicError(opt.DynamicFilter)
+		}
+		fc.DynamicFilter = &xlsxDynamicFilter{Type: autoFilterDynamicTypes[idx]}
+		return nil
+	}
+	token := expressionFormat.FindAllString(opt.Expression, -1)
+	if len(token) != 3 && len(token) != 7 {
+		return fmt.Errorf("incorrect number of tokens in criteria '%s'", opt.Expression)
+	}
+	expressions, tokens, err := f.parseFilterExpression(opt.Expression, token)
+	if err != nil {
+		return err
+	}
+	f.writeAutoFilter(fc, expressions, tokens)
 	return nil
 }
 
@@ -533,9 +1032,14 @@ func (f *File) parseFilterTokens(expression string, tokens []string) ([]int, str
 		return []int{}, "", newUnknownFilterTokenError(tokens[1])
 	}
 	token := tokens[2]
+	// Strip the double quotes of the quoted token.
+	quoted := len(token) > 1 && strings.HasPrefix(token, "\"") && strings.HasSuffix(token, "\"")
+	if quoted {
+		token = strings.ReplaceAll(token[1:len(token)-1], "\"\"", "\"")
+	}
 	// Special handling for Blanks/NonBlanks.
 	re := blankFormat.MatchString(strings.ToLower(token))
-	if re {
+	if re && !quoted {
 		// Only allow Equals or NotEqual in this context.
 		if operator != 2 && operator != 5 {
 			return []int{operator}, token, fmt.Errorf("the operator '%s' in expression '%s' is not valid in relation to Blanks/NonBlanks'", tokens[1], expression)
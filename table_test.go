@@ -74,6 +74,297 @@ func TestAddTable(t *testing.T) {
 	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2"}))
 }
 
+func TestAddTableCalculatedColumns(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Price", "Quantity", "Total"}))
+	for row := 2; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{row * 10, row}))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:C5", Name: "Sales", StyleName: "TableStyleMedium2",
+		CalculatedColumns: []TableCalculatedColumn{{Name: "total", Formula: "=A2*B2"}},
+	}))
+	// Test the formula of the calculated column was applied to all data rows
+	for row := 2; row <= 5; row++ {
+		cell := fmt.Sprintf("C%d", row)
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("A%d*B%d", row, row), formula, cell)
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(row*10*row), result, cell)
+	}
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<tableColumn id="3" name="Total"><calculatedColumnFormula>A2*B2</calculatedColumnFormula></tableColumn>`)
+	// Test add table with calculated columns without header row
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "E2:F4", ShowHeaderRow: boolPtr(false),
+		CalculatedColumns: []TableCalculatedColumn{{Name: "Column2", Formula: "E3+1"}},
+	}))
+	formula, err := f.GetCellFormula("Sheet1", "F4")
+	assert.NoError(t, err)
+	assert.Equal(t, "E4+1", formula)
+	content, ok = f.Pkg.Load("xl/tables/table2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `<tableColumn id="2" name="Column2"><calculatedColumnFormula>E3+1</calculatedColumnFormula></tableColumn>`)
+	// Test add table with not exist calculated column
+	assert.EqualError(t, f.AddTable("Sheet1", &Table{
+		Range: "H1:I3", CalculatedColumns: []TableCalculatedColumn{{Name: "Amount", Formula: "H2*2"}},
+	}), "table column Amount does not exist")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddTableTotalsRow(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Sales", "Price", "Sales [Q1]"}))
+	for row := 2; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{"East", row * 100, row, row}))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "A1:D6", Name: "Sales", TotalsRow: true,
+		TotalsRowColumns: []TableTotalsRowColumn{
+			{Name: "Region", Label: "Total"},
+			{Name: "sales", Function: "sum"},
+			{Name: "Price", Function: "average"},
+			{Name: "Sales [Q1]", Formula: "=SUM(Sales[Sales])/2"},
+		},
+	}))
+	for cell, expected := range map[string]string{
+		"B6": "SUBTOTAL(109,Sales[Sales])",
+		"C6": "SUBTOTAL(101,Sales[Price])",
+		"D6": "SUM(Sales[Sales])/2",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	label, err := f.GetCellValue("Sheet1", "A6")
+	assert.NoError(t, err)
+	assert.Equal(t, "Total", label)
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	for _, expected := range []string{
+		`ref="A1:D6" totalsRowCount="1" totalsRowShown="true"`,
+		`<autoFilter ref="A1:D5"></autoFilter>`,
+		`<tableColumn id="1" name="Region" totalsRowLabel="Total"></tableColumn>`,
+		`<tableColumn id="2" name="Sales" totalsRowFunction="sum"></tableColumn>`,
+		`<tableColumn id="3" name="Price" totalsRowFunction="average"></tableColumn>`,
+		`<tableColumn id="4" name="Sales [Q1]" totalsRowFunction="custom"><totalsRowFormula>SUM(Sales[Sales])/2</totalsRowFormula></tableColumn>`,
+	} {
+		assert.Contains(t, string(content.([]byte)), expected)
+	}
+	// Test add table with the totals row and the escaped column name
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "F1:F1", TotalsRow: true,
+		TotalsRowColumns: []TableTotalsRowColumn{{Name: "Column1", Function: "count"}},
+	}))
+	assert.NoError(t, f.SetCellStr("Sheet1", "H1", "Sales [Q1]"))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{
+		Range: "H1:H3", TotalsRow: true,
+		TotalsRowColumns: []TableTotalsRowColumn{{Name: "Sales [Q1]", Function: "max"}},
+	}))
+	formula, err := f.GetCellFormula("Sheet1", "H3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(104,Table3[Sales '[Q1']])", formula)
+	content, ok = f.Pkg.Load("xl/tables/table2.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `ref="F1:F3" totalsRowCount="1"`)
+	formula, err = f.GetCellFormula("Sheet1", "F3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUBTOTAL(103,Table2[Column1])", formula)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddTableTotalsRow.xlsx")))
+	// Test add table with invalid totals row settings
+	for _, cols := range []struct {
+		columns []TableTotalsRowColumn
+		err     string
+	}{
+		{columns: []TableTotalsRowColumn{{Name: "Column1", Function: "median"}}, err: "unsupported totals row function 'median'"},
+		{columns: []TableTotalsRowColumn{{Name: "Column1", Function: "custom"}}, err: ErrParameterInvalid.Error()},
+		{columns: []TableTotalsRowColumn{{Name: "Amount", Function: "sum"}}, err: "table column Amount does not exist"},
+	} {
+		assert.EqualError(t, f.AddTable("Sheet1", &Table{
+			Range: "J1:J3", TotalsRow: true, TotalsRowColumns: cols.columns,
+		}), cols.err)
+	}
+	// Test add table with the totals row column label and function
+	assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{
+		Range: "J1:J3", TotalsRow: true,
+		TotalsRowColumns: []TableTotalsRowColumn{{Name: "Column1", Label: "Total", Function: "sum"}},
+	}))
+	assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{
+		Range: "J1:J3", TotalsRow: true,
+		TotalsRowColumns: []TableTotalsRowColumn{{Name: "Column1", Label: "Total", Formula: "=1"}},
+	}))
+	// Test add table with the totals row columns without totals row
+	assert.Equal(t, ErrParameterInvalid, f.AddTable("Sheet1", &Table{
+		Range: "J1:J3", TotalsRowColumns: []TableTotalsRowColumn{{Name: "Column1", Function: "sum"}},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetTables(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "B2", &[]interface{}{"Region", "Sales", "Date"}))
+	for row := 3; row <= 6; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("B%d", row), &[]interface{}{"East", row * 100, 45000 + row}))
+	}
+	expected := Table{
+		Range: "B2:D7", Name: "Sales", StyleName: "TableStyleMedium9",
+		ShowColumnStripes: true, ShowHeaderRow: boolPtr(true), ShowRowStripes: boolPtr(false),
+		TotalsRow:        true,
+		TotalsRowColumns: []TableTotalsRowColumn{{Name: "Region", Label: "Total"}, {Name: "Sales", Function: "sum"}},
+		AutoFilter: []AutoFilterOptions{
+			{Column: "B", Values: []string{"East", "West"}},
+			{Column: "C", Expression: "x > 300 and x <= 500"},
+			{Column: "D", DynamicFilter: "thisYear"},
+		},
+	}
+	table := expected
+	table.Range = "D7:B2"
+	assert.NoError(t, f.AddTable("Sheet1", &table))
+	tables, err := f.GetTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []Table{expected}, tables)
+	// Test the returned table can be used to create the same table
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet2", "B2", &[]interface{}{"Region", "Sales", "Date"}))
+	tables[0].Name = "Sales2"
+	assert.NoError(t, f.AddTable("Sheet2", &tables[0]))
+	copied, err := f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, tables, copied)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetTables.xlsx")))
+	// Test get tables with calculated columns
+	assert.NoError(t, f.AddTable("Sheet2", &Table{
+		Range: "F2:G4", ShowHeaderRow: boolPtr(false),
+		CalculatedColumns: []TableCalculatedColumn{{Name: "Column2", Formula: "=F3*2"}},
+	}))
+	tables, err = f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Equal(t, Table{
+		Range: "F3:G4", Name: "Table3", ShowHeaderRow: boolPtr(false), ShowRowStripes: boolPtr(true),
+		CalculatedColumns: []TableCalculatedColumn{{Name: "Column2", Formula: "F3*2"}},
+	}, tables[1])
+	// Test get tables on the worksheet without tables
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	tables, err = f.GetTables("Sheet3")
+	assert.NoError(t, err)
+	assert.Empty(t, tables)
+	// Test get tables with not exist worksheet
+	_, err = f.GetTables("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test get tables with missing table part
+	f.Pkg.Delete("xl/tables/table3.xml")
+	tables, err = f.GetTables("Sheet2")
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+	// Test get tables with unsupported charset table parts
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetTables("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+func TestGetAutoFilterOptions(t *testing.T) {
+	f := NewFile()
+	// Test add table with invalid auto filter
+	assert.EqualError(t, f.AddTable("Sheet1", &Table{
+		Range: "F2:G4", AutoFilter: []AutoFilterOptions{{Column: "H", Values: []string{"East"}}},
+	}), "incorrect index of column 'H'")
+	// Test auto filter options round-trip with the quoted values
+	for _, expression := range []string{
+		`x != "North East" and x != "Say ""Hi"""`,
+		`x == "blanks" or x > 300`,
+		`x != ""`,
+	} {
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:B5", []AutoFilterOptions{{Column: "A", Expression: expression}}))
+		ws, err := f.workSheetReader("Sheet1")
+		assert.NoError(t, err)
+		filter := ws.AutoFilter.FilterColumn[0]
+		opts := getAutoFilterOptions(ws.AutoFilter, 1)
+		assert.Equal(t, []AutoFilterOptions{{Column: "A", Expression: expression}}, opts)
+		assert.NoError(t, f.AutoFilter("Sheet1", "A1:B5", opts))
+		assert.Equal(t, filter, ws.AutoFilter.FilterColumn[0])
+	}
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:B5", []AutoFilterOptions{{Column: "A", Expression: `x != "North East"`}}))
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, &xlsxCustomFilter{Operator: "notEqual", Val: "North East"}, ws.AutoFilter.FilterColumn[0].CustomFilters.CustomFilter[0])
+	// Test get auto filter options with invalid column
+	assert.Empty(t, getAutoFilterOptions(&xlsxAutoFilter{FilterColumn: []*xlsxFilterColumn{{ColID: MaxColumns}}}, 1))
+	assert.Equal(t, []AutoFilterOptions{{Column: "A", Expression: "x == 1 or x == 2"}}, getAutoFilterOptions(&xlsxAutoFilter{
+		FilterColumn: []*xlsxFilterColumn{{CustomFilters: &xlsxCustomFilters{CustomFilter: []*xlsxCustomFilter{
+			{Val: "1"}, {Operator: "equal", Val: "2"},
+		}}}},
+	}, 1))
+	assert.NoError(t, f.Close())
+}
+
+func TestDeleteTable(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Region", "Sales"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"East", 100}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2", Name: "Sales"}))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "D1:E2"}))
+	assert.NoError(t, f.AddTable("Sheet2", &Table{Range: "A1:B2"}))
+	// Test delete the table and keep the cells data
+	assert.NoError(t, f.DeleteTable("Sales"))
+	_, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.False(t, ok)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, 1, ws.(*xlsxWorksheet).TableParts.Count)
+	rels, err := f.relsReader("xl/worksheets/_rels/sheet1.xml.rels")
+	assert.NoError(t, err)
+	for _, rel := range rels.Relationships {
+		assert.NotEqual(t, "../tables/table1.xml", rel.Target)
+	}
+	contentTypes, err := f.contentTypesReader()
+	assert.NoError(t, err)
+	for _, override := range contentTypes.Overrides {
+		assert.NotEqual(t, "/xl/tables/table1.xml", override.PartName)
+	}
+	val, err := f.GetCellValue("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", val)
+	// Test add table with the name of the deleted table
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:B2", Name: "Sales"}))
+	content, ok := f.Pkg.Load("xl/tables/table4.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(content.([]byte)), `name="Sales"`)
+	assert.Equal(t, 2, ws.(*xlsxWorksheet).TableParts.Count)
+	// Test delete the last table of the worksheet
+	assert.NoError(t, f.DeleteTable("TABLE3"))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Nil(t, ws.(*xlsxWorksheet).TableParts)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestDeleteTable.xlsx")))
+	// Test delete not exists table in the workbook with chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type: Col, Series: []ChartSeries{{Categories: "Sheet1!$A$2", Values: "Sheet1!$B$2"}},
+	}))
+	assert.EqualError(t, f.DeleteTable("Table3"), "table Table3 does not exist")
+	// Test delete table with invalid table name
+	assert.EqualError(t, f.DeleteTable("Table 1"), newInvalidNameError("Table 1").Error())
+	// Test delete table with unsupported charset table part
+	f.Pkg.Store("xl/tables/table2.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteTable("Table2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete table with unsupported charset worksheet
+	f.Sheet.Delete("xl/worksheets/sheet1.xml")
+	f.Pkg.Store("xl/worksheets/sheet1.xml", MacintoshCyrillicCharset)
+	f.checked = nil
+	assert.EqualError(t, f.DeleteTable("Table2"), "XML syntax error on line 1: invalid UTF-8")
+	// Test delete table with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.DeleteTable("Table2"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestSetTableHeader(t *testing.T) {
 	f := NewFile()
 	_, err := f.setTableHeader("Sheet1", true, 1, 0, 1)
@@ -149,6 +440,54 @@ func TestAutoFilterError(t *testing.T) {
 	}}), `incorrect number of tokens in criteria '-'`)
 }
 
+func TestAutoFilterCriteria(t *testing.T) {
+	f := NewFile()
+	// Test value list filter combined with a "greater than" expression
+	assert.NoError(t, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+		{Column: "B", Values: []string{"East", "West", ""}},
+		{Column: "C", Expression: "x > 2000"},
+		{Column: "D", Top10: &AutoFilterTop10{Bottom: true, Percent: true, Val: 10}},
+		{Column: "A", DynamicFilter: "thismonth"},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	filter := ws.(*xlsxWorksheet).AutoFilter
+	assert.Equal(t, "$A$1:$D$10", filter.Ref)
+	assert.Len(t, filter.FilterColumn, 4)
+	assert.Equal(t, &xlsxFilterColumn{ColID: 1, Filters: &xlsxFilters{
+		Blank: true, Filter: []*xlsxFilter{{Val: "East"}, {Val: "West"}},
+	}}, filter.FilterColumn[0])
+	assert.Equal(t, &xlsxFilterColumn{ColID: 2, CustomFilters: &xlsxCustomFilters{
+		CustomFilter: []*xlsxCustomFilter{{Operator: "greaterThan", Val: "2000"}},
+	}}, filter.FilterColumn[1])
+	assert.Equal(t, &xlsxFilterColumn{ColID: 3, Top10: &xlsxTop10{Percent: true, Val: 10}}, filter.FilterColumn[2])
+	assert.Equal(t, &xlsxFilterColumn{ColID: 0, DynamicFilter: &xlsxDynamicFilter{Type: "thisMonth"}}, filter.FilterColumn[3])
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAutoFilterCriteria.xlsx")))
+	// Test auto filter with more than one filter criteria on a column
+	assert.Equal(t, ErrAutoFilterCriteria, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+		{Column: "B", Values: []string{"East"}, Expression: "x == West"},
+	}))
+	// Test auto filter with duplicate filter column
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+		{Column: "B", Values: []string{"East"}},
+		{Column: "b", Expression: "x == West"},
+	}), "duplicate filter criteria of column 'b'")
+	// Test auto filter with the column out of the filter range
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+		{Column: "E", Values: []string{"East"}},
+	}), "incorrect index of column 'E'")
+	// Test auto filter with invalid top 10 filter value
+	for _, top10 := range []*AutoFilterTop10{{}, {Val: 501}, {Percent: true, Val: 101}} {
+		assert.Equal(t, ErrAutoFilterTop10, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+			{Column: "D", Top10: top10},
+		}))
+	}
+	// Test auto filter with invalid dynamic filter type
+	assert.EqualError(t, f.AutoFilter("Sheet1", "A1:D10", []AutoFilterOptions{
+		{Column: "A", DynamicFilter: "M13"},
+	}), "unsupported dynamic filter type 'M13'")
+}
+
 func TestParseFilterTokens(t *testing.T) {
 	f := NewFile()
 	// Test with unknown operator
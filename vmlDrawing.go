@@ -46,7 +46,7 @@ type xlsxShape struct {
 	ID          string   `xml:"id,attr"`
 	Type        string   `xml:"type,attr"`
 	Style       string   `xml:"style,attr"`
-	Fillcolor   string   `xml:"fillcolor,attr"`
+	Fillcolor   string   `xml:"fillcolor,attr,omitempty"`
 	Insetmode   string   `xml:"urn:schemas-microsoft-com:office:office insetmode,attr,omitempty"`
 	Strokecolor string   `xml:"strokecolor,attr,omitempty"`
 	Val         string   `xml:",innerxml"`
@@ -54,12 +54,15 @@ type xlsxShape struct {
 
 // xlsxShapetype directly maps the shapetype element.
 type xlsxShapetype struct {
-	ID        string      `xml:"id,attr"`
-	Coordsize string      `xml:"coordsize,attr"`
-	Spt       int         `xml:"o:spt,attr"`
-	Path      string      `xml:"path,attr"`
-	Stroke    *xlsxStroke `xml:"v:stroke"`
-	VPath     *vPath      `xml:"v:path"`
+	ID             string      `xml:"id,attr"`
+	Coordsize      string      `xml:"coordsize,attr"`
+	Spt            int         `xml:"o:spt,attr"`
+	Preferrelative string      `xml:"o:preferrelative,attr,omitempty"`
+	Path           string      `xml:"path,attr"`
+	Filled         string      `xml:"filled,attr,omitempty"`
+	Stroked        string      `xml:"stroked,attr,omitempty"`
+	Stroke         *xlsxStroke `xml:"v:stroke"`
+	VPath          *vPath      `xml:"v:path"`
 }
 
 // xlsxStroke directly maps the stroke element.
@@ -108,6 +111,13 @@ type xlsxDiv struct {
 	Style string `xml:"style,attr"`
 }
 
+// vImageData directly maps the v:imagedata element. This element specifies
+// the picture of the shape by the relationship of the picture.
+type vImageData struct {
+	RelID string `xml:"o:relid,attr"`
+	Title string `xml:"o:title,attr"`
+}
+
 // xClientData (Attached Object Data) directly maps the x:ClientData element.
 // This element specifies data associated with objects attached to a
 // spreadsheet. While this element might contain any of the child elements
@@ -133,11 +143,37 @@ type decodeVmlDrawing struct {
 
 // decodeShape defines the structure used to parse the particular shape element.
 type decodeShape struct {
-	Val string `xml:",innerxml"`
+	ID    string `xml:"id,attr"`
+	Type  string `xml:"type,attr"`
+	Style string `xml:"style,attr"`
+	Val   string `xml:",innerxml"`
+}
+
+// decodeShapeVal defines the structure used to parse the content of the
+// particular shape element.
+type decodeShapeVal struct {
+	ImageData  *decodeVMLImageData  `xml:"imagedata"`
+	ClientData *decodeVMLClientData `xml:"ClientData"`
+}
+
+// decodeVMLImageData defines the structure used to parse the v:imagedata
+// element.
+type decodeVMLImageData struct {
+	RelID string `xml:"relid,attr"`
+}
+
+// decodeVMLClientData defines the structure used to parse the x:ClientData
+// element.
+type decodeVMLClientData struct {
+	ObjectType string `xml:"ObjectType,attr"`
+	Anchor     string `xml:"Anchor"`
+	Row        int    `xml:"Row"`
+	Column     int    `xml:"Column"`
 }
 
 // encodeShape defines the structure used to re-serialization shape element.
 type encodeShape struct {
+	ImageData  *vImageData  `xml:"v:imagedata"`
 	Fill       *vFill       `xml:"v:fill"`
 	Shadow     *vShadow     `xml:"v:shadow"`
 	Path       *vPath       `xml:"v:path"`
@@ -0,0 +1,394 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// This section defines the default value of watermark properties.
+const (
+	defaultWatermarkColor    = "C0C0C0"
+	defaultWatermarkFontSize = 72
+	defaultWatermarkRotation = 45
+	watermarkPageWidth       = 816
+	watermarkPageHeight      = 1056
+)
+
+// SetWatermark provides a function to set the watermark across the printed
+// pages of the worksheet by given worksheet name and watermark settings. The
+// watermark will be generated as a PNG picture with the given text, and
+// placed in the center section of the odd page header, that is the way of
+// adding watermark in Excel. The text will be drawn with the TrueType or
+// OpenType font file specified by the FontFile, so the Family, Bold and
+// Italic of the Font will be ignored, please use the font file of the
+// required typeface and style instead. The existing picture in the center
+// section of the header will be replaced, and the other pictures in the
+// header and footer of the worksheet will be kept. For example, set a
+// diagonal "CONFIDENTIAL" watermark for Sheet1:
+//
+//	fontFile, err := os.ReadFile("DejaVuSans-Bold.ttf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetWatermark("Sheet1", excelize.WatermarkOptions{
+//	    Text:         "CONFIDENTIAL",
+//	    FontFile:     fontFile,
+//	    Font:         &excelize.Font{Size: 60, Color: "#FF0000"},
+//	    Transparency: 70,
+//	})
+func (f *File) SetWatermark(sheet string, opts WatermarkOptions) error {
+	if opts.Text == "" || len(opts.FontFile) == 0 || opts.Transparency < 0 || opts.Transparency > 100 {
+		return ErrParameterInvalid
+	}
+	file, width, height, err := drawWatermark(&opts)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	drawingVML, vml, err := f.vmlDrawingHFReader(sheet, ws)
+	if err != nil {
+		return err
+	}
+	if vml == nil {
+		vmlID := f.countVMLDrawingHF() + 1
+		sheetRelationshipsDrawingVML := "../drawings/vmlDrawingHF" + strconv.Itoa(vmlID) + ".vml"
+		drawingVML = strings.ReplaceAll(sheetRelationshipsDrawingVML, "..", "xl")
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+		sheetRID := f.addRels(sheetRels, SourceRelationshipDrawingVML, sheetRelationshipsDrawingVML, "")
+		f.addSheetNameSpace(sheet, SourceRelationship)
+		ws.mu.Lock()
+		ws.LegacyDrawingHF = &xlsxLegacyDrawingHF{RID: "rId" + strconv.Itoa(sheetRID)}
+		ws.mu.Unlock()
+		vml = newVMLDrawingHF(vmlID)
+		f.VMLDrawing[drawingVML] = vml
+	} else if err = f.deleteVMLDrawingHFShape(drawingVML, vml, "CH"); err != nil {
+		return err
+	}
+	drawingVMLRels := strings.ReplaceAll(strings.ReplaceAll(drawingVML, "xl/drawings", "xl/drawings/_rels"), ".vml", ".vml.rels")
+	rID := f.addRels(drawingVMLRels, SourceRelationshipImage, strings.Replace(f.addMedia(file, ".png"), "xl", "..", 1), "")
+	sp, _ := xml.Marshal(encodeShape{ImageData: &vImageData{RelID: "rId" + strconv.Itoa(rID), Title: "watermark"}})
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID:    "CH",
+		Type:  "#_x0000_t75",
+		Style: fmt.Sprintf("position:absolute;margin-left:0;margin-top:0;width:%gpt;height:%gpt;z-index:1", float64(width)*0.75, float64(height)*0.75),
+		Val:   string(sp[13 : len(sp)-14]),
+	})
+	ws.mu.Lock()
+	if ws.HeaderFooter == nil {
+		ws.HeaderFooter = &xlsxHeaderFooter{}
+	}
+	if idx := strings.Index(ws.HeaderFooter.OddHeader, "&C"); idx == -1 {
+		ws.HeaderFooter.OddHeader += "&C&G"
+	} else if !strings.HasPrefix(ws.HeaderFooter.OddHeader[idx:], "&C&G") {
+		ws.HeaderFooter.OddHeader = ws.HeaderFooter.OddHeader[:idx+2] + "&G" + ws.HeaderFooter.OddHeader[idx+2:]
+	}
+	ws.mu.Unlock()
+	if err = f.setContentTypePartVMLExtensions(); err != nil {
+		return err
+	}
+	return f.setContentTypePartImageExtensions()
+}
+
+// DeleteWatermark provides a function to delete the watermark of the
+// worksheet by given worksheet name, the picture in the center section of the
+// header will be removed, and the other pictures in the header and footer of
+// the worksheet will be kept. For example, delete the watermark of Sheet1:
+//
+//	err := f.DeleteWatermark("Sheet1")
+func (f *File) DeleteWatermark(sheet string) error {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	drawingVML, vml, err := f.vmlDrawingHFReader(sheet, ws)
+	if err != nil || vml == nil {
+		return err
+	}
+	if err = f.deleteVMLDrawingHFShape(drawingVML, vml, "CH"); err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	if ws.HeaderFooter != nil {
+		if ws.HeaderFooter.OddHeader = strings.Replace(ws.HeaderFooter.OddHeader, "&C&G", "&C", 1); ws.HeaderFooter.OddHeader == "&C" {
+			ws.HeaderFooter.OddHeader = ""
+		}
+	}
+	ws.mu.Unlock()
+	if len(vml.Shape) > 0 {
+		return err
+	}
+	drawingVMLRels := strings.ReplaceAll(strings.ReplaceAll(drawingVML, "xl/drawings", "xl/drawings/_rels"), ".vml", ".vml.rels")
+	delete(f.VMLDrawing, drawingVML)
+	delete(f.DecodeVMLDrawing, drawingVML)
+	f.Pkg.Delete(drawingVML)
+	f.Pkg.Delete(drawingVMLRels)
+	f.Relationships.Delete(drawingVMLRels)
+	f.deleteSheetRelationships(sheet, ws.LegacyDrawingHF.RID)
+	ws.mu.Lock()
+	ws.LegacyDrawingHF = nil
+	ws.mu.Unlock()
+	return f.deleteSheetFromContentTypes("/" + drawingVML)
+}
+
+// newVMLDrawingHF provides a function to create the VML drawing of the header
+// and footer pictures by given VML drawing ID.
+func newVMLDrawingHF(vmlID int) *vmlDrawing {
+	return &vmlDrawing{
+		XMLNSv:  "urn:schemas-microsoft-com:vml",
+		XMLNSo:  "urn:schemas-microsoft-com:office:office",
+		XMLNSx:  "urn:schemas-microsoft-com:office:excel",
+		XMLNSmv: "http://macVmlSchemaUri",
+		Shapelayout: &xlsxShapelayout{
+			Ext:   "edit",
+			IDmap: &xlsxIDmap{Ext: "edit", Data: vmlID},
+		},
+		Shapetype: &xlsxShapetype{
+			ID:             "_x0000_t75",
+			Coordsize:      "21600,21600",
+			Spt:            75,
+			Preferrelative: "t",
+			Path:           "m0,0l0,21600,21600,21600,21600,0xe",
+			Filled:         "f",
+			Stroked:        "f",
+			Stroke:         &xlsxStroke{Joinstyle: "miter"},
+			VPath:          &vPath{Gradientshapeok: "t", Connecttype: "rect"},
+		},
+	}
+}
+
+// vmlDrawingHFReader provides a function to get the path and the VML drawing
+// of the header and footer pictures by given worksheet name, the existing
+// shapes in the VML drawing part will be loaded. The VML drawing will be nil
+// if the worksheet doesn't have pictures in the header and footer.
+func (f *File) vmlDrawingHFReader(sheet string, ws *xlsxWorksheet) (string, *vmlDrawing, error) {
+	if ws.LegacyDrawingHF == nil {
+		return "", nil, nil
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.LegacyDrawingHF.RID)
+	drawingVML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	if vml := f.VMLDrawing[drawingVML]; vml != nil {
+		return drawingVML, vml, nil
+	}
+	d, err := f.decodeVMLDrawingReader(drawingVML)
+	if err != nil {
+		return drawingVML, nil, err
+	}
+	vmlID, _ := strconv.Atoi(strings.TrimFunc(path.Base(drawingVML), func(r rune) bool {
+		return !unicode.IsDigit(r)
+	}))
+	if vmlID == 0 {
+		vmlID = f.countVMLDrawingHF() + 1
+	}
+	vml := newVMLDrawingHF(vmlID)
+	if d != nil {
+		for _, shape := range d.Shape {
+			vml.Shape = append(vml.Shape, xlsxShape{ID: shape.ID, Type: shape.Type, Style: shape.Style, Val: shape.Val})
+		}
+	}
+	f.VMLDrawing[drawingVML] = vml
+	return drawingVML, vml, err
+}
+
+// deleteVMLDrawingHFShape provides a function to remove the shape in the VML
+// drawing of the header and footer pictures by given VML drawing part path
+// and shape ID, the relationship and the picture of the shape will be removed
+// if the picture isn't used by others.
+func (f *File) deleteVMLDrawingHFShape(drawingVML string, vml *vmlDrawing, id string) error {
+	drawingVMLRels := strings.ReplaceAll(strings.ReplaceAll(drawingVML, "xl/drawings", "xl/drawings/_rels"), ".vml", ".vml.rels")
+	for idx := 0; idx < len(vml.Shape); idx++ {
+		if vml.Shape[idx].ID != id {
+			continue
+		}
+		var val decodeShapeVal
+		if err := f.xmlNewDecoder(strings.NewReader("<shape>" + vml.Shape[idx].Val + "</shape>")).
+			Decode(&val); err != nil && err != io.EOF {
+			return err
+		}
+		vml.Shape = append(vml.Shape[:idx], vml.Shape[idx+1:]...)
+		idx--
+		if val.ImageData == nil {
+			continue
+		}
+		rels, err := f.relsReader(drawingVMLRels)
+		if err != nil {
+			return err
+		}
+		if rels == nil {
+			continue
+		}
+		var target string
+		rels.mu.Lock()
+		for k, rel := range rels.Relationships {
+			if rel.ID == val.ImageData.RelID {
+				target = rel.Target
+				rels.Relationships = append(rels.Relationships[:k], rels.Relationships[k+1:]...)
+				break
+			}
+		}
+		rels.mu.Unlock()
+		if target != "" {
+			if err = f.deleteMedia(path.Join(path.Dir(drawingVML), target)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// countVMLDrawingHF provides a function to get the maximum index of the VML
+// drawing parts of the header and footer pictures.
+func (f *File) countVMLDrawingHF() int {
+	var count int
+	counter := func(name string) {
+		if strings.HasPrefix(name, "xl/drawings/vmlDrawingHF") {
+			idx, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "xl/drawings/vmlDrawingHF"), ".vml"))
+			if idx > count {
+				count = idx
+			}
+		}
+	}
+	f.Pkg.Range(func(k, v interface{}) bool {
+		counter(strings.TrimPrefix(k.(string), "/"))
+		return true
+	})
+	for name := range f.VMLDrawing {
+		counter(name)
+	}
+	return count
+}
+
+// drawWatermark provides a function to draw the picture of the watermark by
+// given watermark settings, and returns the PNG encoded picture with the
+// width and height in pixels.
+func drawWatermark(opts *WatermarkOptions) ([]byte, int, int, error) {
+	fnt := Font{Size: defaultWatermarkFontSize, Color: defaultWatermarkColor}
+	if opts.Font != nil {
+		if opts.Font.Size > 0 {
+			fnt.Size = opts.Font.Size
+		}
+		if opts.Font.Color != "" {
+			fnt.Color = opts.Font.Color
+		}
+	}
+	rgb, err := strconv.ParseUint(strings.TrimPrefix(fnt.Color, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(fnt.Color, "#")) != 6 {
+		return nil, 0, 0, ErrParameterInvalid
+	}
+	ft, err := opentype.Parse(opts.FontFile)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	face, err := opentype.NewFace(ft, &opentype.FaceOptions{Size: fnt.Size, DPI: 96, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer face.Close()
+	metrics := face.Metrics()
+	drawer := &font.Drawer{
+		Src: image.NewUniform(color.NRGBA{
+			R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb),
+			A: uint8(math.Round(float64(100-opts.Transparency) * 2.55)),
+		}),
+		Face: face,
+		Dot:  fixed.Point26_6{Y: metrics.Ascent},
+	}
+	text := image.NewRGBA(image.Rect(0, 0, drawer.MeasureString(opts.Text).Ceil(), (metrics.Ascent + metrics.Descent).Ceil()))
+	drawer.Dst = text
+	drawer.DrawString(opts.Text)
+	rotation := defaultWatermarkRotation
+	if opts.Rotation != nil {
+		rotation = *opts.Rotation
+	}
+	img := rotateImage(text, float64(rotation))
+	if opts.Tiled {
+		tiled := image.NewRGBA(image.Rect(0, 0, watermarkPageWidth, watermarkPageHeight))
+		size := img.Bounds().Size()
+		stepX, stepY := size.X+size.X/2, size.Y+size.Y/2
+		layout := func(page, size, step int) (int, int) {
+			count := (page + step - size) / step
+			if count < 1 {
+				count = 1
+			}
+			return (page - (count*step - step + size)) / 2, count
+		}
+		offsetX, cols := layout(watermarkPageWidth, size.X, stepX)
+		offsetY, rows := layout(watermarkPageHeight, size.Y, stepY)
+		for y := offsetY; y < offsetY+rows*stepY; y += stepY {
+			for x := offsetX; x < offsetX+cols*stepX; x += stepX {
+				draw.Draw(tiled, image.Rect(x, y, x+size.X, y+size.Y), img, image.Point{}, draw.Over)
+			}
+		}
+		img = tiled
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, img)
+	return buf.Bytes(), img.Bounds().Dx(), img.Bounds().Dy(), err
+}
+
+// rotateImage provides a function to rotate the picture counterclockwise by
+// given picture and angle in degrees, the pixels of the rotated picture will
+// be sampled by bilinear interpolation.
+func rotateImage(src *image.RGBA, angle float64) *image.RGBA {
+	rad := angle * math.Pi / 180
+	sin, cos := math.Sin(rad), math.Cos(rad)
+	w, h := float64(src.Bounds().Dx()), float64(src.Bounds().Dy())
+	dstW, dstH := math.Ceil(math.Abs(w*cos)+math.Abs(h*sin)), math.Ceil(math.Abs(w*sin)+math.Abs(h*cos))
+	dst := image.NewRGBA(image.Rect(0, 0, int(dstW), int(dstH)))
+	pixel := func(x, y int) [4]float64 {
+		if x < 0 || y < 0 || x >= src.Bounds().Dx() || y >= src.Bounds().Dy() {
+			return [4]float64{}
+		}
+		c := src.RGBAAt(x, y)
+		return [4]float64{float64(c.R), float64(c.G), float64(c.B), float64(c.A)}
+	}
+	for y := 0; y < int(dstH); y++ {
+		for x := 0; x < int(dstW); x++ {
+			dx, dy := float64(x)+0.5-dstW/2, float64(y)+0.5-dstH/2
+			sx, sy := dx*cos-dy*sin+w/2-0.5, dx*sin+dy*cos+h/2-0.5
+			x0, y0 := int(math.Floor(sx)), int(math.Floor(sy))
+			fx, fy := sx-float64(x0), sy-float64(y0)
+			p00, p10, p01, p11 := pixel(x0, y0), pixel(x0+1, y0), pixel(x0, y0+1), pixel(x0+1, y0+1)
+			var c [4]uint8
+			for i := range c {
+				c[i] = uint8(math.Round((p00[i]*(1-fx)+p10[i]*fx)*(1-fy) + (p01[i]*(1-fx)+p11[i]*fx)*fy))
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: c[0], G: c[1], B: c[2], A: c[3]})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,124 @@
+package excelize
+
+import (
+	"bytes"
+	"image/png"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestSetWatermark(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddHeader: "&LLeft&CCenter"}))
+	assert.NoError(t, f.SetWatermark("Sheet1", WatermarkOptions{
+		Text:         "CONFIDENTIAL",
+		FontFile:     gobold.TTF,
+		Font:         &Font{Size: 36, Color: "#FF0000"},
+		Transparency: 50,
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.NotNil(t, ws.(*xlsxWorksheet).LegacyDrawingHF)
+	assert.Equal(t, "&LLeft&C&GCenter", ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	vml := f.VMLDrawing["xl/drawings/vmlDrawingHF1.vml"]
+	assert.NotNil(t, vml)
+	assert.Equal(t, "CH", vml.Shape[0].ID)
+	assert.Equal(t, `<v:imagedata o:relid="rId1" o:title="watermark"></v:imagedata>`, vml.Shape[0].Val)
+	// Test the picture of the watermark
+	file, ok := f.Pkg.Load("xl/media/image1.png")
+	assert.True(t, ok)
+	img, err := png.Decode(bytes.NewReader(file.([]byte)))
+	assert.NoError(t, err)
+	size := img.Bounds().Size()
+	assert.Equal(t, size.X, size.Y)
+	_, _, _, alpha := img.At(size.X/2, size.Y/2).RGBA()
+	assert.True(t, alpha <= 0x8080)
+	// Test replace the watermark with tiled horizontal text
+	assert.NoError(t, f.SetWatermark("Sheet1", WatermarkOptions{Text: "DRAFT", FontFile: goregular.TTF, Rotation: intPtr(0), Tiled: true}))
+	assert.Len(t, f.VMLDrawing, 1)
+	vml = f.VMLDrawing["xl/drawings/vmlDrawingHF1.vml"]
+	assert.NotNil(t, vml)
+	assert.True(t, strings.Contains(vml.Shape[0].Style, "width:612pt;height:792pt"))
+	assert.Equal(t, "&LLeft&C&GCenter", ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	// Test the picture of the replaced watermark has been removed
+	assert.Equal(t, 1, f.countMedia())
+	rels, err := f.relsReader("xl/drawings/_rels/vmlDrawingHF1.vml.rels")
+	assert.NoError(t, err)
+	assert.Len(t, rels.Relationships, 1)
+	// Test the other pictures in the header and footer will be kept
+	logo := f.addMedia([]byte("logo"), ".png")
+	rID := f.addRels("xl/drawings/_rels/vmlDrawingHF1.vml.rels", SourceRelationshipImage, strings.Replace(logo, "xl", "..", 1), "")
+	vml.Shape = append(vml.Shape, xlsxShape{
+		ID: "LH", Type: "#_x0000_t75", Style: "position:absolute;width:48pt;height:48pt;z-index:2",
+		Val: `<v:imagedata o:relid="rId` + strconv.Itoa(rID) + `" o:title="logo"/>`,
+	})
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetWatermark.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test replace and delete the watermark after reopen the workbook
+	f, err = OpenFile(filepath.Join("test", "TestSetWatermark.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetWatermark("Sheet1", WatermarkOptions{Text: "COPY", FontFile: goregular.TTF}))
+	vml = f.VMLDrawing["xl/drawings/vmlDrawingHF1.vml"]
+	assert.Len(t, vml.Shape, 2)
+	assert.Equal(t, "LH", vml.Shape[0].ID)
+	assert.Equal(t, "CH", vml.Shape[1].ID)
+	assert.Equal(t, 2, f.countMedia())
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "&LLeft&C&GCenter", ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	assert.NoError(t, f.DeleteWatermark("Sheet1"))
+	assert.NotNil(t, ws.(*xlsxWorksheet).LegacyDrawingHF)
+	assert.Len(t, vml.Shape, 1)
+	assert.Equal(t, "LH", vml.Shape[0].ID)
+	assert.Equal(t, 1, f.countMedia())
+	_, ok = f.Pkg.Load(logo)
+	assert.True(t, ok)
+	assert.Equal(t, "&LLeft&CCenter", ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	// Test delete the VML drawing without pictures in the header and footer
+	assert.NoError(t, f.deleteVMLDrawingHFShape("xl/drawings/vmlDrawingHF1.vml", vml, "LH"))
+	assert.NoError(t, f.DeleteWatermark("Sheet1"))
+	assert.Nil(t, ws.(*xlsxWorksheet).LegacyDrawingHF)
+	_, ok = f.Pkg.Load("xl/drawings/vmlDrawingHF1.vml")
+	assert.False(t, ok)
+	_, ok = f.Pkg.Load(logo)
+	assert.False(t, ok)
+	// Test delete the watermark on the worksheet without watermark
+	assert.NoError(t, f.DeleteWatermark("Sheet1"))
+	// Test set the watermark on the worksheet without header
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetWatermark("Sheet2", WatermarkOptions{Text: "DRAFT", FontFile: goregular.TTF, Font: &Font{Size: 48}}))
+	ws, ok = f.Sheet.Load("xl/worksheets/sheet2.xml")
+	assert.True(t, ok)
+	assert.Equal(t, "&C&G", ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	assert.NoError(t, f.DeleteWatermark("Sheet2"))
+	assert.Empty(t, ws.(*xlsxWorksheet).HeaderFooter.OddHeader)
+	// Test set the watermark with invalid settings
+	for _, opts := range []WatermarkOptions{
+		{},
+		{Text: "DRAFT"},
+		{Text: "DRAFT", FontFile: goregular.TTF, Transparency: -1},
+		{Text: "DRAFT", FontFile: goregular.TTF, Transparency: 101},
+		{Text: "DRAFT", FontFile: goregular.TTF, Font: &Font{Color: "#FF00"}},
+		{Text: "DRAFT", FontFile: goregular.TTF, Font: &Font{Color: "GGGGGG"}},
+	} {
+		assert.Equal(t, ErrParameterInvalid, f.SetWatermark("Sheet1", opts))
+	}
+	// Test set the watermark with invalid font file
+	assert.Error(t, f.SetWatermark("Sheet1", WatermarkOptions{Text: "DRAFT", FontFile: []byte("font")}))
+	// Test set and delete the watermark on not exists worksheet
+	assert.EqualError(t, f.SetWatermark("SheetN", WatermarkOptions{Text: "DRAFT", FontFile: goregular.TTF}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.DeleteWatermark("SheetN"), "sheet SheetN does not exist")
+	// Test set the watermark with unsupported charset content types
+	f.ContentTypes = nil
+	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetWatermark("Sheet1", WatermarkOptions{Text: "DRAFT", FontFile: goregular.TTF}), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
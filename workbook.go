@@ -201,7 +201,11 @@ func (f *File) workBookWriter() {
 			}
 		}
 		f.WorkBook.DecodeAlternateContent = nil
+		unknownElements := f.WorkBook.UnknownElements
+		f.WorkBook.UnknownElements = nil
 		output, _ := xml.Marshal(f.WorkBook)
+		f.WorkBook.UnknownElements = unknownElements
+		output = f.insertUnknownElements(f.getWorkbookPath(), output, unknownElements, f.WorkBook)
 		f.saveFileList(f.getWorkbookPath(), replaceRelationshipsBytes(f.replaceNameSpaceBytes(f.getWorkbookPath(), output)))
 	}
 }
@@ -22,6 +22,7 @@ type xlsxChartSpace struct {
 	Date1904       *attrValBool    `xml:"date1904"`
 	Lang           *attrValString  `xml:"lang"`
 	RoundedCorners *attrValBool    `xml:"roundedCorners"`
+	PivotSource    *cPivotSource   `xml:"pivotSource"`
 	Chart          cChart          `xml:"chart"`
 	SpPr           *cSpPr          `xml:"spPr"`
 	TxPr           *cTxPr          `xml:"txPr"`
@@ -116,7 +117,8 @@ type aP struct {
 // formatting, since they are directly applied to the paragraph and supersede
 // any formatting from styles.
 type aPPr struct {
-	DefRPr aRPr `xml:"a:defRPr"`
+	Algn   string `xml:"algn,attr,omitempty"`
+	DefRPr *aRPr  `xml:"a:defRPr"`
 }
 
 // aSolidFill (Solid Fill) directly maps the solidFill element. This element
@@ -279,6 +281,13 @@ type aEndParaRPr struct {
 	Sz      int    `xml:"sz,attr,omitempty"`
 }
 
+// cPivotSource (Pivot Source) directly maps the pivotSource element. This
+// element specifies the source pivot table for a pivot chart.
+type cPivotSource struct {
+	Name  string      `xml:"name"`
+	FmtID *attrValInt `xml:"fmtId"`
+}
+
 // cAutoTitleDeleted (Auto Title Is Deleted) directly maps the
 // autoTitleDeleted element. This element specifies the title shall not be
 // shown for this chart.
@@ -319,9 +328,21 @@ type cPlotArea struct {
 	CatAx          []*cAxs  `xml:"catAx"`
 	ValAx          []*cAxs  `xml:"valAx"`
 	SerAx          []*cAxs  `xml:"serAx"`
+	DTable         *cDTable `xml:"dTable"`
 	SpPr           *cSpPr   `xml:"spPr"`
 }
 
+// cDTable (Data Table) directly maps the dTable element. This element
+// specifies the data table shown beneath the plot area of the chart.
+type cDTable struct {
+	ShowHorzBorder *attrValBool `xml:"showHorzBorder"`
+	ShowVertBorder *attrValBool `xml:"showVertBorder"`
+	ShowOutline    *attrValBool `xml:"showOutline"`
+	ShowKeys       *attrValBool `xml:"showKeys"`
+	SpPr           *cSpPr       `xml:"spPr"`
+	TxPr           *cTxPr       `xml:"txPr"`
+}
+
 // cCharts specifies the common element of the chart.
 type cCharts struct {
 	BarDir       *attrValString `xml:"barDir"`
@@ -336,6 +357,7 @@ type cCharts struct {
 	SplitPos     *attrValInt    `xml:"splitPos"`
 	SerLines     *attrValString `xml:"serLines"`
 	DLbls        *cDLbls        `xml:"dLbls"`
+	GapWidth     *attrValInt    `xml:"gapWidth"`
 	Shape        *attrValString `xml:"shape"`
 	HoleSize     *attrValInt    `xml:"holeSize"`
 	Smooth       *attrValBool   `xml:"smooth"`
@@ -402,6 +424,8 @@ type cSer struct {
 	DLbls            *cDLbls      `xml:"dLbls"`
 	Marker           *cMarker     `xml:"marker"`
 	InvertIfNegative *attrValBool `xml:"invertIfNegative"`
+	Trendline        *cTrendline  `xml:"trendline"`
+	ErrBars          *cErrBars    `xml:"errBars"`
 	Cat              *cCat        `xml:"cat"`
 	Val              *cVal        `xml:"val"`
 	XVal             *cCat        `xml:"xVal"`
@@ -419,6 +443,32 @@ type cMarker struct {
 	SpPr   *cSpPr         `xml:"spPr"`
 }
 
+// cTrendline (Trendline) directly maps the trendline element. This element
+// specifies a trendline.
+type cTrendline struct {
+	SpPr          *cSpPr         `xml:"spPr"`
+	TrendlineType *attrValString `xml:"trendlineType"`
+	Order         *attrValInt    `xml:"order"`
+	Period        *attrValInt    `xml:"period"`
+	Forward       *attrValFloat  `xml:"forward"`
+	Backward      *attrValFloat  `xml:"backward"`
+	DispRSqr      *attrValBool   `xml:"dispRSqr"`
+	DispEq        *attrValBool   `xml:"dispEq"`
+}
+
+// cErrBars (Error Bars) directly maps the errBars element. This element
+// specifies the error bars of the series.
+type cErrBars struct {
+	ErrDir     *attrValString `xml:"errDir"`
+	ErrBarType *attrValString `xml:"errBarType"`
+	ErrValType *attrValString `xml:"errValType"`
+	NoEndCap   *attrValBool   `xml:"noEndCap"`
+	Plus       *cVal          `xml:"plus"`
+	Minus      *cVal          `xml:"minus"`
+	Val        *attrValFloat  `xml:"val"`
+	SpPr       *cSpPr         `xml:"spPr"`
+}
+
 // cDPt (Data Point) directly maps the dPt element. This element specifies a
 // single data point.
 type cDPt struct {
@@ -481,6 +531,7 @@ type cNumCache struct {
 // entire series or the entire chart. It contains child elements that specify
 // the specific formatting and positioning settings.
 type cDLbls struct {
+	DLbl            []*cDLbl     `xml:"dLbl"`
 	NumFmt          *cNumFmt     `xml:"numFmt"`
 	ShowLegendKey   *attrValBool `xml:"showLegendKey"`
 	ShowVal         *attrValBool `xml:"showVal"`
@@ -491,14 +542,37 @@ type cDLbls struct {
 	ShowLeaderLines *attrValBool `xml:"showLeaderLines"`
 }
 
+// cDLbl (Data Label) directly maps the dLbl element. This element specifies
+// the data label of a single data point.
+type cDLbl struct {
+	IDx            *attrValInt  `xml:"idx"`
+	Delete         *attrValBool `xml:"delete"`
+	ShowLegendKey  *attrValBool `xml:"showLegendKey"`
+	ShowVal        *attrValBool `xml:"showVal"`
+	ShowCatName    *attrValBool `xml:"showCatName"`
+	ShowSerName    *attrValBool `xml:"showSerName"`
+	ShowPercent    *attrValBool `xml:"showPercent"`
+	ShowBubbleSize *attrValBool `xml:"showBubbleSize"`
+}
+
 // cLegend (Legend) directly maps the legend element. This element specifies
 // the legend.
 type cLegend struct {
-	Layout    *string        `xml:"layout"`
-	LegendPos *attrValString `xml:"legendPos"`
-	Overlay   *attrValBool   `xml:"overlay"`
-	SpPr      *cSpPr         `xml:"spPr"`
-	TxPr      *cTxPr         `xml:"txPr"`
+	LegendPos   *attrValString `xml:"legendPos"`
+	LegendEntry []cLegendEntry `xml:"legendEntry"`
+	Layout      *string        `xml:"layout"`
+	Overlay     *attrValBool   `xml:"overlay"`
+	SpPr        *cSpPr         `xml:"spPr"`
+	TxPr        *cTxPr         `xml:"txPr"`
+}
+
+// cLegendEntry (Legend Entry) directly maps the legendEntry element. This
+// element specifies a legend entry, the delete element specifies the legend
+// entry shall be deleted.
+type cLegendEntry struct {
+	Idx    *attrValInt  `xml:"idx"`
+	Delete *attrValBool `xml:"delete"`
+	TxPr   *cTxPr       `xml:"txPr"`
 }
 
 // cPrintSettings directly maps the printSettings element. This element
@@ -539,6 +613,7 @@ type ChartAxis struct {
 	Font           Font
 	LogBase        float64
 	NumFmt         ChartNumFmt
+	Secondary      bool
 }
 
 // ChartDimension directly maps the dimension of the chart.
@@ -556,9 +631,20 @@ type ChartPlotArea struct {
 	ShowPercent      bool
 	ShowSerName      bool
 	ShowVal          bool
+	ShowDataTable    bool
+	DataTable        ChartDataTable
 	NumFmt           ChartNumFmt
 }
 
+// ChartDataTable directly maps the format settings of the data table shown
+// beneath the plot area of the chart.
+type ChartDataTable struct {
+	ShowLegendKeys bool
+	HideHorzBorder bool
+	HideVertBorder bool
+	HideOutline    bool
+}
+
 // Chart directly maps the format settings of the chart.
 type Chart struct {
 	Type         ChartType
@@ -573,13 +659,25 @@ type Chart struct {
 	PlotArea     ChartPlotArea
 	ShowBlanksAs string
 	HoleSize     int
+	GapWidth     *int
+	Overlap      *int
 	order        int
+	pivotSource  *cPivotSource
+}
+
+// PivotChartOptions directly maps the format settings of the pivot chart.
+type PivotChartOptions struct {
+	Cell            string
+	PivotTableRange string
+	Chart           Chart
 }
 
 // ChartLegend directly maps the format settings of the chart legend.
 type ChartLegend struct {
 	Position      string
+	Overlay       bool
 	ShowLegendKey bool
+	HiddenEntries []int
 }
 
 // ChartMarker directly maps the format settings of the chart marker.
@@ -594,6 +692,41 @@ type ChartLine struct {
 	Width  float64
 }
 
+// ChartTrendline directly maps the format settings of the chart series
+// trendline.
+type ChartTrendline struct {
+	Type            string
+	Order           int
+	Period          int
+	Forward         float64
+	Backward        float64
+	DisplayEquation bool
+	DisplayRSquared bool
+}
+
+// ChartErrorBars directly maps the format settings of the chart series error
+// bars.
+type ChartErrorBars struct {
+	Type      string
+	Direction string
+	Value     float64
+	NoEndCap  bool
+	Plus      string
+	Minus     string
+}
+
+// ChartDataPoint directly maps the format settings of a single data point in
+// the chart series. The Index specifies the zero-based index of the data
+// point, and the ShowLabel specifies if showing the data label of the data
+// point, the data label settings of the series will be used if it was nil.
+type ChartDataPoint struct {
+	Index       int
+	Fill        Fill
+	BorderColor string
+	BorderWidth float64
+	ShowLabel   *bool
+}
+
 // ChartSeries directly maps the format settings of the chart series.
 type ChartSeries struct {
 	Name       string
@@ -603,9 +736,15 @@ type ChartSeries struct {
 	Fill       Fill
 	Line       ChartLine
 	Marker     ChartMarker
+	Trendline  ChartTrendline
+	ErrorBars  ChartErrorBars
+	DataPoints []ChartDataPoint
+	SubTotals  []int
 }
 
 // ChartTitle directly maps the format settings of the chart title.
 type ChartTitle struct {
-	Name string
+	Name   string
+	Auto   bool
+	Hidden bool
 }
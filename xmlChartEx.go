@@ -0,0 +1,194 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxChartExSpace directly maps the chartSpace element. The extended chart
+// part contains the chart data and the chart of the chart types introduced
+// in Office 2016, such as sunburst and treemap chart.
+type xlsxChartExSpace struct {
+	XMLName   xml.Name    `xml:"http://schemas.microsoft.com/office/drawing/2014/chartex chartSpace"`
+	XMLNSa    string      `xml:"xmlns:a,attr"`
+	XMLNSr    string      `xml:"xmlns:r,attr"`
+	ChartData cxChartData `xml:"chartData"`
+	Chart     cxChart     `xml:"chart"`
+}
+
+// cxChartData directly maps the chartData element. This element specifies
+// the data used by the series of the extended chart.
+type cxChartData struct {
+	Data []cxData `xml:"data"`
+}
+
+// cxData directly maps the data element. This element specifies the
+// dimensions of the data used by a series of the extended chart.
+type cxData struct {
+	ID     int       `xml:"id,attr"`
+	StrDim *cxStrDim `xml:"strDim"`
+	NumDim *cxNumDim `xml:"numDim"`
+}
+
+// cxStrDim directly maps the strDim element. This element specifies the
+// string dimension of the data, each lvl element specifies a level of the
+// hierarchical categories, from the leaf level to the root level.
+type cxStrDim struct {
+	Type string  `xml:"type,attr"`
+	F    string  `xml:"f"`
+	Lvl  []cxLvl `xml:"lvl"`
+}
+
+// cxNumDim directly maps the numDim element. This element specifies the
+// numeric dimension of the data.
+type cxNumDim struct {
+	Type string  `xml:"type,attr"`
+	F    string  `xml:"f"`
+	Lvl  []cxLvl `xml:"lvl"`
+}
+
+// cxLvl directly maps the lvl element. This element specifies the cached
+// values of a level of the data dimension.
+type cxLvl struct {
+	PtCount    int    `xml:"ptCount,attr"`
+	FormatCode string `xml:"formatCode,attr,omitempty"`
+	Pt         []cxPt `xml:"pt"`
+}
+
+// cxPt directly maps the pt element. This element specifies a cached
+// value of the data dimension.
+type cxPt struct {
+	Idx int    `xml:"idx,attr"`
+	V   string `xml:",chardata"`
+}
+
+// cxChart directly maps the chart element. This element specifies the
+// title, plot area and legend of the extended chart.
+type cxChart struct {
+	Title    *cxTitle   `xml:"title"`
+	PlotArea cxPlotArea `xml:"plotArea"`
+	Legend   *cxLegend  `xml:"legend"`
+}
+
+// cxTitle directly maps the title element. This element specifies the
+// title of the extended chart.
+type cxTitle struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+	Tx      cxTx   `xml:"tx"`
+}
+
+// cxTx directly maps the tx element. This element specifies the text of
+// the title or the series name.
+type cxTx struct {
+	TxData cxTxData `xml:"txData"`
+}
+
+// cxTxData directly maps the txData element. This element specifies the
+// formula and the cached value of the text.
+type cxTxData struct {
+	F string `xml:"f,omitempty"`
+	V string `xml:"v"`
+}
+
+// cxPlotArea directly maps the plotArea element.
+type cxPlotArea struct {
+	PlotAreaRegion cxPlotAreaRegion `xml:"plotAreaRegion"`
+	Axis           []cxAxis         `xml:"axis"`
+}
+
+// cxAxis directly maps the axis element. This element specifies the category
+// or value axis of the extended chart.
+type cxAxis struct {
+	ID             int           `xml:"id,attr"`
+	CatScaling     *cxCatScaling `xml:"catScaling"`
+	ValScaling     *cxValScaling `xml:"valScaling"`
+	MajorGridlines *cxGridlines  `xml:"majorGridlines"`
+	TickLabels     *cxTickLabels `xml:"tickLabels"`
+}
+
+// cxCatScaling directly maps the catScaling element. This element specifies
+// the scaling of the category axis.
+type cxCatScaling struct {
+	GapWidth string `xml:"gapWidth,attr,omitempty"`
+}
+
+// cxValScaling directly maps the valScaling element. This element specifies
+// the scaling of the value axis.
+type cxValScaling struct{}
+
+// cxGridlines directly maps the majorGridlines element.
+type cxGridlines struct{}
+
+// cxTickLabels directly maps the tickLabels element.
+type cxTickLabels struct{}
+
+// cxPlotAreaRegion directly maps the plotAreaRegion element. This element
+// specifies the series of the extended chart.
+type cxPlotAreaRegion struct {
+	Series []cxSeries `xml:"series"`
+}
+
+// cxSeries directly maps the series element. The layoutId attribute
+// specifies the chart type of the series.
+type cxSeries struct {
+	LayoutID   string        `xml:"layoutId,attr"`
+	UniqueID   string        `xml:"uniqueId,attr,omitempty"`
+	Tx         *cxTx         `xml:"tx"`
+	DataPt     []cxDataPt    `xml:"dataPt"`
+	DataLabels *cxDataLabels `xml:"dataLabels"`
+	DataID     attrValInt    `xml:"dataId"`
+	LayoutPr   *cxLayoutPr   `xml:"layoutPr"`
+}
+
+// cxDataPt directly maps the dataPt element. This element specifies the
+// format of an individual data point.
+type cxDataPt struct {
+	Idx  int    `xml:"idx,attr"`
+	SpPr *cSpPr `xml:"spPr"`
+}
+
+// cxDataLabels directly maps the dataLabels element. This element
+// specifies the data labels of the series.
+type cxDataLabels struct {
+	Pos        string        `xml:"pos,attr,omitempty"`
+	Visibility *cxVisibility `xml:"visibility"`
+}
+
+// cxVisibility directly maps the visibility element. This element
+// specifies which content is displayed in the data labels.
+type cxVisibility struct {
+	SeriesName   bool `xml:"seriesName,attr"`
+	CategoryName bool `xml:"categoryName,attr"`
+	Value        bool `xml:"value,attr"`
+}
+
+// cxLayoutPr directly maps the layoutPr element. This element specifies
+// the layout properties of the series.
+type cxLayoutPr struct {
+	ParentLabelLayout *attrValString `xml:"parentLabelLayout"`
+	Subtotals         *cxSubtotals   `xml:"subtotals"`
+}
+
+// cxSubtotals directly maps the subtotals element. This element specifies the
+// indices of the data points which are the subtotals of the waterfall chart.
+type cxSubtotals struct {
+	Idx []attrValInt `xml:"idx"`
+}
+
+// cxLegend directly maps the legend element. This element specifies the
+// legend of the extended chart.
+type cxLegend struct {
+	Pos     string `xml:"pos,attr"`
+	Align   string `xml:"align,attr"`
+	Overlay bool   `xml:"overlay,attr"`
+}
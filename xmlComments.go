@@ -11,7 +11,10 @@
 
 package excelize
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"time"
+)
 
 // xlsxComments directly maps the comments element from the namespace
 // http://schemas.openxmlformats.org/spreadsheetml/2006/main. A comment is a
@@ -72,11 +75,85 @@ type xlsxPhoneticRun struct {
 	T  string `xml:"t"`
 }
 
-// Comment directly maps the comment information.
+// xlsxThreadedComments directly maps the ThreadedComments element. This
+// element is the root of the threaded comments part, which contains the
+// threaded comments and replies of a worksheet.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a threaded comment or a reply of the threaded comment if the
+// parentId attribute was specified.
+type xlsxThreadedComment struct {
+	Ref      string `xml:"ref,attr,omitempty"`
+	DT       string `xml:"dT,attr,omitempty"`
+	PersonID string `xml:"personId,attr"`
+	ID       string `xml:"id,attr"`
+	ParentID string `xml:"parentId,attr,omitempty"`
+	Done     bool   `xml:"done,attr,omitempty"`
+	Text     string `xml:"text"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root of the persons part, which contains the authors of the threaded
+// comments in the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	Person  []xlsxPerson `xml:"person"`
+}
+
+// xlsxPerson directly maps the person element. This element represents an
+// author of the threaded comments.
+type xlsxPerson struct {
+	DisplayName string `xml:"displayName,attr"`
+	ID          string `xml:"id,attr"`
+	UserID      string `xml:"userId,attr,omitempty"`
+	ProviderID  string `xml:"providerId,attr,omitempty"`
+}
+
+// Comment directly maps the comment information. The Anchor specifies the
+// position of the comment box which read from the VML drawing part, it will
+// be ignored when adding the comment. The Width and Height specifies the size
+// of the comment box in pixels, and the AutoSize specifies if sizing the
+// comment box to fit the text when adding the comment. Set the Threaded to
+// add a threaded comment, the Resolved specifies if the thread was resolved,
+// which will be nil for the notes, the Created specifies the creation time of
+// the threaded comment, and the Replies specifies the replies of the thread.
 type Comment struct {
 	Author   string
 	AuthorID int
 	Cell     string
 	Text     string
 	Runs     []RichTextRun
+	Anchor   *CommentAnchor
+	Width    uint
+	Height   uint
+	AutoSize bool
+	Threaded bool
+	Resolved *bool
+	Created  time.Time
+	Replies  []CommentReply
+}
+
+// CommentReply directly maps the reply of the threaded comment.
+type CommentReply struct {
+	Author  string
+	Text    string
+	Created time.Time
+}
+
+// CommentAnchor directly maps the anchor position of the comment box. The
+// columns and rows are the zero-based index, and the offsets are measured in
+// pixels.
+type CommentAnchor struct {
+	LeftColumn   int
+	LeftOffset   int
+	TopRow       int
+	TopOffset    int
+	RightColumn  int
+	RightOffset  int
+	BottomRow    int
+	BottomOffset int
 }
@@ -21,6 +21,7 @@ type decodeCellAnchor struct {
 	EditAs     string            `xml:"editAs,attr,omitempty"`
 	From       *decodeFrom       `xml:"from"`
 	To         *decodeTo         `xml:"to"`
+	Ext        *decodeExt        `xml:"ext"`
 	Sp         *decodeSp         `xml:"sp"`
 	ClientData *decodeClientData `xml:"clientData"`
 	Content    string            `xml:",innerxml"`
@@ -34,8 +35,102 @@ type decodeCellAnchor struct {
 // to a shape. This shape is specified along with all other shapes within
 // either the shape tree or group shape elements.
 type decodeSp struct {
+	Macro  string        `xml:"macro,attr"`
 	NvSpPr *decodeNvSpPr `xml:"nvSpPr"`
 	SpPr   *decodeSpPr   `xml:"spPr"`
+	Style  *decodeStyle  `xml:"style"`
+	TxBody *decodeTxBody `xml:"txBody"`
+}
+
+// decodeStyle directly maps the style element in the shape. This element
+// specifies the style information for the shape by referencing the theme.
+type decodeStyle struct {
+	LnRef   *decodeRef `xml:"lnRef"`
+	FillRef *decodeRef `xml:"fillRef"`
+}
+
+// decodeRef directly maps the lnRef and fillRef element.
+type decodeRef struct {
+	Idx     int            `xml:"idx,attr"`
+	SrgbClr *attrValString `xml:"srgbClr"`
+}
+
+// decodeSolidFill directly maps the solidFill element. This element
+// specifies a solid color fill.
+type decodeSolidFill struct {
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
+}
+
+// decodeSrgbClr directly maps the srgbClr element. This element specifies a
+// color using the red, green, blue RGB color model with the optional alpha
+// value.
+type decodeSrgbClr struct {
+	Val   *string     `xml:"val,attr"`
+	Alpha *attrValInt `xml:"alpha"`
+}
+
+// decodeLn directly maps the ln element. This element specifies an outline
+// style that can be applied to a number of different objects like shapes and
+// text.
+type decodeLn struct {
+	W         int              `xml:"w,attr"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	PrstDash  *attrValString   `xml:"prstDash"`
+	HeadEnd   *decodeLineEnd   `xml:"headEnd"`
+	TailEnd   *decodeLineEnd   `xml:"tailEnd"`
+}
+
+// decodeLineEnd directly maps the headEnd and tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line.
+type decodeLineEnd struct {
+	Type string `xml:"type,attr"`
+	W    string `xml:"w,attr"`
+}
+
+// decodeTxBody directly maps the txBody element. This element specifies the
+// existence of text to be contained within the corresponding shape.
+type decodeTxBody struct {
+	BodyPr *decodeBodyPr `xml:"bodyPr"`
+	P      []decodeP     `xml:"p"`
+}
+
+// decodeBodyPr directly maps the bodyPr element. This element defines the
+// body properties for the text body within a shape.
+type decodeBodyPr struct {
+	Anchor string `xml:"anchor,attr"`
+}
+
+// decodeP directly maps the p element. This element specifies the presence of
+// a paragraph of text within the containing text body.
+type decodeP struct {
+	PPr *decodePPr `xml:"pPr"`
+	R   []decodeR  `xml:"r"`
+}
+
+// decodePPr directly maps the pPr element. This element specifies a set of
+// paragraph properties which shall be applied to the contents of the parent
+// paragraph.
+type decodePPr struct {
+	Algn   string     `xml:"algn,attr"`
+	DefRPr *decodeRPr `xml:"defRPr"`
+}
+
+// decodeR directly maps the r element. This element specifies the presence of
+// a run of text within the containing text body.
+type decodeR struct {
+	RPr *decodeRPr `xml:"rPr"`
+	T   string     `xml:"t"`
+}
+
+// decodeRPr directly maps the rPr element. This element contains all run
+// level text properties.
+type decodeRPr struct {
+	B         bool             `xml:"b,attr"`
+	I         bool             `xml:"i,attr"`
+	Sz        float64          `xml:"sz,attr"`
+	U         string           `xml:"u,attr"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	Latin     *xlsxCTTextFont  `xml:"latin"`
 }
 
 // decodeSp (Non-Visual Properties for a Shape) directly maps the nvSpPr
@@ -77,10 +172,46 @@ type decodeWsDr struct {
 // specifies a two cell anchor placeholder for a group, a shape, or a drawing
 // element. It moves with cells and its extents are in EMU units.
 type decodeTwoCellAnchor struct {
-	From       *decodeFrom       `xml:"from"`
-	To         *decodeTo         `xml:"to"`
-	Pic        *decodePic        `xml:"pic"`
-	ClientData *decodeClientData `xml:"clientData"`
+	From             *decodeFrom             `xml:"from"`
+	To               *decodeTo               `xml:"to"`
+	Sp               *decodeSp               `xml:"sp"`
+	GrpSp            *xlsxInnerXML           `xml:"grpSp"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
+	CxnSp            *xlsxInnerXML           `xml:"cxnSp"`
+	AlternateContent *decodeAlternateContent `xml:"AlternateContent"`
+	Pic              *decodePic              `xml:"pic"`
+	ClientData       *decodeClientData       `xml:"clientData"`
+}
+
+// decodeGraphicFrame directly maps the graphicFrame element. This element
+// specifies the existence of a graphics frame, only the chart relationship of
+// the graphic frame will be decoded.
+type decodeGraphicFrame struct {
+	Graphic decodeGraphic `xml:"graphic"`
+}
+
+// decodeAlternateContent directly maps the AlternateContent element in the
+// cell anchor, which contains the graphic frame of the extended chart.
+type decodeAlternateContent struct {
+	Choice struct {
+		GraphicFrame *decodeGraphicFrame `xml:"graphicFrame"`
+	} `xml:"Choice"`
+}
+
+// decodeGraphic directly maps the graphic element.
+type decodeGraphic struct {
+	GraphicData decodeGraphicData `xml:"graphicData"`
+}
+
+// decodeGraphicData directly maps the graphicData element.
+type decodeGraphicData struct {
+	Chart *decodeGraphicChart `xml:"chart"`
+}
+
+// decodeGraphicChart directly maps the chart element in the graphic data,
+// which specifies the relationship ID of the chart part.
+type decodeGraphicChart struct {
+	RID string `xml:"id,attr"`
 }
 
 // decodeCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
@@ -115,9 +246,28 @@ type decodePicLocks struct {
 // decodeBlip element specifies the existence of an image (binary large image
 // or picture) and contains a reference to the image data.
 type decodeBlip struct {
-	Embed  string `xml:"embed,attr"`
-	Cstate string `xml:"cstate,attr,omitempty"`
-	R      string `xml:"r,attr"`
+	Embed   string             `xml:"embed,attr"`
+	Cstate  string             `xml:"cstate,attr,omitempty"`
+	R       string             `xml:"r,attr"`
+	ExtList *decodeBlipExtList `xml:"extLst"`
+}
+
+// decodeBlipExtList directly maps the extLst element of the blip, which used
+// for future extensibility.
+type decodeBlipExtList struct {
+	Ext []decodeBlipExt `xml:"ext"`
+}
+
+// decodeBlipExt directly maps the ext element of the blip extension list.
+type decodeBlipExt struct {
+	URI     string         `xml:"uri,attr"`
+	SVGBlip *decodeSVGBlip `xml:"svgBlip"`
+}
+
+// decodeSVGBlip specifies a graphic element in Scalable Vector Graphics (SVG)
+// format.
+type decodeSVGBlip struct {
+	Embed string `xml:"embed,attr"`
 }
 
 // decodeStretch directly maps the stretch element. This element specifies
@@ -153,6 +303,7 @@ type decodePrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type decodeXfrm struct {
+	Rot int       `xml:"rot,attr"`
 	Off decodeOff `xml:"off"`
 	Ext decodeExt `xml:"ext"`
 }
@@ -192,8 +343,27 @@ type decodeBlipFill struct {
 // properties of a shape but are used here to describe the visual appearance
 // of a picture within a document.
 type decodeSpPr struct {
-	Xfrm     decodeXfrm     `xml:"xfrm"`
-	PrstGeom decodePrstGeom `xml:"prstGeom"`
+	Xfrm      decodeXfrm       `xml:"xfrm"`
+	PrstGeom  decodePrstGeom   `xml:"prstGeom"`
+	SolidFill *decodeSolidFill `xml:"solidFill"`
+	GradFill  *decodeGradFill  `xml:"gradFill"`
+	Ln        *decodeLn        `xml:"ln"`
+}
+
+// decodeGradFill directly maps the gradFill element. This element defines a
+// gradient fill.
+type decodeGradFill struct {
+	Gs  []decodeGs `xml:"gsLst>gs"`
+	Lin *struct {
+		Ang int `xml:"ang,attr"`
+	} `xml:"lin"`
+}
+
+// decodeGs directly maps the gs element. This element defines a gradient
+// stop.
+type decodeGs struct {
+	Pos     int            `xml:"pos,attr"`
+	SrgbClr *decodeSrgbClr `xml:"srgbClr"`
 }
 
 // decodePic elements encompass the definition of pictures within the
@@ -229,6 +399,55 @@ type decodeTo struct {
 // protected, and fPrintsWithSheet attribute (either true or false) determines
 // whether the object is printed when the sheet is printed.
 type decodeClientData struct {
-	FLocksWithSheet  bool `xml:"fLocksWithSheet,attr"`
-	FPrintsWithSheet bool `xml:"fPrintsWithSheet,attr"`
+	FLocksWithSheet  *bool `xml:"fLocksWithSheet,attr"`
+	FPrintsWithSheet *bool `xml:"fPrintsWithSheet,attr"`
+}
+
+// decodeChartSpace defines the structure used to parse the chartSpace element
+// of the chart part.
+type decodeChartSpace struct {
+	XMLName xml.Name    `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	Chart   decodeChart `xml:"chart"`
+}
+
+// decodeChartSpaceFormat defines the structure used to parse the shape
+// properties of the series and the text properties of the axes in the plot
+// area of the chart part.
+type decodeChartSpaceFormat struct {
+	XMLName  xml.Name `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	PlotArea struct {
+		Elements []decodeChartPlotAreaElement `xml:",any"`
+	} `xml:"chart>plotArea"`
+}
+
+// decodeChartPlotAreaElement directly maps the chart group and axis elements
+// in the plot area.
+type decodeChartPlotAreaElement struct {
+	XMLName xml.Name
+	Ser     []struct {
+		SpPr *decodeSpPr `xml:"spPr"`
+	} `xml:"ser"`
+	TxPr *decodeTxBody `xml:"txPr"`
+}
+
+// decodeChart directly maps the chart element. This element specifies the
+// title, plot area and legend of the chart.
+type decodeChart struct {
+	Title            *decodeChartTitle  `xml:"title"`
+	AutoTitleDeleted *cAutoTitleDeleted `xml:"autoTitleDeleted"`
+	PlotArea         *cPlotArea         `xml:"plotArea"`
+	Legend           *cLegend           `xml:"legend"`
+	DispBlanksAs     *attrValString     `xml:"dispBlanksAs"`
+}
+
+// decodeChartTitle directly maps the title element. This element specifies a
+// title of the chart.
+type decodeChartTitle struct {
+	Tx decodeChartTx `xml:"tx"`
+}
+
+// decodeChartTx directly maps the tx element. This element specifies text to
+// use on a chart, including rich text formatting.
+type decodeChartTx struct {
+	Rich *decodeTxBody `xml:"rich"`
 }
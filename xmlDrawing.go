@@ -41,19 +41,25 @@ const (
 	ContentTypeAddinMacro                         = "application/vnd.ms-excel.addin.macroEnabled.main+xml"
 	ContentTypeDrawing                            = "application/vnd.openxmlformats-officedocument.drawing+xml"
 	ContentTypeDrawingML                          = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeDrawingMLChartEx                   = "application/vnd.ms-office.chartex+xml"
 	ContentTypeMacro                              = "application/vnd.ms-excel.sheet.macroEnabled.main+xml"
 	ContentTypeSheetML                            = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
 	ContentTypeSpreadSheetMLChartsheet            = "application/vnd.openxmlformats-officedocument.spreadsheetml.chartsheet+xml"
 	ContentTypeSpreadSheetMLComments              = "application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"
 	ContentTypeSpreadSheetMLPivotCacheDefinition  = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotCacheDefinition+xml"
+	ContentTypeSpreadSheetMLPerson                = "application/vnd.ms-excel.person+xml"
 	ContentTypeSpreadSheetMLPivotTable            = "application/vnd.openxmlformats-officedocument.spreadsheetml.pivotTable+xml"
 	ContentTypeSpreadSheetMLSharedStrings         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sharedStrings+xml"
+	ContentTypeSpreadSheetMLSheetMetadata         = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheetMetadata+xml"
 	ContentTypeSpreadSheetMLTable                 = "application/vnd.openxmlformats-officedocument.spreadsheetml.table+xml"
+	ContentTypeSpreadSheetMLThreadedComments      = "application/vnd.ms-excel.threadedcomments+xml"
 	ContentTypeSpreadSheetMLWorksheet             = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
 	ContentTypeTemplate                           = "application/vnd.openxmlformats-officedocument.spreadsheetml.template.main+xml"
 	ContentTypeTemplateMacro                      = "application/vnd.ms-excel.template.macroEnabled.main+xml"
 	ContentTypeVBA                                = "application/vnd.ms-office.vbaProject"
 	ContentTypeVML                                = "application/vnd.openxmlformats-officedocument.vmlDrawing"
+	NameSpaceDrawingMLChartEx                     = "http://schemas.microsoft.com/office/drawing/2014/chartex"
+	NameSpaceDrawingMLChartEx201509               = "http://schemas.microsoft.com/office/drawing/2015/9/8/chartex"
 	NameSpaceDrawingMLMain                        = "http://schemas.openxmlformats.org/drawingml/2006/main"
 	NameSpaceDublinCore                           = "http://purl.org/dc/elements/1.1/"
 	NameSpaceDublinCoreMetadataInitiative         = "http://purl.org/dc/dcmitype/"
@@ -62,6 +68,7 @@ const (
 	NameSpaceXML                                  = "http://www.w3.org/XML/1998/namespace"
 	NameSpaceXMLSchemaInstance                    = "http://www.w3.org/2001/XMLSchema-instance"
 	SourceRelationshipChart                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	SourceRelationshipChartEx                     = "http://schemas.microsoft.com/office/2014/relationships/chartEx"
 	SourceRelationshipChartsheet                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chartsheet"
 	SourceRelationshipComments                    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments"
 	SourceRelationshipDialogsheet                 = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/dialogsheet"
@@ -71,10 +78,13 @@ const (
 	SourceRelationshipHyperLink                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
 	SourceRelationshipImage                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
 	SourceRelationshipOfficeDocument              = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	SourceRelationshipPerson                      = "http://schemas.microsoft.com/office/2017/10/relationships/person"
 	SourceRelationshipPivotCache                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotCacheDefinition"
 	SourceRelationshipPivotTable                  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/pivotTable"
 	SourceRelationshipSharedStrings               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings"
+	SourceRelationshipSheetMetadata               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/sheetMetadata"
 	SourceRelationshipTable                       = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/table"
+	SourceRelationshipThreadedComment             = "http://schemas.microsoft.com/office/2017/10/relationships/threadedComment"
 	SourceRelationshipVBAProject                  = "http://schemas.microsoft.com/office/2006/relationships/vbaProject"
 	SourceRelationshipWorkSheet                   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
 	StrictNameSpaceDocumentPropertiesVariantTypes = "http://purl.oclc.org/ooxml/officeDocument/docPropsVTypes"
@@ -124,6 +134,8 @@ const (
 	MaxCellStyles        = 65430
 	MaxColumns           = 16384
 	MaxColumnWidth       = 255
+	MaxExpandRangeCells  = 1 << 20
+	MaxFetchPictureSize  = 100 << 20
 	MaxFieldLength       = 255
 	MaxFilePathLength    = 207
 	MaxFontFamilyLength  = 31
@@ -148,6 +160,16 @@ const (
 	defaultChartLegendPosition  = "bottom"
 	defaultChartShowBlanksAs    = "gap"
 	defaultShapeSize            = 160
+	defaultCommentWidth         = 144
+	defaultCommentHeight        = 79
+	defaultCommentFontSize      = 9
+	maxCommentAutoSizeWidth     = 400
+	defaultShapeGradientAngle   = 90
+	maxCellIndent               = 250
+	defaultShapeShadowBlur      = 4
+	defaultShapeShadowDistance  = 3
+	defaultShapeShadowDirection = 45
+	defaultShapeShadowAlpha     = 60
 	defaultShapeLineWidth       = 1
 )
 
@@ -199,6 +221,14 @@ var supportedImageTypes = map[string]string{
 	".tif": ".tiff", ".tiff": ".tiff", ".wmf": ".wmf", ".wmz": ".wmz",
 }
 
+// supportedImageContentTypes defined supported image media types and the
+// picture extension names.
+var supportedImageContentTypes = map[string]string{
+	"image/bmp": ".bmp", "image/emf": ".emf", "image/x-emf": ".emf", "image/gif": ".gif",
+	"image/jpeg": ".jpeg", "image/png": ".png", "image/svg+xml": ".svg", "image/tiff": ".tiff",
+	"image/wmf": ".wmf", "image/x-wmf": ".wmf",
+}
+
 // supportedContentTypes defined supported file format types.
 var supportedContentTypes = map[string]string{
 	".xlam": ContentTypeAddinMacro,
@@ -218,6 +248,36 @@ var supportedDrawingUnderlineTypes = []string{
 	"wavyDbl",
 }
 
+// shapeTextVerticalAlignments defined supported vertical alignment types of
+// the text in the shape and the corresponding anchor type of the text body.
+var shapeTextVerticalAlignments = map[string]string{"top": "t", "center": "ctr", "bottom": "b"}
+
+// shapeTextAlignments defined supported horizontal alignment types of the
+// paragraphs in the shape and the corresponding alignment type of the
+// paragraph properties.
+var shapeTextAlignments = map[string]string{"left": "l", "center": "ctr", "right": "r", "justify": "just"}
+
+// supportedConnectorTypes defined supported preset geometry types of the
+// connector shape.
+var supportedConnectorTypes = []string{
+	"straightConnector1", "bentConnector2", "bentConnector3", "bentConnector4", "bentConnector5",
+	"curvedConnector2", "curvedConnector3", "curvedConnector4", "curvedConnector5",
+}
+
+// supportedLineEndTypes defined supported line end types in drawing markup
+// language.
+var supportedLineEndTypes = []string{"none", "triangle", "stealth", "diamond", "oval", "arrow"}
+
+// supportedLineDashTypes defined supported preset line dash types in drawing
+// markup language.
+var supportedLineDashTypes = []string{
+	"solid", "dot", "dash", "lgDash", "dashDot", "lgDashDot", "lgDashDotDot", "sysDash", "sysDot", "sysDashDot", "sysDashDotDot",
+}
+
+// lineEndSizes defined supported size types of the line end and the
+// corresponding width and length type in drawing markup language.
+var lineEndSizes = map[string]string{"small": "sm", "medium": "med", "large": "lg"}
+
 // xlsxCNvPr directly maps the cNvPr (Non-Visual Drawing Properties). This
 // element specifies non-visual canvas properties. This allows for additional
 // information that does not affect the appearance of the picture to be stored.
@@ -304,8 +364,11 @@ type xlsxPrstGeom struct {
 // frame. This transformation is applied to the graphic frame just as it would
 // be for a shape or group shape.
 type xlsxXfrm struct {
-	Off xlsxOff `xml:"a:off"`
-	Ext xlsxExt `xml:"a:ext"`
+	Rot   int     `xml:"rot,attr,omitempty"`
+	FlipH bool    `xml:"flipH,attr,omitempty"`
+	FlipV bool    `xml:"flipV,attr,omitempty"`
+	Off   xlsxOff `xml:"a:off"`
+	Ext   xlsxExt `xml:"a:ext"`
 }
 
 // xlsxCNvPicPr directly maps the cNvPicPr (Non-Visual Picture Drawing
@@ -363,7 +426,19 @@ type xlsxBlipFill struct {
 // has a minimum value of greater than or equal to 0. This simple type has a
 // maximum value of less than or equal to 20116800.
 type xlsxLineProperties struct {
-	W int `xml:"w,attr,omitempty"`
+	W         int              `xml:"w,attr,omitempty"`
+	SolidFill *aSolidFillAlpha `xml:"a:solidFill"`
+	PrstDash  *attrValString   `xml:"a:prstDash"`
+	HeadEnd   *aLineEnd        `xml:"a:headEnd"`
+	TailEnd   *aLineEnd        `xml:"a:tailEnd"`
+}
+
+// aLineEnd directly maps the a:headEnd and a:tailEnd element. This element
+// specifies decorations which can be added to the head or tail of a line.
+type aLineEnd struct {
+	Type string `xml:"type,attr,omitempty"`
+	W    string `xml:"w,attr,omitempty"`
+	Len  string `xml:"len,attr,omitempty"`
 }
 
 // xlsxSpPr directly maps the spPr (Shape Properties). This element specifies
@@ -372,9 +447,68 @@ type xlsxLineProperties struct {
 // but are used here to describe the visual appearance of a picture within a
 // document.
 type xlsxSpPr struct {
-	Xfrm     xlsxXfrm           `xml:"a:xfrm"`
-	PrstGeom xlsxPrstGeom       `xml:"a:prstGeom"`
-	Ln       xlsxLineProperties `xml:"a:ln"`
+	Xfrm      xlsxXfrm           `xml:"a:xfrm"`
+	PrstGeom  xlsxPrstGeom       `xml:"a:prstGeom"`
+	SolidFill *aSolidFillAlpha   `xml:"a:solidFill"`
+	GradFill  *aGradFill         `xml:"a:gradFill"`
+	Ln        xlsxLineProperties `xml:"a:ln"`
+	EffectLst *aEffectLst        `xml:"a:effectLst"`
+}
+
+// aGradFill (Gradient Fill) directly maps the a:gradFill element. This
+// element defines a gradient fill, the gradient stops are specified in the
+// a:gsLst element and the direction is specified by the a:lin element.
+type aGradFill struct {
+	RotWithShape string `xml:"rotWithShape,attr,omitempty"`
+	GsLst        []aGs  `xml:"a:gsLst>a:gs"`
+	Lin          *aLin  `xml:"a:lin"`
+}
+
+// aGs (Gradient Stops) directly maps the a:gs element. This element defines
+// a gradient stop, the position is measured in 1000ths of a percent.
+type aGs struct {
+	Pos     int           `xml:"pos,attr"`
+	SrgbClr aSrgbClrAlpha `xml:"a:srgbClr"`
+}
+
+// aLin (Linear Gradient Fill) directly maps the a:lin element. This element
+// specifies a linear gradient, the angle is measured in 60,000ths of a
+// degree.
+type aLin struct {
+	Ang    int  `xml:"ang,attr"`
+	Scaled bool `xml:"scaled,attr"`
+}
+
+// aEffectLst (Effect Container) directly maps the a:effectLst element. This
+// element specifies a list of effects. Effects in an effectLst are applied in
+// the default order by the rendering engine.
+type aEffectLst struct {
+	OuterShdw *aOuterShdw `xml:"a:outerShdw"`
+}
+
+// aOuterShdw (Outer Shadow Effect) directly maps the a:outerShdw element. This
+// element specifies an outer shadow effect, the blur radius and the distance
+// are measured in EMUs and the direction is measured in 60,000ths of a degree.
+type aOuterShdw struct {
+	BlurRad      int           `xml:"blurRad,attr"`
+	Dist         int           `xml:"dist,attr"`
+	Dir          int           `xml:"dir,attr"`
+	Algn         string        `xml:"algn,attr,omitempty"`
+	RotWithShape string        `xml:"rotWithShape,attr,omitempty"`
+	SrgbClr      aSrgbClrAlpha `xml:"a:srgbClr"`
+}
+
+// aSrgbClrAlpha directly maps the a:srgbClr element with the a:alpha color
+// transform, the alpha value is measured in 1000ths of a percent.
+type aSrgbClrAlpha struct {
+	Val   string      `xml:"val,attr"`
+	Alpha *attrValInt `xml:"a:alpha"`
+}
+
+// aSolidFillAlpha directly maps the a:solidFill element with the RGB color
+// which can be specified with the alpha value.
+type aSolidFillAlpha struct {
+	SrgbClr aSrgbClrAlpha `xml:"a:srgbClr"`
 }
 
 // xlsxPic elements encompass the definition of pictures within the DrawingML
@@ -425,6 +559,7 @@ type xdrCellAnchor struct {
 	To           *xlsxTo        `xml:"xdr:to"`
 	Ext          *xlsxExt       `xml:"xdr:ext"`
 	Sp           *xdrSp         `xml:"xdr:sp"`
+	CxnSp        *xdrCxnSp      `xml:"xdr:cxnSp"`
 	Pic          *xlsxPic       `xml:"xdr:pic,omitempty"`
 	GraphicFrame string         `xml:",innerxml"`
 	ClientData   *xdrClientData `xml:"xdr:clientData"`
@@ -489,8 +624,9 @@ type xlsxGraphic struct {
 // document. This graphic object is provided entirely by the document authors
 // who choose to persist this data within the document.
 type xlsxGraphicData struct {
-	URI   string     `xml:"uri,attr"`
-	Chart *xlsxChart `xml:"c:chart,omitempty"`
+	URI     string       `xml:"uri,attr"`
+	Chart   *xlsxChart   `xml:"c:chart,omitempty"`
+	ChartEx *xlsxChartEx `xml:"cx:chart,omitempty"`
 }
 
 // xlsxChart (Chart) directly maps the c:chart element.
@@ -500,6 +636,14 @@ type xlsxChart struct {
 	R   string `xml:"xmlns:r,attr"`
 }
 
+// xlsxChartEx (Extended Chart) directly maps the cx:chart element, which
+// references the extended chart part, such as sunburst and treemap chart.
+type xlsxChartEx struct {
+	CX  string `xml:"xmlns:cx,attr"`
+	RID string `xml:"r:id,attr"`
+	R   string `xml:"xmlns:r,attr"`
+}
+
 // xdrSp (Shape) directly maps the xdr:sp element. This element specifies the
 // existence of a single shape. A shape can either be a preset or a custom
 // geometry, defined using the SpreadsheetDrawingML framework. In addition to a
@@ -535,6 +679,40 @@ type xdrCNvSpPr struct {
 	TxBox bool `xml:"txBox,attr"`
 }
 
+// xdrCxnSp (Connection Shape) directly maps the xdr:cxnSp element. This
+// element specifies a connection shape that is used to connect two sp
+// elements.
+type xdrCxnSp struct {
+	Macro     string        `xml:"macro,attr"`
+	NvCxnSpPr *xdrNvCxnSpPr `xml:"xdr:nvCxnSpPr"`
+	SpPr      *xlsxSpPr     `xml:"xdr:spPr"`
+	Style     *xdrStyle     `xml:"xdr:style"`
+}
+
+// xdrNvCxnSpPr (Non-Visual Properties for a Connection Shape) directly maps
+// the xdr:nvCxnSpPr element. This element specifies all non-visual properties
+// for a connection shape.
+type xdrNvCxnSpPr struct {
+	CNvPr      *xlsxCNvPr     `xml:"xdr:cNvPr"`
+	CNvCxnSpPr *xdrCNvCxnSpPr `xml:"xdr:cNvCxnSpPr"`
+}
+
+// xdrCNvCxnSpPr (Non-Visual Connector Shape Drawing Properties) directly maps
+// the xdr:cNvCxnSpPr element. This element specifies the shapes which the
+// connection shape is connected to.
+type xdrCNvCxnSpPr struct {
+	StCxn  *aCxn `xml:"a:stCxn"`
+	EndCxn *aCxn `xml:"a:endCxn"`
+}
+
+// aCxn directly maps the a:stCxn and a:endCxn element. The ID specifies the
+// shape ID of the connected shape and the Idx specifies the index of the
+// connection site on that shape.
+type aCxn struct {
+	ID  int `xml:"id,attr"`
+	Idx int `xml:"idx,attr"`
+}
+
 // xdrStyle (Shape Style) directly maps the xdr:style element. The element
 // specifies the style that is applied to a shape and the corresponding
 // references for each of the style components such as lines and fills.
@@ -581,11 +759,23 @@ type xdrTxBody struct {
 	P      []*aP    `xml:"a:p"`
 }
 
-// Picture maps the format settings of the picture.
+// Picture maps the format settings of the picture. The Fallback specifies the
+// raster picture which will be displayed by the applications which don't
+// support the SVG picture, it only works for the SVG picture. The Width,
+// Height and DPI are the natural dimensions in pixels and the horizontal
+// resolution in dots per inch of the picture, and the ExtentCx and ExtentCy
+// are the displayed width and height of the picture in EMUs, these fields
+// will be filled by the GetPictures function and ignored on adding pictures.
 type Picture struct {
 	Extension string
 	File      []byte
+	Fallback  []byte
 	Format    *GraphicOptions
+	Width     int
+	Height    int
+	DPI       float64
+	ExtentCx  int
+	ExtentCy  int
 }
 
 // GraphicOptions directly maps the format settings of the picture.
@@ -604,16 +794,58 @@ type GraphicOptions struct {
 	Positioning     string
 }
 
-// Shape directly maps the format settings of the shape.
+// DrawingObject directly maps the drawing object of the worksheet. The ZOrder
+// specifies the stacking order of the object, the object with a greater
+// z-order is drawn in front of the objects with a smaller z-order. The Type
+// of the object could be 'chart', 'connector', 'group', 'picture' or 'shape'.
+type DrawingObject struct {
+	Cell   string
+	Type   string
+	Name   string
+	ZOrder int
+}
+
+// Shape directly maps the format settings of the shape. The Cell specifies the
+// anchor cell of the shape, it will be filled by the GetShapes function and
+// ignored on adding shapes.
 type Shape struct {
-	Type      string
-	Macro     string
-	Width     uint
-	Height    uint
-	Format    GraphicOptions
-	Fill      Fill
-	Line      ShapeLine
-	Paragraph []RichTextRun
+	Cell          string
+	Type          string
+	Macro         string
+	Width         uint
+	Height        uint
+	Rotation      float64
+	Format        GraphicOptions
+	Fill          Fill
+	FillFormat    ShapeFill
+	Line          ShapeLine
+	Shadow        *ShapeShadow
+	Hyperlink     string
+	HyperlinkType string
+	Tooltip       string
+	TextVAlign    string
+	TextAlign     string
+	Paragraph     []RichTextRun
+}
+
+// Connector directly maps the format settings of the connector shape. The
+// From and To specify the cells that the connector stretches between, and
+// the StartShapeID and EndShapeID specify the optional IDs of the shapes which
+// the connector is bound to.
+type Connector struct {
+	Type         string
+	From         string
+	To           string
+	StartShapeID int
+	StartSite    int
+	EndShapeID   int
+	EndSite      int
+	// Deprecated: Use the TailType of the Line instead. The EndArrow will be
+	// ignored if the TailType of the Line was specified.
+	EndArrow string
+	Macro    string
+	Format   GraphicOptions
+	Line     ShapeLine
 }
 
 // ShapeColor directly maps the color settings of the shape.
@@ -623,8 +855,39 @@ type ShapeColor struct {
 	Effect string
 }
 
-// ShapeLine directly maps the line settings of the shape.
+// ShapeFill directly maps the fill settings of the shape which are not
+// available for the cells. The Angle specifies the direction in degrees of the
+// linear gradient fill, default is 90 if it is nil, and the Transparency
+// specifies the transparency percentage in range 0 to 100 of the solid fill
+// or each gradient stop of the gradient fill.
+type ShapeFill struct {
+	Angle        *float64
+	Transparency int
+}
+
+// ShapeLine directly maps the line settings of the shape. The Transparency
+// specifies the transparency percentage in range 0 to 100 of the line color,
+// the DashType specifies the preset dash style of the line, the HeadType and
+// TailType specify the decorations at the start and the end of the line, and
+// the HeadSize and TailSize specify the size of the decorations.
 type ShapeLine struct {
-	Color string
-	Width *float64
+	Color        string
+	Width        *float64
+	Transparency int
+	DashType     string
+	HeadType     string
+	HeadSize     string
+	TailType     string
+	TailSize     string
+}
+
+// ShapeShadow directly maps the outer shadow settings of the shape. The Blur
+// and Distance are measured in points, the Direction is measured in degrees
+// and the Transparency is a percentage in range 0 to 100.
+type ShapeShadow struct {
+	Color        string
+	Blur         float64
+	Distance     float64
+	Direction    float64
+	Transparency int
 }
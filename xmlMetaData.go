@@ -0,0 +1,177 @@
+// Copyright 2016 - 2023 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.16 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxMetadata directly maps the metadata element. A cell in a spreadsheet
+// application can have metadata associated with it. Metadata is just a set of
+// additional properties about the particular cell, and this metadata is stored
+// in the metadata xml part.
+type xlsxMetadata struct {
+	XMLName         xml.Name              `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main metadata"`
+	MetadataTypes   *xlsxMetadataTypes    `xml:"metadataTypes"`
+	MetadataStrings *xlsxMetadataInnerXML `xml:"metadataStrings"`
+	MdxMetadata     *xlsxMetadataInnerXML `xml:"mdxMetadata"`
+	FutureMetadata  []xlsxFutureMetadata  `xml:"futureMetadata"`
+	CellMetadata    *xlsxMetadataBlocks   `xml:"cellMetadata"`
+	ValueMetadata   *xlsxMetadataBlocks   `xml:"valueMetadata"`
+	ExtLst          *xlsxInnerXML         `xml:"extLst"`
+}
+
+// xlsxMetadataInnerXML holds the metadataStrings and mdxMetadata elements,
+// which currently not supported, and the content of them will be retained.
+type xlsxMetadataInnerXML struct {
+	Count   int    `xml:"count,attr,omitempty"`
+	Content string `xml:",innerxml"`
+}
+
+// xlsxMetadataTypes directly maps the metadataTypes element. This element
+// represents the collection of metadata types within the workbook.
+type xlsxMetadataTypes struct {
+	Count        int                `xml:"count,attr"`
+	MetadataType []xlsxMetadataType `xml:"metadataType"`
+}
+
+// xlsxMetadataType directly maps the metadataType element. This element
+// represents a single metadata type, the boolean attributes specify the
+// behaviors of the metadata on the cell operations.
+type xlsxMetadataType struct {
+	Name                string `xml:"name,attr"`
+	MinSupportedVersion int    `xml:"minSupportedVersion,attr"`
+	GhostRow            bool   `xml:"ghostRow,attr,omitempty"`
+	GhostCol            bool   `xml:"ghostCol,attr,omitempty"`
+	Edit                bool   `xml:"edit,attr,omitempty"`
+	Delete              bool   `xml:"delete,attr,omitempty"`
+	Copy                bool   `xml:"copy,attr,omitempty"`
+	PasteAll            bool   `xml:"pasteAll,attr,omitempty"`
+	PasteFormulas       bool   `xml:"pasteFormulas,attr,omitempty"`
+	PasteValues         bool   `xml:"pasteValues,attr,omitempty"`
+	PasteFormats        bool   `xml:"pasteFormats,attr,omitempty"`
+	PasteComments       bool   `xml:"pasteComments,attr,omitempty"`
+	PasteDataValidation bool   `xml:"pasteDataValidation,attr,omitempty"`
+	PasteBorders        bool   `xml:"pasteBorders,attr,omitempty"`
+	PasteColWidths      bool   `xml:"pasteColWidths,attr,omitempty"`
+	PasteNumberFormats  bool   `xml:"pasteNumberFormats,attr,omitempty"`
+	Merge               bool   `xml:"merge,attr,omitempty"`
+	SplitFirst          bool   `xml:"splitFirst,attr,omitempty"`
+	SplitAll            bool   `xml:"splitAll,attr,omitempty"`
+	RowColShift         bool   `xml:"rowColShift,attr,omitempty"`
+	ClearAll            bool   `xml:"clearAll,attr,omitempty"`
+	ClearFormats        bool   `xml:"clearFormats,attr,omitempty"`
+	ClearContents       bool   `xml:"clearContents,attr,omitempty"`
+	ClearComments       bool   `xml:"clearComments,attr,omitempty"`
+	Assign              bool   `xml:"assign,attr,omitempty"`
+	Coerce              bool   `xml:"coerce,attr,omitempty"`
+	Adjust              bool   `xml:"adjust,attr,omitempty"`
+	CellMeta            bool   `xml:"cellMeta,attr,omitempty"`
+}
+
+// xlsxFutureMetadata directly maps the futureMetadata element. This element
+// represents future metadata information.
+type xlsxFutureMetadata struct {
+	Name   string                    `xml:"name,attr"`
+	Count  int                       `xml:"count,attr"`
+	Bk     []xlsxFutureMetadataBlock `xml:"bk"`
+	ExtLst *xlsxInnerXML             `xml:"extLst"`
+}
+
+// xlsxFutureMetadataBlock directly maps the bk element of the future metadata.
+// This element represents a block of future metadata information, the
+// extensions of the block such as the rich value block and the dynamic array
+// properties will be retained.
+type xlsxFutureMetadataBlock struct {
+	ExtLst *xlsxInnerXML `xml:"extLst"`
+}
+
+// xlsxRichValueBlock directly maps the rvb element. This element specifies a
+// rich value structure index.
+type xlsxRichValueBlock struct {
+	I int `xml:"i,attr"`
+}
+
+// decodeFutureMetadataExtLst defined the structure used to parse the rich
+// value block in the extension list of the future metadata block.
+type decodeFutureMetadataExtLst struct {
+	RichValueBlock *xlsxRichValueBlock `xml:"ext>rvb"`
+}
+
+// xlsxMetadataBlocks directly maps the cellMetadata and valueMetadata
+// elements. These elements represent the metadata blocks for the cells and
+// cell values.
+type xlsxMetadataBlocks struct {
+	Count int                 `xml:"count,attr"`
+	Bk    []xlsxMetadataBlock `xml:"bk"`
+}
+
+// xlsxMetadataBlock directly maps the bk element. This element represents a
+// block of metadata records.
+type xlsxMetadataBlock struct {
+	Rc []xlsxMetadataRecord `xml:"rc"`
+}
+
+// xlsxMetadataRecord directly maps the rc element. This element represents a
+// reference to a metadata record, the t attribute specifies the 1-based index
+// of the metadata type, and the v attribute specifies the 0-based index of
+// the metadata record.
+type xlsxMetadataRecord struct {
+	T int `xml:"t,attr"`
+	V int `xml:"v,attr"`
+}
+
+// xlsxRichValueData directly maps the rvData element. This element specifies
+// rich value data.
+type xlsxRichValueData struct {
+	XMLName xml.Name        `xml:"rvData"`
+	Rv      []xlsxRichValue `xml:"rv"`
+}
+
+// xlsxRichValue directly maps the rv element. This element specifies rich
+// value data, the s attribute specifies the index of the rich value structure.
+type xlsxRichValue struct {
+	S int      `xml:"s,attr"`
+	V []string `xml:"v"`
+}
+
+// xlsxRichValueStructures directly maps the rvStructures element. This
+// element specifies rich value structures.
+type xlsxRichValueStructures struct {
+	XMLName xml.Name                 `xml:"rvStructures"`
+	S       []xlsxRichValueStructure `xml:"s"`
+}
+
+// xlsxRichValueStructure directly maps the s element. This element specifies
+// a rich value structure.
+type xlsxRichValueStructure struct {
+	T string                      `xml:"t,attr"`
+	K []xlsxRichValueStructureKey `xml:"k"`
+}
+
+// xlsxRichValueStructureKey directly maps the k element. This element
+// specifies a key in a rich value structure.
+type xlsxRichValueStructureKey struct {
+	N string `xml:"n,attr"`
+	T string `xml:"t,attr"`
+}
+
+// xlsxRichValueRels directly maps the richValueRels element. This element
+// specifies a list of rich value relationships.
+type xlsxRichValueRels struct {
+	XMLName xml.Name                   `xml:"richValueRels"`
+	Rels    []xlsxRichValueRelRelation `xml:"rel"`
+}
+
+// xlsxRichValueRelRelation directly maps the rel element. This element
+// specifies a relationship for a rich value property.
+type xlsxRichValueRelRelation struct {
+	ID string `xml:"id,attr"`
+}
@@ -361,6 +361,17 @@ type Protection struct {
 	Locked bool
 }
 
+// FractionNumFmtOptions directly maps the settings of the fraction number
+// format code. The DenominatorDigits specifies the maximum number of digits of
+// the denominator, and the Denominator specifies the fixed denominator, only
+// one of them could be specified. The ImproperFraction specifies if displaying
+// the value as an improper fraction without the integer part.
+type FractionNumFmtOptions struct {
+	DenominatorDigits int
+	Denominator       int
+	ImproperFraction  bool
+}
+
 // Style directly maps the style settings of the cells.
 type Style struct {
 	Border        []Border
@@ -171,18 +171,29 @@ type xlsxTableColumns struct {
 // xlsxTableColumn directly maps the element representing a single column for
 // this table.
 type xlsxTableColumn struct {
-	DataCellStyle      string `xml:"dataCellStyle,attr,omitempty"`
-	DataDxfID          int    `xml:"dataDxfId,attr,omitempty"`
-	HeaderRowCellStyle string `xml:"headerRowCellStyle,attr,omitempty"`
-	HeaderRowDxfID     int    `xml:"headerRowDxfId,attr,omitempty"`
-	ID                 int    `xml:"id,attr"`
-	Name               string `xml:"name,attr"`
-	QueryTableFieldID  int    `xml:"queryTableFieldId,attr,omitempty"`
-	TotalsRowCellStyle string `xml:"totalsRowCellStyle,attr,omitempty"`
-	TotalsRowDxfID     int    `xml:"totalsRowDxfId,attr,omitempty"`
-	TotalsRowFunction  string `xml:"totalsRowFunction,attr,omitempty"`
-	TotalsRowLabel     string `xml:"totalsRowLabel,attr,omitempty"`
-	UniqueName         string `xml:"uniqueName,attr,omitempty"`
+	DataCellStyle           string            `xml:"dataCellStyle,attr,omitempty"`
+	DataDxfID               int               `xml:"dataDxfId,attr,omitempty"`
+	HeaderRowCellStyle      string            `xml:"headerRowCellStyle,attr,omitempty"`
+	HeaderRowDxfID          int               `xml:"headerRowDxfId,attr,omitempty"`
+	ID                      int               `xml:"id,attr"`
+	Name                    string            `xml:"name,attr"`
+	QueryTableFieldID       int               `xml:"queryTableFieldId,attr,omitempty"`
+	TotalsRowCellStyle      string            `xml:"totalsRowCellStyle,attr,omitempty"`
+	TotalsRowDxfID          int               `xml:"totalsRowDxfId,attr,omitempty"`
+	TotalsRowFunction       string            `xml:"totalsRowFunction,attr,omitempty"`
+	TotalsRowLabel          string            `xml:"totalsRowLabel,attr,omitempty"`
+	UniqueName              string            `xml:"uniqueName,attr,omitempty"`
+	CalculatedColumnFormula *xlsxTableFormula `xml:"calculatedColumnFormula"`
+	TotalsRowFormula        *xlsxTableFormula `xml:"totalsRowFormula"`
+}
+
+// xlsxTableFormula directly maps the calculatedColumnFormula and
+// totalsRowFormula element. This element contains the formula that is used to
+// perform the calculation for each cell in the calculated column or the
+// totals row of the table.
+type xlsxTableFormula struct {
+	Array   bool   `xml:"array,attr,omitempty"`
+	Content string `xml:",chardata"`
 }
 
 // xlsxTableStyleInfo directly maps the tableStyleInfo element. This element
@@ -206,10 +217,46 @@ type Table struct {
 	ShowHeaderRow     *bool
 	ShowLastColumn    bool
 	ShowRowStripes    *bool
+	CalculatedColumns []TableCalculatedColumn
+	TotalsRow         bool
+	TotalsRowColumns  []TableTotalsRowColumn
+	AutoFilter        []AutoFilterOptions
+}
+
+// TableCalculatedColumn directly maps the calculated column of the table. The
+// Name specifies the header name of the column, and the Formula will be
+// applied to all data rows of the column.
+type TableCalculatedColumn struct {
+	Name    string
+	Formula string
+}
+
+// TableTotalsRowColumn directly maps the totals row settings of the table
+// column. The Name specifies the header name of the column, the Label
+// specifies the text in the totals row cell, the Function specifies the
+// aggregate function, and the Formula specifies the formula of the custom
+// function.
+type TableTotalsRowColumn struct {
+	Name     string
+	Label    string
+	Function string
+	Formula  string
 }
 
 // AutoFilterOptions directly maps the auto filter settings.
 type AutoFilterOptions struct {
-	Column     string
-	Expression string
+	Column        string
+	Expression    string
+	Values        []string
+	Top10         *AutoFilterTop10
+	DynamicFilter string
+}
+
+// AutoFilterTop10 directly maps the top 10 filter settings of the auto filter
+// column. Val specifies the number of items, or the percentage if Percent is
+// true. Set Bottom as true to filter the smallest values.
+type AutoFilterTop10 struct {
+	Bottom  bool
+	Percent bool
+	Val     float64
 }
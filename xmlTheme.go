@@ -161,3 +161,20 @@ type xlsxSysClr struct {
 	Val     string `xml:"val,attr"`
 	LastClr string `xml:"lastClr,attr"`
 }
+
+// ThemeFonts directly maps the major or minor fonts of the theme font scheme.
+// The Latin, EastAsian and ComplexScript specifies the typeface of the font
+// for Latin, East Asian and complex script text.
+type ThemeFonts struct {
+	Latin         string
+	EastAsian     string
+	ComplexScript string
+}
+
+// ThemeFontScheme directly maps the font scheme of the theme. The Major
+// specifies the fonts for the headings, and the Minor specifies the fonts for
+// the body text.
+type ThemeFontScheme struct {
+	Major ThemeFonts
+	Minor ThemeFonts
+}
@@ -58,6 +58,7 @@ type xlsxWorkbook struct {
 	FileRecoveryPr         *xlsxFileRecoveryPr      `xml:"fileRecoveryPr"`
 	WebPublishObjects      *xlsxExtLst              `xml:"webPublishObjects"`
 	ExtLst                 *xlsxExtLst              `xml:"extLst"`
+	UnknownElements        []xlsxUnknownElement     `xml:",any"`
 }
 
 // xlsxFileRecoveryPr maps sheet recovery information. This element defines
@@ -323,6 +324,7 @@ type DefinedName struct {
 	Comment  string
 	RefersTo string
 	Scope    string
+	Hidden   bool
 }
 
 // WorkbookPropsOptions directly maps the settings of workbook proprieties.
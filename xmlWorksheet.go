@@ -62,6 +62,7 @@ type xlsxWorksheet struct {
 	TableParts             *xlsxTableParts              `xml:"tableParts"`
 	ExtLst                 *xlsxExtLst                  `xml:"extLst"`
 	DecodeAlternateContent *xlsxInnerXML                `xml:"http://schemas.openxmlformats.org/markup-compatibility/2006 AlternateContent"`
+	UnknownElements        []xlsxUnknownElement         `xml:",any"`
 }
 
 // xlsxDrawing change r:id to rid in the namespace.
@@ -600,7 +601,7 @@ type xlsxIconSet struct {
 // cfvo (Conditional Format Value Object) describes the values of the
 // interpolation points in a gradient scale.
 type xlsxCfvo struct {
-	Gte    bool        `xml:"gte,attr,omitempty"`
+	Gte    *bool       `xml:"gte,attr"`
 	Type   string      `xml:"type,attr,omitempty"`
 	Val    string      `xml:"val,attr,omitempty"`
 	ExtLst *xlsxExtLst `xml:"extLst"`
@@ -713,6 +714,15 @@ type xlsxInnerXML struct {
 	Content string `xml:",innerxml"`
 }
 
+// xlsxUnknownElement holds the element which currently not supported, it
+// will be retained and re-emitted with the KeepUnknownElements option.
+type xlsxUnknownElement struct {
+	XMLName    xml.Name
+	Attr       []xml.Attr
+	Content    string
+	namespaces []xml.Attr
+}
+
 // xlsxWorksheetExt directly maps the ext element in the worksheet.
 type xlsxWorksheetExt struct {
 	XMLName xml.Name `xml:"ext"`
@@ -753,6 +763,14 @@ type decodeX14ConditionalFormattings struct {
 type decodeX14ConditionalFormatting struct {
 	XMLName xml.Name           `xml:"conditionalFormatting"`
 	CfRule  []*decodeX14CfRule `xml:"cfRule"`
+	SQRef   string             `xml:"sqref"`
+	Content string             `xml:",innerxml"`
+}
+
+// decodeX14ConditionalFormattingRules directly maps the content of the
+// conditionalFormattings element.
+type decodeX14ConditionalFormattingRules struct {
+	CondFmt []*decodeX14ConditionalFormatting `xml:"conditionalFormatting"`
 }
 
 // decodeX14CfRule directly maps the cfRule element.
@@ -916,8 +934,11 @@ type Panes struct {
 type ConditionalFormatOptions struct {
 	Type           string
 	AboveAverage   bool
+	StdDev         int
 	Percent        bool
 	Format         int
+	FormatStyle    *Style
+	FormatPreset   string
 	Criteria       string
 	Value          string
 	MinType        string
@@ -937,9 +958,37 @@ type ConditionalFormatOptions struct {
 	IconStyle      string
 	ReverseIcons   bool
 	IconsOnly      bool
+	IconThresholds []ConditionalFormatIconThreshold
 	StopIfTrue     bool
 }
 
+// ConditionalFormatIconThreshold directly maps the threshold of the icon in
+// the icon set conditional formats. The Type specifies the type of the value,
+// and the GreaterThan specifies if the icon will be used for the cell values
+// which greater than the threshold value, otherwise for the cell values which
+// greater than or equal to the threshold value.
+type ConditionalFormatIconThreshold struct {
+	Type        string
+	Value       string
+	GreaterThan bool
+}
+
+// ConditionalFormatVisual directly maps the data bar and icon of the
+// conditional formats which applied to a cell. The BarLength specifies the
+// length of the data bar as a percentage of the cell width, and the IconIndex
+// specifies the zero-based index of the icon in the icon set specified by the
+// IconStyle. The ShowValue specifies if the value of the cell will be shown
+// with the data bar or icon.
+type ConditionalFormatVisual struct {
+	DataBar   bool
+	BarColor  string
+	BarLength float64
+	IconSet   bool
+	IconStyle string
+	IconIndex int
+	ShowValue bool
+}
+
 // SheetProtectionOptions directly maps the settings of worksheet protection.
 type SheetProtectionOptions struct {
 	AlgorithmName       string
@@ -975,16 +1024,41 @@ type HeaderFooterOptions struct {
 	FirstFooter      string
 }
 
+// WatermarkOptions directly maps the settings of the watermark. The Text
+// specifies the text of the watermark, the FontFile specifies the bytes of
+// the TrueType or OpenType font file for drawing the text, the Font specifies
+// the size and color of the text, the Rotation specifies the counterclockwise
+// rotation angle of the text in degrees, the Transparency specifies the
+// transparency of the text in percentage, and the Tiled specifies if the
+// text will be repeated across the page.
+type WatermarkOptions struct {
+	Text         string
+	FontFile     []byte
+	Font         *Font
+	Rotation     *int
+	Transparency int
+	Tiled        bool
+}
+
 // PageLayoutMarginsOptions directly maps the settings of page layout margins.
+// All margins are measured in inches.
 type PageLayoutMarginsOptions struct {
-	Bottom       *float64
-	Footer       *float64
-	Header       *float64
-	Left         *float64
-	Right        *float64
-	Top          *float64
+	// Bottom specified the bottom page margin, default is 0.75.
+	Bottom *float64
+	// Footer specified the footer margin, default is 0.3.
+	Footer *float64
+	// Header specified the header margin, default is 0.3.
+	Header *float64
+	// Left specified the left page margin, default is 0.7.
+	Left *float64
+	// Right specified the right page margin, default is 0.7.
+	Right *float64
+	// Top specified the top page margin, default is 0.75.
+	Top *float64
+	// Horizontally specified center the sheet on the page horizontally.
 	Horizontally *bool
-	Vertically   *bool
+	// Vertically specified center the sheet on the page vertically.
+	Vertically *bool
 }
 
 // PageLayoutOptions directly maps the settings of page layout.
@@ -998,7 +1072,7 @@ type PageLayoutOptions struct {
 	FirstPageNumber *uint
 	// AdjustTo defines the print scaling. This attribute is restricted to
 	// value ranging from 10 (10%) to 400 (400%). This setting is overridden
-	// when fitToWidth and/or fitToHeight are in use.
+	// when the FitToPage of worksheet properties is enabled.
 	AdjustTo *uint
 	// FitToHeight specified the number of vertical pages to fit on.
 	FitToHeight *int
@@ -1019,7 +1093,13 @@ type ViewOptions struct {
 	// column left of Column A, and so on. Also, information in cells is
 	// displayed in the Right to Left format.
 	RightToLeft *bool
-	// ShowFormulas indicating whether this sheet should display formulas.
+	// ShowFormulas indicating whether this sheet should display formulas
+	// instead of their calculated values, the same as the "Show Formulas"
+	// option on the Formulas tab in Excel. For example, show formulas in the
+	// first view of Sheet1:
+	//
+	//	show := true
+	//	err := f.SetSheetView("Sheet1", 0, &excelize.ViewOptions{ShowFormulas: &show})
 	ShowFormulas *bool
 	// ShowGridLines indicating whether this sheet should display grid lines.
 	ShowGridLines *bool
@@ -1103,3 +1183,17 @@ type SheetPropsOptions struct {
 	// ThickBottom specifies if rows have a thick bottom border by default.
 	ThickBottom *bool
 }
+
+// SheetOutline directly maps the outline settings of the worksheet.
+type SheetOutline struct {
+	// Rows specifies the outline level of the grouped rows by row number.
+	Rows map[int]uint8
+	// Cols specifies the outline level of the grouped columns by column name.
+	Cols map[string]uint8
+	// SummaryBelow indicating whether summary rows appear below detail in an
+	// outline.
+	SummaryBelow bool
+	// SummaryRight indicating whether summary columns appear to the right of
+	// detail in an outline.
+	SummaryRight bool
+}